@@ -0,0 +1,113 @@
+package wms
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ASNOpen   = "open"
+	ASNClosed = "closed"
+)
+
+// Receipt states of an ASN line, comparing what has been received against
+// what the supplier said to expect.
+const (
+	LinePending  = "pending"
+	LineShort    = "short"
+	LineComplete = "complete"
+	LineOver     = "over"
+)
+
+// ASN is an advance shipping notice: a supplier telling a warehouse which
+// products to expect, and how many of each, on a given date.
+type ASN struct {
+	Id           string    `json:"id"`
+	Supplier     string    `json:"supplier"`
+	WarehouseId  string    `json:"warehouseId"`
+	ExpectedDate time.Time `json:"expectedDate"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"createdAt"`
+	Lines        []ASNLine `json:"lines"`
+}
+
+type ASNLine struct {
+	Id               string `json:"id"`
+	AsnId            string `json:"asnId"`
+	Sku              string `json:"sku"`
+	ExpectedQuantity int    `json:"expectedQuantity"`
+	ReceivedQuantity int    `json:"receivedQuantity"`
+	ReceiptStatus    string `json:"receiptStatus"`
+}
+
+var ASNDoesNotExist = errors.New("asn does not exist")
+var ASNLineDoesNotExist = errors.New("asn line does not exist")
+var ASNClosedForReceipt = errors.New("asn is closed")
+var DuplicateASNLine = errors.New("asn lists a sku more than once")
+
+func NewASN(supplier, warehouseId string, expectedDate time.Time, lines []ASNLine) ASN {
+	asn := ASN{
+		Id:           generateUUID(),
+		Supplier:     supplier,
+		WarehouseId:  warehouseId,
+		ExpectedDate: expectedDate,
+		Status:       ASNOpen,
+		CreatedAt:    time.Now().UTC(),
+		Lines:        make([]ASNLine, 0, len(lines)),
+	}
+	for _, line := range lines {
+		asn.Lines = append(asn.Lines, NewASNLine(asn.Id, line.Sku, line.ExpectedQuantity))
+	}
+	return asn
+}
+
+func NewASNLine(asnId, sku string, expectedQuantity int) ASNLine {
+	return ASNLine{
+		Id:               generateUUID(),
+		AsnId:            asnId,
+		Sku:              sku,
+		ExpectedQuantity: expectedQuantity,
+		ReceiptStatus:    LinePending,
+	}
+}
+
+// Validate checks that no sku is listed twice, a second line for a product
+// should have been added to the first.
+func (a ASN) Validate() error {
+	skus := make(map[string]bool, len(a.Lines))
+	for _, line := range a.Lines {
+		if skus[line.Sku] {
+			return DuplicateASNLine
+		}
+		skus[line.Sku] = true
+	}
+	return nil
+}
+
+// SetReceiptStatus works out the receipt status of the line from its
+// quantities: nothing received yet, fewer than expected, exactly as expected
+// or more than expected.
+func (l *ASNLine) SetReceiptStatus() {
+	switch {
+	case l.ReceivedQuantity == 0:
+		l.ReceiptStatus = LinePending
+	case l.ReceivedQuantity < l.ExpectedQuantity:
+		l.ReceiptStatus = LineShort
+	case l.ReceivedQuantity == l.ExpectedQuantity:
+		l.ReceiptStatus = LineComplete
+	default:
+		l.ReceiptStatus = LineOver
+	}
+}
+
+// Discrepancies returns the lines that were not received exactly as
+// expected.
+func (a ASN) Discrepancies() []ASNLine {
+	discrepancies := []ASNLine{}
+	for _, line := range a.Lines {
+		if line.ReceiptStatus != LineComplete {
+			discrepancies = append(discrepancies, line)
+		}
+	}
+	return discrepancies
+}
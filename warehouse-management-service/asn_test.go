@@ -0,0 +1,45 @@
+package wms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestASNLineReceiptStatus(t *testing.T) {
+	tests := []struct {
+		received int
+		want     string
+	}{
+		{received: 0, want: LinePending},
+		{received: 2, want: LineShort},
+		{received: 3, want: LineComplete},
+		{received: 4, want: LineOver},
+	}
+	for _, test := range tests {
+		line := ASNLine{ExpectedQuantity: 3, ReceivedQuantity: test.received}
+		line.SetReceiptStatus()
+		if line.ReceiptStatus != test.want {
+			t.Errorf("received %d of 3, want: %v, got: %v", test.received, test.want, line.ReceiptStatus)
+		}
+	}
+}
+
+func TestNewASN(t *testing.T) {
+	asn := NewASN("acme", "w", time.Now(), []ASNLine{{Sku: "a", ExpectedQuantity: 1}, {Sku: "b", ExpectedQuantity: 2}})
+	if asn.Status != ASNOpen || len(asn.Lines) != 2 {
+		t.Fatalf("want an open asn with 2 lines, got: %v", asn)
+	}
+	for _, line := range asn.Lines {
+		if line.Id == "" || line.AsnId != asn.Id || line.ReceiptStatus != LinePending {
+			t.Errorf("want a pending line of asn %s, got: %v", asn.Id, line)
+		}
+	}
+	if err := asn.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	asn = NewASN("acme", "w", time.Now(), []ASNLine{{Sku: "a", ExpectedQuantity: 1}, {Sku: "a", ExpectedQuantity: 2}})
+	if err := asn.Validate(); err != DuplicateASNLine {
+		t.Errorf("want: %v, got: %v", DuplicateASNLine, err)
+	}
+}
@@ -0,0 +1,42 @@
+package wms
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// AuditEvent records a single change to a row. Before is null for creates
+// and After is null for purges, otherwise both hold the row as stored.
+type AuditEvent struct {
+	Id        int64           `json:"id"`
+	Entity    string          `json:"entity"`
+	EntityId  string          `json:"entityId"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	Actor     string          `json:"actor,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the name of whoever is making the
+// request, for the audit log.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or "" if there is none.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
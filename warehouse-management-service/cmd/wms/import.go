@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	wms "warehouse-management-service"
+	"warehouse-management-service/internal/config"
+	"warehouse-management-service/pkg/database/postgres"
+)
+
+var layoutImportParsers = map[string]func(io.Reader) ([]wms.LayoutImportRow, error){
+	"csv":  wms.ParseLayoutCSV,
+	"json": wms.ParseLayoutJSON,
+}
+
+// runImport implements `wms import -warehouse <id> <file>`, which imports a
+// layout straight into the database the server is configured to use. The
+// file is read from stdin when it is "-".
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	warehouseId := flags.String("warehouse", "", "id of the warehouse to import into")
+	format := flags.String("format", "", "csv or json, defaults to the file extension")
+	actor := flags.String("actor", os.Getenv("USER"), "who to record the changes against")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *warehouseId == "" || flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("usage: wms import -warehouse <id> [-format csv|json] <file>")
+	}
+
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	parse, ok := layoutImportParsers[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q, use -format csv or -format json", *format)
+	}
+
+	input := os.Stdin
+	if path != "-" {
+		input, err = os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+	}
+	rows, err := parse(input)
+	if err != nil {
+		return err
+	}
+
+	appConfig, err := config.FromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to read config %v", err)
+	}
+	db, err := postgres.New(appConfig.Postgres).Open()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := wms.WithActor(context.Background(), *actor)
+	layoutImport, err := postgres.NewLayoutImportService(db).ImportLayout(ctx, *warehouseId, rows)
+	var importError *wms.LayoutImportError
+	if errors.As(err, &importError) {
+		for _, row := range importError.Rows {
+			fmt.Fprintf(os.Stderr, "row %d: %s\n", row.Row, row.Error)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"imported %d shelf blocks and %d shelves into warehouse %s\n",
+		len(layoutImport.ShelfBlocks),
+		len(layoutImport.Shelves),
+		*warehouseId,
+	)
+	return nil
+}
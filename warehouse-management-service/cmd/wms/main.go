@@ -19,6 +19,15 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		err := runImport(os.Args[2:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	runDBMigrations := *flag.Bool("migrate", false, "true or false, specifies if database migrations should be run")
 
 	appConfig, err := config.FromEnv()
@@ -58,8 +67,44 @@ func main() {
 	warehouseService := postgres.NewWarehouseService(db)
 	shelfBlockService := postgres.NewShelfBlockService(db)
 	shelfService := postgres.NewShelfService(db)
-
-	h := handler.New(logger, warehouseService, shelfBlockService, shelfService)
+	productService := postgres.NewProductService(db)
+	itemService := postgres.NewItemService(db)
+	auditService := postgres.NewAuditService(db)
+	importService := postgres.NewLayoutImportService(db)
+	exportService := postgres.NewExportService(db)
+	asnService := postgres.NewASNService(db)
+	putawayService := postgres.NewPutawayService(db)
+	orderService := postgres.NewOrderService(db)
+	reservationService := postgres.NewReservationService(db)
+	stockService := postgres.NewStockService(db)
+	expiryService := postgres.NewExpiryService(db)
+	transferService := postgres.NewTransferService(db)
+	cycleCountService := postgres.NewCycleCountService(db)
+	shipmentService := postgres.NewShipmentService(db)
+	returnService := postgres.NewReturnService(db)
+	lotService := postgres.NewLotService(db)
+
+	h := handler.New(logger, handler.Services{
+		Warehouse:    warehouseService,
+		ShelfBlock:   shelfBlockService,
+		Shelf:        shelfService,
+		Product:      productService,
+		Item:         itemService,
+		Audit:        auditService,
+		LayoutImport: importService,
+		Export:       exportService,
+		ASN:          asnService,
+		Putaway:      putawayService,
+		Order:        orderService,
+		Reservation:  reservationService,
+		Stock:        stockService,
+		Expiry:       expiryService,
+		Transfer:     transferService,
+		CycleCount:   cycleCountService,
+		Shipment:     shipmentService,
+		Return:       returnService,
+		Lot:          lotService,
+	}, appConfig.AdminToken)
 
 	exitChan := make(chan os.Signal, 1)
 	signal.Notify(exitChan, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -67,8 +112,8 @@ func main() {
 	var wg sync.WaitGroup
 
 	server := &http.Server{Addr: ":80", Handler: h}
+	wg.Add(1)
 	go func() {
-		wg.Add(1)
 		defer wg.Done()
 
 		if err := server.ListenAndServe(); err != nil {
@@ -81,8 +126,20 @@ func main() {
 	}()
 	logger.Log(log.Info, "Server listening on port 80")
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		runPeriodically(workerCtx, appConfig.ReservationSweepInterval, expireReservations(logger, reservationService))
+	}()
+	go func() {
+		defer wg.Done()
+		runPeriodically(workerCtx, appConfig.ExpirySweepInterval, sweepExpiry(logger, expiryService, appConfig.ExpiryWindowDays))
+	}()
+
 	// listen for exit signals
 	<-exitChan
+	stopWorkers()
 
 	// shutdown server gracefully
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
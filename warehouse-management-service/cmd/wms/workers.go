@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/log"
+)
+
+type reservationExpirer interface {
+	ExpireReservations(ctx context.Context, now time.Time) (int, error)
+}
+
+type expirySweeper interface {
+	SweepExpiry(ctx context.Context, now time.Time, window time.Duration) (wms.ExpirySweep, error)
+}
+
+// runPeriodically calls run every interval until ctx is done.
+func runPeriodically(ctx context.Context, interval time.Duration, run func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run(ctx)
+		}
+	}
+}
+
+// expireReservations expires lapsed reservations, freeing their items for
+// other reservations and pick lists.
+func expireReservations(logger log.Logger, reservations reservationExpirer) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		expired, err := reservations.ExpireReservations(ctx, time.Now().UTC())
+		if err != nil {
+			logger.Log(log.Error, fmt.Sprintf("Failed to expire reservations: %v", err))
+			return
+		}
+		if expired > 0 {
+			logger.Log(log.Info, fmt.Sprintf("Expired %d reservations", expired))
+		}
+	}
+}
+
+// sweepExpiry flags items expiring within windowDays and quarantines expired
+// ones.
+func sweepExpiry(logger log.Logger, expiry expirySweeper, windowDays int) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		sweep, err := expiry.SweepExpiry(ctx, time.Now().UTC(), time.Duration(windowDays)*24*time.Hour)
+		if err != nil {
+			logger.Log(log.Error, fmt.Sprintf("Failed to sweep item expiry: %v", err))
+			return
+		}
+		if sweep.Flagged > 0 || sweep.Quarantined > 0 {
+			logger.Log(log.Info, fmt.Sprintf(
+				"Flagged %d items as near expiry, quarantined %d expired items",
+				sweep.Flagged,
+				sweep.Quarantined,
+			))
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package wms
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	CycleCountOpen     = "open"
+	CycleCountApproved = "approved"
+)
+
+// Reason codes of stock adjustments.
+const (
+	AdjustmentMiscount = "miscount"
+	AdjustmentFound    = "found"
+	AdjustmentLost     = "lost"
+	AdjustmentDamaged  = "damaged"
+	AdjustmentTheft    = "theft"
+)
+
+var adjustmentReasons = map[string]bool{
+	AdjustmentMiscount: true,
+	AdjustmentFound:    true,
+	AdjustmentLost:     true,
+	AdjustmentDamaged:  true,
+	AdjustmentTheft:    true,
+}
+
+// CycleCount is a physical count of the stock on one shelf, or on every
+// shelf of one shelf block. Approving it brings the items on record in line
+// with what was counted.
+type CycleCount struct {
+	Id           string            `json:"id"`
+	WarehouseId  string            `json:"warehouseId"`
+	ShelfId      string            `json:"shelfId,omitempty"`
+	ShelfBlockId string            `json:"shelfBlockId,omitempty"`
+	Status       string            `json:"status"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	ApprovedAt   *time.Time        `json:"approvedAt,omitempty"`
+	ApprovedBy   string            `json:"approvedBy,omitempty"`
+	Counts       []CycleCountLine  `json:"counts"`
+	Adjustments  []StockAdjustment `json:"adjustments"`
+}
+
+// CycleCountLine is the number of items of a sku on a shelf.
+type CycleCountLine struct {
+	ShelfId   string    `json:"shelfId"`
+	Sku       string    `json:"sku"`
+	Quantity  int       `json:"quantity"`
+	CountedBy string    `json:"countedBy,omitempty"`
+	CountedAt time.Time `json:"countedAt"`
+}
+
+// CycleCountScope is what a cycle count covers: either a shelf or a shelf
+// block.
+type CycleCountScope struct {
+	ShelfId      string
+	ShelfBlockId string
+}
+
+// Variance compares the items of a sku on a shelf on record with those
+// counted. Counted is nil while the sku has not been counted there.
+type Variance struct {
+	ShelfId  string `json:"shelfId"`
+	Sku      string `json:"sku"`
+	Expected int    `json:"expected"`
+	Counted  *int   `json:"counted"`
+	Variance int    `json:"variance"`
+}
+
+// AdjustmentReason gives the reason for the adjustments of a sku on a
+// shelf.
+type AdjustmentReason struct {
+	ShelfId string
+	Sku     string
+	Reason  string
+}
+
+// StockCorrection is what approving a count changes for a sku on a shelf:
+// Quantity items are created, or retired if it is negative.
+type StockCorrection struct {
+	ShelfId  string
+	Sku      string
+	Quantity int
+	Reason   string
+}
+
+// StockAdjustment is an entry of the adjustment ledger: one item created
+// (Quantity 1) or retired (Quantity -1) to correct the stock on record.
+type StockAdjustment struct {
+	Id           int64     `json:"id"`
+	CycleCountId string    `json:"cycleCountId,omitempty"`
+	ItemId       string    `json:"itemId"`
+	Sku          string    `json:"sku"`
+	ShelfId      string    `json:"shelfId"`
+	Quantity     int       `json:"quantity"`
+	Reason       string    `json:"reason"`
+	Actor        string    `json:"actor,omitempty"`
+	AdjustedAt   time.Time `json:"adjustedAt"`
+}
+
+var CycleCountDoesNotExist = errors.New("cycle count does not exist")
+var CycleCountNotOpen = errors.New("cycle count is not open")
+var InvalidCycleCountScope = errors.New("cycle count needs either a shelf or a shelf block")
+var ShelfNotInCycleCount = errors.New("shelf is not covered by the cycle count")
+var DuplicateCycleCountLine = errors.New("cycle count lists a sku on a shelf more than once")
+var InvalidAdjustmentReason = errors.New("invalid adjustment reason")
+
+func (s CycleCountScope) Validate() error {
+	if (s.ShelfId == "") == (s.ShelfBlockId == "") {
+		return InvalidCycleCountScope
+	}
+	return nil
+}
+
+func NewCycleCount(scope CycleCountScope, warehouseId string) CycleCount {
+	return CycleCount{
+		Id:           generateUUID(),
+		WarehouseId:  warehouseId,
+		ShelfId:      scope.ShelfId,
+		ShelfBlockId: scope.ShelfBlockId,
+		Status:       CycleCountOpen,
+		CreatedAt:    time.Now().UTC(),
+		Counts:       []CycleCountLine{},
+		Adjustments:  []StockAdjustment{},
+	}
+}
+
+// CheckCounts puts counts without a shelf on the shelf of a count of one
+// shelf, and rejects counts of other shelves or listed twice. Whether the
+// shelves belong to the shelf block of a count is left to the caller.
+func (c CycleCount) CheckCounts(counts []CycleCountLine) ([]CycleCountLine, error) {
+	checked := make([]CycleCountLine, 0, len(counts))
+	seen := make(map[[2]string]bool, len(counts))
+	for _, count := range counts {
+		if count.ShelfId == "" {
+			count.ShelfId = c.ShelfId
+		}
+		if count.ShelfId == "" || (c.ShelfId != "" && count.ShelfId != c.ShelfId) {
+			return nil, ShelfNotInCycleCount
+		}
+		key := [2]string{count.ShelfId, count.Sku}
+		if seen[key] {
+			return nil, DuplicateCycleCountLine
+		}
+		seen[key] = true
+		checked = append(checked, count)
+	}
+	return checked, nil
+}
+
+// NewVarianceReport lines up the items on record, by shelf and sku, with
+// the counts, ordered by shelf and sku.
+func NewVarianceReport(expected []CycleCountLine, counts []CycleCountLine) []Variance {
+	variances := make(map[[2]string]*Variance)
+	variance := func(shelfId, sku string) *Variance {
+		key := [2]string{shelfId, sku}
+		if variances[key] == nil {
+			variances[key] = &Variance{ShelfId: shelfId, Sku: sku}
+		}
+		return variances[key]
+	}
+	for _, line := range expected {
+		variance(line.ShelfId, line.Sku).Expected += line.Quantity
+	}
+	for _, count := range counts {
+		counted := count.Quantity
+		variance(count.ShelfId, count.Sku).Counted = &counted
+	}
+
+	report := make([]Variance, 0, len(variances))
+	for _, v := range variances {
+		if v.Counted != nil {
+			v.Variance = *v.Counted - v.Expected
+		}
+		report = append(report, *v)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].ShelfId != report[j].ShelfId {
+			return report[i].ShelfId < report[j].ShelfId
+		}
+		return report[i].Sku < report[j].Sku
+	})
+	return report
+}
+
+// PlanCorrections turns the counted variances of a report into corrections,
+// each for reason unless overridden for its shelf and sku. Skus not counted
+// are left as they are.
+func PlanCorrections(report []Variance, reason string, overrides []AdjustmentReason) ([]StockCorrection, error) {
+	if !adjustmentReasons[reason] {
+		return nil, InvalidAdjustmentReason
+	}
+	reasons := make(map[[2]string]string, len(overrides))
+	for _, override := range overrides {
+		if !adjustmentReasons[override.Reason] {
+			return nil, InvalidAdjustmentReason
+		}
+		reasons[[2]string{override.ShelfId, override.Sku}] = override.Reason
+	}
+
+	var corrections []StockCorrection
+	for _, variance := range report {
+		if variance.Counted == nil || variance.Variance == 0 {
+			continue
+		}
+		correction := StockCorrection{
+			ShelfId:  variance.ShelfId,
+			Sku:      variance.Sku,
+			Quantity: variance.Variance,
+			Reason:   reason,
+		}
+		if override, ok := reasons[[2]string{variance.ShelfId, variance.Sku}]; ok {
+			correction.Reason = override
+		}
+		corrections = append(corrections, correction)
+	}
+	return corrections, nil
+}
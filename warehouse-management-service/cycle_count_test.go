@@ -0,0 +1,87 @@
+package wms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCycleCountScopeValidate(t *testing.T) {
+	tests := []struct {
+		scope CycleCountScope
+		want  error
+	}{
+		{scope: CycleCountScope{ShelfId: "s"}, want: nil},
+		{scope: CycleCountScope{ShelfBlockId: "b"}, want: nil},
+		{scope: CycleCountScope{}, want: InvalidCycleCountScope},
+		{scope: CycleCountScope{ShelfId: "s", ShelfBlockId: "b"}, want: InvalidCycleCountScope},
+	}
+	for _, test := range tests {
+		if got := test.scope.Validate(); got != test.want {
+			t.Errorf("%v: want: %v, got: %v", test.scope, test.want, got)
+		}
+	}
+}
+
+func TestCheckCounts(t *testing.T) {
+	shelfCount := NewCycleCount(CycleCountScope{ShelfId: "s"}, "w")
+	counts, err := shelfCount.CheckCounts([]CycleCountLine{{Sku: "a", Quantity: 1}, {ShelfId: "s", Sku: "b"}})
+	if err != nil || counts[0].ShelfId != "s" || counts[1].ShelfId != "s" {
+		t.Errorf("want counts on shelf s, got: %v, %v", counts, err)
+	}
+	_, err = shelfCount.CheckCounts([]CycleCountLine{{ShelfId: "other", Sku: "a"}})
+	if err != ShelfNotInCycleCount {
+		t.Errorf("want: %v, got: %v", ShelfNotInCycleCount, err)
+	}
+	_, err = shelfCount.CheckCounts([]CycleCountLine{{Sku: "a"}, {ShelfId: "s", Sku: "a"}})
+	if err != DuplicateCycleCountLine {
+		t.Errorf("want: %v, got: %v", DuplicateCycleCountLine, err)
+	}
+
+	blockCount := NewCycleCount(CycleCountScope{ShelfBlockId: "b"}, "w")
+	_, err = blockCount.CheckCounts([]CycleCountLine{{Sku: "a"}})
+	if err != ShelfNotInCycleCount {
+		t.Errorf("want a count without a shelf rejected, got: %v", err)
+	}
+}
+
+func TestVarianceReportAndCorrections(t *testing.T) {
+	expected := []CycleCountLine{
+		{ShelfId: "s1", Sku: "a", Quantity: 3},
+		{ShelfId: "s1", Sku: "b", Quantity: 2},
+		{ShelfId: "s2", Sku: "a", Quantity: 1},
+	}
+	counts := []CycleCountLine{
+		{ShelfId: "s1", Sku: "a", Quantity: 1},
+		{ShelfId: "s1", Sku: "b", Quantity: 2},
+		{ShelfId: "s2", Sku: "c", Quantity: 4},
+	}
+	one, two, four := 1, 2, 4
+	want := []Variance{
+		{ShelfId: "s1", Sku: "a", Expected: 3, Counted: &one, Variance: -2},
+		{ShelfId: "s1", Sku: "b", Expected: 2, Counted: &two, Variance: 0},
+		{ShelfId: "s2", Sku: "a", Expected: 1},
+		{ShelfId: "s2", Sku: "c", Counted: &four, Variance: 4},
+	}
+	report := NewVarianceReport(expected, counts)
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("want: %v, got: %v", want, report)
+	}
+
+	corrections, err := PlanCorrections(report, AdjustmentLost, []AdjustmentReason{{ShelfId: "s2", Sku: "c", Reason: AdjustmentFound}})
+	wantCorrections := []StockCorrection{
+		{ShelfId: "s1", Sku: "a", Quantity: -2, Reason: AdjustmentLost},
+		{ShelfId: "s2", Sku: "c", Quantity: 4, Reason: AdjustmentFound},
+	}
+	if err != nil || !reflect.DeepEqual(corrections, wantCorrections) {
+		t.Errorf("want: %v, got: %v, %v", wantCorrections, corrections, err)
+	}
+
+	_, err = PlanCorrections(report, "misplaced", nil)
+	if err != InvalidAdjustmentReason {
+		t.Errorf("want: %v, got: %v", InvalidAdjustmentReason, err)
+	}
+	_, err = PlanCorrections(report, AdjustmentLost, []AdjustmentReason{{ShelfId: "s1", Sku: "a", Reason: "misplaced"}})
+	if err != InvalidAdjustmentReason {
+		t.Errorf("want an invalid override rejected, got: %v", err)
+	}
+}
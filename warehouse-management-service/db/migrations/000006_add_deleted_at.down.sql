@@ -0,0 +1,3 @@
+ALTER TABLE shelf DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE shelf_block DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE warehouse DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
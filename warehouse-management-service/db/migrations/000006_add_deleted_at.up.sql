@@ -0,0 +1,3 @@
+ALTER TABLE warehouse ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE shelf_block ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE shelf ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS audit_event(
+    id BIGSERIAL PRIMARY KEY,
+    entity TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    before JSONB,
+    after JSONB,
+    actor TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS audit_event_entity_idx ON audit_event(entity, entity_id, id);
\ No newline at end of file
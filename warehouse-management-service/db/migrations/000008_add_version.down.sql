@@ -0,0 +1,3 @@
+ALTER TABLE shelf DROP COLUMN IF EXISTS version;
+ALTER TABLE shelf_block DROP COLUMN IF EXISTS version;
+ALTER TABLE warehouse DROP COLUMN IF EXISTS version;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE item DROP COLUMN IF EXISTS asn_line_id;
+DROP TABLE IF EXISTS asn_line;
+DROP TABLE IF EXISTS asn;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS asn(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    supplier TEXT NOT NULL,
+    warehouse_id TEXT NOT NULL references warehouse(id) ON DELETE CASCADE,
+    expected_date DATE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS asn_line(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    asn_id TEXT NOT NULL references asn(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL references product(sku),
+    expected_quantity INTEGER NOT NULL CHECK (expected_quantity > 0),
+    received_quantity INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (asn_id, sku)
+);
+ALTER TABLE item ADD COLUMN IF NOT EXISTS asn_line_id TEXT references asn_line(id) ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE shelf DROP COLUMN IF EXISTS max_items;
+ALTER TABLE shelf DROP COLUMN IF EXISTS max_volume_in_cm3;
+ALTER TABLE shelf DROP COLUMN IF EXISTS max_weight_in_kg;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE shelf ADD COLUMN IF NOT EXISTS max_weight_in_kg NUMERIC NOT NULL DEFAULT 0 CHECK (max_weight_in_kg >= 0);
+ALTER TABLE shelf ADD COLUMN IF NOT EXISTS max_volume_in_cm3 NUMERIC NOT NULL DEFAULT 0 CHECK (max_volume_in_cm3 >= 0);
+ALTER TABLE shelf ADD COLUMN IF NOT EXISTS max_items INTEGER NOT NULL DEFAULT 0 CHECK (max_items >= 0);
\ No newline at end of file
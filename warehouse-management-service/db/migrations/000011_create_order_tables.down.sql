@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS pick_list_item;
+DROP TABLE IF EXISTS order_line;
+DROP TABLE IF EXISTS sales_order;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS sales_order(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    customer TEXT NOT NULL,
+    warehouse_id TEXT NOT NULL references warehouse(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS order_line(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    order_id TEXT NOT NULL references sales_order(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL references product(sku),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    UNIQUE (order_id, sku)
+);
+CREATE TABLE IF NOT EXISTS pick_list_item(
+    item_id TEXT PRIMARY KEY references item(id) ON DELETE CASCADE,
+    order_line_id TEXT NOT NULL references order_line(id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reservation_item;
+DROP TABLE IF EXISTS reservation;
\ No newline at end of file
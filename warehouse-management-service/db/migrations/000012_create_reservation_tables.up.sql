@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS reservation(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    warehouse_id TEXT NOT NULL references warehouse(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL references product(sku),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    reference TEXT,
+    status TEXT NOT NULL DEFAULT 'held',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS reservation_held_expires_at_idx ON reservation(expires_at) WHERE status = 'held';
+CREATE TABLE IF NOT EXISTS reservation_item(
+    item_id TEXT PRIMARY KEY references item(id) ON DELETE CASCADE,
+    reservation_id TEXT NOT NULL references reservation(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS reservation_item_reservation_id_idx ON reservation_item(reservation_id);
\ No newline at end of file
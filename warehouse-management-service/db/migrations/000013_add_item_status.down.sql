@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS item_expiration_date_idx;
+ALTER TABLE item DROP COLUMN IF EXISTS near_expiry;
+ALTER TABLE item DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE item ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'available';
+ALTER TABLE item ADD COLUMN IF NOT EXISTS near_expiry BOOLEAN NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS item_expiration_date_idx ON item(expiration_date) WHERE expiration_date IS NOT NULL;
\ No newline at end of file
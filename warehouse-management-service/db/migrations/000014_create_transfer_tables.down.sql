@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_movement;
+ALTER TABLE item DROP COLUMN IF EXISTS transfer_id;
+DROP TABLE IF EXISTS transfer;
\ No newline at end of file
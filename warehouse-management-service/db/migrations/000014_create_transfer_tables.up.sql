@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS transfer(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    from_warehouse_id TEXT NOT NULL references warehouse(id) ON DELETE CASCADE,
+    to_warehouse_id TEXT NOT NULL references warehouse(id) ON DELETE CASCADE,
+    to_shelf_id TEXT NOT NULL references shelf(id),
+    status TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    received_at TIMESTAMP
+);
+ALTER TABLE item ADD COLUMN IF NOT EXISTS transfer_id TEXT references transfer(id) ON DELETE SET NULL;
+CREATE TABLE IF NOT EXISTS stock_movement(
+    id BIGSERIAL PRIMARY KEY,
+    item_id TEXT NOT NULL,
+    sku TEXT NOT NULL,
+    from_shelf_id TEXT,
+    to_shelf_id TEXT NOT NULL,
+    transfer_id TEXT references transfer(id) ON DELETE SET NULL,
+    kind TEXT NOT NULL,
+    actor TEXT,
+    moved_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS stock_movement_item_id_idx ON stock_movement(item_id, id);
+CREATE INDEX IF NOT EXISTS stock_movement_transfer_id_idx ON stock_movement(transfer_id, id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_adjustment;
+DROP TABLE IF EXISTS cycle_count_line;
+DROP TABLE IF EXISTS cycle_count;
\ No newline at end of file
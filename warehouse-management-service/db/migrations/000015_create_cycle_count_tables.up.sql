@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS cycle_count(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    warehouse_id TEXT NOT NULL references warehouse(id) ON DELETE CASCADE,
+    shelf_id TEXT references shelf(id) ON DELETE CASCADE,
+    shelf_block_id TEXT references shelf_block(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    approved_at TIMESTAMP,
+    approved_by TEXT,
+    CHECK ((shelf_id IS NULL) <> (shelf_block_id IS NULL))
+);
+CREATE TABLE IF NOT EXISTS cycle_count_line(
+    cycle_count_id TEXT NOT NULL references cycle_count(id) ON DELETE CASCADE,
+    shelf_id TEXT NOT NULL references shelf(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL references product(sku),
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    counted_by TEXT,
+    counted_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (cycle_count_id, shelf_id, sku)
+);
+CREATE TABLE IF NOT EXISTS stock_adjustment(
+    id BIGSERIAL PRIMARY KEY,
+    cycle_count_id TEXT references cycle_count(id) ON DELETE SET NULL,
+    item_id TEXT NOT NULL,
+    sku TEXT NOT NULL,
+    shelf_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity IN (-1, 1)),
+    reason TEXT NOT NULL,
+    actor TEXT,
+    adjusted_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS stock_adjustment_cycle_count_id_idx ON stock_adjustment(cycle_count_id, id);
+CREATE INDEX IF NOT EXISTS stock_adjustment_item_id_idx ON stock_adjustment(item_id, id);
\ No newline at end of file
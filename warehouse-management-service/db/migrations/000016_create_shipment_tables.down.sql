@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS package_item;
+DROP TABLE IF EXISTS package;
+DROP TABLE IF EXISTS shipment;
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS shipment(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    order_id TEXT NOT NULL UNIQUE references sales_order(id) ON DELETE CASCADE,
+    carrier TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    dispatched_at TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS package(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    shipment_id TEXT NOT NULL references shipment(id) ON DELETE CASCADE,
+    tracking_number TEXT NOT NULL,
+    weight_in_kg NUMERIC NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS package_shipment_id_idx ON package(shipment_id);
+CREATE TABLE IF NOT EXISTS package_item(
+    item_id TEXT PRIMARY KEY,
+    package_id TEXT NOT NULL references package(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    weight_in_kg NUMERIC NOT NULL
+);
+CREATE INDEX IF NOT EXISTS package_item_package_id_idx ON package_item(package_id);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS return_item;
+DROP TABLE IF EXISTS return_authorization;
+-- stock_movement.to_shelf_id stays nullable, the ledger may hold items that went to no shelf
+ALTER TABLE package_item DROP COLUMN IF EXISTS expiration_date;
\ No newline at end of file
@@ -0,0 +1,25 @@
+ALTER TABLE package_item ADD COLUMN IF NOT EXISTS expiration_date TIMESTAMP;
+ALTER TABLE stock_movement ALTER COLUMN to_shelf_id DROP NOT NULL;
+CREATE TABLE IF NOT EXISTS return_authorization(
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid (),
+    shipment_id TEXT NOT NULL references shipment(id) ON DELETE CASCADE,
+    warehouse_id TEXT NOT NULL references warehouse(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'authorized',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    closed_at TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS return_item(
+    item_id TEXT PRIMARY KEY,
+    return_id TEXT NOT NULL references return_authorization(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    expiration_date TIMESTAMP,
+    condition TEXT,
+    notes TEXT,
+    inspected_at TIMESTAMP,
+    outcome TEXT,
+    shelf_id TEXT references shelf(id) ON DELETE SET NULL,
+    new_item_id TEXT,
+    resolved_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS return_item_return_id_idx ON return_item(return_id);
\ No newline at end of file
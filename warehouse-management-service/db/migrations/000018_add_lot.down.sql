@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS package_item_lot_idx;
+DROP INDEX IF EXISTS item_lot_idx;
+ALTER TABLE return_item DROP COLUMN IF EXISTS lot;
+ALTER TABLE package_item DROP COLUMN IF EXISTS lot;
+ALTER TABLE item DROP COLUMN IF EXISTS lot;
+ALTER TABLE product DROP COLUMN IF EXISTS lot_tracked;
\ No newline at end of file
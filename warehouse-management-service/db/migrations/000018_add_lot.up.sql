@@ -0,0 +1,6 @@
+ALTER TABLE product ADD COLUMN IF NOT EXISTS lot_tracked BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE item ADD COLUMN IF NOT EXISTS lot TEXT;
+ALTER TABLE package_item ADD COLUMN IF NOT EXISTS lot TEXT;
+ALTER TABLE return_item ADD COLUMN IF NOT EXISTS lot TEXT;
+CREATE INDEX IF NOT EXISTS item_lot_idx ON item(lot) WHERE lot IS NOT NULL;
+CREATE INDEX IF NOT EXISTS package_item_lot_idx ON package_item(lot) WHERE lot IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS item_serial_number_idx;
+ALTER TABLE return_item DROP COLUMN IF EXISTS serial_number;
+ALTER TABLE package_item DROP COLUMN IF EXISTS serial_number;
+ALTER TABLE item DROP COLUMN IF EXISTS serial_number;
+ALTER TABLE product DROP COLUMN IF EXISTS serialized;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE product ADD COLUMN IF NOT EXISTS serialized BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE item ADD COLUMN IF NOT EXISTS serial_number TEXT;
+ALTER TABLE package_item ADD COLUMN IF NOT EXISTS serial_number TEXT;
+ALTER TABLE return_item ADD COLUMN IF NOT EXISTS serial_number TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS item_serial_number_idx ON item(serial_number) WHERE serial_number IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,42 @@
+package wms
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	EntityWarehouse   = "warehouse"
+	EntityShelfBlock  = "shelf_block"
+	EntityShelf       = "shelf"
+	EntityItem        = "item"
+	EntityASNLine     = "asn_line"
+	EntityOrderLine   = "order_line"
+	EntityReservation = "reservation"
+	EntityCycleCount  = "cycle_count"
+	EntityTransfer    = "transfer"
+	EntityPackageItem = "package_item"
+	EntityReturnItem  = "return_item"
+)
+
+var HasDependents = errors.New("has dependents")
+
+// Dependent is a child row that references the entity being deleted.
+type Dependent struct {
+	Entity string `json:"entity"`
+	Id     string `json:"id"`
+}
+
+// DependentsError is returned when an entity cannot be deleted because other
+// rows still reference it. It matches HasDependents with errors.Is.
+type DependentsError struct {
+	Dependents []Dependent
+}
+
+func (e *DependentsError) Error() string {
+	return fmt.Sprintf("%s: %d", HasDependents.Error(), len(e.Dependents))
+}
+
+func (e *DependentsError) Unwrap() error {
+	return HasDependents
+}
@@ -0,0 +1,35 @@
+package wms
+
+import (
+	"math"
+	"time"
+)
+
+// ExpiringItem is an item that expires soon or has expired, and where it is.
+type ExpiringItem struct {
+	ItemId         string    `json:"itemId"`
+	Sku            string    `json:"sku"`
+	ProductName    string    `json:"productName"`
+	ExpirationDate time.Time `json:"expirationDate"`
+	// DaysLeft is the number of whole days until the item expires, negative
+	// once it has.
+	DaysLeft   int    `json:"daysLeft"`
+	Status     string `json:"status"`
+	ShelfId    string `json:"shelfId"`
+	ShelfLabel string `json:"shelfLabel"`
+	Aisle      string `json:"aisle"`
+	Rack       string `json:"rack"`
+}
+
+func (e *ExpiringItem) SetDaysLeft(now time.Time) {
+	e.DaysLeft = int(math.Floor(e.ExpirationDate.Sub(now).Hours() / 24))
+}
+
+// ExpirySweep is what one run of the expiry sweep changed.
+type ExpirySweep struct {
+	// Flagged is the number of items newly found to expire within the
+	// window.
+	Flagged int
+	// Quarantined is the number of items newly found expired.
+	Quarantined int
+}
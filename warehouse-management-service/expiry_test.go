@@ -0,0 +1,26 @@
+package wms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiringItemSetDaysLeft(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expirationDate time.Time
+		want           int
+	}{
+		{expirationDate: now.Add(36 * time.Hour), want: 1},
+		{expirationDate: now.Add(time.Hour), want: 0},
+		{expirationDate: now.Add(-time.Hour), want: -1},
+		{expirationDate: now.AddDate(0, 0, -3), want: -3},
+	}
+	for _, test := range tests {
+		item := ExpiringItem{ExpirationDate: test.expirationDate}
+		item.SetDaysLeft(now)
+		if item.DaysLeft != test.want {
+			t.Errorf("expiring %v: want: %v, got: %v", test.expirationDate, test.want, item.DaysLeft)
+		}
+	}
+}
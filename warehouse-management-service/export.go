@@ -0,0 +1,71 @@
+package wms
+
+import (
+	"time"
+)
+
+// ExportRow is one line of a warehouse export: an item along with where it is
+// stored. Shelves without items and shelf blocks without shelves get a line
+// of their own, with the parts they lack left empty.
+type ExportRow struct {
+	WarehouseId    string     `json:"warehouseId"`
+	WarehouseName  string     `json:"warehouseName"`
+	ShelfBlockId   string     `json:"shelfBlockId,omitempty"`
+	Aisle          string     `json:"aisle,omitempty"`
+	Rack           string     `json:"rack,omitempty"`
+	StorageType    string     `json:"storageType,omitempty"`
+	ShelfId        string     `json:"shelfId,omitempty"`
+	ShelfLabel     string     `json:"shelfLabel,omitempty"`
+	Section        string     `json:"section,omitempty"`
+	Level          string     `json:"level,omitempty"`
+	ItemId         string     `json:"itemId,omitempty"`
+	Sku            string     `json:"sku,omitempty"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	ReceivedOn     *time.Time `json:"receivedOn,omitempty"`
+}
+
+// ExportColumns names the fields of ExportRow in the order Record lists them.
+var ExportColumns = []string{
+	"warehouseId",
+	"warehouseName",
+	"shelfBlockId",
+	"aisle",
+	"rack",
+	"storageType",
+	"shelfId",
+	"shelfLabel",
+	"section",
+	"level",
+	"itemId",
+	"sku",
+	"expirationDate",
+	"receivedOn",
+}
+
+// Record returns the fields of the row as text, in the order of
+// ExportColumns. Dates are written as RFC 3339.
+func (r ExportRow) Record() []string {
+	return []string{
+		r.WarehouseId,
+		r.WarehouseName,
+		r.ShelfBlockId,
+		r.Aisle,
+		r.Rack,
+		r.StorageType,
+		r.ShelfId,
+		r.ShelfLabel,
+		r.Section,
+		r.Level,
+		r.ItemId,
+		r.Sku,
+		formatExportTime(r.ExpirationDate),
+		formatExportTime(r.ReceivedOn),
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
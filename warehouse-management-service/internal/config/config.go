@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 const (
@@ -15,8 +17,29 @@ const (
 	EnvKeyDBSSlMode             = "DB_SSL_MODE"
 	EnvKeyLogLevel              = "LOG_LEVEL"
 	EnvKeyDBMigrationSourcePath = "DB_MIGRATION_SOURCE_PATH"
+
+	// optional, admin routes are disabled when it is not set
+	EnvKeyAdminToken = "ADMIN_TOKEN"
+	// optional, a duration such as "30s", defaults to DefaultReservationSweepInterval
+	EnvKeyReservationSweepInterval = "RESERVATION_SWEEP_INTERVAL"
+	// optional, a duration, defaults to DefaultExpirySweepInterval
+	EnvKeyExpirySweepInterval = "EXPIRY_SWEEP_INTERVAL"
+	// optional, a number of days, defaults to DefaultExpiryWindowDays
+	EnvKeyExpiryWindowDays = "EXPIRY_WINDOW_DAYS"
 )
 
+// DefaultReservationSweepInterval is how often lapsed reservations are
+// expired when RESERVATION_SWEEP_INTERVAL is not set.
+const DefaultReservationSweepInterval = time.Minute
+
+// DefaultExpirySweepInterval is how often items are checked for expiry when
+// EXPIRY_SWEEP_INTERVAL is not set.
+const DefaultExpirySweepInterval = time.Hour
+
+// DefaultExpiryWindowDays is how many days ahead items are considered near
+// expiry when EXPIRY_WINDOW_DAYS is not set.
+const DefaultExpiryWindowDays = 7
+
 var environmentVariables = map[string]struct{}{
 	EnvKeyDBHost:                struct{}{},
 	EnvKeyDBPort:                struct{}{},
@@ -41,6 +64,14 @@ type Config struct {
 	LogLevel              string         `json:"logLevel"`
 	Postgres              PostgresConfig `json:"postgres"`
 	DBMigrationSourcePath string         `json:"dbMigrationSourcePath"`
+	AdminToken            string         `json:"adminToken"`
+	// ReservationSweepInterval is how often lapsed reservations are expired.
+	// Config files give the sweep intervals in nanoseconds.
+	ReservationSweepInterval time.Duration `json:"reservationSweepInterval"`
+	// ExpirySweepInterval is how often items are checked for expiry, and
+	// ExpiryWindowDays how many days ahead an item counts as near expiry.
+	ExpirySweepInterval time.Duration `json:"expirySweepInterval"`
+	ExpiryWindowDays    int           `json:"expiryWindowDays"`
 }
 
 func FromFile(path string) (*Config, error) {
@@ -49,11 +80,22 @@ func FromFile(path string) (*Config, error) {
 		return nil, err
 	}
 
-	var config Config
+	// settings the file leaves out keep their defaults
+	config := Config{
+		ReservationSweepInterval: DefaultReservationSweepInterval,
+		ExpirySweepInterval:      DefaultExpirySweepInterval,
+		ExpiryWindowDays:         DefaultExpiryWindowDays,
+	}
 	err = json.Unmarshal(bytes, &config)
 	if err != nil {
 		return nil, err
 	}
+	if config.ReservationSweepInterval <= 0 || config.ExpirySweepInterval <= 0 {
+		return nil, fmt.Errorf("invalid config file: %s: sweep intervals must be positive", path)
+	}
+	if config.ExpiryWindowDays < 0 {
+		return nil, fmt.Errorf("invalid config file: %s: expiry window cannot be negative", path)
+	}
 
 	return &config, nil
 }
@@ -67,6 +109,23 @@ func FromEnv() (*Config, error) {
 		}
 		config[envKey] = value
 	}
+
+	reservationSweepInterval, err := durationFromEnv(EnvKeyReservationSweepInterval, DefaultReservationSweepInterval)
+	if err != nil {
+		return nil, err
+	}
+	expirySweepInterval, err := durationFromEnv(EnvKeyExpirySweepInterval, DefaultExpirySweepInterval)
+	if err != nil {
+		return nil, err
+	}
+	expiryWindowDays := DefaultExpiryWindowDays
+	if value := os.Getenv(EnvKeyExpiryWindowDays); value != "" {
+		expiryWindowDays, err = strconv.Atoi(value)
+		if err != nil || expiryWindowDays < 0 {
+			return nil, fmt.Errorf("invalid environment variable: %s: %q", EnvKeyExpiryWindowDays, value)
+		}
+	}
+
 	return &Config{
 			Postgres: PostgresConfig{
 				Host:     config[EnvKeyDBHost],
@@ -76,8 +135,26 @@ func FromEnv() (*Config, error) {
 				DBName:   config[EnvKeyDBName],
 				SSLMode:  config[EnvKeyDBSSlMode],
 			},
-			LogLevel:              config[EnvKeyLogLevel],
-			DBMigrationSourcePath: config[EnvKeyDBMigrationSourcePath],
+			LogLevel:                 config[EnvKeyLogLevel],
+			DBMigrationSourcePath:    config[EnvKeyDBMigrationSourcePath],
+			AdminToken:               os.Getenv(EnvKeyAdminToken),
+			ReservationSweepInterval: reservationSweepInterval,
+			ExpirySweepInterval:      expirySweepInterval,
+			ExpiryWindowDays:         expiryWindowDays,
 		},
 		nil
 }
+
+// durationFromEnv reads an optional, positive duration such as "30s" from
+// the environment.
+func durationFromEnv(envKey string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(envKey)
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid environment variable: %s: %q", envKey, value)
+	}
+	return duration, nil
+}
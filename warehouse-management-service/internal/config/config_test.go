@@ -17,7 +17,10 @@ func TestFromFile(t *testing.T) {
 			DBName:   "db",
 			SSLMode:  "disable",
 		},
-		DBMigrationSourcePath: "file://warehouse-management-service/db/migrations",
+		DBMigrationSourcePath:    "file://warehouse-management-service/db/migrations",
+		ReservationSweepInterval: DefaultReservationSweepInterval,
+		ExpirySweepInterval:      DefaultExpirySweepInterval,
+		ExpiryWindowDays:         DefaultExpiryWindowDays,
 	}
 
 	if err != nil || !reflect.DeepEqual(config, wantConfig) {
@@ -38,3 +41,10 @@ func TestFromFileParseError(t *testing.T) {
 		t.Errorf("got %v, want %v:", err, "json error")
 	}
 }
+
+func TestFromFileInvalidInterval(t *testing.T) {
+	_, err := FromFile("./testdata/bad_interval_config.json")
+	if err == nil {
+		t.Errorf("got %v, want %v:", err, "invalid interval error")
+	}
+}
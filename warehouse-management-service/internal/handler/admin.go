@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+// requireAdmin only lets through requests that carry the admin token as a
+// bearer token. Admin routes are disabled when no token is configured.
+func (h *handler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.adminToken == "" {
+			h.logger.Log(log.Error, "admin route requested but no admin token is configured")
+			h.response(w, http.StatusForbidden, api.AdminResponse{Error: "admin routes are disabled"})
+			return
+		}
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+			h.logger.Log(log.Error, "admin route requested with an invalid token")
+			h.response(w, http.StatusUnauthorized, api.AdminResponse{Error: "invalid admin token"})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
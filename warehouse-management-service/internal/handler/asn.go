@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetASN(w http.ResponseWriter, r *http.Request) {
+	asnId := chi.URLParam(r, "asnId")
+
+	asn, err := h.asnService.GetASNById(r.Context(), asnId)
+	if err != nil {
+		if err == wms.ASNDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ASNResponse{Error: fmt.Sprintf(
+				"failed to get, asn: %s does not exist",
+				asnId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ASNResponse{Error: "Failed to get asn"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ASNResponse{Response: &asn})
+}
+
+func (h *handler) CreateASN(w http.ResponseWriter, r *http.Request) {
+	var createASNRequest api.CreateASNRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ASNResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createASNRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ASNResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(createASNRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ASNResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	lines := make([]wms.ASNLine, 0, len(createASNRequest.Lines))
+	for _, line := range createASNRequest.Lines {
+		lines = append(lines, wms.ASNLine{Sku: line.Sku, ExpectedQuantity: line.ExpectedQuantity})
+	}
+	asn := wms.NewASN(
+		createASNRequest.Supplier,
+		createASNRequest.WarehouseId,
+		*createASNRequest.ExpectedDate,
+		lines,
+	)
+
+	err = asn.Validate()
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ASNResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	err = h.asnService.CreateASN(r.Context(), asn)
+	if err != nil {
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ASNResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				asn.WarehouseId,
+			)})
+			return
+		} else if err == wms.InvalidProduct {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ASNResponse{Error: err.Error()})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ASNResponse{Error: "Failed to create asn"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.ASNResponse{Response: &asn})
+}
+
+func (h *handler) ReceiveASNLine(w http.ResponseWriter, r *http.Request) {
+	asnId := chi.URLParam(r, "asnId")
+	lineId := chi.URLParam(r, "lineId")
+	var receiveRequest api.ReceiveASNLineRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReceiveASNLineResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&receiveRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReceiveASNLineResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(receiveRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReceiveASNLineResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	line, items, err := h.asnService.ReceiveASNLine(
+		r.Context(),
+		asnId,
+		lineId,
+		receiveRequest.Quantity,
+		receiveRequest.ShelfId,
+		receiveRequest.ExpirationDate,
+		receiveRequest.Lot,
+		receiveRequest.SerialNumbers,
+	)
+	if err != nil {
+		if err == wms.ASNDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReceiveASNLineResponse{Error: fmt.Sprintf(
+				"failed to receive, asn: %s does not exist",
+				asnId,
+			)})
+			return
+		} else if err == wms.ASNLineDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReceiveASNLineResponse{Error: fmt.Sprintf(
+				"failed to receive, asn line: %s does not exist",
+				lineId,
+			)})
+			return
+		} else if err == wms.ASNClosedForReceipt {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ReceiveASNLineResponse{Error: fmt.Sprintf(
+				"failed to receive, asn: %s is closed",
+				asnId,
+			)})
+			return
+		} else if err == wms.InvalidShelf {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ReceiveASNLineResponse{Error: fmt.Sprintf(
+				"%s: %s is not in the warehouse of asn %s",
+				err.Error(),
+				receiveRequest.ShelfId,
+				asnId,
+			)})
+			return
+		} else if err == wms.LotRequired ||
+			err == wms.SerialNumberRequired ||
+			err == wms.SerialNumberCountMismatch ||
+			err == wms.RepeatedSerialNumber {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ReceiveASNLineResponse{
+				Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+			return
+		} else if err == wms.DuplicateSerialNumber {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ReceiveASNLineResponse{Error: err.Error()})
+			return
+		} else if errors.Is(err, wms.ShelfOverCapacity) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ReceiveASNLineResponse{Error: err.Error()})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ReceiveASNLineResponse{Error: "Failed to receive asn line"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.ReceiveASNLineResponse{Response: &line, Items: items})
+}
+
+func (h *handler) CloseASN(w http.ResponseWriter, r *http.Request) {
+	asnId := chi.URLParam(r, "asnId")
+
+	asn, err := h.asnService.CloseASN(r.Context(), asnId)
+	if err != nil {
+		if err == wms.ASNDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ASNResponse{Error: fmt.Sprintf(
+				"failed to close, asn: %s does not exist",
+				asnId,
+			)})
+			return
+		} else if err == wms.ASNClosedForReceipt {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ASNResponse{Error: fmt.Sprintf(
+				"failed to close, asn: %s is already closed",
+				asnId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ASNResponse{Error: "Failed to close asn"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.ASNResponse{Response: &asn, Discrepancies: asn.Discrepancies()})
+}
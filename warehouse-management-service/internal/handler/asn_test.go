@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+const asnId = "6a0c3e52-9c1e-4d57-8a3b-2f7e1b4c9d01"
+const asnLineId = "6a0c3e52-9c1e-4d57-8a3b-2f7e1b4c9d02"
+
+func TestCreateASN(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockASNService(mockCtrl)
+	h.asnService = mockObj
+
+	validBody := `{"supplier": "acme", "warehouseId": "` + warehouse.Id + `", "expectedDate": "2023-02-01T00:00:00Z",
+		"lines": [{"sku": "` + product.Sku + `", "expectedQuantity": 3}]}`
+
+	tests := []struct {
+		body           string
+		createASNErr   error
+		wantStatusCode int
+	}{
+		{body: validBody, wantStatusCode: http.StatusCreated},
+		{body: validBody, createASNErr: wms.WarehouseDoesNotExist, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createASNErr: wms.InvalidProduct, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createASNErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{
+			body:           `{"supplier": "acme", "warehouseId": "w", "expectedDate": "2023-02-01T00:00:00Z", "lines": []}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			body: `{"supplier": "acme", "warehouseId": "w", "expectedDate": "2023-02-01T00:00:00Z",
+				"lines": [{"sku": "a", "expectedQuantity": 0}]}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			body: `{"supplier": "acme", "warehouseId": "w", "expectedDate": "2023-02-01T00:00:00Z",
+				"lines": [{"sku": "a", "expectedQuantity": 1}, {"sku": "a", "expectedQuantity": 2}]}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			body:           `{"supplier": "acme", "warehouseId": "w", "lines": [{"sku": "a", "expectedQuantity": 1}]}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.createASNErr != nil {
+			mockObj.EXPECT().CreateASN(gomock.Any(), gomock.Any()).Return(test.createASNErr)
+		}
+
+		request, err := http.NewRequest("POST", "/asn", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s: want: %v, got: %v", test.body, test.wantStatusCode, response.StatusCode)
+		}
+		if response.StatusCode != http.StatusCreated {
+			continue
+		}
+
+		var got api.ASNResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got.Response == nil ||
+			got.Response.Status != wms.ASNOpen ||
+			len(got.Response.Lines) != 1 ||
+			got.Response.Lines[0].AsnId != got.Response.Id ||
+			got.Response.Lines[0].ReceiptStatus != wms.LinePending {
+			t.Errorf("want an open asn with one pending line, got: %v", got)
+		}
+	}
+}
+
+func TestReceiveASNLine(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockASNService(mockCtrl)
+	h.asnService = mockObj
+
+	line := wms.ASNLine{Id: asnLineId, AsnId: asnId, Sku: product.Sku, ExpectedQuantity: 3, ReceivedQuantity: 2, ReceiptStatus: wms.LineShort}
+	items := []wms.Item{wms.NewItem(product.Sku, nil, item.ShelfId, "", ""), wms.NewItem(product.Sku, nil, item.ShelfId, "", "")}
+
+	tests := []struct {
+		body           string
+		receiveErr     error
+		wantStatusCode int
+	}{
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, wantStatusCode: http.StatusOK},
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, receiveErr: wms.ASNDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, receiveErr: wms.ASNLineDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, receiveErr: wms.ASNClosedForReceipt, wantStatusCode: http.StatusConflict},
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, receiveErr: wms.InvalidShelf, wantStatusCode: http.StatusBadRequest},
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, receiveErr: wms.LotRequired, wantStatusCode: http.StatusBadRequest},
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, receiveErr: wms.SerialNumberCountMismatch, wantStatusCode: http.StatusBadRequest},
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, receiveErr: wms.DuplicateSerialNumber, wantStatusCode: http.StatusConflict},
+		{body: `{"quantity": 2, "shelfId": "` + item.ShelfId + `"}`, receiveErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"quantity": 0, "shelfId": "` + item.ShelfId + `"}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"quantity": 20000, "shelfId": "` + item.ShelfId + `"}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"quantity": 2}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode == http.StatusOK {
+			mockObj.EXPECT().ReceiveASNLine(gomock.Any(), asnId, asnLineId, 2, item.ShelfId, nil, "", nil).Return(line, items, nil)
+		} else if test.receiveErr != nil {
+			mockObj.EXPECT().ReceiveASNLine(gomock.Any(), asnId, asnLineId, 2, item.ShelfId, nil, "", nil).Return(wms.ASNLine{}, nil, test.receiveErr)
+		}
+
+		request, err := http.NewRequest(
+			"POST",
+			"/asn/"+asnId+"/line/"+asnLineId+"/receive",
+			strings.NewReader(test.body),
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.receiveErr, test.wantStatusCode, response.StatusCode)
+		}
+		if response.StatusCode != http.StatusOK {
+			continue
+		}
+
+		var got api.ReceiveASNLineResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got.Response == nil || *got.Response != line || len(got.Items) != len(items) {
+			t.Errorf("want: %v and %d items, got: %v", line, len(items), got)
+		}
+	}
+}
+
+func TestCloseASN(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockASNService(mockCtrl)
+	h.asnService = mockObj
+
+	closed := wms.ASN{
+		Id:     asnId,
+		Status: wms.ASNClosed,
+		Lines: []wms.ASNLine{
+			{Id: "complete", ExpectedQuantity: 2, ReceivedQuantity: 2, ReceiptStatus: wms.LineComplete},
+			{Id: "over", ExpectedQuantity: 2, ReceivedQuantity: 3, ReceiptStatus: wms.LineOver},
+		},
+	}
+
+	tests := []struct {
+		closeErr       error
+		wantStatusCode int
+	}{
+		{wantStatusCode: http.StatusOK},
+		{closeErr: wms.ASNDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{closeErr: wms.ASNClosedForReceipt, wantStatusCode: http.StatusConflict},
+		{closeErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		if test.closeErr == nil {
+			mockObj.EXPECT().CloseASN(gomock.Any(), asnId).Return(closed, nil)
+		} else {
+			mockObj.EXPECT().CloseASN(gomock.Any(), asnId).Return(wms.ASN{}, test.closeErr)
+		}
+
+		request, err := http.NewRequest("POST", "/asn/"+asnId+"/close", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ASNResponse
+		err = json.Unmarshal(responseBody, &got)
+		if err != nil {
+			t.Error(err)
+		}
+		if test.closeErr == nil && (len(got.Discrepancies) != 1 || got.Discrepancies[0].Id != "over") {
+			t.Errorf("want the over line as the only discrepancy, got: %v", got.Discrepancies)
+		}
+	}
+}
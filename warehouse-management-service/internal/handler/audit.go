@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+// actorHeader names whoever is making a request, it is recorded against
+// every change the request makes. The service does not authenticate it: the
+// audit trail is only as trustworthy as the clients, so the service has to
+// sit behind a gateway that authenticates callers and sets the header itself,
+// dropping any value the caller sent.
+const actorHeader = "X-Actor"
+
+var auditedEntities = map[string]struct{}{
+	wms.EntityWarehouse:  {},
+	wms.EntityShelfBlock: {},
+	wms.EntityShelf:      {},
+}
+
+func withActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(actorHeader); actor != "" {
+			r = r.WithContext(wms.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *handler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	entity := r.URL.Query().Get("entity")
+	entityId := r.URL.Query().Get("id")
+
+	if _, ok := auditedEntities[entity]; !ok {
+		err := fmt.Errorf("entity must be one of warehouse, shelf_block or shelf")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ListAuditEventsResponse{Error: err.Error()})
+		return
+	}
+	if entityId == "" {
+		err := fmt.Errorf("id cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ListAuditEventsResponse{Error: err.Error()})
+		return
+	}
+
+	cursor, limit, err := pageParams(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ListAuditEventsResponse{Error: err.Error()})
+		return
+	}
+	var after int64
+	if cursor != "" {
+		after, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ListAuditEventsResponse{Error: invalidCursor.Error()})
+			return
+		}
+	}
+
+	events, err := h.auditService.ListAuditEvents(r.Context(), entity, entityId, after, limit+1)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusInternalServerError, api.ListAuditEventsResponse{Error: "Failed to list audit events"})
+		return
+	}
+
+	response := api.ListAuditEventsResponse{Response: events}
+	if len(events) > limit {
+		response.Response = events[:limit]
+		response.NextCursor = encodeCursor(strconv.FormatInt(events[limit-1].Id, 10))
+	}
+	h.response(w, http.StatusOK, response)
+}
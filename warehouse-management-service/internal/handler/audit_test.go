@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockAuditService(mockCtrl)
+	h.auditService = mockObj
+
+	events := []wms.AuditEvent{
+		{Id: 1, Entity: wms.EntityShelf, EntityId: "shelf", Action: wms.AuditCreate},
+		{Id: 2, Entity: wms.EntityShelf, EntityId: "shelf", Action: wms.AuditUpdate, Actor: "alice"},
+	}
+
+	tests := []struct {
+		url                string
+		listAuditEvents    bool
+		wantAfter          int64
+		listAuditEventsRes []wms.AuditEvent
+		listAuditEventsErr error
+		wantStatusCode     int
+		wantCount          int
+		wantNextCursor     string
+	}{
+		{
+			url:            "/audit?entity=product&id=shelf",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			url:            "/audit?entity=shelf",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			url:            "/audit?entity=shelf&id=shelf&cursor=" + encodeCursor("one"),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			url:                "/audit?entity=shelf&id=shelf",
+			listAuditEvents:    true,
+			listAuditEventsRes: events,
+			wantStatusCode:     http.StatusOK,
+			wantCount:          2,
+		},
+		{
+			url:                "/audit?entity=shelf&id=shelf&limit=1",
+			listAuditEvents:    true,
+			listAuditEventsRes: events,
+			wantStatusCode:     http.StatusOK,
+			wantCount:          1,
+			wantNextCursor:     encodeCursor("1"),
+		},
+		{
+			url:                "/audit?entity=shelf&id=shelf&cursor=" + encodeCursor("1"),
+			listAuditEvents:    true,
+			wantAfter:          1,
+			listAuditEventsRes: events[1:],
+			wantStatusCode:     http.StatusOK,
+			wantCount:          1,
+		},
+		{
+			url:                "/audit?entity=shelf&id=shelf",
+			listAuditEvents:    true,
+			listAuditEventsErr: sql.ErrConnDone,
+			wantStatusCode:     http.StatusInternalServerError,
+		},
+	}
+	for _, test := range tests {
+		if test.listAuditEvents {
+			mockObj.EXPECT().
+				ListAuditEvents(gomock.Any(), wms.EntityShelf, "shelf", test.wantAfter, gomock.Any()).
+				Return(test.listAuditEventsRes, test.listAuditEventsErr)
+		}
+
+		request, err := http.NewRequest("GET", test.url, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		var got api.ListAuditEventsResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s want: %v, got: %v", test.url, test.wantStatusCode, response.StatusCode)
+		}
+		if len(got.Response) != test.wantCount || got.NextCursor != test.wantNextCursor {
+			t.Errorf("%s want: %d events, cursor: %q, got: %v", test.url, test.wantCount, test.wantNextCursor, got)
+		}
+	}
+}
+
+func TestWithActor(t *testing.T) {
+	var got string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = wms.ActorFromContext(r.Context())
+	})
+
+	request, err := http.NewRequestWithContext(context.Background(), "GET", "/", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set(actorHeader, "alice")
+	withActor(next).ServeHTTP(nil, request)
+
+	if got != "alice" {
+		t.Errorf("want: %v, got: %v", "alice", got)
+	}
+}
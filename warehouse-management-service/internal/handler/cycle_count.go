@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetCycleCount(w http.ResponseWriter, r *http.Request) {
+	cycleCountId := chi.URLParam(r, "cycleCountId")
+
+	cycleCount, err := h.cycleCountService.GetCycleCountById(r.Context(), cycleCountId)
+	if err != nil {
+		if err == wms.CycleCountDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.CycleCountResponse{Error: fmt.Sprintf(
+				"failed to get, cycle count: %s does not exist",
+				cycleCountId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.CycleCountResponse{Error: "Failed to get cycle count"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.CycleCountResponse{Response: &cycleCount})
+}
+
+func (h *handler) CreateCycleCount(w http.ResponseWriter, r *http.Request) {
+	var createCycleCountRequest api.CreateCycleCountRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createCycleCountRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	scope := wms.CycleCountScope{
+		ShelfId:      createCycleCountRequest.ShelfId,
+		ShelfBlockId: createCycleCountRequest.ShelfBlockId,
+	}
+	err = scope.Validate()
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	cycleCount, err := h.cycleCountService.CreateCycleCount(r.Context(), scope)
+	if err != nil {
+		if err == wms.InvalidShelf {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.CycleCountResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				scope.ShelfId,
+			)})
+			return
+		} else if err == wms.InvalidShelfBlock {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.CycleCountResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				scope.ShelfBlockId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.CycleCountResponse{Error: "Failed to create cycle count"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.CycleCountResponse{Response: &cycleCount})
+}
+
+func (h *handler) SubmitCounts(w http.ResponseWriter, r *http.Request) {
+	cycleCountId := chi.URLParam(r, "cycleCountId")
+	var submitCountsRequest api.SubmitCountsRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&submitCountsRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(submitCountsRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	counts := make([]wms.CycleCountLine, 0, len(submitCountsRequest.Counts))
+	for _, count := range submitCountsRequest.Counts {
+		counts = append(counts, wms.CycleCountLine{ShelfId: count.ShelfId, Sku: count.Sku, Quantity: count.Quantity})
+	}
+
+	cycleCount, err := h.cycleCountService.SubmitCounts(r.Context(), cycleCountId, counts)
+	if err != nil {
+		if err == wms.CycleCountDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.CycleCountResponse{Error: fmt.Sprintf(
+				"failed to submit counts, cycle count: %s does not exist",
+				cycleCountId,
+			)})
+			return
+		} else if err == wms.CycleCountNotOpen {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.CycleCountResponse{Error: fmt.Sprintf(
+				"failed to submit counts, cycle count: %s is not open",
+				cycleCountId,
+			)})
+			return
+		} else if err == wms.ShelfNotInCycleCount || err == wms.DuplicateCycleCountLine || err == wms.InvalidProduct {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+				Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.CycleCountResponse{Error: "Failed to submit counts"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.CycleCountResponse{Response: &cycleCount})
+}
+
+func (h *handler) GetVarianceReport(w http.ResponseWriter, r *http.Request) {
+	cycleCountId := chi.URLParam(r, "cycleCountId")
+
+	report, err := h.cycleCountService.GetVarianceReport(r.Context(), cycleCountId)
+	if err != nil {
+		if err == wms.CycleCountDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.VarianceReportResponse{Error: fmt.Sprintf(
+				"failed to get variance, cycle count: %s does not exist",
+				cycleCountId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.VarianceReportResponse{Error: "Failed to get variance report"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.VarianceReportResponse{Response: report})
+}
+
+func (h *handler) ApproveCycleCount(w http.ResponseWriter, r *http.Request) {
+	cycleCountId := chi.URLParam(r, "cycleCountId")
+	var approveRequest api.ApproveCycleCountRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&approveRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(approveRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	overrides := make([]wms.AdjustmentReason, 0, len(approveRequest.Reasons))
+	for _, reason := range approveRequest.Reasons {
+		overrides = append(overrides, wms.AdjustmentReason{ShelfId: reason.ShelfId, Sku: reason.Sku, Reason: reason.Reason})
+	}
+
+	cycleCount, err := h.cycleCountService.ApproveCycleCount(r.Context(), cycleCountId, approveRequest.Reason, overrides)
+	if err != nil {
+		if err == wms.CycleCountDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.CycleCountResponse{Error: fmt.Sprintf(
+				"failed to approve, cycle count: %s does not exist",
+				cycleCountId,
+			)})
+			return
+		} else if err == wms.CycleCountNotOpen {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.CycleCountResponse{Error: fmt.Sprintf(
+				"failed to approve, cycle count: %s is not open",
+				cycleCountId,
+			)})
+			return
+		} else if err == wms.InvalidAdjustmentReason {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.CycleCountResponse{
+				Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+			return
+		} else if err == wms.LotRequired || err == wms.SerialNumberRequired || err == wms.ExpirationDateRequired {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.CycleCountResponse{Error: fmt.Sprintf(
+				"failed to approve, cycle count: %s found items that have to be received, %s",
+				cycleCountId,
+				err.Error(),
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.CycleCountResponse{Error: "Failed to approve cycle count"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.CycleCountResponse{Response: &cycleCount})
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+const cycleCountId = "8d3f5a2b-4c6e-4f81-9b0a-1d2e3f4a5b60"
+
+func TestCreateCycleCount(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockCycleCountService(mockCtrl)
+	h.cycleCountService = mockObj
+
+	tests := []struct {
+		body           string
+		createErr      error
+		wantStatusCode int
+	}{
+		{body: `{"shelfId": "s"}`, wantStatusCode: http.StatusCreated},
+		{body: `{"shelfBlockId": "b"}`, wantStatusCode: http.StatusCreated},
+		{body: `{"shelfId": "s"}`, createErr: wms.InvalidShelf, wantStatusCode: http.StatusBadRequest},
+		{body: `{"shelfBlockId": "b"}`, createErr: wms.InvalidShelfBlock, wantStatusCode: http.StatusBadRequest},
+		{body: `{"shelfId": "s"}`, createErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"shelfId": "s", "shelfBlockId": "b"}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.createErr != nil {
+			mockObj.EXPECT().CreateCycleCount(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, scope wms.CycleCountScope) (wms.CycleCount, error) {
+					if test.createErr != nil {
+						return wms.CycleCount{}, test.createErr
+					}
+					return wms.NewCycleCount(scope, warehouse.Id), nil
+				},
+			)
+		}
+
+		request, err := http.NewRequest("POST", "/cycle_count", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.createErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.CycleCountResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if test.wantStatusCode == http.StatusCreated && (got.Response == nil || got.Response.Status != wms.CycleCountOpen) {
+			t.Errorf("want an open cycle count, got: %v", got)
+		}
+	}
+}
+
+func TestSubmitCounts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockCycleCountService(mockCtrl)
+	h.cycleCountService = mockObj
+
+	validBody := `{"counts": [{"shelfId": "s", "sku": "` + product.Sku + `", "quantity": 0}]}`
+
+	tests := []struct {
+		body           string
+		submitErr      error
+		wantStatusCode int
+	}{
+		{body: validBody, wantStatusCode: http.StatusOK},
+		{body: validBody, submitErr: wms.CycleCountDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{body: validBody, submitErr: wms.CycleCountNotOpen, wantStatusCode: http.StatusConflict},
+		{body: validBody, submitErr: wms.ShelfNotInCycleCount, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, submitErr: wms.InvalidProduct, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, submitErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"counts": []}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"counts": [{"sku": "a", "quantity": -1}]}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.submitErr != nil {
+			wantCounts := []wms.CycleCountLine{{ShelfId: "s", Sku: product.Sku}}
+			mockObj.EXPECT().SubmitCounts(gomock.Any(), cycleCountId, wantCounts).Return(
+				wms.CycleCount{Id: cycleCountId, Status: wms.CycleCountOpen, Counts: wantCounts},
+				test.submitErr,
+			)
+		}
+
+		request, err := http.NewRequest("POST", "/cycle_count/"+cycleCountId+"/counts", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.submitErr, test.wantStatusCode, response.StatusCode)
+		}
+	}
+}
+
+func TestGetVarianceReport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockCycleCountService(mockCtrl)
+	h.cycleCountService = mockObj
+
+	counted := 1
+	report := []wms.Variance{{ShelfId: "s", Sku: product.Sku, Expected: 3, Counted: &counted, Variance: -2}}
+	mockObj.EXPECT().GetVarianceReport(gomock.Any(), cycleCountId).Return(report, nil)
+
+	request, err := http.NewRequest("GET", "/cycle_count/"+cycleCountId+"/variance", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("want: %v, got: %v", http.StatusOK, response.StatusCode)
+	}
+	var got api.VarianceReportResponse
+	err = json.NewDecoder(response.Body).Decode(&got)
+	if err != nil || !reflect.DeepEqual(got.Response, report) {
+		t.Errorf("want: %v, got: %v, %v", report, got, err)
+	}
+
+	mockObj.EXPECT().GetVarianceReport(gomock.Any(), cycleCountId).Return(nil, wms.CycleCountDoesNotExist)
+	response = executeRequest(request)
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("want: %v, got: %v", http.StatusNotFound, response.StatusCode)
+	}
+}
+
+func TestApproveCycleCount(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockCycleCountService(mockCtrl)
+	h.cycleCountService = mockObj
+
+	validBody := `{"reason": "lost", "reasons": [{"shelfId": "s", "sku": "a", "reason": "found"}]}`
+
+	tests := []struct {
+		body           string
+		approveErr     error
+		wantStatusCode int
+		wantResponse   api.CycleCountResponse
+	}{
+		{
+			body:           validBody,
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.CycleCountResponse{Response: &wms.CycleCount{Id: cycleCountId, Status: wms.CycleCountApproved}},
+		},
+		{
+			body:           validBody,
+			approveErr:     wms.CycleCountDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.CycleCountResponse{Error: "failed to approve, cycle count: " + cycleCountId + " does not exist"},
+		},
+		{
+			body:           validBody,
+			approveErr:     wms.CycleCountNotOpen,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.CycleCountResponse{Error: "failed to approve, cycle count: " + cycleCountId + " is not open"},
+		},
+		{
+			body:           validBody,
+			approveErr:     wms.InvalidAdjustmentReason,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.CycleCountResponse{Error: "Invalid input: " + wms.InvalidAdjustmentReason.Error()},
+		},
+		{
+			body:           validBody,
+			approveErr:     wms.SerialNumberRequired,
+			wantStatusCode: http.StatusConflict,
+			wantResponse: api.CycleCountResponse{Error: "failed to approve, cycle count: " + cycleCountId +
+				" found items that have to be received, " + wms.SerialNumberRequired.Error()},
+		},
+		{
+			body:           validBody,
+			approveErr:     wms.ExpirationDateRequired,
+			wantStatusCode: http.StatusConflict,
+			wantResponse: api.CycleCountResponse{Error: "failed to approve, cycle count: " + cycleCountId +
+				" found items that have to be received, " + wms.ExpirationDateRequired.Error()},
+		},
+		{
+			body:           validBody,
+			approveErr:     sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.CycleCountResponse{Error: "Failed to approve cycle count"},
+		},
+		{
+			body:           `{"reasons": []}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.CycleCountResponse{Error: "Invalid input: Reason: zero value"},
+		},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.approveErr != nil {
+			var cycleCount wms.CycleCount
+			if test.wantResponse.Response != nil {
+				cycleCount = *test.wantResponse.Response
+			}
+			mockObj.EXPECT().ApproveCycleCount(
+				gomock.Any(),
+				cycleCountId,
+				wms.AdjustmentLost,
+				[]wms.AdjustmentReason{{ShelfId: "s", Sku: "a", Reason: wms.AdjustmentFound}},
+			).Return(cycleCount, test.approveErr)
+		}
+
+		request, err := http.NewRequest("POST", "/cycle_count/"+cycleCountId+"/approve", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.approveErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.CycleCountResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
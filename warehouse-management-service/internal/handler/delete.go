@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	wms "warehouse-management-service"
+)
+
+var invalidCascade = errors.New("cascade must be true or false")
+
+// cascadeParam reads the cascade query parameter of a delete request.
+func cascadeParam(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("cascade")
+	if value == "" {
+		return false, nil
+	}
+
+	cascade, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, invalidCascade
+	}
+	return cascade, nil
+}
+
+// dependentsOf returns the rows blocking a delete, if err is a
+// *wms.DependentsError.
+func dependentsOf(err error) ([]wms.Dependent, bool) {
+	var dependentsError *wms.DependentsError
+	if errors.As(err, &dependentsError) {
+		return dependentsError.Dependents, true
+	}
+	return nil, false
+}
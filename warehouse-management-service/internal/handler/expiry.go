@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"time"
+	wms "warehouse-management-service"
+	"warehouse-management-service/internal/config"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+// maxExpiringWithinDays bounds how far ahead the expiring items of a
+// warehouse can be listed.
+const maxExpiringWithinDays = 365
+
+func (h *handler) ListExpiringItems(w http.ResponseWriter, r *http.Request) {
+	warehouseId := chi.URLParam(r, "warehouseId")
+
+	withinDays := config.DefaultExpiryWindowDays
+	if value := r.URL.Query().Get("withinDays"); value != "" {
+		var err error
+		withinDays, err = strconv.Atoi(value)
+		if err != nil || withinDays < 0 || withinDays > maxExpiringWithinDays {
+			err := fmt.Errorf("withinDays must be a number between 0 and %d", maxExpiringWithinDays)
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ListExpiringItemsResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	items, err := h.expiryService.ListExpiringItems(r.Context(), warehouseId, time.Now().UTC(), withinDays)
+	if err != nil {
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ListExpiringItemsResponse{Error: fmt.Sprintf(
+				"failed to list expiring items, warehouse: %s does not exist",
+				warehouseId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ListExpiringItemsResponse{Error: "Failed to list expiring items"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ListExpiringItemsResponse{Response: items})
+}
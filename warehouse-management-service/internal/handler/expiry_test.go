@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"testing"
+	wms "warehouse-management-service"
+	"warehouse-management-service/internal/config"
+	mock "warehouse-management-service/internal/handler/mock"
+)
+
+func TestListExpiringItems(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockExpiryService(mockCtrl)
+	h.expiryService = mockObj
+
+	tests := []struct {
+		query          string
+		wantWithinDays int
+		listErr        error
+		wantStatusCode int
+	}{
+		{query: "", wantWithinDays: config.DefaultExpiryWindowDays, wantStatusCode: http.StatusOK},
+		{query: "?withinDays=30", wantWithinDays: 30, wantStatusCode: http.StatusOK},
+		{query: "?withinDays=0", wantWithinDays: 0, wantStatusCode: http.StatusOK},
+		{query: "?withinDays=3", wantWithinDays: 3, listErr: wms.WarehouseDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{query: "?withinDays=3", wantWithinDays: 3, listErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{query: "?withinDays=-1", wantStatusCode: http.StatusBadRequest},
+		{query: "?withinDays=366", wantStatusCode: http.StatusBadRequest},
+		{query: "?withinDays=week", wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest {
+			mockObj.EXPECT().
+				ListExpiringItems(gomock.Any(), warehouse.Id, gomock.Any(), test.wantWithinDays).
+				Return([]wms.ExpiringItem{}, test.listErr)
+		}
+
+		request, err := http.NewRequest("GET", "/warehouse/"+warehouse.Id+"/expiring"+test.query, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s: want: %v, got: %v", test.query, test.wantStatusCode, response.StatusCode)
+		}
+	}
+}
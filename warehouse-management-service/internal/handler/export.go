@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/export"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) ExportWarehouse(w http.ResponseWriter, r *http.Request) {
+	warehouseId := chi.URLParam(r, "warehouseId")
+
+	formatName := r.URL.Query().Get("format")
+	if formatName == "" {
+		formatName = "csv"
+	}
+	format, ok := export.Formats[formatName]
+	if !ok {
+		err := fmt.Errorf("format must be one of csv, jsonl or xlsx, got: %s", formatName)
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.WarehouseResponse{Error: err.Error()})
+		return
+	}
+
+	// the writer is only created once the first row is in, so that a
+	// missing warehouse can still be answered with a 404
+	var writer export.Writer
+	start := func() {
+		w.Header().Set("Content-Type", format.ContentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(
+			`attachment; filename="warehouse-%s.%s"`,
+			warehouseId,
+			format.Extension,
+		))
+		writer = format.NewWriter(w)
+	}
+	err := h.exportService.ExportWarehouse(r.Context(), warehouseId, func(row wms.ExportRow) error {
+		if writer == nil {
+			start()
+		}
+		return writer.Write(row)
+	})
+	if err == nil {
+		if writer == nil {
+			start()
+		}
+		err = writer.Close()
+	}
+	if err != nil && writer != nil {
+		// part of the export has been sent already, break the connection
+		// rather than let it pass for a complete file
+		h.logger.Log(log.Error, err)
+		panic(http.ErrAbortHandler)
+	}
+
+	if err != nil {
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.WarehouseResponse{Error: fmt.Sprintf(
+				"failed to export, warehouse: %s does not exist",
+				warehouseId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.WarehouseResponse{Error: "Failed to export warehouse"})
+			return
+		}
+	}
+}
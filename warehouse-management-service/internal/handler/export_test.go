@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+)
+
+var exportRows = []wms.ExportRow{
+	{WarehouseId: warehouse.Id, WarehouseName: warehouse.Name, ShelfBlockId: "block", Aisle: "1", Rack: "1"},
+	{WarehouseId: warehouse.Id, WarehouseName: warehouse.Name, ShelfBlockId: "block", ShelfId: "shelf", ItemId: "item"},
+}
+
+// exportRowsThen feeds exportRows to the callback of ExportWarehouse and then
+// returns err.
+func exportRowsThen(err error) func(context.Context, string, func(wms.ExportRow) error) error {
+	return func(ctx context.Context, warehouseId string, each func(wms.ExportRow) error) error {
+		for _, row := range exportRows {
+			eachErr := each(row)
+			if eachErr != nil {
+				return eachErr
+			}
+		}
+		return err
+	}
+}
+
+func TestExportWarehouse(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockExportService(mockCtrl)
+	h.exportService = mockObj
+
+	mockObj.EXPECT().ExportWarehouse(gomock.Any(), warehouse.Id, gomock.Any()).DoAndReturn(exportRowsThen(nil))
+
+	request, err := http.NewRequest("GET", "/warehouse/"+warehouse.Id+"/export", nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("want: %v, got: %v", http.StatusOK, response.StatusCode)
+	}
+	if got := response.Header.Get("Content-Type"); got != "text/csv" {
+		t.Errorf("want: %v, got: %v", "text/csv", got)
+	}
+	wantDisposition := `attachment; filename="warehouse-` + warehouse.Id + `.csv"`
+	if got := response.Header.Get("Content-Disposition"); got != wantDisposition {
+		t.Errorf("want: %v, got: %v", wantDisposition, got)
+	}
+
+	records, err := csv.NewReader(response.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{wms.ExportColumns, exportRows[0].Record(), exportRows[1].Record()}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("want: %v, got: %v", want, records)
+	}
+}
+
+func TestExportWarehouseErrors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockExportService(mockCtrl)
+	h.exportService = mockObj
+
+	tests := []struct {
+		format         string
+		exportErr      error
+		wantStatusCode int
+	}{
+		{format: "pdf", wantStatusCode: http.StatusBadRequest},
+		{format: "jsonl", exportErr: wms.WarehouseDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{format: "xlsx", exportErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		if test.exportErr != nil {
+			mockObj.EXPECT().ExportWarehouse(gomock.Any(), warehouse.Id, gomock.Any()).Return(test.exportErr)
+		}
+
+		request, err := http.NewRequest("GET", "/warehouse/"+warehouse.Id+"/export?format="+test.format, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		if got := response.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("want: %v, got: %v", "application/json", got)
+		}
+	}
+}
+
+func TestExportWarehouseFailsMidStream(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockExportService(mockCtrl)
+	h.exportService = mockObj
+
+	mockObj.EXPECT().ExportWarehouse(gomock.Any(), warehouse.Id, gomock.Any()).DoAndReturn(exportRowsThen(sql.ErrConnDone))
+
+	request, err := http.NewRequest("GET", "/warehouse/"+warehouse.Id+"/export", nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Errorf("want: %v, got: %v", http.ErrAbortHandler, recovered)
+		}
+	}()
+	executeRequest(request)
+}
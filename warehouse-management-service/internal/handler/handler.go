@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"gopkg.in/validator.v2"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 	wms "warehouse-management-service"
 	"warehouse-management-service/pkg/api"
 	"warehouse-management-service/pkg/log"
@@ -17,7 +20,12 @@ type WarehouseService interface {
 	GetWarehouseById(ctx context.Context, id string) (*wms.Warehouse, error)
 	CreateWarehouse(ctx context.Context, warehouse *wms.Warehouse) error
 	UpdateWarehouse(ctx context.Context, warehouse *wms.Warehouse) error
-	DeleteWarehouse(ctx context.Context, id string) error
+	DeleteWarehouse(ctx context.Context, id string, version int, cascade bool) error
+	RestoreWarehouse(ctx context.Context, id string) error
+	PurgeWarehouse(ctx context.Context, id string) error
+	ListWarehouses(ctx context.Context, after string, limit int) ([]wms.Warehouse, error)
+	NearestWarehouses(ctx context.Context, latitude, longitude float64, radiusInKm *float64, limit int) ([]wms.NearbyWarehouse, error)
+	GetWarehouseLayout(ctx context.Context, id string, includeItemCounts bool) (wms.WarehouseLayout, error)
 }
 
 // mockgen -source="./shelf_block.go" -destination="./internal/handler/mock/shelf_block.go"
@@ -25,34 +33,202 @@ type ShelfBlockService interface {
 	GetShelfBlockById(ctx context.Context, id string) (wms.ShelfBlock, error)
 	CreateShelfBlock(ctx context.Context, shelfBlock wms.ShelfBlock) error
 	UpdateShelfBlock(ctx context.Context, shelfBlock wms.ShelfBlock) error
-	DeleteShelfBlockById(ctx context.Context, id string) error
+	DeleteShelfBlockById(ctx context.Context, id string, version int, cascade bool) error
+	RestoreShelfBlockById(ctx context.Context, id string) error
+	PurgeShelfBlockById(ctx context.Context, id string) error
+	ListShelfBlocks(ctx context.Context, warehouseId string, after string, limit int) ([]wms.ShelfBlock, error)
 }
 
 type ShelfService interface {
 	GetShelfById(ctx context.Context, id string) (wms.Shelf, error)
+	GetShelfUtilization(ctx context.Context, id string) (wms.ShelfUtilization, error)
 	CreateShelf(ctx context.Context, shelf wms.Shelf) error
 	UpdateShelf(ctx context.Context, shelf wms.Shelf) error
-	DeleteShelfById(ctx context.Context, id string) error
+	DeleteShelfById(ctx context.Context, id string, version int, cascade bool) error
+	RestoreShelfById(ctx context.Context, id string) error
+	PurgeShelfById(ctx context.Context, id string) error
+	ListShelves(ctx context.Context, shelfBlockId string, after string, limit int) ([]wms.Shelf, error)
+}
+
+// mockgen -source="./product.go" -destination="./internal/handler/mock/product.go"
+type ProductService interface {
+	GetProductBySku(ctx context.Context, sku string) (wms.Product, error)
+	CreateProduct(ctx context.Context, product wms.Product) error
+	UpdateProduct(ctx context.Context, product wms.Product) error
+	DeleteProductBySku(ctx context.Context, sku string) error
+}
+
+// mockgen -source="./item.go" -destination="./internal/handler/mock/item.go"
+type ItemService interface {
+	GetItemById(ctx context.Context, id string) (wms.Item, error)
+	GetItemBySerialNumber(ctx context.Context, serialNumber string) (wms.Item, error)
+	CreateItem(ctx context.Context, item wms.Item) error
+	MoveItem(ctx context.Context, id string, shelfId string) error
+	DeleteItemById(ctx context.Context, id string) error
+}
+
+// mockgen -source="./audit.go" -destination="./internal/handler/mock/audit.go"
+type AuditService interface {
+	ListAuditEvents(ctx context.Context, entity string, entityId string, after int64, limit int) ([]wms.AuditEvent, error)
+}
+
+// mockgen -source="./layout_import.go" -destination="./internal/handler/mock/layout_import.go"
+type LayoutImportService interface {
+	ImportLayout(ctx context.Context, warehouseId string, rows []wms.LayoutImportRow) (wms.LayoutImport, error)
+}
+
+// mockgen -source="./export.go" -destination="./internal/handler/mock/export.go"
+type ExportService interface {
+	ExportWarehouse(ctx context.Context, warehouseId string, each func(wms.ExportRow) error) error
+}
+
+// mockgen -source="./asn.go" -destination="./internal/handler/mock/asn.go"
+type ASNService interface {
+	GetASNById(ctx context.Context, id string) (wms.ASN, error)
+	CreateASN(ctx context.Context, asn wms.ASN) error
+	ReceiveASNLine(ctx context.Context, asnId string, lineId string, quantity int, shelfId string, expirationDate *time.Time, lot string, serialNumbers []string) (wms.ASNLine, []wms.Item, error)
+	CloseASN(ctx context.Context, id string) (wms.ASN, error)
+}
+
+// mockgen -source="./putaway.go" -destination="./internal/handler/mock/putaway.go"
+type PutawayService interface {
+	SuggestPutaway(ctx context.Context, warehouseId string, sku string, quantity int, limit int) ([]wms.PutawaySuggestion, error)
+}
+
+// mockgen -source="./order.go" -destination="./internal/handler/mock/order.go"
+type OrderService interface {
+	GetOrderById(ctx context.Context, id string) (wms.Order, error)
+	CreateOrder(ctx context.Context, order wms.Order) error
+	GeneratePickList(ctx context.Context, orderId string) (wms.PickList, error)
+	GetPickList(ctx context.Context, orderId string) (wms.PickList, error)
+}
+
+// mockgen -source="./reservation.go" -destination="./internal/handler/mock/reservation.go"
+type ReservationService interface {
+	GetReservationById(ctx context.Context, id string) (wms.Reservation, error)
+	CreateReservation(ctx context.Context, reservation wms.Reservation) (wms.Reservation, error)
+	ConfirmReservation(ctx context.Context, id string) (wms.Reservation, error)
+	ReleaseReservation(ctx context.Context, id string) (wms.Reservation, error)
+}
+
+// mockgen -source="./stock.go" -destination="./internal/handler/mock/stock.go"
+type StockService interface {
+	GetProductStock(ctx context.Context, sku string) (wms.ProductStock, error)
+	GetWarehouseInventory(ctx context.Context, warehouseId string) (wms.WarehouseInventory, error)
+}
+
+// mockgen -source="./expiry.go" -destination="./internal/handler/mock/expiry.go"
+type ExpiryService interface {
+	ListExpiringItems(ctx context.Context, warehouseId string, now time.Time, withinDays int) ([]wms.ExpiringItem, error)
+}
+
+// mockgen -source="./transfer.go" -destination="./internal/handler/mock/transfer.go"
+type TransferService interface {
+	GetTransferById(ctx context.Context, id string) (wms.Transfer, error)
+	CreateTransfer(ctx context.Context, selection wms.TransferSelection, toShelfId string) (wms.Transfer, error)
+	ReceiveTransfer(ctx context.Context, id string) (wms.Transfer, error)
+}
+
+// mockgen -source="./cycle_count.go" -destination="./internal/handler/mock/cycle_count.go"
+type CycleCountService interface {
+	GetCycleCountById(ctx context.Context, id string) (wms.CycleCount, error)
+	CreateCycleCount(ctx context.Context, scope wms.CycleCountScope) (wms.CycleCount, error)
+	SubmitCounts(ctx context.Context, id string, counts []wms.CycleCountLine) (wms.CycleCount, error)
+	GetVarianceReport(ctx context.Context, id string) ([]wms.Variance, error)
+	ApproveCycleCount(ctx context.Context, id string, reason string, overrides []wms.AdjustmentReason) (wms.CycleCount, error)
+}
+
+// mockgen -source="./shipment.go" -destination="./internal/handler/mock/shipment.go"
+type ShipmentService interface {
+	GetShipmentById(ctx context.Context, id string) (wms.Shipment, error)
+	CreateShipment(ctx context.Context, shipment wms.Shipment) error
+	PackItems(ctx context.Context, shipmentId string, trackingNumber string, itemIds []string) (wms.Package, error)
+	DispatchShipment(ctx context.Context, id string) (wms.Shipment, error)
+}
+
+// mockgen -source="./return_authorization.go" -destination="./internal/handler/mock/return_authorization.go"
+type ReturnService interface {
+	GetReturnById(ctx context.Context, id string) (wms.ReturnAuthorization, error)
+	CreateReturn(ctx context.Context, shipmentId string, reason string, itemIds []string) (wms.ReturnAuthorization, error)
+	InspectReturnItem(ctx context.Context, returnId string, itemId string, condition string, notes string) (wms.ReturnItem, error)
+	ResolveReturnItem(ctx context.Context, returnId string, itemId string, outcome string, shelfId string) (wms.ReturnItem, error)
+}
+
+// mockgen -source="./lot.go" -destination="./internal/handler/mock/lot.go"
+type LotService interface {
+	GetLotRecall(ctx context.Context, lot string) (wms.LotRecall, error)
 }
 
 type handler struct {
-	warehouseService  WarehouseService
-	shelfBlockService ShelfBlockService
-	shelfService      ShelfService
-	logger            log.Logger
-}
-
-func New(
-	logger log.Logger,
-	warehouseService WarehouseService,
-	shelfBlockService ShelfBlockService,
-	shelfService ShelfService,
-) http.Handler {
+	warehouseService   WarehouseService
+	shelfBlockService  ShelfBlockService
+	shelfService       ShelfService
+	productService     ProductService
+	itemService        ItemService
+	auditService       AuditService
+	importService      LayoutImportService
+	exportService      ExportService
+	asnService         ASNService
+	putawayService     PutawayService
+	orderService       OrderService
+	reservationService ReservationService
+	stockService       StockService
+	expiryService      ExpiryService
+	transferService    TransferService
+	cycleCountService  CycleCountService
+	shipmentService    ShipmentService
+	returnService      ReturnService
+	lotService         LotService
+	logger             log.Logger
+	adminToken         string
+}
+
+// Services are the services the handler serves requests with.
+type Services struct {
+	Warehouse    WarehouseService
+	ShelfBlock   ShelfBlockService
+	Shelf        ShelfService
+	Product      ProductService
+	Item         ItemService
+	Audit        AuditService
+	LayoutImport LayoutImportService
+	Export       ExportService
+	ASN          ASNService
+	Putaway      PutawayService
+	Order        OrderService
+	Reservation  ReservationService
+	Stock        StockService
+	Expiry       ExpiryService
+	Transfer     TransferService
+	CycleCount   CycleCountService
+	Shipment     ShipmentService
+	Return       ReturnService
+	Lot          LotService
+}
+
+func New(logger log.Logger, services Services, adminToken string) http.Handler {
 	handler := &handler{
-		logger:            logger,
-		warehouseService:  warehouseService,
-		shelfBlockService: shelfBlockService,
-		shelfService:      shelfService,
+		logger:             logger,
+		warehouseService:   services.Warehouse,
+		shelfBlockService:  services.ShelfBlock,
+		shelfService:       services.Shelf,
+		productService:     services.Product,
+		itemService:        services.Item,
+		auditService:       services.Audit,
+		importService:      services.LayoutImport,
+		exportService:      services.Export,
+		asnService:         services.ASN,
+		putawayService:     services.Putaway,
+		orderService:       services.Order,
+		reservationService: services.Reservation,
+		stockService:       services.Stock,
+		expiryService:      services.Expiry,
+		transferService:    services.Transfer,
+		cycleCountService:  services.CycleCount,
+		shipmentService:    services.Shipment,
+		returnService:      services.Return,
+		lotService:         services.Lot,
+		adminToken:         adminToken,
 	}
 	return handler.router()
 }
@@ -91,9 +267,43 @@ func (h *handler) GetWarehouse(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	setETag(w, warehouse.Version)
 	h.response(w, http.StatusOK, api.GetWarehouseResponse{Response: *warehouse})
 }
 
+func (h *handler) GetWarehouseLayout(w http.ResponseWriter, r *http.Request) {
+	warehouseId := chi.URLParam(r, "warehouseId")
+
+	includeItemCounts := false
+	if value := r.URL.Query().Get("itemCounts"); value != "" {
+		var err error
+		includeItemCounts, err = strconv.ParseBool(value)
+		if err != nil {
+			err := fmt.Errorf("itemCounts must be true or false")
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.GetWarehouseLayoutResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	layout, err := h.warehouseService.GetWarehouseLayout(r.Context(), warehouseId, includeItemCounts)
+	if err != nil {
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.GetWarehouseLayoutResponse{Error: fmt.Sprintf(
+				"failed to get layout, warehouse: %s does not exist",
+				warehouseId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.GetWarehouseLayoutResponse{Error: "Failed to get warehouse layout"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.GetWarehouseLayoutResponse{Response: layout})
+}
+
 func (h *handler) CreateWarehouse(w http.ResponseWriter, r *http.Request) {
 	var createWarehouseRequest api.CreateWarehouseRequest
 
@@ -147,6 +357,13 @@ func (h *handler) CreateWarehouse(w http.ResponseWriter, r *http.Request) {
 func (h *handler) UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
 	var updateWarehouseRequest api.UpdateWarehouseRequest
 
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.WarehouseResponse{Error: err.Error()})
+		return
+	}
+
 	if r.Body == nil {
 		err := fmt.Errorf("request body cannot be empty")
 		h.logger.Log(log.Error, err)
@@ -159,7 +376,7 @@ func (h *handler) UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 
-	err := decoder.Decode(&updateWarehouseRequest)
+	err = decoder.Decode(&updateWarehouseRequest)
 	if err != nil {
 		h.logger.Log(log.Error, err)
 		h.response(w, http.StatusBadRequest, api.WarehouseResponse{
@@ -167,7 +384,13 @@ func (h *handler) UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = validator.Validate(updateWarehouseRequest)
+	h.updateWarehouse(w, r, updateWarehouseRequest, version)
+}
+
+// updateWarehouse validates updateWarehouseRequest and persists it against
+// version, for both PUT and PATCH.
+func (h *handler) updateWarehouse(w http.ResponseWriter, r *http.Request, updateWarehouseRequest api.UpdateWarehouseRequest, version int) {
+	err := validator.Validate(updateWarehouseRequest)
 	if err != nil {
 		h.logger.Log(log.Error, err)
 		h.response(w, http.StatusBadRequest, api.WarehouseResponse{
@@ -180,8 +403,17 @@ func (h *handler) UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
 		Name:      updateWarehouseRequest.Name,
 		Latitude:  updateWarehouseRequest.Latitude,
 		Longitude: updateWarehouseRequest.Longitude,
+		Version:   version,
 	})
 	if err != nil {
+		if err == wms.VersionMismatch {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusPreconditionFailed, api.WarehouseResponse{Error: fmt.Sprintf(
+				"failed to update, warehouse: %s has been modified",
+				updateWarehouseRequest.Id,
+			)})
+			return
+		}
 		if err == wms.WarehouseDoesNotExist {
 			h.logger.Log(log.Error, err)
 			h.response(w, http.StatusNotFound, api.WarehouseResponse{Error: fmt.Sprintf(
@@ -200,6 +432,7 @@ func (h *handler) UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, version+1)
 	h.response(
 		w,
 		http.StatusOK,
@@ -221,8 +454,41 @@ func (h *handler) DeleteWarehouse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.warehouseService.DeleteWarehouse(r.Context(), warehouseId)
+	cascade, err := cascadeParam(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.WarehouseResponse{Error: err.Error()})
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.WarehouseResponse{Error: err.Error()})
+		return
+	}
+
+	err = h.warehouseService.DeleteWarehouse(r.Context(), warehouseId, version, cascade)
 	if err != nil {
+		if err == wms.VersionMismatch {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusPreconditionFailed, api.WarehouseResponse{Error: fmt.Sprintf(
+				"failed to delete, warehouse: %s has been modified",
+				warehouseId,
+			)})
+			return
+		}
+		if dependents, ok := dependentsOf(err); ok {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.DependentsResponse{
+				Error: fmt.Sprintf(
+					"failed to delete, warehouse: %s has dependents",
+					warehouseId,
+				),
+				Dependents: dependents,
+			})
+			return
+		}
 		if err == wms.WarehouseDoesNotExist {
 			h.logger.Log(log.Error, err)
 			h.response(w, http.StatusNotFound, api.WarehouseResponse{Error: fmt.Sprintf(
@@ -251,6 +517,81 @@ func (h *handler) DeleteWarehouse(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+func (h *handler) ListWarehouses(w http.ResponseWriter, r *http.Request) {
+	after, limit, err := pageParams(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ListWarehousesResponse{Error: err.Error()})
+		return
+	}
+
+	// fetch one extra row to find out if there is a next page
+	warehouses, err := h.warehouseService.ListWarehouses(r.Context(), after, limit+1)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusInternalServerError, api.ListWarehousesResponse{Error: "Failed to list warehouses"})
+		return
+	}
+
+	response := api.ListWarehousesResponse{Response: warehouses}
+	if len(warehouses) > limit {
+		response.Response = warehouses[:limit]
+		response.NextCursor = encodeCursor(warehouses[limit-1].Id)
+	}
+	h.response(w, http.StatusOK, response)
+}
+
+func (h *handler) NearestWarehouses(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	latitude, err := strconv.ParseFloat(query.Get("lat"), 64)
+	// the comparisons are negated so that NaN, which compares false, fails them
+	if err != nil || !(latitude >= -90 && latitude <= 90) {
+		err := fmt.Errorf("lat must be a number between -90 and 90")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.NearestWarehousesResponse{Error: err.Error()})
+		return
+	}
+
+	longitude, err := strconv.ParseFloat(query.Get("lng"), 64)
+	if err != nil || !(longitude >= -180 && longitude <= 180) {
+		err := fmt.Errorf("lng must be a number between -180 and 180")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.NearestWarehousesResponse{Error: err.Error()})
+		return
+	}
+
+	var radiusInKm *float64
+	if value := query.Get("radiusKm"); value != "" {
+		radius, err := strconv.ParseFloat(value, 64)
+		if err != nil || !(radius > 0) || math.IsInf(radius, 1) {
+			err := fmt.Errorf("radiusKm must be a positive number")
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.NearestWarehousesResponse{Error: err.Error()})
+			return
+		}
+		radiusInKm = &radius
+	}
+
+	limit := defaultNearestWarehouses
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			h.logger.Log(log.Error, invalidLimit)
+			h.response(w, http.StatusBadRequest, api.NearestWarehousesResponse{Error: invalidLimit.Error()})
+			return
+		}
+	}
+
+	warehouses, err := h.warehouseService.NearestWarehouses(r.Context(), latitude, longitude, radiusInKm, limit)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusInternalServerError, api.NearestWarehousesResponse{Error: "Failed to find nearest warehouses"})
+		return
+	}
+	h.response(w, http.StatusOK, api.NearestWarehousesResponse{Response: warehouses})
+}
+
 func (h *handler) GetShelfBlock(w http.ResponseWriter, r *http.Request) {
 	shelfBlockId := chi.URLParam(r, "shelfBlockId")
 
@@ -279,6 +620,7 @@ func (h *handler) GetShelfBlock(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	setETag(w, shelfBlock.Version)
 	h.response(w, http.StatusOK, api.GetShelfBlockResponse{Response: shelfBlock})
 }
 
@@ -345,6 +687,13 @@ func (h *handler) CreateShelfBlock(w http.ResponseWriter, r *http.Request) {
 func (h *handler) UpdateShelfBlock(w http.ResponseWriter, r *http.Request) {
 	var updateShelfBlockRequest api.UpdateShelfBlockRequest
 
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.ShelfBlockResponse{Error: err.Error()})
+		return
+	}
+
 	if r.Body == nil {
 		err := fmt.Errorf("request body cannot be empty")
 		h.logger.Log(log.Error, err)
@@ -358,7 +707,7 @@ func (h *handler) UpdateShelfBlock(w http.ResponseWriter, r *http.Request) {
 
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&updateShelfBlockRequest)
+	err = decoder.Decode(&updateShelfBlockRequest)
 	if err != nil {
 		h.logger.Log(log.Error, err)
 		h.response(w, http.StatusBadRequest, api.ShelfBlockResponse{
@@ -367,7 +716,13 @@ func (h *handler) UpdateShelfBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = validator.Validate(updateShelfBlockRequest)
+	h.updateShelfBlock(w, r, updateShelfBlockRequest, version)
+}
+
+// updateShelfBlock validates updateShelfBlockRequest and persists it against
+// version, for both PUT and PATCH.
+func (h *handler) updateShelfBlock(w http.ResponseWriter, r *http.Request, updateShelfBlockRequest api.UpdateShelfBlockRequest, version int) {
+	err := validator.Validate(updateShelfBlockRequest)
 	if err != nil {
 		h.logger.Log(log.Error, err)
 		h.response(w, http.StatusBadRequest, api.ShelfBlockResponse{
@@ -381,9 +736,18 @@ func (h *handler) UpdateShelfBlock(w http.ResponseWriter, r *http.Request) {
 		Rack:        updateShelfBlockRequest.Rack,
 		StorageType: updateShelfBlockRequest.StorageType,
 		WarehouseId: updateShelfBlockRequest.WarehouseId,
+		Version:     version,
 	}
 	err = h.shelfBlockService.UpdateShelfBlock(r.Context(), shelfBlock)
 	if err != nil {
+		if err == wms.VersionMismatch {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusPreconditionFailed, api.ShelfBlockResponse{Error: fmt.Sprintf(
+				"failed to update, shelf_block: %s has been modified",
+				updateShelfBlockRequest.Id,
+			)})
+			return
+		}
 		if err == wms.ShelfBlockDoesNotExist {
 			h.logger.Log(log.Error, err)
 			h.response(w, http.StatusNotFound, api.ShelfBlockResponse{Error: fmt.Sprintf(
@@ -409,6 +773,7 @@ func (h *handler) UpdateShelfBlock(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	setETag(w, version+1)
 	h.response(w, http.StatusOK, api.ShelfBlockResponse{Response: fmt.Sprintf(
 		"Successfully updated shelf_block: %s",
 		shelfBlock.Id,
@@ -425,8 +790,41 @@ func (h *handler) DeleteShelfBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.shelfBlockService.DeleteShelfBlockById(r.Context(), shelfBlockId)
+	cascade, err := cascadeParam(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ShelfBlockResponse{Error: err.Error()})
+		return
+	}
+
+	version, err := ifMatchVersion(r)
 	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.ShelfBlockResponse{Error: err.Error()})
+		return
+	}
+
+	err = h.shelfBlockService.DeleteShelfBlockById(r.Context(), shelfBlockId, version, cascade)
+	if err != nil {
+		if err == wms.VersionMismatch {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusPreconditionFailed, api.ShelfBlockResponse{Error: fmt.Sprintf(
+				"failed to delete, shelf_block: %s has been modified",
+				shelfBlockId,
+			)})
+			return
+		}
+		if dependents, ok := dependentsOf(err); ok {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.DependentsResponse{
+				Error: fmt.Sprintf(
+					"failed to delete, shelf_block: %s has dependents",
+					shelfBlockId,
+				),
+				Dependents: dependents,
+			})
+			return
+		}
 		if err == wms.ShelfBlockDoesNotExist {
 			h.logger.Log(log.Error, err)
 			h.response(w, http.StatusNotFound, api.ShelfBlockResponse{Error: fmt.Sprintf(
@@ -455,6 +853,38 @@ func (h *handler) DeleteShelfBlock(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+func (h *handler) ListShelfBlocks(w http.ResponseWriter, r *http.Request) {
+	warehouseId := r.URL.Query().Get("warehouseId")
+
+	if warehouseId == "" {
+		err := fmt.Errorf("warehouseId cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ListShelfBlocksResponse{Error: err.Error()})
+		return
+	}
+
+	after, limit, err := pageParams(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ListShelfBlocksResponse{Error: err.Error()})
+		return
+	}
+
+	shelfBlocks, err := h.shelfBlockService.ListShelfBlocks(r.Context(), warehouseId, after, limit+1)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusInternalServerError, api.ListShelfBlocksResponse{Error: "Failed to list shelf_blocks"})
+		return
+	}
+
+	response := api.ListShelfBlocksResponse{Response: shelfBlocks}
+	if len(shelfBlocks) > limit {
+		response.Response = shelfBlocks[:limit]
+		response.NextCursor = encodeCursor(shelfBlocks[limit-1].Id)
+	}
+	h.response(w, http.StatusOK, response)
+}
+
 func (h *handler) GetShelf(w http.ResponseWriter, r *http.Request) {
 	shelfId := chi.URLParam(r, "shelfId")
 
@@ -482,7 +912,24 @@ func (h *handler) GetShelf(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	h.response(w, http.StatusOK, api.ShelfResponse{Response: shelf})
+
+	utilization, err := h.shelfService.GetShelfUtilization(r.Context(), shelfId)
+	if err != nil {
+		if err == wms.ShelfDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ShelfResponse{Error: fmt.Sprintf(
+				"failed to get, shelf: %s does not exist",
+				shelfId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ShelfResponse{Error: "Failed to get shelf"})
+			return
+		}
+	}
+	setETag(w, shelf.Version)
+	h.response(w, http.StatusOK, api.ShelfResponse{Response: shelf, Utilization: &utilization})
 }
 
 func (h *handler) CreateShelf(w http.ResponseWriter, r *http.Request) {
@@ -519,6 +966,9 @@ func (h *handler) CreateShelf(w http.ResponseWriter, r *http.Request) {
 		createShelfRequest.Section,
 		createShelfRequest.Level,
 		createShelfRequest.ShelfBlockId)
+	shelf.MaxWeightInKg = createShelfRequest.MaxWeightInKg
+	shelf.MaxVolumeInCm3 = createShelfRequest.MaxVolumeInCm3
+	shelf.MaxItems = createShelfRequest.MaxItems
 
 	err = h.shelfService.CreateShelf(r.Context(), shelf)
 	if err != nil {
@@ -549,6 +999,13 @@ func (h *handler) CreateShelf(w http.ResponseWriter, r *http.Request) {
 func (h *handler) UpdateShelf(w http.ResponseWriter, r *http.Request) {
 	var updateShelfRequest wms.Shelf
 
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.ShelfResponse{Error: err.Error()})
+		return
+	}
+
 	if r.Body == nil {
 		err := fmt.Errorf("request body cannot be empty")
 		h.logger.Log(log.Error, err)
@@ -561,7 +1018,7 @@ func (h *handler) UpdateShelf(w http.ResponseWriter, r *http.Request) {
 
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&updateShelfRequest)
+	err = decoder.Decode(&updateShelfRequest)
 	if err != nil {
 		h.logger.Log(log.Error, err)
 		h.response(w, http.StatusBadRequest, api.ShelfResponse{
@@ -570,7 +1027,13 @@ func (h *handler) UpdateShelf(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = validator.Validate(updateShelfRequest)
+	h.updateShelf(w, r, updateShelfRequest, version)
+}
+
+// updateShelf validates updateShelfRequest and persists it against version,
+// for both PUT and PATCH.
+func (h *handler) updateShelf(w http.ResponseWriter, r *http.Request, updateShelfRequest wms.Shelf, version int) {
+	err := validator.Validate(updateShelfRequest)
 	if err != nil {
 		h.logger.Log(log.Error, err)
 		h.response(w, http.StatusBadRequest, api.ShelfResponse{
@@ -578,8 +1041,17 @@ func (h *handler) UpdateShelf(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	updateShelfRequest.Version = version
 	err = h.shelfService.UpdateShelf(r.Context(), updateShelfRequest)
 	if err != nil {
+		if err == wms.VersionMismatch {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusPreconditionFailed, api.ShelfResponse{Error: fmt.Sprintf(
+				"failed to update, shelf: %s has been modified",
+				updateShelfRequest.Id,
+			)})
+			return
+		}
 		if err == wms.ShelfDoesNotExist {
 			h.logger.Log(log.Error, err)
 			h.response(w, http.StatusNotFound, api.ShelfResponse{Error: fmt.Sprintf(
@@ -605,6 +1077,7 @@ func (h *handler) UpdateShelf(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	setETag(w, version+1)
 	h.response(w, http.StatusOK, api.ShelfResponse{Message: fmt.Sprintf(
 		"Successfully updated shelf: %s",
 		updateShelfRequest.Id,
@@ -621,8 +1094,41 @@ func (h *handler) DeleteShelf(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.shelfService.DeleteShelfById(r.Context(), shelfId)
+	cascade, err := cascadeParam(r)
 	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ShelfResponse{Error: err.Error()})
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.ShelfResponse{Error: err.Error()})
+		return
+	}
+
+	err = h.shelfService.DeleteShelfById(r.Context(), shelfId, version, cascade)
+	if err != nil {
+		if err == wms.VersionMismatch {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusPreconditionFailed, api.ShelfResponse{Error: fmt.Sprintf(
+				"failed to delete, shelf: %s has been modified",
+				shelfId,
+			)})
+			return
+		}
+		if dependents, ok := dependentsOf(err); ok {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.DependentsResponse{
+				Error: fmt.Sprintf(
+					"failed to delete, shelf: %s has dependents",
+					shelfId,
+				),
+				Dependents: dependents,
+			})
+			return
+		}
 		if err == wms.ShelfDoesNotExist {
 			h.logger.Log(log.Error, err)
 			h.response(w, http.StatusNotFound, api.ShelfResponse{Error: fmt.Sprintf(
@@ -651,6 +1157,38 @@ func (h *handler) DeleteShelf(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+func (h *handler) ListShelves(w http.ResponseWriter, r *http.Request) {
+	shelfBlockId := r.URL.Query().Get("shelfBlockId")
+
+	if shelfBlockId == "" {
+		err := fmt.Errorf("shelfBlockId cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ListShelvesResponse{Error: err.Error()})
+		return
+	}
+
+	after, limit, err := pageParams(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ListShelvesResponse{Error: err.Error()})
+		return
+	}
+
+	shelves, err := h.shelfService.ListShelves(r.Context(), shelfBlockId, after, limit+1)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusInternalServerError, api.ListShelvesResponse{Error: "Failed to list shelves"})
+		return
+	}
+
+	response := api.ListShelvesResponse{Response: shelves}
+	if len(shelves) > limit {
+		response.Response = shelves[:limit]
+		response.NextCursor = encodeCursor(shelves[limit-1].Id)
+	}
+	h.response(w, http.StatusOK, response)
+}
+
 func (h *handler) response(w http.ResponseWriter, statusCode int, response interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 
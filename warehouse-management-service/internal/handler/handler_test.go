@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	wms "warehouse-management-service"
@@ -56,6 +57,26 @@ func TestPing(t *testing.T) {
 	}
 }
 
+func TestNew(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockWarehouseService(mockCtrl)
+	mockObj.EXPECT().GetWarehouseById(gomock.Any(), warehouse.Id).Return(&warehouse, nil)
+
+	router := New(h.logger, Services{Warehouse: mockObj}, "")
+
+	request, err := http.NewRequest("GET", fmt.Sprintf("/warehouse/%s", warehouse.Id), nil)
+	if err != nil {
+		t.Error(err)
+	}
+	responseRecorder := httptest.NewRecorder()
+	router.ServeHTTP(responseRecorder, request)
+	if responseRecorder.Code != http.StatusOK {
+		t.Errorf("want: %v, got: %v", http.StatusOK, responseRecorder.Code)
+	}
+}
+
 func TestGetWarehouseById(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -127,6 +148,93 @@ func TestGetWarehouseById(t *testing.T) {
 	}
 }
 
+func TestGetWarehouseLayout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockWarehouseService(mockCtrl)
+
+	layout := wms.NewWarehouseLayout(
+		warehouse,
+		[]wms.ShelfBlock{{Id: "block", Aisle: "1", Rack: "1", StorageType: "regular", WarehouseId: warehouse.Id}},
+		[]wms.Shelf{{Id: "shelf", Label: "1A", Section: "A", Level: "1", ShelfBlockId: "block"}},
+		map[string]int{"shelf": 3},
+	)
+
+	tests := []struct {
+		requestURL        string
+		wantItemCounts    bool
+		getLayoutResponse wms.WarehouseLayout
+		getLayoutErr      error
+		wantStatusCode    int
+		wantError         string
+	}{
+		{
+			requestURL:        fmt.Sprintf("/warehouse/%s/layout?itemCounts=true", warehouse.Id),
+			wantItemCounts:    true,
+			getLayoutResponse: layout,
+			wantStatusCode:    http.StatusOK,
+		},
+		{
+			requestURL:     fmt.Sprintf("/warehouse/%s/layout", warehouse.Id),
+			wantItemCounts: false,
+			getLayoutErr:   wms.WarehouseDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantError: fmt.Sprintf(
+				"failed to get layout, warehouse: %s does not exist",
+				warehouse.Id,
+			),
+		},
+		{
+			requestURL:     fmt.Sprintf("/warehouse/%s/layout", warehouse.Id),
+			wantItemCounts: false,
+			getLayoutErr:   sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "Failed to get warehouse layout",
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().GetWarehouseLayout(gomock.Any(), warehouse.Id, test.wantItemCounts).Return(test.getLayoutResponse, test.getLayoutErr)
+		h.warehouseService = mockObj
+
+		request, err := http.NewRequest("GET", test.requestURL, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.GetWarehouseLayoutResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		if got.Error != test.wantError {
+			t.Errorf("want: %v, got: %v", test.wantError, got.Error)
+		}
+		if test.wantStatusCode == http.StatusOK {
+			shelf := got.Response.Aisles[0].Racks[0].ShelfBlocks[0].Sections[0].Shelves[0]
+			if shelf.Id != "shelf" || shelf.ItemCount == nil || *shelf.ItemCount != 3 {
+				t.Errorf("want: %v, got: %v", layout, got.Response)
+			}
+		}
+	}
+
+	request, err := http.NewRequest("GET", fmt.Sprintf("/warehouse/%s/layout?itemCounts=maybe", warehouse.Id), nil)
+	if err != nil {
+		t.Error(err)
+	}
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusBadRequest {
+		t.Errorf("want: %v, got: %v", http.StatusBadRequest, response.StatusCode)
+	}
+}
+
 func TestCreateWarehouse(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -348,6 +456,7 @@ func TestUpdateWarehouseRequestError(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
+		request.Header.Set("If-Match", `"1"`)
 
 		response := executeRequest(request)
 		responseBody, err := io.ReadAll(response.Body)
@@ -427,6 +536,7 @@ func TestUpdateWarehouse(t *testing.T) {
 			Name:      test.updateWarehouseRequest.Name,
 			Latitude:  test.updateWarehouseRequest.Latitude,
 			Longitude: test.updateWarehouseRequest.Longitude,
+			Version:   1,
 		}
 
 		mockObj.EXPECT().UpdateWarehouse(
@@ -448,6 +558,7 @@ func TestUpdateWarehouse(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
+		request.Header.Set("If-Match", `"1"`)
 
 		response := executeRequest(request)
 		responseBody, err := io.ReadAll(response.Body)
@@ -506,7 +617,7 @@ func TestDeleteWarehouse(t *testing.T) {
 	}
 	for _, test := range tests {
 
-		mockObj.EXPECT().DeleteWarehouse(gomock.Any(), test.deleteWarehouseRequest).Return(test.deleteWarehouseErr)
+		mockObj.EXPECT().DeleteWarehouse(gomock.Any(), test.deleteWarehouseRequest, 1, false).Return(test.deleteWarehouseErr)
 		h.warehouseService = mockObj
 
 		requestURL := fmt.Sprintf("/warehouse/%s", test.deleteWarehouseRequest)
@@ -518,6 +629,7 @@ func TestDeleteWarehouse(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
+		request.Header.Set("If-Match", `"1"`)
 
 		response := executeRequest(request)
 		responseBody, err := io.ReadAll(response.Body)
@@ -840,6 +952,7 @@ func TestUpdateShelfBlock(t *testing.T) {
 				Rack:        request.Rack,
 				StorageType: request.StorageType,
 				WarehouseId: request.WarehouseId,
+				Version:     1,
 			},
 		).Return(test.updateShelfBlockErr)
 
@@ -860,6 +973,7 @@ func TestUpdateShelfBlock(t *testing.T) {
 			t.Error(err)
 			return
 		}
+		request.Header.Set("If-Match", `"1"`)
 
 		response := executeRequest(request)
 		responseBody, err := io.ReadAll(response.Body)
@@ -942,6 +1056,7 @@ func TestUpdateShelfBlockRequestError(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
+		request.Header.Set("If-Match", `"1"`)
 
 		response := executeRequest(request)
 		responseBody, err := io.ReadAll(response.Body)
@@ -1002,7 +1117,7 @@ func TestDeleteShelfBlock(t *testing.T) {
 	}
 	for _, test := range tests {
 
-		mockObj.EXPECT().DeleteShelfBlockById(gomock.Any(), test.deleteShelfBlockRequest).Return(test.deleteShelfBlockErr)
+		mockObj.EXPECT().DeleteShelfBlockById(gomock.Any(), test.deleteShelfBlockRequest, 1, false).Return(test.deleteShelfBlockErr)
 		h.shelfBlockService = mockObj
 
 		requestURL := fmt.Sprintf("/shelf_block/%s", test.deleteShelfBlockRequest)
@@ -1014,6 +1129,7 @@ func TestDeleteShelfBlock(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
+		request.Header.Set("If-Match", `"1"`)
 
 		response := executeRequest(request)
 		responseBody, err := io.ReadAll(response.Body)
@@ -1041,6 +1157,8 @@ func TestGetShelfById(t *testing.T) {
 		Level:        "12",
 		ShelfBlockId: "863e835b-a05b-4554-b0af-a45389ebbb78",
 	}
+	percent := 50.0
+	utilization := wms.ShelfUtilization{ItemCount: 2, WeightInKg: 3, VolumeInCm3: 1000, Percent: &percent}
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
@@ -1050,6 +1168,7 @@ func TestGetShelfById(t *testing.T) {
 		getShelfByIdRequest string
 		shelfByIdResponse   wms.Shelf
 		shelfByIdErr        error
+		utilizationErr      error
 		wantStatusCode      int
 		wantResponse        interface{}
 	}{
@@ -1058,7 +1177,15 @@ func TestGetShelfById(t *testing.T) {
 			shelfByIdResponse:   shelf,
 			shelfByIdErr:        nil,
 			wantStatusCode:      http.StatusOK,
-			wantResponse:        api.ShelfResponse{Response: shelf},
+			wantResponse:        api.ShelfResponse{Response: shelf, Utilization: &utilization},
+		},
+		{
+			getShelfByIdRequest: shelf.Id,
+			shelfByIdResponse:   shelf,
+			shelfByIdErr:        nil,
+			utilizationErr:      sql.ErrConnDone,
+			wantStatusCode:      http.StatusInternalServerError,
+			wantResponse:        api.ShelfResponse{Error: "Failed to get shelf"},
 		},
 		{
 			getShelfByIdRequest: shelf.Id,
@@ -1085,6 +1212,9 @@ func TestGetShelfById(t *testing.T) {
 			gomock.Any(),
 			test.getShelfByIdRequest,
 		).Return(test.shelfByIdResponse, test.shelfByIdErr)
+		if test.shelfByIdErr == nil {
+			mockObj.EXPECT().GetShelfUtilization(gomock.Any(), test.getShelfByIdRequest).Return(utilization, test.utilizationErr)
+		}
 		h.shelfService = mockObj
 
 		requestURL := fmt.Sprintf("/shelf/%s", test.getShelfByIdRequest)
@@ -1109,7 +1239,7 @@ func TestGetShelfById(t *testing.T) {
 			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
 		}
 
-		if got != test.wantResponse {
+		if !reflect.DeepEqual(got, test.wantResponse) {
 			t.Errorf("want: %v, got: %v", test.wantResponse, got)
 		}
 	}
@@ -1270,6 +1400,7 @@ func TestUpdateShelf(t *testing.T) {
 				Section:      request.Section,
 				Level:        request.Level,
 				ShelfBlockId: request.ShelfBlockId,
+				Version:      1,
 			},
 		).Return(test.updateShelfErr)
 
@@ -1290,6 +1421,7 @@ func TestUpdateShelf(t *testing.T) {
 			t.Error(err)
 			return
 		}
+		request.Header.Set("If-Match", `"1"`)
 
 		response := executeRequest(request)
 		responseBody, err := io.ReadAll(response.Body)
@@ -1349,7 +1481,7 @@ func TestDeleteShelf(t *testing.T) {
 
 	for _, test := range tests {
 
-		mockObj.EXPECT().DeleteShelfById(gomock.Any(), test.deleteShelfRequest).Return(test.deleteShelfErr)
+		mockObj.EXPECT().DeleteShelfById(gomock.Any(), test.deleteShelfRequest, 1, false).Return(test.deleteShelfErr)
 		h.shelfService = mockObj
 
 		requestURL := fmt.Sprintf("/shelf/%s", test.deleteShelfRequest)
@@ -1361,6 +1493,7 @@ func TestDeleteShelf(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
+		request.Header.Set("If-Match", `"1"`)
 
 		response := executeRequest(request)
 		responseBody, err := io.ReadAll(response.Body)
@@ -1380,9 +1513,355 @@ func TestDeleteShelf(t *testing.T) {
 	}
 }
 
+func TestListWarehouses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockWarehouseService(mockCtrl)
+
+	secondWarehouse := warehouse
+	secondWarehouse.Id = "9f3c0d3e-6d1a-4a43-8f3b-2d9a7e5c1b20"
+
+	tests := []struct {
+		requestURL         string
+		wantAfter          string
+		wantLimit          int
+		listWarehousesResp []wms.Warehouse
+		listWarehousesErr  error
+		wantStatusCode     int
+		wantResponseLength int
+		wantNextCursor     string
+		wantError          string
+	}{
+		{
+			requestURL:         "/warehouse?limit=1",
+			wantAfter:          "",
+			wantLimit:          2,
+			listWarehousesResp: []wms.Warehouse{warehouse, secondWarehouse},
+			wantStatusCode:     http.StatusOK,
+			wantResponseLength: 1,
+			wantNextCursor:     encodeCursor(warehouse.Id),
+		},
+		{
+			requestURL:         fmt.Sprintf("/warehouse?limit=1&cursor=%s", encodeCursor(warehouse.Id)),
+			wantAfter:          warehouse.Id,
+			wantLimit:          2,
+			listWarehousesResp: []wms.Warehouse{secondWarehouse},
+			wantStatusCode:     http.StatusOK,
+			wantResponseLength: 1,
+		},
+		{
+			requestURL:        "/warehouse",
+			wantAfter:         "",
+			wantLimit:         defaultPageSize + 1,
+			listWarehousesErr: sql.ErrConnDone,
+			wantStatusCode:    http.StatusInternalServerError,
+			wantError:         "Failed to list warehouses",
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().ListWarehouses(gomock.Any(), test.wantAfter, test.wantLimit).Return(test.listWarehousesResp, test.listWarehousesErr)
+		h.warehouseService = mockObj
+
+		request, err := http.NewRequest("GET", test.requestURL, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ListWarehousesResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if len(got.Response) != test.wantResponseLength || got.NextCursor != test.wantNextCursor || got.Error != test.wantError {
+			t.Errorf("want: %v items, cursor %q, error %q, got: %v", test.wantResponseLength, test.wantNextCursor, test.wantError, got)
+		}
+	}
+}
+
+func TestNearestWarehouses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockWarehouseService(mockCtrl)
+
+	nearby := []wms.NearbyWarehouse{{Warehouse: warehouse, DistanceInKm: 1.5}}
+	radius := 25.0
+
+	mockObj.EXPECT().NearestWarehouses(gomock.Any(), 12.97, 77.59, &radius, 5).Return(nearby, nil)
+	mockObj.EXPECT().NearestWarehouses(gomock.Any(), 12.97, 77.59, nil, defaultNearestWarehouses).Return(nil, sql.ErrConnDone)
+	h.warehouseService = mockObj
+
+	tests := []struct {
+		requestURL     string
+		wantStatusCode int
+		wantResponse   api.NearestWarehousesResponse
+	}{
+		{
+			requestURL:     "/warehouse/nearest?lat=12.97&lng=77.59&radiusKm=25&limit=5",
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.NearestWarehousesResponse{Response: nearby},
+		},
+		{
+			requestURL:     "/warehouse/nearest?lat=12.97&lng=77.59",
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.NearestWarehousesResponse{Error: "Failed to find nearest warehouses"},
+		},
+	}
+
+	for _, test := range tests {
+		request, err := http.NewRequest("GET", test.requestURL, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.NearestWarehousesResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		if got.Error != test.wantResponse.Error || len(got.Response) != len(test.wantResponse.Response) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+		for i := range got.Response {
+			if got.Response[i] != test.wantResponse.Response[i] {
+				t.Errorf("want: %v, got: %v", test.wantResponse.Response[i], got.Response[i])
+			}
+		}
+	}
+}
+
+func TestNearestWarehousesRequestError(t *testing.T) {
+	requestURLs := []string{
+		"/warehouse/nearest",
+		"/warehouse/nearest?lat=12.97",
+		"/warehouse/nearest?lat=91&lng=77.59",
+		"/warehouse/nearest?lat=12.97&lng=-181",
+		"/warehouse/nearest?lat=12.97&lng=77.59&radiusKm=0",
+		"/warehouse/nearest?lat=12.97&lng=77.59&radiusKm=far",
+		"/warehouse/nearest?lat=NaN&lng=77.59",
+		"/warehouse/nearest?lat=12.97&lng=NaN",
+		"/warehouse/nearest?lat=12.97&lng=Inf",
+		"/warehouse/nearest?lat=12.97&lng=77.59&radiusKm=NaN",
+		"/warehouse/nearest?lat=12.97&lng=77.59&radiusKm=Inf",
+		"/warehouse/nearest?lat=12.97&lng=77.59&limit=1000",
+	}
+
+	for _, requestURL := range requestURLs {
+		request, err := http.NewRequest("GET", requestURL, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s want: %v, got: %v", requestURL, http.StatusBadRequest, response.StatusCode)
+		}
+	}
+}
+
+func TestListRequestError(t *testing.T) {
+	requestURLs := []string{
+		"/warehouse?limit=0",
+		"/warehouse?limit=101",
+		"/warehouse?limit=ten",
+		"/warehouse?cursor=%25%25",
+		"/shelf_block",
+		"/shelf_block?warehouseId=foo&limit=-1",
+		"/shelf",
+		"/shelf?shelfBlockId=foo&cursor=!",
+	}
+
+	for _, requestURL := range requestURLs {
+		request, err := http.NewRequest("GET", requestURL, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s want: %v, got: %v", requestURL, http.StatusBadRequest, response.StatusCode)
+		}
+	}
+}
+
+func TestListShelfBlocks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfBlockService(mockCtrl)
+
+	shelfBlock := wms.ShelfBlock{
+		Id:          "863e835b-a05b-4554-b0af-a45389ebbb78",
+		Aisle:       "1",
+		Rack:        "1",
+		StorageType: "regular",
+		WarehouseId: warehouse.Id,
+	}
+
+	mockObj.EXPECT().ListShelfBlocks(gomock.Any(), warehouse.Id, "", 11).Return([]wms.ShelfBlock{shelfBlock}, nil)
+	mockObj.EXPECT().ListShelfBlocks(gomock.Any(), warehouse.Id, "", 11).Return(nil, sql.ErrConnDone)
+	h.shelfBlockService = mockObj
+
+	wantStatusCodes := []int{http.StatusOK, http.StatusInternalServerError}
+	for _, wantStatusCode := range wantStatusCodes {
+		request, err := http.NewRequest("GET", fmt.Sprintf("/shelf_block?warehouseId=%s&limit=10", warehouse.Id), nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ListShelfBlocksResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != wantStatusCode {
+			t.Errorf("want: %v, got: %v", wantStatusCode, response.StatusCode)
+		}
+		if wantStatusCode == http.StatusOK && (len(got.Response) != 1 || got.Response[0] != shelfBlock || got.NextCursor != "") {
+			t.Errorf("want: %v, got: %v", shelfBlock, got)
+		}
+	}
+}
+
+func TestListShelves(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfService(mockCtrl)
+
+	shelves := []wms.Shelf{
+		{Id: "a", Label: "1A", Section: "A", Level: "1", ShelfBlockId: "block"},
+		{Id: "b", Label: "1B", Section: "B", Level: "1", ShelfBlockId: "block"},
+		{Id: "c", Label: "1C", Section: "C", Level: "1", ShelfBlockId: "block"},
+	}
+
+	mockObj.EXPECT().ListShelves(gomock.Any(), "block", "a", 3).Return(shelves[1:], nil)
+	h.shelfService = mockObj
+
+	request, err := http.NewRequest("GET", fmt.Sprintf("/shelf?shelfBlockId=block&limit=2&cursor=%s", encodeCursor("a")), nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	response := executeRequest(request)
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Error(err)
+	}
+	var got api.ListShelvesResponse
+	err = json.Unmarshal(responseBody, &got)
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("want: %v, got: %v", http.StatusOK, response.StatusCode)
+	}
+	if len(got.Response) != 2 || got.NextCursor != "" {
+		t.Errorf("want: %v, got: %v", shelves[1:], got)
+	}
+}
+
 func executeRequest(request *http.Request) *http.Response {
 	responseRecorder := httptest.NewRecorder()
 
 	h.router().ServeHTTP(responseRecorder, request)
 	return responseRecorder.Result()
 }
+
+func TestDeleteWarehouseDependents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockWarehouseService(mockCtrl)
+	h.warehouseService = mockObj
+
+	warehouseId := "85bd3b85-ad4d-4224-b589-fb2a80a6ce45"
+	dependents := []wms.Dependent{{Entity: wms.EntityShelfBlock, Id: "block"}}
+
+	mockObj.EXPECT().
+		DeleteWarehouse(gomock.Any(), warehouseId, 1, false).
+		Return(&wms.DependentsError{Dependents: dependents})
+
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("/warehouse/%s", warehouseId), nil)
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set("If-Match", `"1"`)
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusConflict {
+		t.Errorf("want: %v, got: %v", http.StatusConflict, response.StatusCode)
+	}
+
+	var got api.DependentsResponse
+	err = json.NewDecoder(response.Body).Decode(&got)
+	if err != nil {
+		t.Error(err)
+	}
+	wantError := fmt.Sprintf("failed to delete, warehouse: %s has dependents", warehouseId)
+	if got.Error != wantError || len(got.Dependents) != 1 || got.Dependents[0] != dependents[0] {
+		t.Errorf("want: %v %v, got: %v", wantError, dependents, got)
+	}
+
+	mockObj.EXPECT().DeleteWarehouse(gomock.Any(), warehouseId, 1, true).Return(nil)
+
+	request, err = http.NewRequest("DELETE", fmt.Sprintf("/warehouse/%s?cascade=true", warehouseId), nil)
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set("If-Match", `"1"`)
+	response = executeRequest(request)
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("want: %v, got: %v", http.StatusOK, response.StatusCode)
+	}
+
+	request, err = http.NewRequest("DELETE", fmt.Sprintf("/warehouse/%s?cascade=maybe", warehouseId), nil)
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set("If-Match", `"1"`)
+	response = executeRequest(request)
+	if response.StatusCode != http.StatusBadRequest {
+		t.Errorf("want: %v, got: %v", http.StatusBadRequest, response.StatusCode)
+	}
+}
+
+func TestDeleteShelfDependents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfService(mockCtrl)
+	h.shelfService = mockObj
+
+	shelfId := "shelf"
+	dependents := []wms.Dependent{{Entity: wms.EntityItem, Id: "item"}}
+
+	mockObj.EXPECT().
+		DeleteShelfById(gomock.Any(), shelfId, 1, false).
+		Return(&wms.DependentsError{Dependents: dependents})
+
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("/shelf/%s", shelfId), nil)
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set("If-Match", `"1"`)
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusConflict {
+		t.Errorf("want: %v, got: %v", http.StatusConflict, response.StatusCode)
+	}
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetItem(w http.ResponseWriter, r *http.Request) {
+	itemId := chi.URLParam(r, "itemId")
+
+	if itemId == "" {
+		err := fmt.Errorf("%v", api.GetItemResponse{
+			Error: "item id cannot be empty",
+		})
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, err)
+		return
+	}
+
+	item, err := h.itemService.GetItemById(r.Context(), itemId)
+	if err != nil {
+		if err == wms.ItemDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.GetItemResponse{Error: fmt.Sprintf(
+				"failed to get, item: %s does not exist",
+				itemId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.GetItemResponse{Error: "Failed to get item"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.GetItemResponse{Response: item})
+}
+
+func (h *handler) GetItemBySerialNumber(w http.ResponseWriter, r *http.Request) {
+	serialNumber := chi.URLParam(r, "serialNumber")
+
+	item, err := h.itemService.GetItemBySerialNumber(r.Context(), serialNumber)
+	if err != nil {
+		if err == wms.ItemDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.GetItemResponse{Error: fmt.Sprintf(
+				"failed to get, item with serial number: %s does not exist",
+				serialNumber,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.GetItemResponse{Error: "Failed to get item"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.GetItemResponse{Response: item})
+}
+
+func (h *handler) ReceiveItem(w http.ResponseWriter, r *http.Request) {
+	var createItemRequest api.CreateItemRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ItemResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createItemRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ItemResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(createItemRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ItemResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	item := wms.NewItem(
+		createItemRequest.Sku,
+		createItemRequest.ExpirationDate,
+		createItemRequest.ShelfId,
+		createItemRequest.Lot,
+		createItemRequest.SerialNumber,
+	)
+
+	err = h.itemService.CreateItem(r.Context(), item)
+	if err != nil {
+		if err == wms.InvalidProduct {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				item.Sku,
+			)})
+			return
+		} else if err == wms.InvalidShelf {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				item.ShelfId,
+			)})
+			return
+		} else if err == wms.LotRequired || err == wms.SerialNumberRequired {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				item.Sku,
+			)})
+			return
+		} else if err == wms.DuplicateSerialNumber {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				item.SerialNumber,
+			)})
+			return
+		} else if errors.Is(err, wms.ShelfOverCapacity) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ItemResponse{Error: err.Error()})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ItemResponse{Error: "Failed to receive item"},
+			)
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.ItemResponse{Response: fmt.Sprintf(
+		"Successfully received item: %s",
+		item.Id,
+	)})
+}
+
+func (h *handler) MoveItem(w http.ResponseWriter, r *http.Request) {
+	var moveItemRequest api.MoveItemRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ItemResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&moveItemRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ItemResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(moveItemRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ItemResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	err = h.itemService.MoveItem(r.Context(), moveItemRequest.Id, moveItemRequest.ShelfId)
+	if err != nil {
+		if err == wms.ItemDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ItemResponse{Error: fmt.Sprintf(
+				"failed to move, item: %s does not exist",
+				moveItemRequest.Id,
+			)})
+			return
+		} else if err == wms.InvalidShelf {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				moveItemRequest.ShelfId,
+			)})
+			return
+		} else if errors.Is(err, wms.ShelfOverCapacity) || errors.Is(err, wms.ItemNotTransferable) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ItemResponse{Error: err.Error()})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ItemResponse{Error: "Failed to move item"},
+			)
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.ItemResponse{Response: fmt.Sprintf(
+		"Successfully moved item: %s",
+		moveItemRequest.Id,
+	)})
+}
+
+func (h *handler) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	itemId := chi.URLParam(r, "itemId")
+
+	if itemId == "" {
+		err := fmt.Errorf("%v", api.ItemResponse{Error: "item id cannot be empty"})
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := h.itemService.DeleteItemById(r.Context(), itemId)
+	if err != nil {
+		if err == wms.ItemDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ItemResponse{Error: fmt.Sprintf(
+				"failed to delete, item: %s does not exist",
+				itemId,
+			)})
+			return
+		} else if err == wms.ItemClaimed {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ItemResponse{Error: fmt.Sprintf(
+				"failed to delete, item: %s is reserved or being picked",
+				itemId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ItemResponse{Error: "Failed to delete item"},
+			)
+			return
+		}
+	}
+
+	h.response(
+		w,
+		http.StatusOK,
+		api.ItemResponse{Response: fmt.Sprintf(
+			"Successfully deleted item: %s",
+			itemId,
+		)},
+	)
+}
@@ -0,0 +1,435 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+var item = wms.Item{
+	Id:         "0f6f3b9c-52d3-4d6e-a1f7-7a8c4e2b9d01",
+	Sku:        product.Sku,
+	ReceivedOn: time.Date(2023, time.January, 10, 9, 30, 0, 0, time.UTC),
+	ShelfId:    "8387eec6-040a-4eb8-b1b5-9277b2d1a72c",
+}
+
+func TestGetItem(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockItemService(mockCtrl)
+
+	tests := []struct {
+		getItemResponse wms.Item
+		getItemErr      error
+		wantStatusCode  int
+		wantResponse    api.GetItemResponse
+	}{
+		{
+			getItemResponse: item,
+			getItemErr:      nil,
+			wantStatusCode:  http.StatusOK,
+			wantResponse:    api.GetItemResponse{Response: item},
+		},
+		{
+			getItemResponse: wms.Item{},
+			getItemErr:      sql.ErrConnDone,
+			wantStatusCode:  http.StatusInternalServerError,
+			wantResponse:    api.GetItemResponse{Error: "Failed to get item"},
+		},
+		{
+			getItemResponse: wms.Item{},
+			getItemErr:      wms.ItemDoesNotExist,
+			wantStatusCode:  http.StatusNotFound,
+			wantResponse: api.GetItemResponse{Error: fmt.Sprintf(
+				"failed to get, item: %s does not exist",
+				item.Id,
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().GetItemById(gomock.Any(), item.Id).Return(test.getItemResponse, test.getItemErr)
+		h.itemService = mockObj
+
+		request, err := http.NewRequest(
+			"GET",
+			fmt.Sprintf("/item/%s", item.Id),
+			nil,
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.GetItemResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if got.Error != test.wantResponse.Error ||
+			got.Response.Id != test.wantResponse.Response.Id ||
+			!got.Response.ReceivedOn.Equal(test.wantResponse.Response.ReceivedOn) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestGetItemBySerialNumber(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockItemService(mockCtrl)
+	h.itemService = mockObj
+
+	serialized := item
+	serialized.SerialNumber = "SN1"
+
+	tests := []struct {
+		getItemErr     error
+		wantStatusCode int
+		wantResponse   api.GetItemResponse
+	}{
+		{
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.GetItemResponse{Response: serialized},
+		},
+		{
+			getItemErr:     wms.ItemDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.GetItemResponse{Error: "failed to get, item with serial number: SN1 does not exist"},
+		},
+		{
+			getItemErr:     sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.GetItemResponse{Error: "Failed to get item"},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().GetItemBySerialNumber(gomock.Any(), "SN1").Return(serialized, test.getItemErr)
+
+		request, err := http.NewRequest("GET", "/item/serial/SN1", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.getItemErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.GetItemResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil ||
+			got.Error != test.wantResponse.Error ||
+			got.Response.Id != test.wantResponse.Response.Id ||
+			got.Response.SerialNumber != test.wantResponse.Response.SerialNumber {
+			t.Errorf("want: %v, got: %v, %v", test.wantResponse, got, err)
+		}
+	}
+}
+
+func TestReceiveItem(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockItemService(mockCtrl)
+
+	createItemRequest := api.CreateItemRequest{
+		Sku:          item.Sku,
+		ShelfId:      item.ShelfId,
+		SerialNumber: "SN1",
+	}
+
+	tests := []struct {
+		createItemErr  error
+		wantStatusCode int
+		wantResponse   api.ItemResponse
+	}{
+		{
+			createItemErr:  nil,
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.ItemResponse{Response: "Successfully received item: "},
+		},
+		{
+			createItemErr:  sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.ItemResponse{Error: "Failed to receive item"},
+		},
+		{
+			createItemErr:  wms.InvalidProduct,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse: api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				wms.InvalidProduct.Error(),
+				item.Sku,
+			)},
+		},
+		{
+			createItemErr:  wms.InvalidShelf,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse: api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				wms.InvalidShelf.Error(),
+				item.ShelfId,
+			)},
+		},
+		{
+			createItemErr:  wms.DuplicateSerialNumber,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.ItemResponse{Error: wms.DuplicateSerialNumber.Error() + ": SN1"},
+		},
+		{
+			createItemErr:  wms.LotRequired,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse: api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				wms.LotRequired.Error(),
+				item.Sku,
+			)},
+		},
+		{
+			createItemErr:  &wms.ShelfCapacityError{ShelfId: item.ShelfId, Exceeded: []string{"11 of 10 items"}},
+			wantStatusCode: http.StatusConflict,
+			wantResponse: api.ItemResponse{Error: fmt.Sprintf(
+				"shelf over capacity: %s: 11 of 10 items",
+				item.ShelfId,
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().CreateItem(gomock.Any(), gomock.Any()).Return(test.createItemErr)
+		h.itemService = mockObj
+
+		marshalledRequest, err := json.Marshal(createItemRequest)
+		if err != nil {
+			t.Error(err)
+		}
+		request, err := http.NewRequest(
+			"POST",
+			"/item",
+			bytes.NewBuffer(marshalledRequest),
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ItemResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if got.Error != test.wantResponse.Error {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+
+		if !strings.HasPrefix(got.Response, test.wantResponse.Response) {
+			t.Errorf("want: %v, got: %v", test.wantResponse.Response, got.Response)
+		}
+	}
+}
+
+func TestReceiveItemRequestError(t *testing.T) {
+	tests := []string{
+		`{"sku": "", "shelfId": "foo"}`,
+		`{"sku": "foo", "shelfId": ""}`,
+		`{"sku": "foo", "shelfId": "bar", "expirationDate": "31-01-2030"}`,
+	}
+
+	for _, test := range tests {
+		request, err := http.NewRequest("POST", "/item", strings.NewReader(test))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != http.StatusBadRequest {
+			t.Errorf("want: %v, got: %v", http.StatusBadRequest, response.StatusCode)
+		}
+	}
+}
+
+func TestMoveItem(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockItemService(mockCtrl)
+
+	moveItemRequest := api.MoveItemRequest{
+		Id:      item.Id,
+		ShelfId: "d6f1f0a6-3b7b-4a6e-9d2c-1f0e9b8a7c65",
+	}
+
+	tests := []struct {
+		moveItemErr    error
+		wantStatusCode int
+		wantResponse   api.ItemResponse
+	}{
+		{
+			moveItemErr:    nil,
+			wantStatusCode: http.StatusOK,
+			wantResponse: api.ItemResponse{Response: fmt.Sprintf(
+				"Successfully moved item: %s",
+				item.Id,
+			)},
+		},
+		{
+			moveItemErr:    sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.ItemResponse{Error: "Failed to move item"},
+		},
+		{
+			moveItemErr:    wms.ItemDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse: api.ItemResponse{Error: fmt.Sprintf(
+				"failed to move, item: %s does not exist",
+				item.Id,
+			)},
+		},
+		{
+			moveItemErr:    wms.InvalidShelf,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse: api.ItemResponse{Error: fmt.Sprintf("%s: %s",
+				wms.InvalidShelf.Error(),
+				moveItemRequest.ShelfId,
+			)},
+		},
+		{
+			moveItemErr:    &wms.ItemNotTransferableError{ItemId: item.Id, Reason: "is reserved or being picked"},
+			wantStatusCode: http.StatusConflict,
+			wantResponse: api.ItemResponse{Error: (&wms.ItemNotTransferableError{
+				ItemId: item.Id,
+				Reason: "is reserved or being picked",
+			}).Error()},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().MoveItem(gomock.Any(), moveItemRequest.Id, moveItemRequest.ShelfId).Return(test.moveItemErr)
+		h.itemService = mockObj
+
+		marshalledRequest, err := json.Marshal(moveItemRequest)
+		if err != nil {
+			t.Error(err)
+		}
+		request, err := http.NewRequest(
+			"PUT",
+			"/item",
+			bytes.NewBuffer(marshalledRequest),
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ItemResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestDeleteItem(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockItemService(mockCtrl)
+
+	tests := []struct {
+		deleteItemErr  error
+		wantStatusCode int
+		wantResponse   api.ItemResponse
+	}{
+		{
+			deleteItemErr:  nil,
+			wantStatusCode: http.StatusOK,
+			wantResponse: api.ItemResponse{Response: fmt.Sprintf(
+				"Successfully deleted item: %s",
+				item.Id,
+			)},
+		},
+		{
+			deleteItemErr:  sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.ItemResponse{Error: "Failed to delete item"},
+		},
+		{
+			deleteItemErr:  wms.ItemDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse: api.ItemResponse{Error: fmt.Sprintf(
+				"failed to delete, item: %s does not exist",
+				item.Id,
+			)},
+		},
+		{
+			deleteItemErr:  wms.ItemClaimed,
+			wantStatusCode: http.StatusConflict,
+			wantResponse: api.ItemResponse{Error: fmt.Sprintf(
+				"failed to delete, item: %s is reserved or being picked",
+				item.Id,
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().DeleteItemById(gomock.Any(), item.Id).Return(test.deleteItemErr)
+		h.itemService = mockObj
+
+		request, err := http.NewRequest(
+			"DELETE",
+			fmt.Sprintf("/item/%s", item.Id),
+			nil,
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ItemResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"io"
+	"mime"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+// maxLayoutImportBytes caps an import body, a few thousand shelves of CSV
+// fit comfortably.
+const maxLayoutImportBytes = 10 << 20
+
+var unsupportedImportType = errors.New("Content-Type must be text/csv or application/json")
+
+// layoutImportParsers maps the media types an import can be sent as to
+// their parsers.
+var layoutImportParsers = map[string]func(io.Reader) ([]wms.LayoutImportRow, error){
+	"text/csv":         wms.ParseLayoutCSV,
+	"application/json": wms.ParseLayoutJSON,
+}
+
+func (h *handler) ImportLayout(w http.ResponseWriter, r *http.Request) {
+	warehouseId := chi.URLParam(r, "warehouseId")
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	parse, ok := layoutImportParsers[mediaType]
+	if err != nil || !ok {
+		h.logger.Log(log.Error, unsupportedImportType)
+		h.response(w, http.StatusUnsupportedMediaType, api.ImportLayoutResponse{Error: unsupportedImportType.Error()})
+		return
+	}
+
+	rows, err := parse(http.MaxBytesReader(w, r.Body, maxLayoutImportBytes))
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ImportLayoutResponse{Error: err.Error()})
+		return
+	}
+
+	layoutImport, err := h.importService.ImportLayout(r.Context(), warehouseId, rows)
+	if err != nil {
+		var importError *wms.LayoutImportError
+		if errors.As(err, &importError) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ImportLayoutResponse{
+				Error: err.Error(),
+				Rows:  importError.Rows,
+			})
+			return
+		} else if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ImportLayoutResponse{Error: fmt.Sprintf(
+				"failed to import, warehouse: %s does not exist",
+				warehouseId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ImportLayoutResponse{Error: "Failed to import layout"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.ImportLayoutResponse{Response: layoutImport})
+}
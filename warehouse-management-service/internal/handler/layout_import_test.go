@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+func TestImportLayout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockLayoutImportService(mockCtrl)
+	h.importService = mockObj
+
+	rows := []wms.LayoutImportRow{{Aisle: "1", Rack: "1", StorageType: "regular", Section: "A", Level: "1"}}
+	created := wms.LayoutImport{
+		ShelfBlocks: []wms.ShelfBlock{{Id: "block", Aisle: "1", Rack: "1", StorageType: "regular", WarehouseId: warehouse.Id}},
+		Shelves:     []wms.Shelf{{Id: "shelf", Label: "1A", Section: "A", Level: "1", ShelfBlockId: "block"}},
+	}
+	rejected := &wms.LayoutImportError{Rows: []wms.LayoutImportRowError{{Row: 1, Error: "duplicates row 1"}}}
+
+	tests := []struct {
+		contentType    string
+		body           string
+		imported       bool
+		importErr      error
+		wantStatusCode int
+		wantResponse   api.ImportLayoutResponse
+	}{
+		{
+			contentType:    "text/csv",
+			body:           "aisle,rack,storageType,section,level\n1,1,regular,A,1\n",
+			imported:       true,
+			wantStatusCode: http.StatusCreated,
+			wantResponse:   api.ImportLayoutResponse{Response: created},
+		},
+		{
+			contentType:    "application/json; charset=utf-8",
+			body:           `[{"aisle":"1","rack":"1","storageType":"regular","section":"A","level":"1"}]`,
+			imported:       true,
+			importErr:      rejected,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.ImportLayoutResponse{Error: rejected.Error(), Rows: rejected.Rows},
+		},
+		{
+			contentType:    "text/csv",
+			body:           "aisle,rack,storageType,section,level\n1,1,regular,A,1\n",
+			imported:       true,
+			importErr:      wms.WarehouseDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse: api.ImportLayoutResponse{
+				Error: "failed to import, warehouse: " + warehouse.Id + " does not exist",
+			},
+		},
+		{
+			contentType:    "text/csv",
+			body:           "aisle,rack\n",
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.ImportLayoutResponse{Error: `invalid layout import: missing column "storageType"`},
+		},
+		{
+			contentType:    "application/xml",
+			body:           "<layout/>",
+			wantStatusCode: http.StatusUnsupportedMediaType,
+			wantResponse:   api.ImportLayoutResponse{Error: unsupportedImportType.Error()},
+		},
+	}
+	for _, test := range tests {
+		if test.imported {
+			result := created
+			if test.importErr != nil {
+				result = wms.LayoutImport{}
+			}
+			mockObj.EXPECT().ImportLayout(gomock.Any(), warehouse.Id, rows).Return(result, test.importErr)
+		}
+
+		request, err := http.NewRequest("POST", "/warehouse/"+warehouse.Id+"/import", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+		request.Header.Set("Content-Type", test.contentType)
+
+		response := executeRequest(request)
+		var got api.ImportLayoutResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		if !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
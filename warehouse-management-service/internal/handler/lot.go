@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetLotRecall(w http.ResponseWriter, r *http.Request) {
+	lot := chi.URLParam(r, "lot")
+
+	recall, err := h.lotService.GetLotRecall(r.Context(), lot)
+	if err != nil {
+		if err == wms.LotDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.LotRecallResponse{Error: fmt.Sprintf(
+				"failed to get, lot: %s: %s",
+				lot,
+				err.Error(),
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.LotRecallResponse{Error: "Failed to get lot"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.LotRecallResponse{Response: &recall})
+}
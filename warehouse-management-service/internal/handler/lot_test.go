@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+func TestGetLotRecall(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockLotService(mockCtrl)
+	h.lotService = mockObj
+
+	recall := wms.LotRecall{
+		Lot:     "L1",
+		Shelves: []wms.LotShelf{{ShelfId: item.ShelfId, WarehouseId: warehouse.Id, Sku: product.Sku, ItemIds: []string{item.Id}}},
+		Shipments: []wms.LotShipment{{
+			ShipmentId:     shipmentId,
+			OrderId:        orderId,
+			Customer:       "customer",
+			Status:         wms.ShipmentDispatched,
+			PackageId:      "p",
+			TrackingNumber: "TRK1",
+			Sku:            product.Sku,
+			ItemIds:        []string{"i"},
+		}},
+	}
+
+	tests := []struct {
+		recallErr      error
+		wantStatusCode int
+		wantResponse   api.LotRecallResponse
+	}{
+		{
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.LotRecallResponse{Response: &recall},
+		},
+		{
+			recallErr:      wms.LotDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.LotRecallResponse{Error: "failed to get, lot: L1: no items of the lot have been received"},
+		},
+		{
+			recallErr:      sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.LotRecallResponse{Error: "Failed to get lot"},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().GetLotRecall(gomock.Any(), "L1").Return(recall, test.recallErr)
+
+		request, err := http.NewRequest("GET", "/lot/L1/items", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.recallErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.LotRecallResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil || !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v, %v", test.wantResponse, got, err)
+		}
+	}
+}
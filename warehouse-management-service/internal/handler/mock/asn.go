@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./asn.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockASNService is a mock of ASNService interface.
+type MockASNService struct {
+	ctrl     *gomock.Controller
+	recorder *MockASNServiceMockRecorder
+}
+
+// MockASNServiceMockRecorder is the mock recorder for MockASNService.
+type MockASNServiceMockRecorder struct {
+	mock *MockASNService
+}
+
+// NewMockASNService creates a new mock instance.
+func NewMockASNService(ctrl *gomock.Controller) *MockASNService {
+	mock := &MockASNService{ctrl: ctrl}
+	mock.recorder = &MockASNServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockASNService) EXPECT() *MockASNServiceMockRecorder {
+	return m.recorder
+}
+
+// CloseASN mocks base method.
+func (m *MockASNService) CloseASN(ctx context.Context, id string) (warehousemanagementservice.ASN, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseASN", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ASN)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseASN indicates an expected call of CloseASN.
+func (mr *MockASNServiceMockRecorder) CloseASN(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseASN", reflect.TypeOf((*MockASNService)(nil).CloseASN), ctx, id)
+}
+
+// CreateASN mocks base method.
+func (m *MockASNService) CreateASN(ctx context.Context, asn warehousemanagementservice.ASN) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateASN", ctx, asn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateASN indicates an expected call of CreateASN.
+func (mr *MockASNServiceMockRecorder) CreateASN(ctx, asn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateASN", reflect.TypeOf((*MockASNService)(nil).CreateASN), ctx, asn)
+}
+
+// GetASNById mocks base method.
+func (m *MockASNService) GetASNById(ctx context.Context, id string) (warehousemanagementservice.ASN, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetASNById", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ASN)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetASNById indicates an expected call of GetASNById.
+func (mr *MockASNServiceMockRecorder) GetASNById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetASNById", reflect.TypeOf((*MockASNService)(nil).GetASNById), ctx, id)
+}
+
+// ReceiveASNLine mocks base method.
+func (m *MockASNService) ReceiveASNLine(ctx context.Context, asnId, lineId string, quantity int, shelfId string, expirationDate *time.Time, lot string, serialNumbers []string) (warehousemanagementservice.ASNLine, []warehousemanagementservice.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveASNLine", ctx, asnId, lineId, quantity, shelfId, expirationDate, lot, serialNumbers)
+	ret0, _ := ret[0].(warehousemanagementservice.ASNLine)
+	ret1, _ := ret[1].([]warehousemanagementservice.Item)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReceiveASNLine indicates an expected call of ReceiveASNLine.
+func (mr *MockASNServiceMockRecorder) ReceiveASNLine(ctx, asnId, lineId, quantity, shelfId, expirationDate, lot, serialNumbers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveASNLine", reflect.TypeOf((*MockASNService)(nil).ReceiveASNLine), ctx, asnId, lineId, quantity, shelfId, expirationDate, lot, serialNumbers)
+}
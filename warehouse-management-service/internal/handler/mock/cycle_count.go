@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cycle_count.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCycleCountService is a mock of CycleCountService interface.
+type MockCycleCountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCycleCountServiceMockRecorder
+}
+
+// MockCycleCountServiceMockRecorder is the mock recorder for MockCycleCountService.
+type MockCycleCountServiceMockRecorder struct {
+	mock *MockCycleCountService
+}
+
+// NewMockCycleCountService creates a new mock instance.
+func NewMockCycleCountService(ctrl *gomock.Controller) *MockCycleCountService {
+	mock := &MockCycleCountService{ctrl: ctrl}
+	mock.recorder = &MockCycleCountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCycleCountService) EXPECT() *MockCycleCountServiceMockRecorder {
+	return m.recorder
+}
+
+// ApproveCycleCount mocks base method.
+func (m *MockCycleCountService) ApproveCycleCount(ctx context.Context, id, reason string, overrides []warehousemanagementservice.AdjustmentReason) (warehousemanagementservice.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveCycleCount", ctx, id, reason, overrides)
+	ret0, _ := ret[0].(warehousemanagementservice.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveCycleCount indicates an expected call of ApproveCycleCount.
+func (mr *MockCycleCountServiceMockRecorder) ApproveCycleCount(ctx, id, reason, overrides interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveCycleCount", reflect.TypeOf((*MockCycleCountService)(nil).ApproveCycleCount), ctx, id, reason, overrides)
+}
+
+// CreateCycleCount mocks base method.
+func (m *MockCycleCountService) CreateCycleCount(ctx context.Context, scope warehousemanagementservice.CycleCountScope) (warehousemanagementservice.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCycleCount", ctx, scope)
+	ret0, _ := ret[0].(warehousemanagementservice.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCycleCount indicates an expected call of CreateCycleCount.
+func (mr *MockCycleCountServiceMockRecorder) CreateCycleCount(ctx, scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCycleCount", reflect.TypeOf((*MockCycleCountService)(nil).CreateCycleCount), ctx, scope)
+}
+
+// GetCycleCountById mocks base method.
+func (m *MockCycleCountService) GetCycleCountById(ctx context.Context, id string) (warehousemanagementservice.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCycleCountById", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCycleCountById indicates an expected call of GetCycleCountById.
+func (mr *MockCycleCountServiceMockRecorder) GetCycleCountById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCycleCountById", reflect.TypeOf((*MockCycleCountService)(nil).GetCycleCountById), ctx, id)
+}
+
+// GetVarianceReport mocks base method.
+func (m *MockCycleCountService) GetVarianceReport(ctx context.Context, id string) ([]warehousemanagementservice.Variance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVarianceReport", ctx, id)
+	ret0, _ := ret[0].([]warehousemanagementservice.Variance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVarianceReport indicates an expected call of GetVarianceReport.
+func (mr *MockCycleCountServiceMockRecorder) GetVarianceReport(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVarianceReport", reflect.TypeOf((*MockCycleCountService)(nil).GetVarianceReport), ctx, id)
+}
+
+// SubmitCounts mocks base method.
+func (m *MockCycleCountService) SubmitCounts(ctx context.Context, id string, counts []warehousemanagementservice.CycleCountLine) (warehousemanagementservice.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitCounts", ctx, id, counts)
+	ret0, _ := ret[0].(warehousemanagementservice.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitCounts indicates an expected call of SubmitCounts.
+func (mr *MockCycleCountServiceMockRecorder) SubmitCounts(ctx, id, counts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitCounts", reflect.TypeOf((*MockCycleCountService)(nil).SubmitCounts), ctx, id, counts)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./expiry.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExpiryService is a mock of ExpiryService interface.
+type MockExpiryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExpiryServiceMockRecorder
+}
+
+// MockExpiryServiceMockRecorder is the mock recorder for MockExpiryService.
+type MockExpiryServiceMockRecorder struct {
+	mock *MockExpiryService
+}
+
+// NewMockExpiryService creates a new mock instance.
+func NewMockExpiryService(ctrl *gomock.Controller) *MockExpiryService {
+	mock := &MockExpiryService{ctrl: ctrl}
+	mock.recorder = &MockExpiryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExpiryService) EXPECT() *MockExpiryServiceMockRecorder {
+	return m.recorder
+}
+
+// ListExpiringItems mocks base method.
+func (m *MockExpiryService) ListExpiringItems(ctx context.Context, warehouseId string, now time.Time, withinDays int) ([]warehousemanagementservice.ExpiringItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiringItems", ctx, warehouseId, now, withinDays)
+	ret0, _ := ret[0].([]warehousemanagementservice.ExpiringItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiringItems indicates an expected call of ListExpiringItems.
+func (mr *MockExpiryServiceMockRecorder) ListExpiringItems(ctx, warehouseId, now, withinDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiringItems", reflect.TypeOf((*MockExpiryService)(nil).ListExpiringItems), ctx, warehouseId, now, withinDays)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./export.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExportService is a mock of ExportService interface.
+type MockExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportServiceMockRecorder
+}
+
+// MockExportServiceMockRecorder is the mock recorder for MockExportService.
+type MockExportServiceMockRecorder struct {
+	mock *MockExportService
+}
+
+// NewMockExportService creates a new mock instance.
+func NewMockExportService(ctrl *gomock.Controller) *MockExportService {
+	mock := &MockExportService{ctrl: ctrl}
+	mock.recorder = &MockExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportService) EXPECT() *MockExportServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportWarehouse mocks base method.
+func (m *MockExportService) ExportWarehouse(ctx context.Context, warehouseId string, each func(warehousemanagementservice.ExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportWarehouse", ctx, warehouseId, each)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportWarehouse indicates an expected call of ExportWarehouse.
+func (mr *MockExportServiceMockRecorder) ExportWarehouse(ctx, warehouseId, each interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportWarehouse", reflect.TypeOf((*MockExportService)(nil).ExportWarehouse), ctx, warehouseId, each)
+}
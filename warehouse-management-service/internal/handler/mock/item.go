@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./item.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockItemService is a mock of ItemService interface.
+type MockItemService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemServiceMockRecorder
+}
+
+// MockItemServiceMockRecorder is the mock recorder for MockItemService.
+type MockItemServiceMockRecorder struct {
+	mock *MockItemService
+}
+
+// NewMockItemService creates a new mock instance.
+func NewMockItemService(ctrl *gomock.Controller) *MockItemService {
+	mock := &MockItemService{ctrl: ctrl}
+	mock.recorder = &MockItemServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemService) EXPECT() *MockItemServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateItem mocks base method.
+func (m *MockItemService) CreateItem(ctx context.Context, item warehousemanagementservice.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockItemServiceMockRecorder) CreateItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItemService)(nil).CreateItem), ctx, item)
+}
+
+// DeleteItemById mocks base method.
+func (m *MockItemService) DeleteItemById(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItemById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItemById indicates an expected call of DeleteItemById.
+func (mr *MockItemServiceMockRecorder) DeleteItemById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItemById", reflect.TypeOf((*MockItemService)(nil).DeleteItemById), ctx, id)
+}
+
+// GetItemById mocks base method.
+func (m *MockItemService) GetItemById(ctx context.Context, id string) (warehousemanagementservice.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemById", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemById indicates an expected call of GetItemById.
+func (mr *MockItemServiceMockRecorder) GetItemById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemById", reflect.TypeOf((*MockItemService)(nil).GetItemById), ctx, id)
+}
+
+// GetItemBySerialNumber mocks base method.
+func (m *MockItemService) GetItemBySerialNumber(ctx context.Context, serialNumber string) (warehousemanagementservice.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemBySerialNumber", ctx, serialNumber)
+	ret0, _ := ret[0].(warehousemanagementservice.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemBySerialNumber indicates an expected call of GetItemBySerialNumber.
+func (mr *MockItemServiceMockRecorder) GetItemBySerialNumber(ctx, serialNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemBySerialNumber", reflect.TypeOf((*MockItemService)(nil).GetItemBySerialNumber), ctx, serialNumber)
+}
+
+// MoveItem mocks base method.
+func (m *MockItemService) MoveItem(ctx context.Context, id, shelfId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", ctx, id, shelfId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockItemServiceMockRecorder) MoveItem(ctx, id, shelfId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockItemService)(nil).MoveItem), ctx, id, shelfId)
+}
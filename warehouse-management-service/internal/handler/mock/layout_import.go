@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./layout_import.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLayoutImportService is a mock of LayoutImportService interface.
+type MockLayoutImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLayoutImportServiceMockRecorder
+}
+
+// MockLayoutImportServiceMockRecorder is the mock recorder for MockLayoutImportService.
+type MockLayoutImportServiceMockRecorder struct {
+	mock *MockLayoutImportService
+}
+
+// NewMockLayoutImportService creates a new mock instance.
+func NewMockLayoutImportService(ctrl *gomock.Controller) *MockLayoutImportService {
+	mock := &MockLayoutImportService{ctrl: ctrl}
+	mock.recorder = &MockLayoutImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLayoutImportService) EXPECT() *MockLayoutImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportLayout mocks base method.
+func (m *MockLayoutImportService) ImportLayout(ctx context.Context, warehouseId string, rows []warehousemanagementservice.LayoutImportRow) (warehousemanagementservice.LayoutImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLayout", ctx, warehouseId, rows)
+	ret0, _ := ret[0].(warehousemanagementservice.LayoutImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportLayout indicates an expected call of ImportLayout.
+func (mr *MockLayoutImportServiceMockRecorder) ImportLayout(ctx, warehouseId, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLayout", reflect.TypeOf((*MockLayoutImportService)(nil).ImportLayout), ctx, warehouseId, rows)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./lot.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLotService is a mock of LotService interface.
+type MockLotService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLotServiceMockRecorder
+}
+
+// MockLotServiceMockRecorder is the mock recorder for MockLotService.
+type MockLotServiceMockRecorder struct {
+	mock *MockLotService
+}
+
+// NewMockLotService creates a new mock instance.
+func NewMockLotService(ctrl *gomock.Controller) *MockLotService {
+	mock := &MockLotService{ctrl: ctrl}
+	mock.recorder = &MockLotServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLotService) EXPECT() *MockLotServiceMockRecorder {
+	return m.recorder
+}
+
+// GetLotRecall mocks base method.
+func (m *MockLotService) GetLotRecall(ctx context.Context, lot string) (warehousemanagementservice.LotRecall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLotRecall", ctx, lot)
+	ret0, _ := ret[0].(warehousemanagementservice.LotRecall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLotRecall indicates an expected call of GetLotRecall.
+func (mr *MockLotServiceMockRecorder) GetLotRecall(ctx, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLotRecall", reflect.TypeOf((*MockLotService)(nil).GetLotRecall), ctx, lot)
+}
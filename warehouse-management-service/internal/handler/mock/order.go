@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./order.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderService) CreateOrder(ctx context.Context, order warehousemanagementservice.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderServiceMockRecorder) CreateOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderService)(nil).CreateOrder), ctx, order)
+}
+
+// GeneratePickList mocks base method.
+func (m *MockOrderService) GeneratePickList(ctx context.Context, orderId string) (warehousemanagementservice.PickList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GeneratePickList", ctx, orderId)
+	ret0, _ := ret[0].(warehousemanagementservice.PickList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GeneratePickList indicates an expected call of GeneratePickList.
+func (mr *MockOrderServiceMockRecorder) GeneratePickList(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePickList", reflect.TypeOf((*MockOrderService)(nil).GeneratePickList), ctx, orderId)
+}
+
+// GetOrderById mocks base method.
+func (m *MockOrderService) GetOrderById(ctx context.Context, id string) (warehousemanagementservice.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderById", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderById indicates an expected call of GetOrderById.
+func (mr *MockOrderServiceMockRecorder) GetOrderById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderById", reflect.TypeOf((*MockOrderService)(nil).GetOrderById), ctx, id)
+}
+
+// GetPickList mocks base method.
+func (m *MockOrderService) GetPickList(ctx context.Context, orderId string) (warehousemanagementservice.PickList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPickList", ctx, orderId)
+	ret0, _ := ret[0].(warehousemanagementservice.PickList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPickList indicates an expected call of GetPickList.
+func (mr *MockOrderServiceMockRecorder) GetPickList(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPickList", reflect.TypeOf((*MockOrderService)(nil).GetPickList), ctx, orderId)
+}
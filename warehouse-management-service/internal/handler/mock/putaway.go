@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./putaway.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPutawayService is a mock of PutawayService interface.
+type MockPutawayService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPutawayServiceMockRecorder
+}
+
+// MockPutawayServiceMockRecorder is the mock recorder for MockPutawayService.
+type MockPutawayServiceMockRecorder struct {
+	mock *MockPutawayService
+}
+
+// NewMockPutawayService creates a new mock instance.
+func NewMockPutawayService(ctrl *gomock.Controller) *MockPutawayService {
+	mock := &MockPutawayService{ctrl: ctrl}
+	mock.recorder = &MockPutawayServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPutawayService) EXPECT() *MockPutawayServiceMockRecorder {
+	return m.recorder
+}
+
+// SuggestPutaway mocks base method.
+func (m *MockPutawayService) SuggestPutaway(ctx context.Context, warehouseId, sku string, quantity, limit int) ([]warehousemanagementservice.PutawaySuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestPutaway", ctx, warehouseId, sku, quantity, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.PutawaySuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestPutaway indicates an expected call of SuggestPutaway.
+func (mr *MockPutawayServiceMockRecorder) SuggestPutaway(ctx, warehouseId, sku, quantity, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestPutaway", reflect.TypeOf((*MockPutawayService)(nil).SuggestPutaway), ctx, warehouseId, sku, quantity, limit)
+}
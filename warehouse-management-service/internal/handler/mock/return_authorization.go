@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./return_authorization.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReturnService is a mock of ReturnService interface.
+type MockReturnService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnServiceMockRecorder
+}
+
+// MockReturnServiceMockRecorder is the mock recorder for MockReturnService.
+type MockReturnServiceMockRecorder struct {
+	mock *MockReturnService
+}
+
+// NewMockReturnService creates a new mock instance.
+func NewMockReturnService(ctrl *gomock.Controller) *MockReturnService {
+	mock := &MockReturnService{ctrl: ctrl}
+	mock.recorder = &MockReturnServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnService) EXPECT() *MockReturnServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateReturn mocks base method.
+func (m *MockReturnService) CreateReturn(ctx context.Context, shipmentId, reason string, itemIds []string) (warehousemanagementservice.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturn", ctx, shipmentId, reason, itemIds)
+	ret0, _ := ret[0].(warehousemanagementservice.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockReturnServiceMockRecorder) CreateReturn(ctx, shipmentId, reason, itemIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockReturnService)(nil).CreateReturn), ctx, shipmentId, reason, itemIds)
+}
+
+// GetReturnById mocks base method.
+func (m *MockReturnService) GetReturnById(ctx context.Context, id string) (warehousemanagementservice.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnById", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnById indicates an expected call of GetReturnById.
+func (mr *MockReturnServiceMockRecorder) GetReturnById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnById", reflect.TypeOf((*MockReturnService)(nil).GetReturnById), ctx, id)
+}
+
+// InspectReturnItem mocks base method.
+func (m *MockReturnService) InspectReturnItem(ctx context.Context, returnId, itemId, condition, notes string) (warehousemanagementservice.ReturnItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectReturnItem", ctx, returnId, itemId, condition, notes)
+	ret0, _ := ret[0].(warehousemanagementservice.ReturnItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectReturnItem indicates an expected call of InspectReturnItem.
+func (mr *MockReturnServiceMockRecorder) InspectReturnItem(ctx, returnId, itemId, condition, notes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectReturnItem", reflect.TypeOf((*MockReturnService)(nil).InspectReturnItem), ctx, returnId, itemId, condition, notes)
+}
+
+// ResolveReturnItem mocks base method.
+func (m *MockReturnService) ResolveReturnItem(ctx context.Context, returnId, itemId, outcome, shelfId string) (warehousemanagementservice.ReturnItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReturnItem", ctx, returnId, itemId, outcome, shelfId)
+	ret0, _ := ret[0].(warehousemanagementservice.ReturnItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveReturnItem indicates an expected call of ResolveReturnItem.
+func (mr *MockReturnServiceMockRecorder) ResolveReturnItem(ctx, returnId, itemId, outcome, shelfId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReturnItem", reflect.TypeOf((*MockReturnService)(nil).ResolveReturnItem), ctx, returnId, itemId, outcome, shelfId)
+}
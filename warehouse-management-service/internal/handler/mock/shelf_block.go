@@ -50,17 +50,17 @@ func (mr *MockShelfBlockServiceMockRecorder) CreateShelfBlock(ctx, shelfBlock in
 }
 
 // DeleteShelfBlockById mocks base method.
-func (m *MockShelfBlockService) DeleteShelfBlockById(ctx context.Context, id string) error {
+func (m *MockShelfBlockService) DeleteShelfBlockById(ctx context.Context, id string, version int, cascade bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteShelfBlockById", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteShelfBlockById", ctx, id, version, cascade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteShelfBlockById indicates an expected call of DeleteShelfBlockById.
-func (mr *MockShelfBlockServiceMockRecorder) DeleteShelfBlockById(ctx, id interface{}) *gomock.Call {
+func (mr *MockShelfBlockServiceMockRecorder) DeleteShelfBlockById(ctx, id, version, cascade interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShelfBlockById", reflect.TypeOf((*MockShelfBlockService)(nil).DeleteShelfBlockById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShelfBlockById", reflect.TypeOf((*MockShelfBlockService)(nil).DeleteShelfBlockById), ctx, id, version, cascade)
 }
 
 // GetShelfBlockById mocks base method.
@@ -78,6 +78,49 @@ func (mr *MockShelfBlockServiceMockRecorder) GetShelfBlockById(ctx, id interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShelfBlockById", reflect.TypeOf((*MockShelfBlockService)(nil).GetShelfBlockById), ctx, id)
 }
 
+// ListShelfBlocks mocks base method.
+func (m *MockShelfBlockService) ListShelfBlocks(ctx context.Context, warehouseId, after string, limit int) ([]warehousemanagementservice.ShelfBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShelfBlocks", ctx, warehouseId, after, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.ShelfBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShelfBlocks indicates an expected call of ListShelfBlocks.
+func (mr *MockShelfBlockServiceMockRecorder) ListShelfBlocks(ctx, warehouseId, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShelfBlocks", reflect.TypeOf((*MockShelfBlockService)(nil).ListShelfBlocks), ctx, warehouseId, after, limit)
+}
+
+// PurgeShelfBlockById mocks base method.
+func (m *MockShelfBlockService) PurgeShelfBlockById(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeShelfBlockById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeShelfBlockById indicates an expected call of PurgeShelfBlockById.
+func (mr *MockShelfBlockServiceMockRecorder) PurgeShelfBlockById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeShelfBlockById", reflect.TypeOf((*MockShelfBlockService)(nil).PurgeShelfBlockById), ctx, id)
+}
+
+// RestoreShelfBlockById mocks base method.
+func (m *MockShelfBlockService) RestoreShelfBlockById(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreShelfBlockById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreShelfBlockById indicates an expected call of RestoreShelfBlockById.
+func (mr *MockShelfBlockServiceMockRecorder) RestoreShelfBlockById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreShelfBlockById", reflect.TypeOf((*MockShelfBlockService)(nil).RestoreShelfBlockById), ctx, id)
+}
+
 // UpdateShelfBlock mocks base method.
 func (m *MockShelfBlockService) UpdateShelfBlock(ctx context.Context, shelfBlock warehousemanagementservice.ShelfBlock) error {
 	m.ctrl.T.Helper()
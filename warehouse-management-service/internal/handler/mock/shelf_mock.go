@@ -50,17 +50,17 @@ func (mr *MockShelfServiceMockRecorder) CreateShelf(ctx, shelf interface{}) *gom
 }
 
 // DeleteShelfById mocks base method.
-func (m *MockShelfService) DeleteShelfById(ctx context.Context, id string) error {
+func (m *MockShelfService) DeleteShelfById(ctx context.Context, id string, version int, cascade bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteShelfById", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteShelfById", ctx, id, version, cascade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteShelfById indicates an expected call of DeleteShelfById.
-func (mr *MockShelfServiceMockRecorder) DeleteShelfById(ctx, id interface{}) *gomock.Call {
+func (mr *MockShelfServiceMockRecorder) DeleteShelfById(ctx, id, version, cascade interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShelfById", reflect.TypeOf((*MockShelfService)(nil).DeleteShelfById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShelfById", reflect.TypeOf((*MockShelfService)(nil).DeleteShelfById), ctx, id, version, cascade)
 }
 
 // GetShelfById mocks base method.
@@ -78,6 +78,64 @@ func (mr *MockShelfServiceMockRecorder) GetShelfById(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShelfById", reflect.TypeOf((*MockShelfService)(nil).GetShelfById), ctx, id)
 }
 
+// GetShelfUtilization mocks base method.
+func (m *MockShelfService) GetShelfUtilization(ctx context.Context, id string) (warehousemanagementservice.ShelfUtilization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShelfUtilization", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ShelfUtilization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShelfUtilization indicates an expected call of GetShelfUtilization.
+func (mr *MockShelfServiceMockRecorder) GetShelfUtilization(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShelfUtilization", reflect.TypeOf((*MockShelfService)(nil).GetShelfUtilization), ctx, id)
+}
+
+// ListShelves mocks base method.
+func (m *MockShelfService) ListShelves(ctx context.Context, shelfBlockId, after string, limit int) ([]warehousemanagementservice.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShelves", ctx, shelfBlockId, after, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShelves indicates an expected call of ListShelves.
+func (mr *MockShelfServiceMockRecorder) ListShelves(ctx, shelfBlockId, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShelves", reflect.TypeOf((*MockShelfService)(nil).ListShelves), ctx, shelfBlockId, after, limit)
+}
+
+// PurgeShelfById mocks base method.
+func (m *MockShelfService) PurgeShelfById(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeShelfById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeShelfById indicates an expected call of PurgeShelfById.
+func (mr *MockShelfServiceMockRecorder) PurgeShelfById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeShelfById", reflect.TypeOf((*MockShelfService)(nil).PurgeShelfById), ctx, id)
+}
+
+// RestoreShelfById mocks base method.
+func (m *MockShelfService) RestoreShelfById(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreShelfById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreShelfById indicates an expected call of RestoreShelfById.
+func (mr *MockShelfServiceMockRecorder) RestoreShelfById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreShelfById", reflect.TypeOf((*MockShelfService)(nil).RestoreShelfById), ctx, id)
+}
+
 // UpdateShelf mocks base method.
 func (m *MockShelfService) UpdateShelf(ctx context.Context, shelf warehousemanagementservice.Shelf) error {
 	m.ctrl.T.Helper()
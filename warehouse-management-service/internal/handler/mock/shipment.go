@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./shipment.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShipmentService is a mock of ShipmentService interface.
+type MockShipmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentServiceMockRecorder
+}
+
+// MockShipmentServiceMockRecorder is the mock recorder for MockShipmentService.
+type MockShipmentServiceMockRecorder struct {
+	mock *MockShipmentService
+}
+
+// NewMockShipmentService creates a new mock instance.
+func NewMockShipmentService(ctrl *gomock.Controller) *MockShipmentService {
+	mock := &MockShipmentService{ctrl: ctrl}
+	mock.recorder = &MockShipmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentService) EXPECT() *MockShipmentServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateShipment mocks base method.
+func (m *MockShipmentService) CreateShipment(ctx context.Context, shipment warehousemanagementservice.Shipment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", ctx, shipment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockShipmentServiceMockRecorder) CreateShipment(ctx, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockShipmentService)(nil).CreateShipment), ctx, shipment)
+}
+
+// DispatchShipment mocks base method.
+func (m *MockShipmentService) DispatchShipment(ctx context.Context, id string) (warehousemanagementservice.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchShipment", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchShipment indicates an expected call of DispatchShipment.
+func (mr *MockShipmentServiceMockRecorder) DispatchShipment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchShipment", reflect.TypeOf((*MockShipmentService)(nil).DispatchShipment), ctx, id)
+}
+
+// GetShipmentById mocks base method.
+func (m *MockShipmentService) GetShipmentById(ctx context.Context, id string) (warehousemanagementservice.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentById", ctx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentById indicates an expected call of GetShipmentById.
+func (mr *MockShipmentServiceMockRecorder) GetShipmentById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentById", reflect.TypeOf((*MockShipmentService)(nil).GetShipmentById), ctx, id)
+}
+
+// PackItems mocks base method.
+func (m *MockShipmentService) PackItems(ctx context.Context, shipmentId, trackingNumber string, itemIds []string) (warehousemanagementservice.Package, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PackItems", ctx, shipmentId, trackingNumber, itemIds)
+	ret0, _ := ret[0].(warehousemanagementservice.Package)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PackItems indicates an expected call of PackItems.
+func (mr *MockShipmentServiceMockRecorder) PackItems(ctx, shipmentId, trackingNumber, itemIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PackItems", reflect.TypeOf((*MockShipmentService)(nil).PackItems), ctx, shipmentId, trackingNumber, itemIds)
+}
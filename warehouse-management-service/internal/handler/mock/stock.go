@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./stock.go
+
+// Package mock_warehousemanagementservice is a generated GoMock package.
+package mock_warehousemanagementservice
+
+import (
+	context "context"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStockService is a mock of StockService interface.
+type MockStockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockServiceMockRecorder
+}
+
+// MockStockServiceMockRecorder is the mock recorder for MockStockService.
+type MockStockServiceMockRecorder struct {
+	mock *MockStockService
+}
+
+// NewMockStockService creates a new mock instance.
+func NewMockStockService(ctrl *gomock.Controller) *MockStockService {
+	mock := &MockStockService{ctrl: ctrl}
+	mock.recorder = &MockStockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockService) EXPECT() *MockStockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetProductStock mocks base method.
+func (m *MockStockService) GetProductStock(ctx context.Context, sku string) (warehousemanagementservice.ProductStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductStock", ctx, sku)
+	ret0, _ := ret[0].(warehousemanagementservice.ProductStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductStock indicates an expected call of GetProductStock.
+func (mr *MockStockServiceMockRecorder) GetProductStock(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductStock", reflect.TypeOf((*MockStockService)(nil).GetProductStock), ctx, sku)
+}
+
+// GetWarehouseInventory mocks base method.
+func (m *MockStockService) GetWarehouseInventory(ctx context.Context, warehouseId string) (warehousemanagementservice.WarehouseInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarehouseInventory", ctx, warehouseId)
+	ret0, _ := ret[0].(warehousemanagementservice.WarehouseInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarehouseInventory indicates an expected call of GetWarehouseInventory.
+func (mr *MockStockServiceMockRecorder) GetWarehouseInventory(ctx, warehouseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouseInventory", reflect.TypeOf((*MockStockService)(nil).GetWarehouseInventory), ctx, warehouseId)
+}
@@ -50,17 +50,17 @@ func (mr *MockWarehouseServiceMockRecorder) CreateWarehouse(ctx, warehouse inter
 }
 
 // DeleteWarehouse mocks base method.
-func (m *MockWarehouseService) DeleteWarehouse(ctx context.Context, id string) error {
+func (m *MockWarehouseService) DeleteWarehouse(ctx context.Context, id string, version int, cascade bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWarehouse", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteWarehouse", ctx, id, version, cascade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteWarehouse indicates an expected call of DeleteWarehouse.
-func (mr *MockWarehouseServiceMockRecorder) DeleteWarehouse(ctx, id interface{}) *gomock.Call {
+func (mr *MockWarehouseServiceMockRecorder) DeleteWarehouse(ctx, id, version, cascade interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWarehouse", reflect.TypeOf((*MockWarehouseService)(nil).DeleteWarehouse), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWarehouse", reflect.TypeOf((*MockWarehouseService)(nil).DeleteWarehouse), ctx, id, version, cascade)
 }
 
 // GetWarehouseById mocks base method.
@@ -78,6 +78,79 @@ func (mr *MockWarehouseServiceMockRecorder) GetWarehouseById(ctx, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouseById", reflect.TypeOf((*MockWarehouseService)(nil).GetWarehouseById), ctx, id)
 }
 
+// GetWarehouseLayout mocks base method.
+func (m *MockWarehouseService) GetWarehouseLayout(ctx context.Context, id string, includeItemCounts bool) (warehousemanagementservice.WarehouseLayout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarehouseLayout", ctx, id, includeItemCounts)
+	ret0, _ := ret[0].(warehousemanagementservice.WarehouseLayout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarehouseLayout indicates an expected call of GetWarehouseLayout.
+func (mr *MockWarehouseServiceMockRecorder) GetWarehouseLayout(ctx, id, includeItemCounts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouseLayout", reflect.TypeOf((*MockWarehouseService)(nil).GetWarehouseLayout), ctx, id, includeItemCounts)
+}
+
+// ListWarehouses mocks base method.
+func (m *MockWarehouseService) ListWarehouses(ctx context.Context, after string, limit int) ([]warehousemanagementservice.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWarehouses", ctx, after, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWarehouses indicates an expected call of ListWarehouses.
+func (mr *MockWarehouseServiceMockRecorder) ListWarehouses(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWarehouses", reflect.TypeOf((*MockWarehouseService)(nil).ListWarehouses), ctx, after, limit)
+}
+
+// NearestWarehouses mocks base method.
+func (m *MockWarehouseService) NearestWarehouses(ctx context.Context, latitude, longitude float64, radiusInKm *float64, limit int) ([]warehousemanagementservice.NearbyWarehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NearestWarehouses", ctx, latitude, longitude, radiusInKm, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.NearbyWarehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NearestWarehouses indicates an expected call of NearestWarehouses.
+func (mr *MockWarehouseServiceMockRecorder) NearestWarehouses(ctx, latitude, longitude, radiusInKm, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NearestWarehouses", reflect.TypeOf((*MockWarehouseService)(nil).NearestWarehouses), ctx, latitude, longitude, radiusInKm, limit)
+}
+
+// PurgeWarehouse mocks base method.
+func (m *MockWarehouseService) PurgeWarehouse(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeWarehouse", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeWarehouse indicates an expected call of PurgeWarehouse.
+func (mr *MockWarehouseServiceMockRecorder) PurgeWarehouse(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeWarehouse", reflect.TypeOf((*MockWarehouseService)(nil).PurgeWarehouse), ctx, id)
+}
+
+// RestoreWarehouse mocks base method.
+func (m *MockWarehouseService) RestoreWarehouse(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreWarehouse", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreWarehouse indicates an expected call of RestoreWarehouse.
+func (mr *MockWarehouseServiceMockRecorder) RestoreWarehouse(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreWarehouse", reflect.TypeOf((*MockWarehouseService)(nil).RestoreWarehouse), ctx, id)
+}
+
 // UpdateWarehouse mocks base method.
 func (m *MockWarehouseService) UpdateWarehouse(ctx context.Context, warehouse *warehousemanagementservice.Warehouse) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	orderId := chi.URLParam(r, "orderId")
+
+	order, err := h.orderService.GetOrderById(r.Context(), orderId)
+	if err != nil {
+		if err == wms.OrderDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.OrderResponse{Error: fmt.Sprintf(
+				"failed to get, order: %s does not exist",
+				orderId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.OrderResponse{Error: "Failed to get order"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.OrderResponse{Response: &order})
+}
+
+func (h *handler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	var createOrderRequest api.CreateOrderRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.OrderResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createOrderRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.OrderResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(createOrderRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.OrderResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	lines := make([]wms.OrderLine, 0, len(createOrderRequest.Lines))
+	for _, line := range createOrderRequest.Lines {
+		lines = append(lines, wms.OrderLine{Sku: line.Sku, Quantity: line.Quantity})
+	}
+	order := wms.NewOrder(createOrderRequest.Customer, createOrderRequest.WarehouseId, lines)
+
+	err = order.Validate()
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.OrderResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	err = h.orderService.CreateOrder(r.Context(), order)
+	if err != nil {
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.OrderResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				order.WarehouseId,
+			)})
+			return
+		} else if err == wms.InvalidProduct {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.OrderResponse{Error: err.Error()})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.OrderResponse{Error: "Failed to create order"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.OrderResponse{Response: &order})
+}
+
+func (h *handler) GeneratePickList(w http.ResponseWriter, r *http.Request) {
+	orderId := chi.URLParam(r, "orderId")
+
+	pickList, err := h.orderService.GeneratePickList(r.Context(), orderId)
+	if err != nil {
+		var insufficientStock *wms.InsufficientStockError
+		if err == wms.OrderDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.PickListResponse{Error: fmt.Sprintf(
+				"failed to generate pick list, order: %s does not exist",
+				orderId,
+			)})
+			return
+		} else if err == wms.OrderNotOpen {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.PickListResponse{Error: fmt.Sprintf(
+				"failed to generate pick list, order: %s is not open",
+				orderId,
+			)})
+			return
+		} else if errors.As(err, &insufficientStock) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.PickListResponse{
+				Error:     wms.InsufficientStock.Error(),
+				Shortages: insufficientStock.Shortages,
+			})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.PickListResponse{Error: "Failed to generate pick list"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.PickListResponse{Response: &pickList})
+}
+
+func (h *handler) GetPickList(w http.ResponseWriter, r *http.Request) {
+	orderId := chi.URLParam(r, "orderId")
+
+	pickList, err := h.orderService.GetPickList(r.Context(), orderId)
+	if err != nil {
+		if err == wms.OrderDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.PickListResponse{Error: fmt.Sprintf(
+				"failed to get pick list, order: %s does not exist",
+				orderId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.PickListResponse{Error: "Failed to get pick list"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.PickListResponse{Response: &pickList})
+}
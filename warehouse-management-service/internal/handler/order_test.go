@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+const orderId = "0b7e2f4a-3c5d-4e6f-8a9b-1c2d3e4f5a60"
+
+func TestCreateOrder(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockOrderService(mockCtrl)
+	h.orderService = mockObj
+
+	validBody := `{"customer": "acme", "warehouseId": "` + warehouse.Id + `", "lines": [{"sku": "` + product.Sku + `", "quantity": 3}]}`
+
+	tests := []struct {
+		body           string
+		createOrderErr error
+		wantStatusCode int
+	}{
+		{body: validBody, wantStatusCode: http.StatusCreated},
+		{body: validBody, createOrderErr: wms.WarehouseDoesNotExist, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createOrderErr: wms.InvalidProduct, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createOrderErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"customer": "acme", "warehouseId": "w", "lines": []}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"customer": "acme", "warehouseId": "w", "lines": [{"sku": "a", "quantity": 0}]}`, wantStatusCode: http.StatusBadRequest},
+		{
+			body:           `{"customer": "acme", "warehouseId": "w", "lines": [{"sku": "a", "quantity": 1}, {"sku": "a", "quantity": 2}]}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{body: `{"warehouseId": "w", "lines": [{"sku": "a", "quantity": 1}]}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.createOrderErr != nil {
+			mockObj.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(test.createOrderErr)
+		}
+
+		request, err := http.NewRequest("POST", "/order", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s: want: %v, got: %v", test.body, test.wantStatusCode, response.StatusCode)
+		}
+		if response.StatusCode != http.StatusCreated {
+			continue
+		}
+
+		var got api.OrderResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got.Response == nil ||
+			got.Response.Status != wms.OrderOpen ||
+			len(got.Response.Lines) != 1 ||
+			got.Response.Lines[0].OrderId != got.Response.Id ||
+			got.Response.Lines[0].Quantity != 3 {
+			t.Errorf("want an open order with one line of 3, got: %v", got)
+		}
+	}
+}
+
+func TestGeneratePickList(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockOrderService(mockCtrl)
+	h.orderService = mockObj
+
+	pickList := wms.PickList{OrderId: orderId, Entries: []wms.PickListEntry{
+		{Sequence: 1, ItemId: item.Id, Sku: product.Sku, ShelfId: item.ShelfId, Aisle: "1", Rack: "1", Section: "1", Level: "1"},
+	}}
+	shortages := []wms.StockShortage{{Sku: product.Sku, Requested: 3, Available: 1}}
+
+	tests := []struct {
+		generateErr    error
+		wantStatusCode int
+		wantResponse   api.PickListResponse
+	}{
+		{wantStatusCode: http.StatusCreated, wantResponse: api.PickListResponse{Response: &pickList}},
+		{
+			generateErr:    wms.OrderDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.PickListResponse{Error: "failed to generate pick list, order: " + orderId + " does not exist"},
+		},
+		{
+			generateErr:    wms.OrderNotOpen,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.PickListResponse{Error: "failed to generate pick list, order: " + orderId + " is not open"},
+		},
+		{
+			generateErr:    &wms.InsufficientStockError{Shortages: shortages},
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.PickListResponse{Error: wms.InsufficientStock.Error(), Shortages: shortages},
+		},
+		{
+			generateErr:    sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.PickListResponse{Error: "Failed to generate pick list"},
+		},
+	}
+
+	for _, test := range tests {
+		if test.generateErr == nil {
+			mockObj.EXPECT().GeneratePickList(gomock.Any(), orderId).Return(pickList, nil)
+		} else {
+			mockObj.EXPECT().GeneratePickList(gomock.Any(), orderId).Return(wms.PickList{}, test.generateErr)
+		}
+
+		request, err := http.NewRequest("POST", "/order/"+orderId+"/pick_list", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.generateErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.PickListResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestGetPickList(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockOrderService(mockCtrl)
+	h.orderService = mockObj
+
+	pickList := wms.PickList{OrderId: orderId, Entries: []wms.PickListEntry{
+		{Sequence: 1, ItemId: item.Id, Sku: product.Sku, ShelfId: item.ShelfId, Aisle: "1", Rack: "1", Section: "1", Level: "1"},
+	}}
+
+	tests := []struct {
+		getErr         error
+		wantStatusCode int
+		wantResponse   api.PickListResponse
+	}{
+		{wantStatusCode: http.StatusOK, wantResponse: api.PickListResponse{Response: &pickList}},
+		{
+			getErr:         wms.OrderDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.PickListResponse{Error: "failed to get pick list, order: " + orderId + " does not exist"},
+		},
+		{
+			getErr:         sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.PickListResponse{Error: "Failed to get pick list"},
+		},
+	}
+
+	for _, test := range tests {
+		if test.getErr == nil {
+			mockObj.EXPECT().GetPickList(gomock.Any(), orderId).Return(pickList, nil)
+		} else {
+			mockObj.EXPECT().GetPickList(gomock.Any(), orderId).Return(wms.PickList{}, test.getErr)
+		}
+
+		request, err := http.NewRequest("GET", "/order/"+orderId+"/pick_list", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.getErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.PickListResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+
+	defaultNearestWarehouses = 10
+)
+
+var invalidCursor = errors.New("invalid cursor")
+var invalidLimit = errors.New("limit must be a number between 1 and 100")
+
+// pageParams reads the cursor and limit query parameters of a list request.
+// The cursor is opaque to clients, it wraps the id of the last row of the
+// previous page.
+func pageParams(r *http.Request) (string, int, error) {
+	limit := defaultPageSize
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxPageSize {
+			return "", 0, invalidLimit
+		}
+		limit = parsed
+	}
+
+	after, err := decodeCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return after, limit, nil
+}
+
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(decoded) == 0 {
+		return "", invalidCursor
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"io"
+	"mime"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+var unsupportedPatchType = fmt.Errorf("Content-Type must be %s", mergePatchContentType)
+var emptyPatch = errors.New("request body cannot be empty")
+var idChanged = errors.New("id cannot be changed")
+
+// checkMergePatch makes sure a PATCH carries an RFC 7396 merge patch. Plain
+// application/json is accepted too, since a merge patch is just a JSON object.
+func checkMergePatch(r *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != mergePatchContentType && mediaType != "application/json") {
+		return unsupportedPatchType
+	}
+	if r.Body == nil {
+		return emptyPatch
+	}
+	return nil
+}
+
+// mergePatch applies patch to target as described in RFC 7396: objects are
+// merged key by key, a null removes the key and anything else replaces it.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+// decodeMergePatch applies the merge patch in body to the JSON form of current
+// and decodes the result into into. Fields the patch does not mention keep
+// their current value; a field removed with null comes back as its zero value.
+func decodeMergePatch(body io.Reader, current interface{}, into interface{}) error {
+	var patch interface{}
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+	err := decoder.Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	var target interface{}
+	decoder = json.NewDecoder(bytes.NewReader(currentJSON))
+	decoder.UseNumber()
+	err = decoder.Decode(&target)
+	if err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(target, patch))
+	if err != nil {
+		return err
+	}
+	decoder = json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(into)
+}
+
+// patchStatus picks the status for an error from checkMergePatch.
+func patchStatus(err error) int {
+	if err == unsupportedPatchType {
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusBadRequest
+}
+
+func (h *handler) PatchWarehouse(w http.ResponseWriter, r *http.Request) {
+	warehouseId := chi.URLParam(r, "warehouseId")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.WarehouseResponse{Error: err.Error()})
+		return
+	}
+
+	err = checkMergePatch(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, patchStatus(err), api.WarehouseResponse{Error: err.Error()})
+		return
+	}
+
+	warehouse, err := h.warehouseService.GetWarehouseById(r.Context(), warehouseId)
+	if err != nil {
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.WarehouseResponse{Error: fmt.Sprintf(
+				"failed to update, warehouse: %s does not exist",
+				warehouseId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.WarehouseResponse{Error: "Failed to update warehouse"})
+			return
+		}
+	}
+	if warehouse.Version != version {
+		h.logger.Log(log.Error, wms.VersionMismatch)
+		h.response(w, http.StatusPreconditionFailed, api.WarehouseResponse{Error: fmt.Sprintf(
+			"failed to update, warehouse: %s has been modified",
+			warehouseId,
+		)})
+		return
+	}
+
+	current := api.UpdateWarehouseRequest{
+		Id:        warehouse.Id,
+		Name:      warehouse.Name,
+		Latitude:  warehouse.Latitude,
+		Longitude: warehouse.Longitude,
+	}
+	var updateWarehouseRequest api.UpdateWarehouseRequest
+	err = decodeMergePatch(r.Body, current, &updateWarehouseRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.WarehouseResponse{Error: "Failed to parse request"})
+		return
+	}
+	if updateWarehouseRequest.Id != warehouseId {
+		h.logger.Log(log.Error, idChanged)
+		h.response(w, http.StatusBadRequest, api.WarehouseResponse{Error: idChanged.Error()})
+		return
+	}
+
+	h.updateWarehouse(w, r, updateWarehouseRequest, version)
+}
+
+func (h *handler) PatchShelfBlock(w http.ResponseWriter, r *http.Request) {
+	shelfBlockId := chi.URLParam(r, "shelfBlockId")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.ShelfBlockResponse{Error: err.Error()})
+		return
+	}
+
+	err = checkMergePatch(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, patchStatus(err), api.ShelfBlockResponse{Error: err.Error()})
+		return
+	}
+
+	shelfBlock, err := h.shelfBlockService.GetShelfBlockById(r.Context(), shelfBlockId)
+	if err != nil {
+		if err == wms.ShelfBlockDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ShelfBlockResponse{Error: fmt.Sprintf(
+				"failed to update, shelf_block: %s does not exist",
+				shelfBlockId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ShelfBlockResponse{Error: "Failed to update shelf_block"})
+			return
+		}
+	}
+	if shelfBlock.Version != version {
+		h.logger.Log(log.Error, wms.VersionMismatch)
+		h.response(w, http.StatusPreconditionFailed, api.ShelfBlockResponse{Error: fmt.Sprintf(
+			"failed to update, shelf_block: %s has been modified",
+			shelfBlockId,
+		)})
+		return
+	}
+
+	current := api.UpdateShelfBlockRequest{
+		Id:          shelfBlock.Id,
+		Aisle:       shelfBlock.Aisle,
+		Rack:        shelfBlock.Rack,
+		StorageType: shelfBlock.StorageType,
+		WarehouseId: shelfBlock.WarehouseId,
+	}
+	var updateShelfBlockRequest api.UpdateShelfBlockRequest
+	err = decodeMergePatch(r.Body, current, &updateShelfBlockRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ShelfBlockResponse{Error: "Failed to parse request"})
+		return
+	}
+	if updateShelfBlockRequest.Id != shelfBlockId {
+		h.logger.Log(log.Error, idChanged)
+		h.response(w, http.StatusBadRequest, api.ShelfBlockResponse{Error: idChanged.Error()})
+		return
+	}
+
+	h.updateShelfBlock(w, r, updateShelfBlockRequest, version)
+}
+
+func (h *handler) PatchShelf(w http.ResponseWriter, r *http.Request) {
+	shelfId := chi.URLParam(r, "shelfId")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, ifMatchStatus(err), api.ShelfResponse{Error: err.Error()})
+		return
+	}
+
+	err = checkMergePatch(r)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, patchStatus(err), api.ShelfResponse{Error: err.Error()})
+		return
+	}
+
+	shelf, err := h.shelfService.GetShelfById(r.Context(), shelfId)
+	if err != nil {
+		if err == wms.ShelfDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ShelfResponse{Error: fmt.Sprintf(
+				"failed to update, shelf: %s does not exist",
+				shelfId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ShelfResponse{Error: "Failed to update shelf"})
+			return
+		}
+	}
+	if shelf.Version != version {
+		h.logger.Log(log.Error, wms.VersionMismatch)
+		h.response(w, http.StatusPreconditionFailed, api.ShelfResponse{Error: fmt.Sprintf(
+			"failed to update, shelf: %s has been modified",
+			shelfId,
+		)})
+		return
+	}
+
+	// the version travels in If-Match, not in the document being patched
+	shelf.Version = 0
+	var updateShelfRequest wms.Shelf
+	err = decodeMergePatch(r.Body, shelf, &updateShelfRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ShelfResponse{Error: "Failed to parse request"})
+		return
+	}
+	if updateShelfRequest.Id != shelfId {
+		h.logger.Log(log.Error, idChanged)
+		h.response(w, http.StatusBadRequest, api.ShelfResponse{Error: idChanged.Error()})
+		return
+	}
+
+	h.updateShelf(w, r, updateShelfRequest, version)
+}
@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{target: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{target: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{target: `{"a":"b"}`, patch: `{"a":null}`, want: `{}`},
+		{target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{target: `{"a":["b"]}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{target: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, want: `{"a":{"b":"d"}}`},
+		{target: `{"a":"foo"}`, patch: `"bar"`, want: `"bar"`},
+		{target: `{"e":null}`, patch: `{"a":1}`, want: `{"a":1,"e":null}`},
+		{target: `[1,2]`, patch: `{"a":"b","c":null}`, want: `{"a":"b"}`},
+	}
+	for _, test := range tests {
+		var target, patch, want interface{}
+		for _, document := range []struct {
+			raw  string
+			into *interface{}
+		}{{test.target, &target}, {test.patch, &patch}, {test.want, &want}} {
+			err := json.Unmarshal([]byte(document.raw), document.into)
+			if err != nil {
+				t.Error(err)
+			}
+		}
+
+		got := mergePatch(target, patch)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("want: %v, got: %v", want, got)
+		}
+	}
+}
+
+func TestPatchWarehouse(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockWarehouseService(mockCtrl)
+	h.warehouseService = mockObj
+
+	current := wms.Warehouse{Id: "warehouse", Name: "old", Latitude: 12.9716, Longitude: 77.5946, Version: 2}
+
+	tests := []struct {
+		patch          string
+		contentType    string
+		ifMatch        string
+		get            bool
+		getErr         error
+		update         *wms.Warehouse
+		wantStatusCode int
+		wantResponse   api.WarehouseResponse
+	}{
+		{
+			patch:          `{"name":"new"}`,
+			contentType:    mergePatchContentType,
+			ifMatch:        `"2"`,
+			get:            true,
+			update:         &wms.Warehouse{Id: "warehouse", Name: "new", Latitude: 12.9716, Longitude: 77.5946, Version: 2},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.WarehouseResponse{Response: "Successfully updated warehouse: warehouse"},
+		},
+		{
+			patch:          `{"name":null}`,
+			contentType:    mergePatchContentType,
+			ifMatch:        `"2"`,
+			get:            true,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.WarehouseResponse{Error: "Invalid input: Name: zero value"},
+		},
+		{
+			patch:          `{"latitude":91}`,
+			contentType:    mergePatchContentType,
+			ifMatch:        `"2"`,
+			get:            true,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.WarehouseResponse{Error: "Invalid input: Latitude: greater than max"},
+		},
+		{
+			patch:          `{"id":"other"}`,
+			contentType:    mergePatchContentType,
+			ifMatch:        `"2"`,
+			get:            true,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.WarehouseResponse{Error: idChanged.Error()},
+		},
+		{
+			patch:          `{"colour":"red"}`,
+			contentType:    mergePatchContentType,
+			ifMatch:        `"2"`,
+			get:            true,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.WarehouseResponse{Error: "Failed to parse request"},
+		},
+		{
+			patch:          `{"name":"new"}`,
+			contentType:    mergePatchContentType,
+			ifMatch:        `"1"`,
+			get:            true,
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantResponse:   api.WarehouseResponse{Error: "failed to update, warehouse: warehouse has been modified"},
+		},
+		{
+			patch:          `{"name":"new"}`,
+			contentType:    mergePatchContentType,
+			ifMatch:        `"2"`,
+			get:            true,
+			getErr:         wms.WarehouseDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.WarehouseResponse{Error: "failed to update, warehouse: warehouse does not exist"},
+		},
+		{
+			patch:          `{"name":"new"}`,
+			contentType:    "text/plain",
+			ifMatch:        `"2"`,
+			wantStatusCode: http.StatusUnsupportedMediaType,
+			wantResponse:   api.WarehouseResponse{Error: unsupportedPatchType.Error()},
+		},
+		{
+			patch:          `{"name":"new"}`,
+			contentType:    mergePatchContentType,
+			wantStatusCode: http.StatusPreconditionRequired,
+			wantResponse:   api.WarehouseResponse{Error: missingIfMatch.Error()},
+		},
+	}
+	for _, test := range tests {
+		if test.get {
+			warehouse := current
+			mockObj.EXPECT().GetWarehouseById(gomock.Any(), current.Id).Return(&warehouse, test.getErr)
+		}
+		if test.update != nil {
+			mockObj.EXPECT().UpdateWarehouse(gomock.Any(), test.update).Return(nil)
+		}
+
+		request, err := http.NewRequest("PATCH", "/warehouse/"+current.Id, strings.NewReader(test.patch))
+		if err != nil {
+			t.Error(err)
+		}
+		request.Header.Set("Content-Type", test.contentType)
+		if test.ifMatch != "" {
+			request.Header.Set("If-Match", test.ifMatch)
+		}
+
+		response := executeRequest(request)
+		var got api.WarehouseResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestPatchShelfBlock(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfBlockService(mockCtrl)
+	h.shelfBlockService = mockObj
+
+	current := wms.ShelfBlock{Id: "block", Aisle: "1", Rack: "1", StorageType: "regular", WarehouseId: "warehouse", Version: 1}
+	patched := current
+	patched.Rack = "2"
+	patched.StorageType = "cold"
+
+	mockObj.EXPECT().GetShelfBlockById(gomock.Any(), current.Id).Return(current, nil)
+	mockObj.EXPECT().UpdateShelfBlock(gomock.Any(), patched).Return(nil)
+
+	request, err := http.NewRequest("PATCH", "/shelf_block/block", strings.NewReader(`{"rack":"2","storageType":"cold"}`))
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set("Content-Type", mergePatchContentType)
+	request.Header.Set("If-Match", `"1"`)
+
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("want: %v, got: %v", http.StatusOK, response.StatusCode)
+	}
+	if etag := response.Header.Get("ETag"); etag != `"2"` {
+		t.Errorf("want: %v, got: %v", `"2"`, etag)
+	}
+}
+
+func TestPatchShelf(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfService(mockCtrl)
+	h.shelfService = mockObj
+
+	current := wms.Shelf{Id: "shelf", Label: "1A", Section: "A", Level: "1", ShelfBlockId: "block", Version: 4}
+	patched := current
+	patched.Label = "1B"
+	patched.ShelfBlockId = "other-block"
+
+	mockObj.EXPECT().GetShelfById(gomock.Any(), current.Id).Return(current, nil)
+	mockObj.EXPECT().UpdateShelf(gomock.Any(), patched).Return(wms.InvalidShelfBlock)
+
+	request, err := http.NewRequest("PATCH", "/shelf/shelf", strings.NewReader(`{"label":"1B","shelfBlockId":"other-block"}`))
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("If-Match", `"4"`)
+
+	response := executeRequest(request)
+	var got api.ShelfResponse
+	err = json.NewDecoder(response.Body).Decode(&got)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := api.ShelfResponse{Error: "invalid shelf block: other-block"}
+	if response.StatusCode != http.StatusBadRequest {
+		t.Errorf("want: %v, got: %v", http.StatusBadRequest, response.StatusCode)
+	}
+	if got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
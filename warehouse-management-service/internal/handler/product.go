@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	sku := chi.URLParam(r, "sku")
+
+	if sku == "" {
+		err := fmt.Errorf("%v", api.GetProductResponse{
+			Error: "sku cannot be empty",
+		})
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, err)
+		return
+	}
+
+	product, err := h.productService.GetProductBySku(r.Context(), sku)
+	if err != nil {
+		if err == wms.ProductDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.GetProductResponse{Error: fmt.Sprintf(
+				"failed to get, product: %s does not exist",
+				sku,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.GetProductResponse{Error: "Failed to get product"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.GetProductResponse{Response: product})
+}
+
+func (h *handler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	var createProductRequest api.CreateProductRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ProductResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createProductRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ProductResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(createProductRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ProductResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	product := wms.NewProduct(
+		createProductRequest.Name,
+		createProductRequest.MRP,
+		createProductRequest.Variant,
+		createProductRequest.LengthInCm,
+		createProductRequest.WidthInCm,
+		createProductRequest.BreadthInCm,
+		createProductRequest.WeightInKg,
+		createProductRequest.Perishable,
+		createProductRequest.LotTracked,
+		createProductRequest.Serialized,
+	)
+
+	err = h.productService.CreateProduct(r.Context(), product)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusInternalServerError, api.ProductResponse{Error: "Failed to create product"})
+		return
+	}
+
+	h.response(w, http.StatusOK, api.ProductResponse{Response: fmt.Sprintf(
+		"Successfully created product: %s",
+		product.Sku,
+	)})
+}
+
+func (h *handler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	var updateProductRequest api.UpdateProductRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ProductResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&updateProductRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ProductResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(updateProductRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ProductResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	err = h.productService.UpdateProduct(r.Context(), wms.Product{
+		Sku:         updateProductRequest.Sku,
+		Name:        updateProductRequest.Name,
+		MRP:         updateProductRequest.MRP,
+		Variant:     updateProductRequest.Variant,
+		LengthInCm:  updateProductRequest.LengthInCm,
+		WidthInCm:   updateProductRequest.WidthInCm,
+		BreadthInCm: updateProductRequest.BreadthInCm,
+		WeightInKg:  updateProductRequest.WeightInKg,
+		Perishable:  updateProductRequest.Perishable,
+		LotTracked:  updateProductRequest.LotTracked,
+		Serialized:  updateProductRequest.Serialized,
+	})
+	if err != nil {
+		if err == wms.ProductDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ProductResponse{Error: fmt.Sprintf(
+				"failed to update, product: %s does not exist",
+				updateProductRequest.Sku,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ProductResponse{Error: "Failed to update product"},
+			)
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.ProductResponse{Response: fmt.Sprintf(
+		"Successfully updated product: %s",
+		updateProductRequest.Sku,
+	)})
+}
+
+func (h *handler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	sku := chi.URLParam(r, "sku")
+
+	if sku == "" {
+		err := fmt.Errorf("%v", api.ProductResponse{Error: "sku cannot be empty"})
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := h.productService.DeleteProductBySku(r.Context(), sku)
+	if err != nil {
+		if dependents, ok := dependentsOf(err); ok {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.DependentsResponse{
+				Error: fmt.Sprintf(
+					"failed to delete, product: %s has dependents",
+					sku,
+				),
+				Dependents: dependents,
+			})
+			return
+		}
+		if err == wms.ProductDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ProductResponse{Error: fmt.Sprintf(
+				"failed to delete, product: %s does not exist",
+				sku,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ProductResponse{Error: "Failed to delete product"},
+			)
+			return
+		}
+	}
+
+	h.response(
+		w,
+		http.StatusOK,
+		api.ProductResponse{Response: fmt.Sprintf(
+			"Successfully deleted product: %s",
+			sku,
+		)},
+	)
+}
@@ -0,0 +1,387 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+var product = wms.Product{
+	Sku:         "4d0f7a0c-5f38-4a6b-8fc1-2b1b8d2d6a10",
+	Name:        "Basmati Rice",
+	MRP:         120.5,
+	Variant:     "1kg",
+	LengthInCm:  20,
+	WidthInCm:   10,
+	BreadthInCm: 5,
+	WeightInKg:  1,
+	Perishable:  true,
+}
+
+func TestGetProduct(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockProductService(mockCtrl)
+
+	tests := []struct {
+		getProductRequest  string
+		getProductResponse wms.Product
+		getProductErr      error
+		wantStatusCode     int
+		wantResponse       api.GetProductResponse
+	}{
+		{
+			getProductRequest:  product.Sku,
+			getProductResponse: product,
+			getProductErr:      nil,
+			wantStatusCode:     http.StatusOK,
+			wantResponse:       api.GetProductResponse{Response: product},
+		},
+		{
+			getProductRequest:  product.Sku,
+			getProductResponse: wms.Product{},
+			getProductErr:      sql.ErrConnDone,
+			wantStatusCode:     http.StatusInternalServerError,
+			wantResponse:       api.GetProductResponse{Error: "Failed to get product"},
+		},
+		{
+			getProductRequest:  product.Sku,
+			getProductResponse: wms.Product{},
+			getProductErr:      wms.ProductDoesNotExist,
+			wantStatusCode:     http.StatusNotFound,
+			wantResponse: api.GetProductResponse{Error: fmt.Sprintf(
+				"failed to get, product: %s does not exist",
+				product.Sku,
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().GetProductBySku(gomock.Any(), test.getProductRequest).Return(test.getProductResponse, test.getProductErr)
+		h.productService = mockObj
+
+		request, err := http.NewRequest(
+			"GET",
+			fmt.Sprintf("/product/%s", test.getProductRequest),
+			nil,
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.GetProductResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestCreateProduct(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockProductService(mockCtrl)
+
+	tests := []struct {
+		createProductRequest api.CreateProductRequest
+		createProductErr     error
+		wantStatusCode       int
+		wantResponse         api.ProductResponse
+	}{
+		{
+			createProductRequest: api.CreateProductRequest{
+				Name:       "Basmati Rice",
+				MRP:        120.5,
+				Variant:    "1kg",
+				WeightInKg: 1,
+				Perishable: true,
+			},
+			createProductErr: nil,
+			wantStatusCode:   http.StatusOK,
+			wantResponse:     api.ProductResponse{Response: "Successfully created product: "},
+		},
+		{
+			createProductRequest: api.CreateProductRequest{
+				Name:       "Basmati Rice",
+				MRP:        120.5,
+				Variant:    "1kg",
+				WeightInKg: 1,
+				Perishable: true,
+			},
+			createProductErr: sql.ErrConnDone,
+			wantStatusCode:   http.StatusInternalServerError,
+			wantResponse:     api.ProductResponse{Error: "Failed to create product"},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Return(test.createProductErr)
+		h.productService = mockObj
+
+		marshalledRequest, err := json.Marshal(test.createProductRequest)
+		if err != nil {
+			t.Error(err)
+		}
+		request, err := http.NewRequest(
+			"POST",
+			"/product",
+			bytes.NewBuffer(marshalledRequest),
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ProductResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if got.Error != test.wantResponse.Error {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+
+		if !strings.HasPrefix(got.Response, test.wantResponse.Response) {
+			t.Errorf("want: %v, got: %v", test.wantResponse.Response, got.Response)
+		}
+	}
+}
+
+func TestCreateProductRequestError(t *testing.T) {
+	tests := []struct {
+		createProductRequest string
+		wantStatusCode       int
+	}{
+		{
+			createProductRequest: `{"name": "", "mrp": 10, "perishable": false}`,
+			wantStatusCode:       http.StatusBadRequest,
+		},
+		{
+			createProductRequest: `{"name": "Salt", "mrp": -10, "perishable": false}`,
+			wantStatusCode:       http.StatusBadRequest,
+		},
+		{
+			createProductRequest: `{"name": "Salt", "mrp": 10, "colour": "white"}`,
+			wantStatusCode:       http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		request, err := http.NewRequest(
+			"POST",
+			"/product",
+			strings.NewReader(test.createProductRequest),
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+	}
+}
+
+func TestUpdateProduct(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockProductService(mockCtrl)
+
+	updateProductRequest := api.UpdateProductRequest{
+		Sku:        product.Sku,
+		Name:       "Basmati Rice",
+		MRP:        125,
+		Variant:    "1kg",
+		WeightInKg: 1,
+		Perishable: true,
+	}
+
+	tests := []struct {
+		updateProductErr error
+		wantStatusCode   int
+		wantResponse     api.ProductResponse
+	}{
+		{
+			updateProductErr: nil,
+			wantStatusCode:   http.StatusOK,
+			wantResponse: api.ProductResponse{Response: fmt.Sprintf(
+				"Successfully updated product: %s",
+				product.Sku,
+			)},
+		},
+		{
+			updateProductErr: sql.ErrConnDone,
+			wantStatusCode:   http.StatusInternalServerError,
+			wantResponse:     api.ProductResponse{Error: "Failed to update product"},
+		},
+		{
+			updateProductErr: wms.ProductDoesNotExist,
+			wantStatusCode:   http.StatusNotFound,
+			wantResponse: api.ProductResponse{Error: fmt.Sprintf(
+				"failed to update, product: %s does not exist",
+				product.Sku,
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Return(test.updateProductErr)
+		h.productService = mockObj
+
+		marshalledRequest, err := json.Marshal(updateProductRequest)
+		if err != nil {
+			t.Error(err)
+		}
+		request, err := http.NewRequest(
+			"PUT",
+			"/product",
+			bytes.NewBuffer(marshalledRequest),
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ProductResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestDeleteProduct(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockProductService(mockCtrl)
+
+	tests := []struct {
+		deleteProductErr error
+		wantStatusCode   int
+		wantResponse     api.ProductResponse
+	}{
+		{
+			deleteProductErr: nil,
+			wantStatusCode:   http.StatusOK,
+			wantResponse: api.ProductResponse{Response: fmt.Sprintf(
+				"Successfully deleted product: %s",
+				product.Sku,
+			)},
+		},
+		{
+			deleteProductErr: sql.ErrConnDone,
+			wantStatusCode:   http.StatusInternalServerError,
+			wantResponse:     api.ProductResponse{Error: "Failed to delete product"},
+		},
+		{
+			deleteProductErr: wms.ProductDoesNotExist,
+			wantStatusCode:   http.StatusNotFound,
+			wantResponse: api.ProductResponse{Error: fmt.Sprintf(
+				"failed to delete, product: %s does not exist",
+				product.Sku,
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().DeleteProductBySku(gomock.Any(), product.Sku).Return(test.deleteProductErr)
+		h.productService = mockObj
+
+		request, err := http.NewRequest(
+			"DELETE",
+			fmt.Sprintf("/product/%s", product.Sku),
+			nil,
+		)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		responseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var got api.ProductResponse
+		err = json.Unmarshal(responseBody, &got)
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestDeleteProductDependents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockProductService(mockCtrl)
+	h.productService = mockObj
+
+	dependents := []wms.Dependent{{Entity: wms.EntityOrderLine, Id: "line"}}
+	mockObj.EXPECT().
+		DeleteProductBySku(gomock.Any(), product.Sku).
+		Return(&wms.DependentsError{Dependents: dependents})
+
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("/product/%s", product.Sku), nil)
+	if err != nil {
+		t.Error(err)
+	}
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusConflict {
+		t.Errorf("want: %v, got: %v", http.StatusConflict, response.StatusCode)
+	}
+
+	var got api.DependentsResponse
+	err = json.NewDecoder(response.Body).Decode(&got)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	wantError := fmt.Sprintf("failed to delete, product: %s has dependents", product.Sku)
+	if got.Error != wantError || len(got.Dependents) != 1 || got.Dependents[0] != dependents[0] {
+		t.Errorf("want: %s with %v, got: %v", wantError, dependents, got)
+	}
+}
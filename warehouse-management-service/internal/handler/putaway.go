@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+const defaultPutawaySuggestions = 5
+
+func (h *handler) SuggestPutaway(w http.ResponseWriter, r *http.Request) {
+	var suggestRequest api.SuggestPutawayRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.SuggestPutawayResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&suggestRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.SuggestPutawayResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(suggestRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.SuggestPutawayResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	limit := suggestRequest.Limit
+	if limit == 0 {
+		limit = defaultPutawaySuggestions
+	}
+
+	suggestions, err := h.putawayService.SuggestPutaway(
+		r.Context(),
+		suggestRequest.WarehouseId,
+		suggestRequest.Sku,
+		suggestRequest.Quantity,
+		limit,
+	)
+	if err != nil {
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.SuggestPutawayResponse{Error: fmt.Sprintf(
+				"failed to suggest, warehouse: %s does not exist",
+				suggestRequest.WarehouseId,
+			)})
+			return
+		} else if err == wms.InvalidProduct {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.SuggestPutawayResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				suggestRequest.Sku,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.SuggestPutawayResponse{Error: "Failed to suggest putaway"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.SuggestPutawayResponse{Response: suggestions})
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+func TestSuggestPutaway(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockPutawayService(mockCtrl)
+	h.putawayService = mockObj
+
+	suggestions := []wms.PutawaySuggestion{{ShelfId: "s", Score: 50, Reasons: []string{"already holds 4 of the sku"}}}
+	body := `{"warehouseId": "` + warehouse.Id + `", "sku": "` + product.Sku + `", "quantity": 4}`
+
+	tests := []struct {
+		body           string
+		wantLimit      int
+		suggestErr     error
+		wantStatusCode int
+	}{
+		{body: body, wantLimit: defaultPutawaySuggestions, wantStatusCode: http.StatusOK},
+		{
+			body:           `{"warehouseId": "` + warehouse.Id + `", "sku": "` + product.Sku + `", "quantity": 4, "limit": 2}`,
+			wantLimit:      2,
+			wantStatusCode: http.StatusOK,
+		},
+		{body: body, wantLimit: defaultPutawaySuggestions, suggestErr: wms.WarehouseDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{body: body, wantLimit: defaultPutawaySuggestions, suggestErr: wms.InvalidProduct, wantStatusCode: http.StatusBadRequest},
+		{body: body, wantLimit: defaultPutawaySuggestions, suggestErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"warehouseId": "` + warehouse.Id + `", "sku": "` + product.Sku + `", "quantity": 0}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"warehouseId": "` + warehouse.Id + `", "quantity": 1}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantLimit != 0 {
+			mockObj.EXPECT().SuggestPutaway(gomock.Any(), warehouse.Id, product.Sku, 4, test.wantLimit).Return(suggestions, test.suggestErr)
+		}
+
+		request, err := http.NewRequest("POST", "/putaway/suggest", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.suggestErr, test.wantStatusCode, response.StatusCode)
+		}
+		if response.StatusCode != http.StatusOK {
+			continue
+		}
+
+		var got api.SuggestPutawayResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(got.Response, suggestions) {
+			t.Errorf("want: %v, got: %v", suggestions, got.Response)
+		}
+	}
+}
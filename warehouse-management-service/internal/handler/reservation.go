@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	"time"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetReservation(w http.ResponseWriter, r *http.Request) {
+	reservationId := chi.URLParam(r, "reservationId")
+
+	reservation, err := h.reservationService.GetReservationById(r.Context(), reservationId)
+	if err != nil {
+		if err == wms.ReservationDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReservationResponse{Error: fmt.Sprintf(
+				"failed to get, reservation: %s does not exist",
+				reservationId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ReservationResponse{Error: "Failed to get reservation"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ReservationResponse{Response: &reservation})
+}
+
+func (h *handler) CreateReservation(w http.ResponseWriter, r *http.Request) {
+	var createReservationRequest api.CreateReservationRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReservationResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createReservationRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReservationResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(createReservationRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReservationResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	reservation, err := h.reservationService.CreateReservation(r.Context(), wms.NewReservation(
+		createReservationRequest.WarehouseId,
+		createReservationRequest.Sku,
+		createReservationRequest.Quantity,
+		createReservationRequest.Reference,
+		time.Duration(createReservationRequest.TTLSeconds)*time.Second,
+	))
+	if err != nil {
+		var insufficientStock *wms.InsufficientStockError
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ReservationResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				createReservationRequest.WarehouseId,
+			)})
+			return
+		} else if err == wms.InvalidProduct {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ReservationResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				createReservationRequest.Sku,
+			)})
+			return
+		} else if errors.As(err, &insufficientStock) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ReservationResponse{
+				Error:     wms.InsufficientStock.Error(),
+				Shortages: insufficientStock.Shortages,
+			})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ReservationResponse{Error: "Failed to create reservation"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.ReservationResponse{Response: &reservation})
+}
+
+func (h *handler) ConfirmReservation(w http.ResponseWriter, r *http.Request) {
+	h.transitionReservation(w, r, "confirm", h.reservationService.ConfirmReservation)
+}
+
+func (h *handler) ReleaseReservation(w http.ResponseWriter, r *http.Request) {
+	h.transitionReservation(w, r, "release", h.reservationService.ReleaseReservation)
+}
+
+func (h *handler) transitionReservation(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	transition func(ctx context.Context, id string) (wms.Reservation, error),
+) {
+	reservationId := chi.URLParam(r, "reservationId")
+
+	reservation, err := transition(r.Context(), reservationId)
+	if err != nil {
+		if err == wms.ReservationDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReservationResponse{Error: fmt.Sprintf(
+				"failed to %s, reservation: %s does not exist",
+				action,
+				reservationId,
+			)})
+			return
+		} else if err == wms.ReservationNotHeld {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ReservationResponse{Error: fmt.Sprintf(
+				"failed to %s, reservation: %s is no longer held",
+				action,
+				reservationId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ReservationResponse{Error: fmt.Sprintf(
+				"Failed to %s reservation",
+				action,
+			)})
+			return
+		}
+	}
+
+	h.response(w, http.StatusOK, api.ReservationResponse{Response: &reservation})
+}
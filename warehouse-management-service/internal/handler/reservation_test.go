@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+const reservationId = "3f9a1c2e-7b4d-4e5f-9a6b-8c7d6e5f4a30"
+
+func TestCreateReservation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockReservationService(mockCtrl)
+	h.reservationService = mockObj
+
+	validBody := `{"warehouseId": "` + warehouse.Id + `", "sku": "` + product.Sku + `", "quantity": 2, "ttlSeconds": 60}`
+	shortages := []wms.StockShortage{{Sku: product.Sku, Requested: 2, Available: 1}}
+
+	tests := []struct {
+		body           string
+		createErr      error
+		wantStatusCode int
+	}{
+		{body: validBody, wantStatusCode: http.StatusCreated},
+		{body: validBody, createErr: wms.WarehouseDoesNotExist, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createErr: wms.InvalidProduct, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createErr: &wms.InsufficientStockError{Shortages: shortages}, wantStatusCode: http.StatusConflict},
+		{body: validBody, createErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"warehouseId": "w", "sku": "a", "quantity": 0}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"warehouseId": "w", "sku": "a", "quantity": 1, "ttlSeconds": -1}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"sku": "a", "quantity": 1}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.createErr != nil {
+			mockObj.EXPECT().CreateReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, reservation wms.Reservation) (wms.Reservation, error) {
+					if reservation.ExpiresAt.Sub(reservation.CreatedAt) != time.Minute {
+						t.Errorf("want a reservation held for a minute, got: %v", reservation)
+					}
+					if test.createErr != nil {
+						return wms.Reservation{}, test.createErr
+					}
+					reservation.ItemIds = []string{"i1", "i2"}
+					return reservation, nil
+				},
+			)
+		}
+
+		request, err := http.NewRequest("POST", "/reservation", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.createErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.ReservationResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if test.wantStatusCode == http.StatusConflict && !reflect.DeepEqual(got.Shortages, shortages) {
+			t.Errorf("want: %v, got: %v", shortages, got.Shortages)
+		}
+		if test.wantStatusCode == http.StatusCreated &&
+			(got.Response == nil || got.Response.Status != wms.ReservationHeld || len(got.Response.ItemIds) != 2) {
+			t.Errorf("want a held reservation of 2 items, got: %v", got)
+		}
+	}
+}
+
+func TestTransitionReservation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockReservationService(mockCtrl)
+	h.reservationService = mockObj
+
+	tests := []struct {
+		action         string
+		transitionErr  error
+		wantStatusCode int
+		wantResponse   api.ReservationResponse
+	}{
+		{
+			action:         "confirm",
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.ReservationResponse{Response: &wms.Reservation{Id: reservationId, Status: wms.ReservationConfirmed}},
+		},
+		{
+			action:         "release",
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.ReservationResponse{Response: &wms.Reservation{Id: reservationId, Status: wms.ReservationReleased}},
+		},
+		{
+			action:         "confirm",
+			transitionErr:  wms.ReservationDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.ReservationResponse{Error: "failed to confirm, reservation: " + reservationId + " does not exist"},
+		},
+		{
+			action:         "confirm",
+			transitionErr:  wms.ReservationNotHeld,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.ReservationResponse{Error: "failed to confirm, reservation: " + reservationId + " is no longer held"},
+		},
+		{
+			action:         "release",
+			transitionErr:  wms.ReservationNotHeld,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.ReservationResponse{Error: "failed to release, reservation: " + reservationId + " is no longer held"},
+		},
+		{
+			action:         "release",
+			transitionErr:  sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.ReservationResponse{Error: "Failed to release reservation"},
+		},
+	}
+
+	for _, test := range tests {
+		var reservation wms.Reservation
+		if test.wantResponse.Response != nil {
+			reservation = *test.wantResponse.Response
+		}
+		if test.action == "confirm" {
+			mockObj.EXPECT().ConfirmReservation(gomock.Any(), reservationId).Return(reservation, test.transitionErr)
+		} else {
+			mockObj.EXPECT().ReleaseReservation(gomock.Any(), reservationId).Return(reservation, test.transitionErr)
+		}
+
+		request, err := http.NewRequest("POST", "/reservation/"+reservationId+"/"+test.action, nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.action, test.transitionErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.ReservationResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
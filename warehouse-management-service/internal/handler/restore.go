@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *handler) RestoreWarehouse(w http.ResponseWriter, r *http.Request) {
+	warehouseId := chi.URLParam(r, "warehouseId")
+
+	err := h.warehouseService.RestoreWarehouse(r.Context(), warehouseId)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		if err == wms.WarehouseDoesNotExist {
+			h.response(w, http.StatusNotFound, api.WarehouseResponse{Error: fmt.Sprintf(
+				"failed to restore, warehouse: %s does not exist or is not deleted",
+				warehouseId,
+			)})
+		} else {
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.WarehouseResponse{Error: "Failed to restore warehouse"},
+			)
+		}
+		return
+	}
+
+	h.response(w, http.StatusOK, api.WarehouseResponse{Response: fmt.Sprintf(
+		"Successfully restored warehouse: %s",
+		warehouseId,
+	)})
+}
+
+func (h *handler) PurgeWarehouse(w http.ResponseWriter, r *http.Request) {
+	warehouseId := chi.URLParam(r, "warehouseId")
+
+	err := h.warehouseService.PurgeWarehouse(r.Context(), warehouseId)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		if dependents, ok := dependentsOf(err); ok {
+			h.response(w, http.StatusConflict, api.DependentsResponse{
+				Error: fmt.Sprintf(
+					"failed to purge, warehouse: %s has dependents",
+					warehouseId,
+				),
+				Dependents: dependents,
+			})
+		} else if err == wms.WarehouseDoesNotExist {
+			h.response(w, http.StatusNotFound, api.WarehouseResponse{Error: fmt.Sprintf(
+				"failed to purge, warehouse: %s does not exist or is not deleted",
+				warehouseId,
+			)})
+		} else {
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.WarehouseResponse{Error: "Failed to purge warehouse"},
+			)
+		}
+		return
+	}
+
+	h.response(w, http.StatusOK, api.WarehouseResponse{Response: fmt.Sprintf(
+		"Successfully purged warehouse: %s",
+		warehouseId,
+	)})
+}
+
+func (h *handler) RestoreShelfBlock(w http.ResponseWriter, r *http.Request) {
+	shelfBlockId := chi.URLParam(r, "shelfBlockId")
+
+	err := h.shelfBlockService.RestoreShelfBlockById(r.Context(), shelfBlockId)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		if err == wms.ShelfBlockDoesNotExist {
+			h.response(w, http.StatusNotFound, api.ShelfBlockResponse{Error: fmt.Sprintf(
+				"failed to restore, shelf_block: %s does not exist or is not deleted",
+				shelfBlockId,
+			)})
+		} else if err == wms.InvalidWarehouse {
+			h.response(w, http.StatusConflict, api.ShelfBlockResponse{Error: fmt.Sprintf(
+				"failed to restore, shelf_block: %s belongs to a deleted warehouse",
+				shelfBlockId,
+			)})
+		} else {
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ShelfBlockResponse{Error: "Failed to restore shelf_block"},
+			)
+		}
+		return
+	}
+
+	h.response(w, http.StatusOK, api.ShelfBlockResponse{Response: fmt.Sprintf(
+		"Successfully restored shelf_block: %s",
+		shelfBlockId,
+	)})
+}
+
+func (h *handler) PurgeShelfBlock(w http.ResponseWriter, r *http.Request) {
+	shelfBlockId := chi.URLParam(r, "shelfBlockId")
+
+	err := h.shelfBlockService.PurgeShelfBlockById(r.Context(), shelfBlockId)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		if dependents, ok := dependentsOf(err); ok {
+			h.response(w, http.StatusConflict, api.DependentsResponse{
+				Error: fmt.Sprintf(
+					"failed to purge, shelf_block: %s has dependents",
+					shelfBlockId,
+				),
+				Dependents: dependents,
+			})
+		} else if err == wms.ShelfBlockDoesNotExist {
+			h.response(w, http.StatusNotFound, api.ShelfBlockResponse{Error: fmt.Sprintf(
+				"failed to purge, shelf_block: %s does not exist or is not deleted",
+				shelfBlockId,
+			)})
+		} else {
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ShelfBlockResponse{Error: "Failed to purge shelf_block"},
+			)
+		}
+		return
+	}
+
+	h.response(w, http.StatusOK, api.ShelfBlockResponse{Response: fmt.Sprintf(
+		"Successfully purged shelf_block: %s",
+		shelfBlockId,
+	)})
+}
+
+func (h *handler) RestoreShelf(w http.ResponseWriter, r *http.Request) {
+	shelfId := chi.URLParam(r, "shelfId")
+
+	err := h.shelfService.RestoreShelfById(r.Context(), shelfId)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		if err == wms.ShelfDoesNotExist {
+			h.response(w, http.StatusNotFound, api.ShelfResponse{Error: fmt.Sprintf(
+				"failed to restore, shelf: %s does not exist or is not deleted",
+				shelfId,
+			)})
+		} else if err == wms.InvalidShelfBlock {
+			h.response(w, http.StatusConflict, api.ShelfResponse{Error: fmt.Sprintf(
+				"failed to restore, shelf: %s belongs to a deleted shelf_block",
+				shelfId,
+			)})
+		} else {
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ShelfResponse{Error: "Failed to restore shelf"},
+			)
+		}
+		return
+	}
+
+	h.response(w, http.StatusOK, api.ShelfResponse{Message: fmt.Sprintf(
+		"Successfully restored shelf: %s",
+		shelfId,
+	)})
+}
+
+func (h *handler) PurgeShelf(w http.ResponseWriter, r *http.Request) {
+	shelfId := chi.URLParam(r, "shelfId")
+
+	err := h.shelfService.PurgeShelfById(r.Context(), shelfId)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		if dependents, ok := dependentsOf(err); ok {
+			h.response(w, http.StatusConflict, api.DependentsResponse{
+				Error: fmt.Sprintf(
+					"failed to purge, shelf: %s has dependents",
+					shelfId,
+				),
+				Dependents: dependents,
+			})
+		} else if err == wms.ShelfDoesNotExist {
+			h.response(w, http.StatusNotFound, api.ShelfResponse{Error: fmt.Sprintf(
+				"failed to purge, shelf: %s does not exist or is not deleted",
+				shelfId,
+			)})
+		} else {
+			h.response(
+				w,
+				http.StatusInternalServerError,
+				api.ShelfResponse{Error: "Failed to purge shelf"},
+			)
+		}
+		return
+	}
+
+	h.response(w, http.StatusOK, api.ShelfResponse{Message: fmt.Sprintf(
+		"Successfully purged shelf: %s",
+		shelfId,
+	)})
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+func TestRestoreWarehouse(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockWarehouseService(mockCtrl)
+	h.warehouseService = mockObj
+
+	tests := []struct {
+		restoreWarehouseErr error
+		wantStatusCode      int
+		wantResponse        api.WarehouseResponse
+	}{
+		{
+			restoreWarehouseErr: nil,
+			wantStatusCode:      http.StatusOK,
+			wantResponse: api.WarehouseResponse{Response: fmt.Sprintf(
+				"Successfully restored warehouse: %s",
+				warehouse.Id,
+			)},
+		},
+		{
+			restoreWarehouseErr: wms.WarehouseDoesNotExist,
+			wantStatusCode:      http.StatusNotFound,
+			wantResponse: api.WarehouseResponse{Error: fmt.Sprintf(
+				"failed to restore, warehouse: %s does not exist or is not deleted",
+				warehouse.Id,
+			)},
+		},
+		{
+			restoreWarehouseErr: sql.ErrConnDone,
+			wantStatusCode:      http.StatusInternalServerError,
+			wantResponse:        api.WarehouseResponse{Error: "Failed to restore warehouse"},
+		},
+	}
+	for _, test := range tests {
+		mockObj.EXPECT().RestoreWarehouse(gomock.Any(), warehouse.Id).Return(test.restoreWarehouseErr)
+
+		request, err := http.NewRequest("POST", fmt.Sprintf("/warehouse/%s/restore", warehouse.Id), nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		var got api.WarehouseResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestRestoreShelf(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfService(mockCtrl)
+	h.shelfService = mockObj
+
+	shelfId := "shelf"
+	tests := []struct {
+		restoreShelfErr error
+		wantStatusCode  int
+		wantResponse    api.ShelfResponse
+	}{
+		{
+			restoreShelfErr: nil,
+			wantStatusCode:  http.StatusOK,
+			wantResponse:    api.ShelfResponse{Message: "Successfully restored shelf: shelf"},
+		},
+		{
+			restoreShelfErr: wms.ShelfDoesNotExist,
+			wantStatusCode:  http.StatusNotFound,
+			wantResponse: api.ShelfResponse{
+				Error: "failed to restore, shelf: shelf does not exist or is not deleted",
+			},
+		},
+		{
+			restoreShelfErr: wms.InvalidShelfBlock,
+			wantStatusCode:  http.StatusConflict,
+			wantResponse: api.ShelfResponse{
+				Error: "failed to restore, shelf: shelf belongs to a deleted shelf_block",
+			},
+		},
+	}
+	for _, test := range tests {
+		mockObj.EXPECT().RestoreShelfById(gomock.Any(), shelfId).Return(test.restoreShelfErr)
+
+		request, err := http.NewRequest("POST", fmt.Sprintf("/shelf/%s/restore", shelfId), nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		var got api.ShelfResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestPurgeShelfBlock(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfBlockService(mockCtrl)
+	h.shelfBlockService = mockObj
+
+	shelfBlockId := "block"
+	h.adminToken = "secret"
+	defer func() { h.adminToken = "" }()
+
+	tests := []struct {
+		authorization  string
+		purge          bool
+		purgeErr       error
+		wantStatusCode int
+	}{
+		{
+			authorization:  "",
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			authorization:  "Bearer wrong",
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			authorization:  "Bearer secret",
+			purge:          true,
+			purgeErr:       nil,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			authorization:  "Bearer secret",
+			purge:          true,
+			purgeErr:       wms.ShelfBlockDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			authorization:  "Bearer secret",
+			purge:          true,
+			purgeErr:       &wms.DependentsError{Dependents: []wms.Dependent{{Entity: wms.EntityItem, Id: "item"}}},
+			wantStatusCode: http.StatusConflict,
+		},
+	}
+	for _, test := range tests {
+		if test.purge {
+			mockObj.EXPECT().PurgeShelfBlockById(gomock.Any(), shelfBlockId).Return(test.purgeErr)
+		}
+
+		request, err := http.NewRequest("DELETE", fmt.Sprintf("/admin/shelf_block/%s", shelfBlockId), nil)
+		if err != nil {
+			t.Error(err)
+		}
+		request.Header.Set("Authorization", test.authorization)
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+	}
+}
+
+func TestPurgeDisabledWithoutAdminToken(t *testing.T) {
+	request, err := http.NewRequest("DELETE", "/admin/warehouse/warehouse", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set("Authorization", "Bearer ")
+
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusForbidden {
+		t.Errorf("want: %v, got: %v", http.StatusForbidden, response.StatusCode)
+	}
+}
@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetReturn(w http.ResponseWriter, r *http.Request) {
+	returnId := chi.URLParam(r, "returnId")
+
+	authorization, err := h.returnService.GetReturnById(r.Context(), returnId)
+	if err != nil {
+		if err == wms.ReturnDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReturnResponse{Error: fmt.Sprintf(
+				"failed to get, return: %s does not exist",
+				returnId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ReturnResponse{Error: "Failed to get return"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ReturnResponse{Response: &authorization})
+}
+
+func (h *handler) CreateReturn(w http.ResponseWriter, r *http.Request) {
+	var createReturnRequest api.CreateReturnRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createReturnRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(createReturnRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	authorization, err := h.returnService.CreateReturn(
+		r.Context(),
+		createReturnRequest.ShipmentId,
+		createReturnRequest.Reason,
+		createReturnRequest.ItemIds,
+	)
+	if err != nil {
+		if err == wms.ShipmentDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ReturnResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				createReturnRequest.ShipmentId,
+			)})
+			return
+		} else if err == wms.ItemNotShipped || err == wms.DuplicateReturnItem {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ReturnResponse{
+				Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+			return
+		} else if err == wms.ShipmentNotDispatched || err == wms.ItemAlreadyReturned {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ReturnResponse{Error: fmt.Sprintf(
+				"failed to create return, shipment: %s: %s",
+				createReturnRequest.ShipmentId,
+				err.Error(),
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ReturnResponse{Error: "Failed to create return"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.ReturnResponse{Response: &authorization})
+}
+
+func (h *handler) InspectReturnItem(w http.ResponseWriter, r *http.Request) {
+	returnId := chi.URLParam(r, "returnId")
+	itemId := chi.URLParam(r, "itemId")
+	var inspectReturnItemRequest api.InspectReturnItemRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnItemResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&inspectReturnItemRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnItemResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(inspectReturnItemRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnItemResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	item, err := h.returnService.InspectReturnItem(
+		r.Context(),
+		returnId,
+		itemId,
+		inspectReturnItemRequest.Condition,
+		inspectReturnItemRequest.Notes,
+	)
+	if err != nil {
+		if err == wms.ReturnDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReturnItemResponse{Error: fmt.Sprintf(
+				"failed to inspect, return: %s does not exist",
+				returnId,
+			)})
+			return
+		} else if err == wms.ReturnItemDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReturnItemResponse{Error: fmt.Sprintf(
+				"failed to inspect, item: %s is not part of return: %s",
+				itemId,
+				returnId,
+			)})
+			return
+		} else if err == wms.InvalidItemCondition {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ReturnItemResponse{
+				Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+			return
+		} else if err == wms.ReturnAlreadyClosed || err == wms.ReturnItemResolved {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ReturnItemResponse{Error: fmt.Sprintf(
+				"failed to inspect, item: %s: %s",
+				itemId,
+				err.Error(),
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ReturnItemResponse{Error: "Failed to inspect returned item"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ReturnItemResponse{Response: &item})
+}
+
+func (h *handler) ResolveReturnItem(w http.ResponseWriter, r *http.Request) {
+	returnId := chi.URLParam(r, "returnId")
+	itemId := chi.URLParam(r, "itemId")
+	var resolveReturnItemRequest api.ResolveReturnItemRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnItemResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&resolveReturnItemRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnItemResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(resolveReturnItemRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ReturnItemResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	item, err := h.returnService.ResolveReturnItem(
+		r.Context(),
+		returnId,
+		itemId,
+		resolveReturnItemRequest.Outcome,
+		resolveReturnItemRequest.ShelfId,
+	)
+	if err != nil {
+		if err == wms.ReturnDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReturnItemResponse{Error: fmt.Sprintf(
+				"failed to resolve, return: %s does not exist",
+				returnId,
+			)})
+			return
+		} else if err == wms.ReturnItemDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ReturnItemResponse{Error: fmt.Sprintf(
+				"failed to resolve, item: %s is not part of return: %s",
+				itemId,
+				returnId,
+			)})
+			return
+		} else if err == wms.InvalidReturnOutcome || err == wms.QuarantineShelfRequired || err == wms.InvalidShelf {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ReturnItemResponse{
+				Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+			return
+		} else if err == wms.ReturnAlreadyClosed ||
+			err == wms.ReturnItemResolved ||
+			err == wms.ReturnItemNotInspected ||
+			err == wms.ItemNotResellable ||
+			err == wms.NoPutawayShelf ||
+			err == wms.DuplicateSerialNumber ||
+			errors.Is(err, wms.ShelfOverCapacity) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ReturnItemResponse{Error: fmt.Sprintf(
+				"failed to resolve, item: %s: %s",
+				itemId,
+				err.Error(),
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ReturnItemResponse{Error: "Failed to resolve returned item"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ReturnItemResponse{Response: &item})
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+const returnId = "5b7d9f1c-3e5a-4b7c-9d1e-3f5a7b9c1d80"
+
+func TestCreateReturn(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockReturnService(mockCtrl)
+	h.returnService = mockObj
+
+	validBody := `{"shipmentId": "` + shipmentId + `", "reason": "wrong size", "itemIds": ["` + item.Id + `"]}`
+	authorization := wms.ReturnAuthorization{
+		Id:          returnId,
+		ShipmentId:  shipmentId,
+		WarehouseId: warehouse.Id,
+		Reason:      "wrong size",
+		Status:      wms.ReturnAuthorized,
+		Items:       []wms.ReturnItem{{ReturnId: returnId, ItemId: item.Id, Sku: product.Sku}},
+	}
+
+	tests := []struct {
+		body           string
+		createErr      error
+		wantStatusCode int
+	}{
+		{body: validBody, wantStatusCode: http.StatusCreated},
+		{body: validBody, createErr: wms.ShipmentDoesNotExist, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createErr: wms.ItemNotShipped, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createErr: wms.ShipmentNotDispatched, wantStatusCode: http.StatusConflict},
+		{body: validBody, createErr: wms.ItemAlreadyReturned, wantStatusCode: http.StatusConflict},
+		{body: validBody, createErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"shipmentId": "` + shipmentId + `", "reason": "wrong size", "itemIds": []}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"shipmentId": "` + shipmentId + `", "itemIds": ["i"]}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.createErr != nil {
+			mockObj.EXPECT().CreateReturn(gomock.Any(), shipmentId, "wrong size", []string{item.Id}).Return(authorization, test.createErr)
+		}
+
+		request, err := http.NewRequest("POST", "/return", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.createErr, test.wantStatusCode, response.StatusCode)
+		}
+		if response.StatusCode != http.StatusCreated {
+			continue
+		}
+
+		var got api.ReturnResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil || !reflect.DeepEqual(got.Response, &authorization) {
+			t.Errorf("want: %v, got: %v, %v", authorization, got, err)
+		}
+	}
+}
+
+func TestInspectReturnItem(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockReturnService(mockCtrl)
+	h.returnService = mockObj
+
+	validBody := `{"condition": "damaged", "notes": "crushed box"}`
+	inspected := wms.ReturnItem{ReturnId: returnId, ItemId: item.Id, Sku: product.Sku, Condition: wms.ConditionDamaged, Notes: "crushed box"}
+
+	tests := []struct {
+		body           string
+		inspectErr     error
+		wantStatusCode int
+		wantResponse   api.ReturnItemResponse
+	}{
+		{
+			body:           validBody,
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.ReturnItemResponse{Response: &inspected},
+		},
+		{
+			body:           validBody,
+			inspectErr:     wms.ReturnDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.ReturnItemResponse{Error: "failed to inspect, return: " + returnId + " does not exist"},
+		},
+		{
+			body:           validBody,
+			inspectErr:     wms.ReturnItemDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.ReturnItemResponse{Error: "failed to inspect, item: " + item.Id + " is not part of return: " + returnId},
+		},
+		{
+			body:           validBody,
+			inspectErr:     wms.InvalidItemCondition,
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.ReturnItemResponse{Error: "Invalid input: invalid item condition"},
+		},
+		{
+			body:           validBody,
+			inspectErr:     wms.ReturnItemResolved,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.ReturnItemResponse{Error: "failed to inspect, item: " + item.Id + ": returned item already has an outcome"},
+		},
+		{
+			body:           validBody,
+			inspectErr:     sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.ReturnItemResponse{Error: "Failed to inspect returned item"},
+		},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().InspectReturnItem(gomock.Any(), returnId, item.Id, wms.ConditionDamaged, "crushed box").Return(inspected, test.inspectErr)
+
+		request, err := http.NewRequest("POST", "/return/"+returnId+"/item/"+item.Id+"/inspect", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.inspectErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.ReturnItemResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil || !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v, %v", test.wantResponse, got, err)
+		}
+	}
+}
+
+func TestResolveReturnItem(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockReturnService(mockCtrl)
+	h.returnService = mockObj
+
+	validBody := `{"outcome": "restock"}`
+	restocked := wms.ReturnItem{
+		ReturnId:  returnId,
+		ItemId:    item.Id,
+		Sku:       product.Sku,
+		Condition: wms.ConditionResellable,
+		Outcome:   wms.ReturnRestock,
+		ShelfId:   item.ShelfId,
+		NewItemId: "new",
+	}
+
+	tests := []struct {
+		body           string
+		resolveErr     error
+		wantStatusCode int
+	}{
+		{body: validBody, wantStatusCode: http.StatusOK},
+		{body: validBody, resolveErr: wms.ReturnDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{body: validBody, resolveErr: wms.ReturnItemDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{body: validBody, resolveErr: wms.InvalidReturnOutcome, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, resolveErr: wms.InvalidShelf, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, resolveErr: wms.ReturnItemNotInspected, wantStatusCode: http.StatusConflict},
+		{body: validBody, resolveErr: wms.ItemNotResellable, wantStatusCode: http.StatusConflict},
+		{body: validBody, resolveErr: wms.NoPutawayShelf, wantStatusCode: http.StatusConflict},
+		{body: validBody, resolveErr: &wms.ShelfCapacityError{ShelfId: item.ShelfId}, wantStatusCode: http.StatusConflict},
+		{body: validBody, resolveErr: wms.ReturnAlreadyClosed, wantStatusCode: http.StatusConflict},
+		{body: validBody, resolveErr: wms.DuplicateSerialNumber, wantStatusCode: http.StatusConflict},
+		{body: validBody, resolveErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"shelfId": "s"}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.resolveErr != nil {
+			mockObj.EXPECT().ResolveReturnItem(gomock.Any(), returnId, item.Id, wms.ReturnRestock, "").Return(restocked, test.resolveErr)
+		}
+
+		request, err := http.NewRequest("POST", "/return/"+returnId+"/item/"+item.Id+"/resolve", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.resolveErr, test.wantStatusCode, response.StatusCode)
+		}
+		if response.StatusCode != http.StatusOK {
+			continue
+		}
+
+		var got api.ReturnItemResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil || !reflect.DeepEqual(got.Response, &restocked) {
+			t.Errorf("want: %v, got: %v, %v", restocked, got, err)
+		}
+	}
+}
@@ -10,23 +10,100 @@ import (
 func (h *handler) router() http.Handler {
 	router := chi.NewRouter()
 	router.Use(middleware.Logger)
+	router.Use(withActor)
 
 	router.Get("/ping", h.Ping)
 
+	router.Get("/warehouse", h.ListWarehouses)
+	router.Get("/warehouse/nearest", h.NearestWarehouses)
 	router.Get("/warehouse/{warehouseId}", h.GetWarehouse)
+	router.Get("/warehouse/{warehouseId}/layout", h.GetWarehouseLayout)
+	router.Get("/warehouse/{warehouseId}/stock", h.GetWarehouseStock)
+	router.Get("/warehouse/{warehouseId}/expiring", h.ListExpiringItems)
 	router.Post("/warehouse", h.CreateWarehouse)
 	router.Put("/warehouse", h.UpdateWarehouse)
+	router.Patch("/warehouse/{warehouseId}", h.PatchWarehouse)
 	router.Delete("/warehouse/{warehouseId}", h.DeleteWarehouse)
+	router.Post("/warehouse/{warehouseId}/restore", h.RestoreWarehouse)
+	router.Get("/warehouse/{warehouseId}/export", h.ExportWarehouse)
+	router.Post("/warehouse/{warehouseId}/import", h.ImportLayout)
 
+	router.Get("/shelf_block", h.ListShelfBlocks)
 	router.Get("/shelf_block/{shelfBlockId}", h.GetShelfBlock)
 	router.Post("/shelf_block", h.CreateShelfBlock)
 	router.Put("/shelf_block", h.UpdateShelfBlock)
+	router.Patch("/shelf_block/{shelfBlockId}", h.PatchShelfBlock)
 	router.Delete("/shelf_block/{shelfBlockId}", h.DeleteShelfBlock)
+	router.Post("/shelf_block/{shelfBlockId}/restore", h.RestoreShelfBlock)
 
+	router.Get("/shelf", h.ListShelves)
 	router.Get("/shelf/{shelfId}", h.GetShelf)
 	router.Post("/shelf", h.CreateShelf)
 	router.Put("/shelf", h.UpdateShelf)
+	router.Patch("/shelf/{shelfId}", h.PatchShelf)
 	router.Delete("/shelf/{shelfId}", h.DeleteShelf)
+	router.Post("/shelf/{shelfId}/restore", h.RestoreShelf)
+
+	router.Get("/product/{sku}", h.GetProduct)
+	router.Get("/product/{sku}/stock", h.GetProductStock)
+	router.Post("/product", h.CreateProduct)
+	router.Put("/product", h.UpdateProduct)
+	router.Delete("/product/{sku}", h.DeleteProduct)
+
+	router.Get("/item/{itemId}", h.GetItem)
+	router.Get("/item/serial/{serialNumber}", h.GetItemBySerialNumber)
+	router.Post("/item", h.ReceiveItem)
+	router.Put("/item", h.MoveItem)
+	router.Delete("/item/{itemId}", h.DeleteItem)
+
+	router.Get("/asn/{asnId}", h.GetASN)
+	router.Post("/asn", h.CreateASN)
+	router.Post("/asn/{asnId}/line/{lineId}/receive", h.ReceiveASNLine)
+	router.Post("/asn/{asnId}/close", h.CloseASN)
+
+	router.Post("/putaway/suggest", h.SuggestPutaway)
+
+	router.Get("/order/{orderId}", h.GetOrder)
+	router.Post("/order", h.CreateOrder)
+	router.Get("/order/{orderId}/pick_list", h.GetPickList)
+	router.Post("/order/{orderId}/pick_list", h.GeneratePickList)
+
+	router.Get("/reservation/{reservationId}", h.GetReservation)
+	router.Post("/reservation", h.CreateReservation)
+	router.Post("/reservation/{reservationId}/confirm", h.ConfirmReservation)
+	router.Post("/reservation/{reservationId}/release", h.ReleaseReservation)
+
+	router.Get("/transfer/{transferId}", h.GetTransfer)
+	router.Post("/transfer", h.CreateTransfer)
+	router.Post("/transfer/{transferId}/receive", h.ReceiveTransfer)
+
+	router.Get("/cycle_count/{cycleCountId}", h.GetCycleCount)
+	router.Post("/cycle_count", h.CreateCycleCount)
+	router.Post("/cycle_count/{cycleCountId}/counts", h.SubmitCounts)
+	router.Get("/cycle_count/{cycleCountId}/variance", h.GetVarianceReport)
+	router.Post("/cycle_count/{cycleCountId}/approve", h.ApproveCycleCount)
+
+	router.Get("/shipment/{shipmentId}", h.GetShipment)
+	router.Post("/shipment", h.CreateShipment)
+	router.Post("/shipment/{shipmentId}/package", h.PackItems)
+	router.Post("/shipment/{shipmentId}/dispatch", h.DispatchShipment)
+
+	router.Get("/return/{returnId}", h.GetReturn)
+	router.Post("/return", h.CreateReturn)
+	router.Post("/return/{returnId}/item/{itemId}/inspect", h.InspectReturnItem)
+	router.Post("/return/{returnId}/item/{itemId}/resolve", h.ResolveReturnItem)
+
+	router.Get("/lot/{lot}/items", h.GetLotRecall)
+
+	router.Get("/audit", h.ListAuditEvents)
+
+	router.Route("/admin", func(router chi.Router) {
+		router.Use(h.requireAdmin)
+
+		router.Delete("/warehouse/{warehouseId}", h.PurgeWarehouse)
+		router.Delete("/shelf_block/{shelfBlockId}", h.PurgeShelfBlock)
+		router.Delete("/shelf/{shelfId}", h.PurgeShelf)
+	})
 
 	return router
 }
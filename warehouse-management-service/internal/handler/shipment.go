@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetShipment(w http.ResponseWriter, r *http.Request) {
+	shipmentId := chi.URLParam(r, "shipmentId")
+
+	shipment, err := h.shipmentService.GetShipmentById(r.Context(), shipmentId)
+	if err != nil {
+		if err == wms.ShipmentDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ShipmentResponse{Error: fmt.Sprintf(
+				"failed to get, shipment: %s does not exist",
+				shipmentId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ShipmentResponse{Error: "Failed to get shipment"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ShipmentResponse{Response: &shipment})
+}
+
+func (h *handler) CreateShipment(w http.ResponseWriter, r *http.Request) {
+	var createShipmentRequest api.CreateShipmentRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ShipmentResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createShipmentRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ShipmentResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(createShipmentRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.ShipmentResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	shipment := wms.NewShipment(createShipmentRequest.OrderId, createShipmentRequest.Carrier)
+
+	err = h.shipmentService.CreateShipment(r.Context(), shipment)
+	if err != nil {
+		if err == wms.OrderDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.ShipmentResponse{Error: fmt.Sprintf("%s: %s",
+				err.Error(),
+				shipment.OrderId,
+			)})
+			return
+		} else if err == wms.OrderNotPicked || err == wms.ShipmentExists {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ShipmentResponse{Error: fmt.Sprintf(
+				"failed to create shipment, order: %s: %s",
+				shipment.OrderId,
+				err.Error(),
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ShipmentResponse{Error: "Failed to create shipment"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.ShipmentResponse{Response: &shipment})
+}
+
+func (h *handler) PackItems(w http.ResponseWriter, r *http.Request) {
+	shipmentId := chi.URLParam(r, "shipmentId")
+	var packItemsRequest api.PackItemsRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.PackageResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&packItemsRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.PackageResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(packItemsRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.PackageResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	pkg, err := h.shipmentService.PackItems(r.Context(), shipmentId, packItemsRequest.TrackingNumber, packItemsRequest.ItemIds)
+	if err != nil {
+		if err == wms.ShipmentDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.PackageResponse{Error: fmt.Sprintf(
+				"failed to pack, shipment: %s does not exist",
+				shipmentId,
+			)})
+			return
+		} else if err == wms.ShipmentNotOpen {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.PackageResponse{Error: fmt.Sprintf(
+				"failed to pack, shipment: %s has been dispatched",
+				shipmentId,
+			)})
+			return
+		} else if err == wms.ItemNotOnPickList || err == wms.DuplicatePackageItem {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.PackageResponse{
+				Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+			return
+		} else if err == wms.ItemAlreadyPacked {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.PackageResponse{Error: err.Error()})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.PackageResponse{Error: "Failed to pack items"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.PackageResponse{Response: &pkg})
+}
+
+func (h *handler) DispatchShipment(w http.ResponseWriter, r *http.Request) {
+	shipmentId := chi.URLParam(r, "shipmentId")
+
+	shipment, err := h.shipmentService.DispatchShipment(r.Context(), shipmentId)
+	if err != nil {
+		if err == wms.ShipmentDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ShipmentResponse{Error: fmt.Sprintf(
+				"failed to dispatch, shipment: %s does not exist",
+				shipmentId,
+			)})
+			return
+		} else if err == wms.ShipmentNotOpen {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ShipmentResponse{Error: fmt.Sprintf(
+				"failed to dispatch, shipment: %s has been dispatched",
+				shipmentId,
+			)})
+			return
+		} else if err == wms.ShipmentNotPacked {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.ShipmentResponse{Error: fmt.Sprintf(
+				"failed to dispatch, shipment: %s has picked items left to pack",
+				shipmentId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ShipmentResponse{Error: "Failed to dispatch shipment"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ShipmentResponse{Response: &shipment})
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+const shipmentId = "4a6c8e0b-2d4f-4a6b-8c0d-2e4f6a8b0c70"
+
+func TestCreateShipment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShipmentService(mockCtrl)
+	h.shipmentService = mockObj
+
+	validBody := `{"orderId": "` + orderId + `", "carrier": "dhl"}`
+
+	tests := []struct {
+		body           string
+		createErr      error
+		wantStatusCode int
+	}{
+		{body: validBody, wantStatusCode: http.StatusCreated},
+		{body: validBody, createErr: wms.OrderDoesNotExist, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, createErr: wms.OrderNotPicked, wantStatusCode: http.StatusConflict},
+		{body: validBody, createErr: wms.ShipmentExists, wantStatusCode: http.StatusConflict},
+		{body: validBody, createErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"orderId": "` + orderId + `"}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.createErr != nil {
+			mockObj.EXPECT().CreateShipment(gomock.Any(), gomock.Any()).Return(test.createErr)
+		}
+
+		request, err := http.NewRequest("POST", "/shipment", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.createErr, test.wantStatusCode, response.StatusCode)
+		}
+		if response.StatusCode != http.StatusCreated {
+			continue
+		}
+
+		var got api.ShipmentResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil || got.Response == nil || got.Response.OrderId != orderId || got.Response.Status != wms.ShipmentOpen {
+			t.Errorf("want an open shipment of order %s, got: %v, %v", orderId, got, err)
+		}
+	}
+}
+
+func TestPackItems(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShipmentService(mockCtrl)
+	h.shipmentService = mockObj
+
+	validBody := `{"trackingNumber": "TRK1", "itemIds": ["` + item.Id + `"]}`
+	pkg := wms.Package{
+		Id:             "p",
+		ShipmentId:     shipmentId,
+		TrackingNumber: "TRK1",
+		WeightInKg:     1.5,
+		Items:          []wms.PackedItem{{ItemId: item.Id, Sku: product.Sku, WeightInKg: 1.5}},
+	}
+
+	tests := []struct {
+		body           string
+		packErr        error
+		wantStatusCode int
+	}{
+		{body: validBody, wantStatusCode: http.StatusCreated},
+		{body: validBody, packErr: wms.ShipmentDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{body: validBody, packErr: wms.ShipmentNotOpen, wantStatusCode: http.StatusConflict},
+		{body: validBody, packErr: wms.ItemAlreadyPacked, wantStatusCode: http.StatusConflict},
+		{body: validBody, packErr: wms.ItemNotOnPickList, wantStatusCode: http.StatusBadRequest},
+		{body: validBody, packErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"trackingNumber": "TRK1", "itemIds": []}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"itemIds": ["i"]}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.packErr != nil {
+			mockObj.EXPECT().PackItems(gomock.Any(), shipmentId, "TRK1", []string{item.Id}).Return(pkg, test.packErr)
+		}
+
+		request, err := http.NewRequest("POST", "/shipment/"+shipmentId+"/package", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.packErr, test.wantStatusCode, response.StatusCode)
+		}
+		if response.StatusCode != http.StatusCreated {
+			continue
+		}
+
+		var got api.PackageResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil || !reflect.DeepEqual(got.Response, &pkg) {
+			t.Errorf("want: %v, got: %v, %v", pkg, got, err)
+		}
+	}
+}
+
+func TestDispatchShipment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShipmentService(mockCtrl)
+	h.shipmentService = mockObj
+
+	tests := []struct {
+		dispatchErr    error
+		wantStatusCode int
+		wantResponse   api.ShipmentResponse
+	}{
+		{
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.ShipmentResponse{Response: &wms.Shipment{Id: shipmentId, OrderId: orderId, Status: wms.ShipmentDispatched}},
+		},
+		{
+			dispatchErr:    wms.ShipmentDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.ShipmentResponse{Error: "failed to dispatch, shipment: " + shipmentId + " does not exist"},
+		},
+		{
+			dispatchErr:    wms.ShipmentNotOpen,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.ShipmentResponse{Error: "failed to dispatch, shipment: " + shipmentId + " has been dispatched"},
+		},
+		{
+			dispatchErr:    wms.ShipmentNotPacked,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.ShipmentResponse{Error: "failed to dispatch, shipment: " + shipmentId + " has picked items left to pack"},
+		},
+		{
+			dispatchErr:    sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.ShipmentResponse{Error: "Failed to dispatch shipment"},
+		},
+	}
+
+	for _, test := range tests {
+		var shipment wms.Shipment
+		if test.wantResponse.Response != nil {
+			shipment = *test.wantResponse.Response
+		}
+		mockObj.EXPECT().DispatchShipment(gomock.Any(), shipmentId).Return(shipment, test.dispatchErr)
+
+		request, err := http.NewRequest("POST", "/shipment/"+shipmentId+"/dispatch", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.dispatchErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.ShipmentResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetProductStock(w http.ResponseWriter, r *http.Request) {
+	sku := chi.URLParam(r, "sku")
+
+	stock, err := h.stockService.GetProductStock(r.Context(), sku)
+	if err != nil {
+		if err == wms.ProductDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.ProductStockResponse{Error: fmt.Sprintf(
+				"failed to get stock, product: %s does not exist",
+				sku,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.ProductStockResponse{Error: "Failed to get product stock"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.ProductStockResponse{Response: &stock})
+}
+
+func (h *handler) GetWarehouseStock(w http.ResponseWriter, r *http.Request) {
+	warehouseId := chi.URLParam(r, "warehouseId")
+
+	inventory, err := h.stockService.GetWarehouseInventory(r.Context(), warehouseId)
+	if err != nil {
+		if err == wms.WarehouseDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.WarehouseInventoryResponse{Error: fmt.Sprintf(
+				"failed to get stock, warehouse: %s does not exist",
+				warehouseId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.WarehouseInventoryResponse{Error: "Failed to get warehouse stock"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.WarehouseInventoryResponse{Response: &inventory})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+func TestGetProductStock(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockStockService(mockCtrl)
+	h.stockService = mockObj
+
+	stock := wms.NewProductStock(product.Sku, []wms.StockRow{{
+		WarehouseId:  warehouse.Id,
+		ShelfBlockId: "b",
+		ShelfId:      item.ShelfId,
+		Sku:          product.Sku,
+		StockLevel:   wms.StockLevel{OnHand: 2, Reserved: 1, Available: 1},
+	}})
+
+	tests := []struct {
+		stockErr       error
+		wantStatusCode int
+		wantResponse   api.ProductStockResponse
+	}{
+		{wantStatusCode: http.StatusOK, wantResponse: api.ProductStockResponse{Response: &stock}},
+		{
+			stockErr:       wms.ProductDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.ProductStockResponse{Error: "failed to get stock, product: " + product.Sku + " does not exist"},
+		},
+		{
+			stockErr:       sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.ProductStockResponse{Error: "Failed to get product stock"},
+		},
+	}
+
+	for _, test := range tests {
+		if test.stockErr == nil {
+			mockObj.EXPECT().GetProductStock(gomock.Any(), product.Sku).Return(stock, nil)
+		} else {
+			mockObj.EXPECT().GetProductStock(gomock.Any(), product.Sku).Return(wms.ProductStock{}, test.stockErr)
+		}
+
+		request, err := http.NewRequest("GET", "/product/"+product.Sku+"/stock", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.stockErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.ProductStockResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
+
+func TestGetWarehouseStock(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockStockService(mockCtrl)
+	h.stockService = mockObj
+
+	tests := []struct {
+		stockErr       error
+		wantStatusCode int
+	}{
+		{wantStatusCode: http.StatusOK},
+		{stockErr: wms.WarehouseDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{stockErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().GetWarehouseInventory(gomock.Any(), warehouse.Id).Return(wms.NewWarehouseInventory(warehouse.Id, nil), test.stockErr)
+
+		request, err := http.NewRequest("GET", "/warehouse/"+warehouse.Id+"/stock", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.stockErr, test.wantStatusCode, response.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/validator.v2"
+	"net/http"
+	wms "warehouse-management-service"
+	"warehouse-management-service/pkg/api"
+	"warehouse-management-service/pkg/log"
+)
+
+func (h *handler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	transferId := chi.URLParam(r, "transferId")
+
+	transfer, err := h.transferService.GetTransferById(r.Context(), transferId)
+	if err != nil {
+		if err == wms.TransferDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.TransferResponse{Error: fmt.Sprintf(
+				"failed to get, transfer: %s does not exist",
+				transferId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.TransferResponse{Error: "Failed to get transfer"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.TransferResponse{Response: &transfer})
+}
+
+func (h *handler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var createTransferRequest api.CreateTransferRequest
+
+	if r.Body == nil {
+		err := fmt.Errorf("request body cannot be empty")
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.TransferResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&createTransferRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.TransferResponse{
+			Error: "Failed to parse request",
+		})
+		return
+	}
+
+	err = validator.Validate(createTransferRequest)
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.TransferResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	selection := wms.TransferSelection{
+		ItemIds:     createTransferRequest.ItemIds,
+		FromShelfId: createTransferRequest.FromShelfId,
+		Sku:         createTransferRequest.Sku,
+		Quantity:    createTransferRequest.Quantity,
+	}
+	err = selection.Validate()
+	if err != nil {
+		h.logger.Log(log.Error, err)
+		h.response(w, http.StatusBadRequest, api.TransferResponse{
+			Error: fmt.Sprintf("Invalid input: %v", err.Error())})
+		return
+	}
+
+	transfer, err := h.transferService.CreateTransfer(r.Context(), selection, createTransferRequest.ToShelfId)
+	if err != nil {
+		var insufficientStock *wms.InsufficientStockError
+		if err == wms.InvalidShelf {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusBadRequest, api.TransferResponse{Error: err.Error()})
+			return
+		} else if err == wms.ItemDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.TransferResponse{Error: "failed to transfer, " + err.Error()})
+			return
+		} else if errors.Is(err, wms.ItemNotTransferable) || errors.Is(err, wms.ShelfOverCapacity) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.TransferResponse{Error: err.Error()})
+			return
+		} else if errors.As(err, &insufficientStock) {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.TransferResponse{
+				Error:     wms.InsufficientStock.Error(),
+				Shortages: insufficientStock.Shortages,
+			})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.TransferResponse{Error: "Failed to create transfer"})
+			return
+		}
+	}
+
+	h.response(w, http.StatusCreated, api.TransferResponse{Response: &transfer})
+}
+
+func (h *handler) ReceiveTransfer(w http.ResponseWriter, r *http.Request) {
+	transferId := chi.URLParam(r, "transferId")
+
+	transfer, err := h.transferService.ReceiveTransfer(r.Context(), transferId)
+	if err != nil {
+		if err == wms.TransferDoesNotExist {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusNotFound, api.TransferResponse{Error: fmt.Sprintf(
+				"failed to receive, transfer: %s does not exist",
+				transferId,
+			)})
+			return
+		} else if err == wms.TransferNotInTransit {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusConflict, api.TransferResponse{Error: fmt.Sprintf(
+				"failed to receive, transfer: %s is not in transit",
+				transferId,
+			)})
+			return
+		} else {
+			h.logger.Log(log.Error, err)
+			h.response(w, http.StatusInternalServerError, api.TransferResponse{Error: "Failed to receive transfer"})
+			return
+		}
+	}
+	h.response(w, http.StatusOK, api.TransferResponse{Response: &transfer})
+}
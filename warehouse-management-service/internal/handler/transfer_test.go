@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+const transferId = "6b2e4f1a-3c5d-4e7f-8a9b-0c1d2e3f4a50"
+
+func TestCreateTransfer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockTransferService(mockCtrl)
+	h.transferService = mockObj
+
+	byItems := `{"toShelfId": "s2", "itemIds": ["` + item.Id + `"]}`
+	bySku := `{"toShelfId": "s2", "fromShelfId": "s1", "sku": "` + product.Sku + `", "quantity": 2}`
+	shortages := []wms.StockShortage{{Sku: product.Sku, Requested: 2, Available: 1}}
+
+	tests := []struct {
+		body           string
+		createErr      error
+		wantStatusCode int
+	}{
+		{body: byItems, wantStatusCode: http.StatusCreated},
+		{body: bySku, wantStatusCode: http.StatusCreated},
+		{body: byItems, createErr: wms.InvalidShelf, wantStatusCode: http.StatusBadRequest},
+		{body: byItems, createErr: wms.ItemDoesNotExist, wantStatusCode: http.StatusNotFound},
+		{body: byItems, createErr: &wms.ItemNotTransferableError{ItemId: item.Id, Reason: "is in_transit"}, wantStatusCode: http.StatusConflict},
+		{body: byItems, createErr: wms.ShelfOverCapacity, wantStatusCode: http.StatusConflict},
+		{body: bySku, createErr: &wms.InsufficientStockError{Shortages: shortages}, wantStatusCode: http.StatusConflict},
+		{body: byItems, createErr: sql.ErrConnDone, wantStatusCode: http.StatusInternalServerError},
+		{body: `{"itemIds": ["i"]}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"toShelfId": "s2", "itemIds": ["i", "i"]}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"toShelfId": "s2", "itemIds": ["i"], "sku": "a"}`, wantStatusCode: http.StatusBadRequest},
+		{body: `{"toShelfId": "s2", "fromShelfId": "s1", "sku": "a"}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		if test.wantStatusCode != http.StatusBadRequest || test.createErr != nil {
+			mockObj.EXPECT().CreateTransfer(gomock.Any(), gomock.Any(), "s2").Return(
+				wms.Transfer{Id: transferId, ToShelfId: "s2", Status: wms.TransferInTransit},
+				test.createErr,
+			)
+		}
+
+		request, err := http.NewRequest("POST", "/transfer", strings.NewReader(test.body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%s, %v: want: %v, got: %v", test.body, test.createErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.TransferResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if test.createErr == nil && test.wantStatusCode == http.StatusCreated && (got.Response == nil || got.Response.Id != transferId) {
+			t.Errorf("want transfer %s, got: %v", transferId, got)
+		}
+		if _, ok := test.createErr.(*wms.InsufficientStockError); ok && !reflect.DeepEqual(got.Shortages, shortages) {
+			t.Errorf("want: %v, got: %v", shortages, got.Shortages)
+		}
+	}
+}
+
+func TestReceiveTransfer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockTransferService(mockCtrl)
+	h.transferService = mockObj
+
+	tests := []struct {
+		receiveErr     error
+		wantStatusCode int
+		wantResponse   api.TransferResponse
+	}{
+		{
+			wantStatusCode: http.StatusOK,
+			wantResponse:   api.TransferResponse{Response: &wms.Transfer{Id: transferId, Status: wms.TransferCompleted}},
+		},
+		{
+			receiveErr:     wms.TransferDoesNotExist,
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   api.TransferResponse{Error: "failed to receive, transfer: " + transferId + " does not exist"},
+		},
+		{
+			receiveErr:     wms.TransferNotInTransit,
+			wantStatusCode: http.StatusConflict,
+			wantResponse:   api.TransferResponse{Error: "failed to receive, transfer: " + transferId + " is not in transit"},
+		},
+		{
+			receiveErr:     sql.ErrConnDone,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResponse:   api.TransferResponse{Error: "Failed to receive transfer"},
+		},
+	}
+
+	for _, test := range tests {
+		var transfer wms.Transfer
+		if test.wantResponse.Response != nil {
+			transfer = *test.wantResponse.Response
+		}
+		mockObj.EXPECT().ReceiveTransfer(gomock.Any(), transferId).Return(transfer, test.receiveErr)
+
+		request, err := http.NewRequest("POST", "/transfer/"+transferId+"/receive", nil)
+		if err != nil {
+			t.Error(err)
+		}
+
+		response := executeRequest(request)
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("%v: want: %v, got: %v", test.receiveErr, test.wantStatusCode, response.StatusCode)
+		}
+
+		var got api.TransferResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.wantResponse) {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+	}
+}
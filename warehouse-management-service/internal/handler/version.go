@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var missingIfMatch = errors.New("If-Match header is required")
+var invalidIfMatch = errors.New("If-Match must be an ETag returned by a GET")
+
+// ifMatchVersion reads the row version a PUT or DELETE was made against from
+// its If-Match header.
+func ifMatchVersion(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return 0, missingIfMatch
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, `"`) {
+		return 0, invalidIfMatch
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil {
+		return 0, invalidIfMatch
+	}
+
+	return version, nil
+}
+
+func ifMatchStatus(err error) int {
+	if err == missingIfMatch {
+		return http.StatusPreconditionRequired
+	}
+	return http.StatusBadRequest
+}
+
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
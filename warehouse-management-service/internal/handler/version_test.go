@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"testing"
+	wms "warehouse-management-service"
+	mock "warehouse-management-service/internal/handler/mock"
+	"warehouse-management-service/pkg/api"
+)
+
+func TestGetShelfETag(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfService(mockCtrl)
+	h.shelfService = mockObj
+
+	shelf := wms.Shelf{Id: "shelf", Label: "1A", Section: "A", Level: "1", ShelfBlockId: "block", Version: 3}
+	mockObj.EXPECT().GetShelfById(gomock.Any(), shelf.Id).Return(shelf, nil)
+	mockObj.EXPECT().GetShelfUtilization(gomock.Any(), shelf.Id).Return(wms.ShelfUtilization{}, nil)
+
+	request, err := http.NewRequest("GET", "/shelf/shelf", nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	response := executeRequest(request)
+	if got := response.Header.Get("ETag"); got != `"3"` {
+		t.Errorf("want: %v, got: %v", `"3"`, got)
+	}
+}
+
+func TestUpdateShelfIfMatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockShelfService(mockCtrl)
+	h.shelfService = mockObj
+
+	shelf := wms.Shelf{Id: "shelf", Label: "1A", Section: "A", Level: "1", ShelfBlockId: "block"}
+
+	tests := []struct {
+		ifMatch        string
+		update         bool
+		updateShelfErr error
+		wantStatusCode int
+		wantETag       string
+		wantResponse   api.ShelfResponse
+	}{
+		{
+			ifMatch:        "",
+			wantStatusCode: http.StatusPreconditionRequired,
+			wantResponse:   api.ShelfResponse{Error: missingIfMatch.Error()},
+		},
+		{
+			ifMatch:        "3",
+			wantStatusCode: http.StatusBadRequest,
+			wantResponse:   api.ShelfResponse{Error: invalidIfMatch.Error()},
+		},
+		{
+			ifMatch:        `"3"`,
+			update:         true,
+			updateShelfErr: wms.VersionMismatch,
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantResponse:   api.ShelfResponse{Error: "failed to update, shelf: shelf has been modified"},
+		},
+		{
+			ifMatch:        `"3"`,
+			update:         true,
+			updateShelfErr: nil,
+			wantStatusCode: http.StatusOK,
+			wantETag:       `"4"`,
+			wantResponse:   api.ShelfResponse{Message: "Successfully updated shelf: shelf"},
+		},
+	}
+	for _, test := range tests {
+		if test.update {
+			versioned := shelf
+			versioned.Version = 3
+			mockObj.EXPECT().UpdateShelf(gomock.Any(), versioned).Return(test.updateShelfErr)
+		}
+
+		marshalledRequest, err := json.Marshal(shelf)
+		if err != nil {
+			t.Error(err)
+		}
+		request, err := http.NewRequest("PUT", "/shelf", bytes.NewBuffer(marshalledRequest))
+		if err != nil {
+			t.Error(err)
+		}
+		if test.ifMatch != "" {
+			request.Header.Set("If-Match", test.ifMatch)
+		}
+
+		response := executeRequest(request)
+		var got api.ShelfResponse
+		err = json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if response.StatusCode != test.wantStatusCode {
+			t.Errorf("want: %v, got: %v", test.wantStatusCode, response.StatusCode)
+		}
+		if got != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, got)
+		}
+		if etag := response.Header.Get("ETag"); etag != test.wantETag {
+			t.Errorf("want: %v, got: %v", test.wantETag, etag)
+		}
+	}
+}
+
+func TestDeleteWarehouseIfMatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := mock.NewMockWarehouseService(mockCtrl)
+	h.warehouseService = mockObj
+
+	mockObj.EXPECT().DeleteWarehouse(gomock.Any(), warehouse.Id, 2, false).Return(wms.VersionMismatch)
+
+	request, err := http.NewRequest("DELETE", "/warehouse/"+warehouse.Id, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	request.Header.Set("If-Match", `"2"`)
+
+	response := executeRequest(request)
+	if response.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("want: %v, got: %v", http.StatusPreconditionFailed, response.StatusCode)
+	}
+}
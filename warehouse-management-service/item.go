@@ -0,0 +1,59 @@
+package wms
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ItemAvailable = "available"
+	// ItemQuarantined items have expired. They stay on their shelf but are
+	// never reserved or picked.
+	ItemQuarantined = "quarantined"
+	// ItemInTransit items are on their way to a shelf of another warehouse.
+	// They count towards the capacity of that shelf but cannot be reserved
+	// or picked until the transfer is received.
+	ItemInTransit = "in_transit"
+)
+
+type Item struct {
+	Id             string     `json:"id,omitempty"`
+	Sku            string     `json:"sku,omitempty"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	ReceivedOn     time.Time  `json:"receivedOn"`
+	ShelfId        string     `json:"shelfId,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	// NearExpiry is set by the expiry sweep on items that expire within its
+	// window.
+	NearExpiry bool `json:"nearExpiry,omitempty"`
+	// Lot is the supplier's lot or batch number the item was received
+	// under, empty for items of products that are not lot tracked.
+	Lot string `json:"lot,omitempty"`
+	// SerialNumber identifies a single unit of a serialized product. No two
+	// items share one.
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+var ItemDoesNotExist = errors.New("item does not exist")
+var ItemClaimed = errors.New("item is reserved or being picked")
+var InvalidProduct = errors.New("invalid product")
+var InvalidShelf = errors.New("invalid shelf")
+var LotRequired = errors.New("product is lot tracked, a lot is required")
+var ExpirationDateRequired = errors.New("product is perishable, an expiration date is required")
+var SerialNumberRequired = errors.New("product is serialized, every item needs a serial number")
+var SerialNumberCountMismatch = errors.New("serial numbers must be given one per item")
+var RepeatedSerialNumber = errors.New("serial number is given more than once")
+var DuplicateSerialNumber = errors.New("serial number is already in use")
+
+func NewItem(sku string, expirationDate *time.Time, shelfId string, lot string, serialNumber string) Item {
+	return Item{
+		Id:             generateUUID(),
+		Sku:            sku,
+		Lot:            lot,
+		SerialNumber:   serialNumber,
+		ExpirationDate: expirationDate,
+		ReceivedOn:     time.Now().UTC(),
+		ShelfId:        shelfId,
+		Status:         ItemAvailable,
+	}
+}
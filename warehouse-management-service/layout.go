@@ -0,0 +1,128 @@
+package wms
+
+import (
+	"sort"
+	"strconv"
+)
+
+// WarehouseLayout is the floor map of a warehouse: its shelf blocks grouped
+// by aisle and rack, and the shelves of each block grouped by section and
+// ordered by level.
+type WarehouseLayout struct {
+	Warehouse
+	Aisles []AisleLayout `json:"aisles"`
+}
+
+type AisleLayout struct {
+	Aisle string       `json:"aisle"`
+	Racks []RackLayout `json:"racks"`
+}
+
+type RackLayout struct {
+	Rack        string             `json:"rack"`
+	ShelfBlocks []ShelfBlockLayout `json:"shelfBlocks"`
+}
+
+type ShelfBlockLayout struct {
+	ShelfBlock
+	Sections []SectionLayout `json:"sections"`
+}
+
+type SectionLayout struct {
+	Section string        `json:"section"`
+	Shelves []ShelfLayout `json:"shelves"`
+}
+
+type ShelfLayout struct {
+	Shelf
+	ItemCount *int `json:"itemCount,omitempty"`
+}
+
+// NewWarehouseLayout builds the layout tree of a warehouse. Shelves whose
+// shelf block is not in shelfBlocks are left out. Item counts are only set
+// when itemCounts is non-nil, shelves missing from it have no items.
+func NewWarehouseLayout(
+	warehouse Warehouse,
+	shelfBlocks []ShelfBlock,
+	shelves []Shelf,
+	itemCounts map[string]int,
+) WarehouseLayout {
+	shelvesByBlock := make(map[string][]Shelf)
+	for _, shelf := range shelves {
+		shelvesByBlock[shelf.ShelfBlockId] = append(shelvesByBlock[shelf.ShelfBlockId], shelf)
+	}
+
+	blocks := make([]ShelfBlock, len(shelfBlocks))
+	copy(blocks, shelfBlocks)
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].Aisle != blocks[j].Aisle {
+			return lessLabel(blocks[i].Aisle, blocks[j].Aisle)
+		}
+		if blocks[i].Rack != blocks[j].Rack {
+			return lessLabel(blocks[i].Rack, blocks[j].Rack)
+		}
+		return blocks[i].Id < blocks[j].Id
+	})
+
+	layout := WarehouseLayout{Warehouse: warehouse, Aisles: []AisleLayout{}}
+	for _, block := range blocks {
+		aisles := &layout.Aisles
+		if len(*aisles) == 0 || (*aisles)[len(*aisles)-1].Aisle != block.Aisle {
+			*aisles = append(*aisles, AisleLayout{Aisle: block.Aisle, Racks: []RackLayout{}})
+		}
+		aisle := &(*aisles)[len(*aisles)-1]
+
+		if len(aisle.Racks) == 0 || aisle.Racks[len(aisle.Racks)-1].Rack != block.Rack {
+			aisle.Racks = append(aisle.Racks, RackLayout{Rack: block.Rack, ShelfBlocks: []ShelfBlockLayout{}})
+		}
+		rack := &aisle.Racks[len(aisle.Racks)-1]
+
+		rack.ShelfBlocks = append(rack.ShelfBlocks, ShelfBlockLayout{
+			ShelfBlock: block,
+			Sections:   newSectionLayouts(shelvesByBlock[block.Id], itemCounts),
+		})
+	}
+
+	return layout
+}
+
+func newSectionLayouts(shelves []Shelf, itemCounts map[string]int) []SectionLayout {
+	sorted := make([]Shelf, len(shelves))
+	copy(sorted, shelves)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Section != sorted[j].Section {
+			return lessLabel(sorted[i].Section, sorted[j].Section)
+		}
+		if sorted[i].Level != sorted[j].Level {
+			return lessLabel(sorted[i].Level, sorted[j].Level)
+		}
+		return sorted[i].Id < sorted[j].Id
+	})
+
+	sections := []SectionLayout{}
+	for _, shelf := range sorted {
+		if len(sections) == 0 || sections[len(sections)-1].Section != shelf.Section {
+			sections = append(sections, SectionLayout{Section: shelf.Section, Shelves: []ShelfLayout{}})
+		}
+		section := &sections[len(sections)-1]
+
+		shelfLayout := ShelfLayout{Shelf: shelf}
+		if itemCounts != nil {
+			count := itemCounts[shelf.Id]
+			shelfLayout.ItemCount = &count
+		}
+		section.Shelves = append(section.Shelves, shelfLayout)
+	}
+	return sections
+}
+
+// lessLabel orders location labels such as aisles and levels, numerically
+// when both are numbers so that "2" comes before "10".
+func lessLabel(a, b string) bool {
+	numA, errA := strconv.Atoi(a)
+	numB, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil && numA != numB {
+		return numA < numB
+	}
+	return a < b
+}
@@ -0,0 +1,254 @@
+package wms
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LayoutImportRow is one shelf of a bulk layout import. Rows with the same
+// aisle and rack belong to the same shelf block. Label defaults to the level
+// followed by the section, e.g. "1A".
+type LayoutImportRow struct {
+	Aisle       string `json:"aisle"`
+	Rack        string `json:"rack"`
+	StorageType string `json:"storageType"`
+	Section     string `json:"section"`
+	Level       string `json:"level"`
+	Label       string `json:"label,omitempty"`
+}
+
+// LayoutImportRowError says why a row of an import was rejected. Row is the
+// 1-based position of the row in the document, not counting a CSV header.
+type LayoutImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// LayoutImportError lists every rejected row of an import. It matches
+// InvalidLayoutImport with errors.Is.
+type LayoutImportError struct {
+	Rows []LayoutImportRowError
+}
+
+func (e *LayoutImportError) Error() string {
+	return fmt.Sprintf("%s: %d rows rejected", InvalidLayoutImport.Error(), len(e.Rows))
+}
+
+func (e *LayoutImportError) Unwrap() error {
+	return InvalidLayoutImport
+}
+
+// LayoutImport is what an import creates in a warehouse.
+type LayoutImport struct {
+	ShelfBlocks []ShelfBlock `json:"shelfBlocks"`
+	Shelves     []Shelf      `json:"shelves"`
+}
+
+var InvalidLayoutImport = errors.New("invalid layout import")
+var EmptyLayoutImport = errors.New("layout import has no rows")
+
+var layoutImportColumns = []string{"aisle", "rack", "storageType", "section", "level", "label"}
+
+// ParseLayoutCSV reads import rows from CSV with a header row naming the
+// columns: aisle, rack, storageType, section, level and, optionally, label.
+func ParseLayoutCSV(r io.Reader) ([]LayoutImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, EmptyLayoutImport
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidLayoutImport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		column := ""
+		for _, known := range layoutImportColumns {
+			if strings.EqualFold(strings.TrimSpace(name), known) {
+				column = known
+			}
+		}
+		if column == "" {
+			return nil, fmt.Errorf("%w: unknown column %q", InvalidLayoutImport, name)
+		}
+		columns[column] = i
+	}
+	// every column but label is required
+	for _, required := range layoutImportColumns[:5] {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", InvalidLayoutImport, required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok {
+			return ""
+		}
+		return record[i]
+	}
+
+	var rows []LayoutImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", InvalidLayoutImport, err)
+		}
+		rows = append(rows, LayoutImportRow{
+			Aisle:       field(record, "aisle"),
+			Rack:        field(record, "rack"),
+			StorageType: field(record, "storageType"),
+			Section:     field(record, "section"),
+			Level:       field(record, "level"),
+			Label:       field(record, "label"),
+		})
+	}
+	if len(rows) == 0 {
+		return nil, EmptyLayoutImport
+	}
+
+	return rows, nil
+}
+
+// ParseLayoutJSON reads import rows from a JSON array of LayoutImportRow.
+func ParseLayoutJSON(r io.Reader) ([]LayoutImportRow, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var rows []LayoutImportRow
+	err := decoder.Decode(&rows)
+	if err == io.EOF {
+		return nil, EmptyLayoutImport
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidLayoutImport, err)
+	}
+	if len(rows) == 0 {
+		return nil, EmptyLayoutImport
+	}
+
+	return rows, nil
+}
+
+type blockLocation struct {
+	aisle string
+	rack  string
+}
+
+type shelfLocation struct {
+	blockLocation
+	section string
+	level   string
+}
+
+// NewLayoutImport checks every row of an import into warehouseId and builds
+// the shelf blocks and shelves to create, one block per aisle and rack.
+// existing are the shelf blocks already in the warehouse; a row may not add
+// to them. All rejected rows are reported together in a *LayoutImportError.
+func NewLayoutImport(warehouseId string, rows []LayoutImportRow, existing []ShelfBlock) (LayoutImport, error) {
+	if len(rows) == 0 {
+		return LayoutImport{}, EmptyLayoutImport
+	}
+
+	existingBlocks := make(map[blockLocation]bool, len(existing))
+	for _, block := range existing {
+		existingBlocks[blockLocation{block.Aisle, block.Rack}] = true
+	}
+
+	layoutImport := LayoutImport{ShelfBlocks: []ShelfBlock{}, Shelves: []Shelf{}}
+	blocks := make(map[blockLocation]int)
+	blockRows := make(map[blockLocation]int)
+	shelfRows := make(map[shelfLocation]int)
+	var rejected []LayoutImportRowError
+	reject := func(row int, format string, args ...interface{}) {
+		rejected = append(rejected, LayoutImportRowError{Row: row, Error: fmt.Sprintf(format, args...)})
+	}
+
+	for i, row := range rows {
+		number := i + 1
+		row = LayoutImportRow{
+			Aisle:       strings.TrimSpace(row.Aisle),
+			Rack:        strings.TrimSpace(row.Rack),
+			StorageType: strings.TrimSpace(row.StorageType),
+			Section:     strings.TrimSpace(row.Section),
+			Level:       strings.TrimSpace(row.Level),
+			Label:       strings.TrimSpace(row.Label),
+		}
+
+		var missing []string
+		for _, field := range []struct{ name, value string }{
+			{"aisle", row.Aisle},
+			{"rack", row.Rack},
+			{"storageType", row.StorageType},
+			{"section", row.Section},
+			{"level", row.Level},
+		} {
+			if field.value == "" {
+				missing = append(missing, field.name)
+			}
+		}
+		if len(missing) > 0 {
+			reject(number, "missing %s", strings.Join(missing, ", "))
+			continue
+		}
+
+		location := blockLocation{row.Aisle, row.Rack}
+		if existingBlocks[location] {
+			reject(number, "shelf block at aisle %s rack %s already exists", row.Aisle, row.Rack)
+			continue
+		}
+
+		blockIndex, ok := blocks[location]
+		if !ok {
+			blockIndex = len(layoutImport.ShelfBlocks)
+			blocks[location] = blockIndex
+			blockRows[location] = number
+			layoutImport.ShelfBlocks = append(
+				layoutImport.ShelfBlocks,
+				NewShelfBlock(row.Aisle, row.Rack, row.StorageType, warehouseId),
+			)
+		}
+		block := layoutImport.ShelfBlocks[blockIndex]
+		if block.StorageType != row.StorageType {
+			reject(
+				number,
+				"storage type %s differs from %s on row %d",
+				row.StorageType,
+				block.StorageType,
+				blockRows[location],
+			)
+			continue
+		}
+
+		shelf := shelfLocation{location, row.Section, row.Level}
+		if first, ok := shelfRows[shelf]; ok {
+			reject(number, "duplicates row %d", first)
+			continue
+		}
+		shelfRows[shelf] = number
+
+		label := row.Label
+		if label == "" {
+			label = row.Level + row.Section
+		}
+		layoutImport.Shelves = append(
+			layoutImport.Shelves,
+			NewShelf(label, row.Section, row.Level, block.Id),
+		)
+	}
+
+	if len(rejected) > 0 {
+		return LayoutImport{}, &LayoutImportError{Rows: rejected}
+	}
+	return layoutImport, nil
+}
@@ -0,0 +1,130 @@
+package wms
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLayoutCSV(t *testing.T) {
+	rows, err := ParseLayoutCSV(strings.NewReader(
+		"Aisle,rack,storageType,section,level,label\n" +
+			"1, 1, regular, A, 1,\n" +
+			"1,1,regular,A,2,top\n",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []LayoutImportRow{
+		{Aisle: "1", Rack: "1", StorageType: "regular", Section: "A", Level: "1"},
+		{Aisle: "1", Rack: "1", StorageType: "regular", Section: "A", Level: "2", Label: "top"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("want: %v, got: %v", want, rows)
+	}
+}
+
+func TestParseLayoutCSVErrors(t *testing.T) {
+	tests := []struct {
+		csv     string
+		wantErr error
+	}{
+		{csv: "", wantErr: EmptyLayoutImport},
+		{csv: "aisle,rack,storageType,section,level\n", wantErr: EmptyLayoutImport},
+		{csv: "aisle,rack,storageType,section\n1,1,regular,A\n", wantErr: InvalidLayoutImport},
+		{csv: "aisle,rack,storageType,section,level,colour\n", wantErr: InvalidLayoutImport},
+		{csv: "aisle,rack,storageType,section,level\n1,1,regular\n", wantErr: InvalidLayoutImport},
+	}
+	for _, test := range tests {
+		_, err := ParseLayoutCSV(strings.NewReader(test.csv))
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%q: want: %v, got: %v", test.csv, test.wantErr, err)
+		}
+	}
+}
+
+func TestParseLayoutJSON(t *testing.T) {
+	rows, err := ParseLayoutJSON(strings.NewReader(
+		`[{"aisle":"1","rack":"2","storageType":"cold","section":"B","level":"3"}]`,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []LayoutImportRow{{Aisle: "1", Rack: "2", StorageType: "cold", Section: "B", Level: "3"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("want: %v, got: %v", want, rows)
+	}
+
+	_, err = ParseLayoutJSON(strings.NewReader(`[{"aisle":"1","shelf":"x"}]`))
+	if !errors.Is(err, InvalidLayoutImport) {
+		t.Errorf("want: %v, got: %v", InvalidLayoutImport, err)
+	}
+	_, err = ParseLayoutJSON(strings.NewReader(`[]`))
+	if err != EmptyLayoutImport {
+		t.Errorf("want: %v, got: %v", EmptyLayoutImport, err)
+	}
+}
+
+func TestNewLayoutImport(t *testing.T) {
+	rows := []LayoutImportRow{
+		{Aisle: "1", Rack: "1", StorageType: "regular", Section: "A", Level: "1"},
+		{Aisle: "1", Rack: "1", StorageType: "regular", Section: "A", Level: "2", Label: "top"},
+		{Aisle: "1", Rack: "2", StorageType: "cold", Section: "A", Level: "1"},
+	}
+
+	layoutImport, err := NewLayoutImport("w", rows, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(layoutImport.ShelfBlocks) != 2 {
+		t.Fatalf("want 2 shelf blocks, got: %v", layoutImport.ShelfBlocks)
+	}
+	first, second := layoutImport.ShelfBlocks[0], layoutImport.ShelfBlocks[1]
+	if first.Aisle != "1" || first.Rack != "1" || first.StorageType != "regular" || first.WarehouseId != "w" {
+		t.Errorf("unexpected shelf block: %v", first)
+	}
+	if second.Rack != "2" || second.StorageType != "cold" {
+		t.Errorf("unexpected shelf block: %v", second)
+	}
+
+	if len(layoutImport.Shelves) != 3 {
+		t.Fatalf("want 3 shelves, got: %v", layoutImport.Shelves)
+	}
+	labels := []string{"1A", "top", "1A"}
+	blocks := []string{first.Id, first.Id, second.Id}
+	for i, shelf := range layoutImport.Shelves {
+		if shelf.Label != labels[i] || shelf.ShelfBlockId != blocks[i] || shelf.Id == "" {
+			t.Errorf("unexpected shelf %d: %v", i, shelf)
+		}
+	}
+}
+
+func TestNewLayoutImportRejectedRows(t *testing.T) {
+	rows := []LayoutImportRow{
+		{Aisle: "1", Rack: "1", StorageType: "regular", Section: "A", Level: "1"},
+		{Aisle: "1", Rack: "", StorageType: "regular", Section: "", Level: "1"},
+		{Aisle: "1", Rack: "1", StorageType: "cold", Section: "A", Level: "2"},
+		{Aisle: "1", Rack: "1", StorageType: "regular", Section: "A", Level: " 1 "},
+		{Aisle: "2", Rack: "1", StorageType: "regular", Section: "A", Level: "1"},
+	}
+	existing := []ShelfBlock{{Id: "b", Aisle: "2", Rack: "1"}}
+
+	_, err := NewLayoutImport("w", rows, existing)
+
+	var importError *LayoutImportError
+	if !errors.As(err, &importError) || !errors.Is(err, InvalidLayoutImport) {
+		t.Fatalf("want: %v, got: %v", InvalidLayoutImport, err)
+	}
+	want := []LayoutImportRowError{
+		{Row: 2, Error: "missing rack, section"},
+		{Row: 3, Error: "storage type cold differs from regular on row 1"},
+		{Row: 4, Error: "duplicates row 1"},
+		{Row: 5, Error: "shelf block at aisle 2 rack 1 already exists"},
+	}
+	if !reflect.DeepEqual(importError.Rows, want) {
+		t.Errorf("want: %v, got: %v", want, importError.Rows)
+	}
+}
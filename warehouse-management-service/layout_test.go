@@ -0,0 +1,77 @@
+package wms
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewWarehouseLayout(t *testing.T) {
+	warehouse := Warehouse{Id: "w", Name: "layout"}
+	shelfBlocks := []ShelfBlock{
+		{Id: "b3", Aisle: "10", Rack: "1", WarehouseId: "w"},
+		{Id: "b2", Aisle: "2", Rack: "2", WarehouseId: "w"},
+		{Id: "b1", Aisle: "2", Rack: "1", WarehouseId: "w"},
+	}
+	shelves := []Shelf{
+		{Id: "s3", Section: "B", Level: "1", ShelfBlockId: "b1"},
+		{Id: "s2", Section: "A", Level: "10", ShelfBlockId: "b1"},
+		{Id: "s1", Section: "A", Level: "9", ShelfBlockId: "b1"},
+		{Id: "orphan", Section: "A", Level: "1", ShelfBlockId: "other"},
+	}
+
+	layout := NewWarehouseLayout(warehouse, shelfBlocks, shelves, map[string]int{"s1": 4})
+
+	if layout.Warehouse != warehouse {
+		t.Errorf("want: %v, got: %v", warehouse, layout.Warehouse)
+	}
+	if len(layout.Aisles) != 2 || layout.Aisles[0].Aisle != "2" || layout.Aisles[1].Aisle != "10" {
+		t.Fatalf("want aisles [2 10], got: %v", layout.Aisles)
+	}
+
+	racks := layout.Aisles[0].Racks
+	if len(racks) != 2 || racks[0].Rack != "1" || racks[1].Rack != "2" {
+		t.Fatalf("want racks [1 2], got: %v", racks)
+	}
+
+	sections := racks[0].ShelfBlocks[0].Sections
+	if len(sections) != 2 || sections[0].Section != "A" || sections[1].Section != "B" {
+		t.Fatalf("want sections [A B], got: %v", sections)
+	}
+	levels := sections[0].Shelves
+	if len(levels) != 2 || levels[0].Id != "s1" || levels[1].Id != "s2" {
+		t.Fatalf("want shelves [s1 s2], got: %v", levels)
+	}
+	if levels[0].ItemCount == nil || *levels[0].ItemCount != 4 {
+		t.Errorf("want item count 4, got: %v", levels[0].ItemCount)
+	}
+	if levels[1].ItemCount == nil || *levels[1].ItemCount != 0 {
+		t.Errorf("want item count 0, got: %v", levels[1].ItemCount)
+	}
+
+	if len(racks[1].ShelfBlocks[0].Sections) != 0 {
+		t.Errorf("want no sections, got: %v", racks[1].ShelfBlocks[0].Sections)
+	}
+}
+
+func TestNewWarehouseLayoutWithoutItemCounts(t *testing.T) {
+	layout := NewWarehouseLayout(
+		Warehouse{Id: "w"},
+		[]ShelfBlock{{Id: "b1", Aisle: "1", Rack: "1"}},
+		[]Shelf{{Id: "s1", Section: "A", Level: "1", ShelfBlockId: "b1"}},
+		nil,
+	)
+
+	shelf := layout.Aisles[0].Racks[0].ShelfBlocks[0].Sections[0].Shelves[0]
+	if shelf.ItemCount != nil {
+		t.Errorf("want no item count, got: %v", *shelf.ItemCount)
+	}
+
+	marshalled, err := json.Marshal(shelf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"s1","section":"A","level":"1","shelfBlockId":"b1"}`
+	if string(marshalled) != want {
+		t.Errorf("want: %s, got: %s", want, marshalled)
+	}
+}
@@ -0,0 +1,39 @@
+package wms
+
+import "errors"
+
+// LotRecall is everywhere the items of a lot have gone: the shelves holding
+// the ones still in stock and the shipments that carried the others out.
+type LotRecall struct {
+	Lot       string        `json:"lot"`
+	Shelves   []LotShelf    `json:"shelves"`
+	Shipments []LotShipment `json:"shipments"`
+}
+
+// LotShelf is a shelf holding items of a lot.
+type LotShelf struct {
+	ShelfId     string   `json:"shelfId"`
+	WarehouseId string   `json:"warehouseId"`
+	Sku         string   `json:"sku"`
+	ItemIds     []string `json:"itemIds"`
+}
+
+// LotShipment is a package of a shipment that items of a lot were packed
+// into, with the customer it went to.
+type LotShipment struct {
+	ShipmentId     string   `json:"shipmentId"`
+	OrderId        string   `json:"orderId"`
+	Customer       string   `json:"customer"`
+	Status         string   `json:"status"`
+	PackageId      string   `json:"packageId"`
+	TrackingNumber string   `json:"trackingNumber"`
+	Sku            string   `json:"sku"`
+	ItemIds        []string `json:"itemIds"`
+}
+
+var LotDoesNotExist = errors.New("no items of the lot have been received")
+
+// Found reports whether any item of the lot was found.
+func (l LotRecall) Found() bool {
+	return len(l.Shelves) > 0 || len(l.Shipments) > 0
+}
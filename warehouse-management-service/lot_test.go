@@ -0,0 +1,23 @@
+package wms
+
+import "testing"
+
+func TestCheckLot(t *testing.T) {
+	tests := []struct {
+		lotTracked bool
+		lot        string
+		want       error
+	}{
+		{lotTracked: true, lot: "L1"},
+		{lotTracked: true, want: LotRequired},
+		{lotTracked: false},
+		{lotTracked: false, lot: "L1"},
+	}
+
+	for _, test := range tests {
+		got := Product{Sku: "a", LotTracked: test.lotTracked}.CheckLot(test.lot)
+		if got != test.want {
+			t.Errorf("%v, %q: want: %v, got: %v", test.lotTracked, test.lot, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package wms
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	OrderOpen    = "open"
+	OrderPicking = "picking"
+	OrderShipped = "shipped"
+)
+
+// Order is a customer order to be fulfilled from the stock of one
+// warehouse.
+type Order struct {
+	Id          string      `json:"id"`
+	Customer    string      `json:"customer"`
+	WarehouseId string      `json:"warehouseId"`
+	Status      string      `json:"status"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	Lines       []OrderLine `json:"lines"`
+}
+
+type OrderLine struct {
+	Id       string `json:"id"`
+	OrderId  string `json:"orderId"`
+	Sku      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+var OrderDoesNotExist = errors.New("order does not exist")
+var OrderNotOpen = errors.New("order is not open")
+var DuplicateOrderLine = errors.New("order lists a sku more than once")
+var InsufficientStock = errors.New("insufficient stock")
+
+// StockShortage is a sku that an order wants more of than can be picked.
+type StockShortage struct {
+	Sku       string `json:"sku"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+}
+
+// InsufficientStockError is returned when the stock of a warehouse cannot
+// cover an order. It matches InsufficientStock with errors.Is.
+type InsufficientStockError struct {
+	Shortages []StockShortage
+}
+
+func (e *InsufficientStockError) Error() string {
+	shortages := make([]string, 0, len(e.Shortages))
+	for _, shortage := range e.Shortages {
+		shortages = append(shortages, fmt.Sprintf("%s %d of %d", shortage.Sku, shortage.Available, shortage.Requested))
+	}
+	return fmt.Sprintf("%s: %s", InsufficientStock.Error(), strings.Join(shortages, ", "))
+}
+
+func (e *InsufficientStockError) Unwrap() error {
+	return InsufficientStock
+}
+
+func NewOrder(customer, warehouseId string, lines []OrderLine) Order {
+	order := Order{
+		Id:          generateUUID(),
+		Customer:    customer,
+		WarehouseId: warehouseId,
+		Status:      OrderOpen,
+		CreatedAt:   time.Now().UTC(),
+		Lines:       make([]OrderLine, 0, len(lines)),
+	}
+	for _, line := range lines {
+		order.Lines = append(order.Lines, OrderLine{
+			Id:       generateUUID(),
+			OrderId:  order.Id,
+			Sku:      line.Sku,
+			Quantity: line.Quantity,
+		})
+	}
+	return order
+}
+
+// Validate checks that no sku is ordered on two lines.
+func (o Order) Validate() error {
+	skus := make(map[string]bool, len(o.Lines))
+	for _, line := range o.Lines {
+		if skus[line.Sku] {
+			return DuplicateOrderLine
+		}
+		skus[line.Sku] = true
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package wms
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewOrder(t *testing.T) {
+	order := NewOrder("acme", "w", []OrderLine{{Sku: "a", Quantity: 1}, {Sku: "b", Quantity: 2}})
+	if order.Status != OrderOpen || len(order.Lines) != 2 {
+		t.Fatalf("want an open order with 2 lines, got: %v", order)
+	}
+	for _, line := range order.Lines {
+		if line.Id == "" || line.OrderId != order.Id {
+			t.Errorf("want a line of order %s, got: %v", order.Id, line)
+		}
+	}
+	if err := order.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	order = NewOrder("acme", "w", []OrderLine{{Sku: "a", Quantity: 1}, {Sku: "a", Quantity: 2}})
+	if err := order.Validate(); err != DuplicateOrderLine {
+		t.Errorf("want: %v, got: %v", DuplicateOrderLine, err)
+	}
+}
+
+func TestInsufficientStockError(t *testing.T) {
+	err := error(&InsufficientStockError{Shortages: []StockShortage{
+		{Sku: "a", Requested: 3, Available: 1},
+		{Sku: "b", Requested: 2, Available: 0},
+	}})
+	if !errors.Is(err, InsufficientStock) {
+		t.Errorf("want %v to match %v", err, InsufficientStock)
+	}
+	if want := "insufficient stock: a 1 of 3, b 0 of 2"; err.Error() != want {
+		t.Errorf("want: %v, got: %v", want, err.Error())
+	}
+}
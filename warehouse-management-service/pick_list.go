@@ -0,0 +1,76 @@
+package wms
+
+import (
+	"sort"
+	"time"
+)
+
+// PickListEntry is one item to pick for an order line, and where it is.
+type PickListEntry struct {
+	Sequence       int        `json:"sequence"`
+	OrderLineId    string     `json:"orderLineId"`
+	ItemId         string     `json:"itemId"`
+	Sku            string     `json:"sku"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	ReceivedOn     time.Time  `json:"receivedOn"`
+	ShelfId        string     `json:"shelfId"`
+	ShelfLabel     string     `json:"shelfLabel"`
+	Aisle          string     `json:"aisle"`
+	Rack           string     `json:"rack"`
+	Section        string     `json:"section"`
+	Level          string     `json:"level"`
+}
+
+type PickList struct {
+	OrderId string          `json:"orderId"`
+	Entries []PickListEntry `json:"entries"`
+}
+
+// NewPickList orders entries into a walk through the warehouse and numbers
+// them from 1. Aisles are walked in order, going up the racks of one aisle
+// and back down the racks of the next so that the picker never doubles back
+// along an aisle; each shelf is picked section by section, bottom level
+// first.
+func NewPickList(orderId string, entries []PickListEntry) PickList {
+	route := make([]PickListEntry, len(entries))
+	copy(route, entries)
+
+	aisles := []string{}
+	seen := map[string]bool{}
+	for _, entry := range route {
+		if !seen[entry.Aisle] {
+			seen[entry.Aisle] = true
+			aisles = append(aisles, entry.Aisle)
+		}
+	}
+	sort.Slice(aisles, func(i, j int) bool { return lessLabel(aisles[i], aisles[j]) })
+	aisleOrder := make(map[string]int, len(aisles))
+	for i, aisle := range aisles {
+		aisleOrder[aisle] = i
+	}
+
+	sort.SliceStable(route, func(i, j int) bool {
+		a, b := route[i], route[j]
+		if a.Aisle != b.Aisle {
+			return aisleOrder[a.Aisle] < aisleOrder[b.Aisle]
+		}
+		if a.Rack != b.Rack {
+			if aisleOrder[a.Aisle]%2 == 1 {
+				return lessLabel(b.Rack, a.Rack)
+			}
+			return lessLabel(a.Rack, b.Rack)
+		}
+		if a.Section != b.Section {
+			return lessLabel(a.Section, b.Section)
+		}
+		if a.Level != b.Level {
+			return lessLabel(a.Level, b.Level)
+		}
+		return a.ItemId < b.ItemId
+	})
+
+	for i := range route {
+		route[i].Sequence = i + 1
+	}
+	return PickList{OrderId: orderId, Entries: route}
+}
@@ -0,0 +1,29 @@
+package wms
+
+import "testing"
+
+func TestNewPickList(t *testing.T) {
+	entries := []PickListEntry{
+		{ItemId: "a2-r2", Aisle: "2", Rack: "R2", Section: "1", Level: "1"},
+		{ItemId: "a10-r1", Aisle: "10", Rack: "R1", Section: "1", Level: "1"},
+		{ItemId: "a1-r2", Aisle: "1", Rack: "R2", Section: "1", Level: "1"},
+		{ItemId: "a2-r1", Aisle: "2", Rack: "R1", Section: "1", Level: "1"},
+		{ItemId: "a1-r1-l2", Aisle: "1", Rack: "R1", Section: "1", Level: "2"},
+		{ItemId: "a1-r1-l1", Aisle: "1", Rack: "R1", Section: "1", Level: "1"},
+		{ItemId: "a1-r1-s2", Aisle: "1", Rack: "R1", Section: "2", Level: "1"},
+	}
+
+	pickList := NewPickList("o", entries)
+	want := []string{"a1-r1-l1", "a1-r1-l2", "a1-r1-s2", "a1-r2", "a2-r2", "a2-r1", "a10-r1"}
+	if pickList.OrderId != "o" || len(pickList.Entries) != len(want) {
+		t.Fatalf("want %d entries for order o, got: %v", len(want), pickList)
+	}
+	for i, entry := range pickList.Entries {
+		if entry.ItemId != want[i] || entry.Sequence != i+1 {
+			t.Errorf("stop %d: want: %v, got: %v (sequence %d)", i+1, want[i], entry.ItemId, entry.Sequence)
+		}
+	}
+	if entries[0].Sequence != 0 {
+		t.Errorf("want the entries passed in left unchanged, got: %v", entries[0])
+	}
+}
@@ -0,0 +1,5 @@
+package api
+
+type AdminResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"time"
+	wms "warehouse-management-service"
+)
+
+type CreateASNRequest struct {
+	Supplier     string                 `json:"supplier" validate:"nonzero"`
+	WarehouseId  string                 `json:"warehouseId" validate:"nonzero"`
+	ExpectedDate *time.Time             `json:"expectedDate" validate:"nonzero"`
+	Lines        []CreateASNLineRequest `json:"lines" validate:"min=1"`
+}
+
+type CreateASNLineRequest struct {
+	Sku              string `json:"sku" validate:"nonzero"`
+	ExpectedQuantity int    `json:"expectedQuantity" validate:"min=1"`
+}
+
+type ReceiveASNLineRequest struct {
+	Quantity       int        `json:"quantity" validate:"min=1,max=10000"`
+	ShelfId        string     `json:"shelfId" validate:"nonzero"`
+	ExpirationDate *time.Time `json:"expirationDate"`
+	Lot            string     `json:"lot"`
+	// SerialNumbers gives every item received its own serial number, in
+	// the order the items are returned.
+	SerialNumbers []string `json:"serialNumbers" validate:"max=10000"`
+}
+
+type ASNResponse struct {
+	Response *wms.ASN `json:"response,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	// Discrepancies lists the lines of a closed ASN that were received
+	// short or over.
+	Discrepancies []wms.ASNLine `json:"discrepancies,omitempty"`
+}
+
+type ReceiveASNLineResponse struct {
+	Response *wms.ASNLine `json:"response,omitempty"`
+	Items    []wms.Item   `json:"items,omitempty"`
+	Error    string       `json:"error,omitempty"`
+}
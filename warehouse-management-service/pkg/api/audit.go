@@ -0,0 +1,11 @@
+package api
+
+import (
+	wms "warehouse-management-service"
+)
+
+type ListAuditEventsResponse struct {
+	Response   []wms.AuditEvent `json:"response"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
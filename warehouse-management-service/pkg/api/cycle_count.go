@@ -0,0 +1,44 @@
+package api
+
+import wms "warehouse-management-service"
+
+// CreateCycleCountRequest counts either a shelf or a shelf block.
+type CreateCycleCountRequest struct {
+	ShelfId      string `json:"shelfId"`
+	ShelfBlockId string `json:"shelfBlockId"`
+}
+
+type SubmitCountsRequest struct {
+	Counts []SubmitCountRequest `json:"counts" validate:"min=1,max=1000"`
+}
+
+// SubmitCountRequest is the number of items of a sku counted on a shelf. The
+// shelf may be left out when counting a single shelf.
+type SubmitCountRequest struct {
+	ShelfId  string `json:"shelfId"`
+	Sku      string `json:"sku" validate:"nonzero"`
+	Quantity int    `json:"quantity" validate:"min=0,max=100000"`
+}
+
+// ApproveCycleCountRequest gives the reason code recorded for every
+// adjustment, unless Reasons has another for its shelf and sku.
+type ApproveCycleCountRequest struct {
+	Reason  string                    `json:"reason" validate:"nonzero"`
+	Reasons []AdjustmentReasonRequest `json:"reasons"`
+}
+
+type AdjustmentReasonRequest struct {
+	ShelfId string `json:"shelfId" validate:"nonzero"`
+	Sku     string `json:"sku" validate:"nonzero"`
+	Reason  string `json:"reason" validate:"nonzero"`
+}
+
+type CycleCountResponse struct {
+	Response *wms.CycleCount `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+type VarianceReportResponse struct {
+	Response []wms.Variance `json:"response"`
+	Error    string         `json:"error,omitempty"`
+}
@@ -0,0 +1,8 @@
+package api
+
+import wms "warehouse-management-service"
+
+type DependentsResponse struct {
+	Error      string          `json:"error"`
+	Dependents []wms.Dependent `json:"dependents"`
+}
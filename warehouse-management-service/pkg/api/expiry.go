@@ -0,0 +1,8 @@
+package api
+
+import wms "warehouse-management-service"
+
+type ListExpiringItemsResponse struct {
+	Response []wms.ExpiringItem `json:"response"`
+	Error    string             `json:"error,omitempty"`
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"time"
+	wms "warehouse-management-service"
+)
+
+type CreateItemRequest struct {
+	Sku            string     `json:"sku" validate:"nonzero"`
+	ExpirationDate *time.Time `json:"expirationDate"`
+	ShelfId        string     `json:"shelfId" validate:"nonzero"`
+	Lot            string     `json:"lot"`
+	SerialNumber   string     `json:"serialNumber"`
+}
+
+type MoveItemRequest struct {
+	Id      string `json:"id" validate:"nonzero"`
+	ShelfId string `json:"shelfId" validate:"nonzero"`
+}
+
+type GetItemResponse struct {
+	Response wms.Item `json:"response,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+type ItemResponse struct {
+	Response string `json:"response,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
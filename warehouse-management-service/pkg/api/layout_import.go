@@ -0,0 +1,9 @@
+package api
+
+import wms "warehouse-management-service"
+
+type ImportLayoutResponse struct {
+	Response wms.LayoutImport           `json:"response,omitempty"`
+	Error    string                     `json:"error,omitempty"`
+	Rows     []wms.LayoutImportRowError `json:"rows,omitempty"`
+}
@@ -0,0 +1,8 @@
+package api
+
+import wms "warehouse-management-service"
+
+type LotRecallResponse struct {
+	Response *wms.LotRecall `json:"response,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
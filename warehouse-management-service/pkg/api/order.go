@@ -0,0 +1,26 @@
+package api
+
+import wms "warehouse-management-service"
+
+type CreateOrderRequest struct {
+	Customer    string                   `json:"customer" validate:"nonzero"`
+	WarehouseId string                   `json:"warehouseId" validate:"nonzero"`
+	Lines       []CreateOrderLineRequest `json:"lines" validate:"min=1"`
+}
+
+type CreateOrderLineRequest struct {
+	Sku      string `json:"sku" validate:"nonzero"`
+	Quantity int    `json:"quantity" validate:"min=1,max=10000"`
+}
+
+type OrderResponse struct {
+	Response *wms.Order `json:"response,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+type PickListResponse struct {
+	Response *wms.PickList `json:"response,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	// Shortages lists the skus that kept a pick list from being generated.
+	Shortages []wms.StockShortage `json:"shortages,omitempty"`
+}
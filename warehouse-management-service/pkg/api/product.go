@@ -0,0 +1,42 @@
+package api
+
+import (
+	wms "warehouse-management-service"
+)
+
+type CreateProductRequest struct {
+	Name        string  `json:"name" validate:"nonzero"`
+	MRP         float64 `json:"mrp" validate:"min=0"`
+	Variant     string  `json:"variant"`
+	LengthInCm  float64 `json:"lengthInCm" validate:"min=0"`
+	WidthInCm   float64 `json:"widthInCm" validate:"min=0"`
+	BreadthInCm float64 `json:"breadthInCm" validate:"min=0"`
+	WeightInKg  float64 `json:"weightInKg" validate:"min=0"`
+	Perishable  bool    `json:"perishable"`
+	LotTracked  bool    `json:"lotTracked"`
+	Serialized  bool    `json:"serialized"`
+}
+
+type UpdateProductRequest struct {
+	Sku         string  `json:"sku" validate:"nonzero"`
+	Name        string  `json:"name" validate:"nonzero"`
+	MRP         float64 `json:"mrp" validate:"min=0"`
+	Variant     string  `json:"variant"`
+	LengthInCm  float64 `json:"lengthInCm" validate:"min=0"`
+	WidthInCm   float64 `json:"widthInCm" validate:"min=0"`
+	BreadthInCm float64 `json:"breadthInCm" validate:"min=0"`
+	WeightInKg  float64 `json:"weightInKg" validate:"min=0"`
+	Perishable  bool    `json:"perishable"`
+	LotTracked  bool    `json:"lotTracked"`
+	Serialized  bool    `json:"serialized"`
+}
+
+type GetProductResponse struct {
+	Response wms.Product `json:"response,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+type ProductResponse struct {
+	Response string `json:"response,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,16 @@
+package api
+
+import wms "warehouse-management-service"
+
+type SuggestPutawayRequest struct {
+	WarehouseId string `json:"warehouseId" validate:"nonzero"`
+	Sku         string `json:"sku" validate:"nonzero"`
+	Quantity    int    `json:"quantity" validate:"min=1"`
+	// Limit caps the number of suggestions, 5 when left out.
+	Limit int `json:"limit" validate:"min=0,max=50"`
+}
+
+type SuggestPutawayResponse struct {
+	Response []wms.PutawaySuggestion `json:"response"`
+	Error    string                  `json:"error,omitempty"`
+}
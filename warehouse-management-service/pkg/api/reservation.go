@@ -0,0 +1,20 @@
+package api
+
+import wms "warehouse-management-service"
+
+type CreateReservationRequest struct {
+	WarehouseId string `json:"warehouseId" validate:"nonzero"`
+	Sku         string `json:"sku" validate:"nonzero"`
+	Quantity    int    `json:"quantity" validate:"min=1,max=10000"`
+	Reference   string `json:"reference"`
+	// TTLSeconds is how long the reservation is held for unless confirmed,
+	// wms.DefaultReservationTTL when 0. At most a week.
+	TTLSeconds int `json:"ttlSeconds" validate:"min=0,max=604800"`
+}
+
+type ReservationResponse struct {
+	Response *wms.Reservation `json:"response,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	// Shortages says how much of the sku could have been reserved.
+	Shortages []wms.StockShortage `json:"shortages,omitempty"`
+}
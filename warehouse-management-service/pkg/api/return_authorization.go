@@ -0,0 +1,29 @@
+package api
+
+import wms "warehouse-management-service"
+
+type CreateReturnRequest struct {
+	ShipmentId string   `json:"shipmentId" validate:"nonzero"`
+	Reason     string   `json:"reason" validate:"nonzero"`
+	ItemIds    []string `json:"itemIds" validate:"min=1,max=1000"`
+}
+
+type InspectReturnItemRequest struct {
+	Condition string `json:"condition" validate:"nonzero"`
+	Notes     string `json:"notes"`
+}
+
+type ResolveReturnItemRequest struct {
+	Outcome string `json:"outcome" validate:"nonzero"`
+	ShelfId string `json:"shelfId"`
+}
+
+type ReturnResponse struct {
+	Response *wms.ReturnAuthorization `json:"response,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+type ReturnItemResponse struct {
+	Response *wms.ReturnItem `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
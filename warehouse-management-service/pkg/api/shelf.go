@@ -18,7 +18,14 @@ type UpdateShelfRequest struct {
 }
 
 type ShelfResponse struct {
-	Message  string    `json:"message,omitempty"`
-	Response wms.Shelf `json:"response,omitempty"`
-	Error    string    `json:"error,omitempty"`
+	Message     string                `json:"message,omitempty"`
+	Response    wms.Shelf             `json:"response,omitempty"`
+	Utilization *wms.ShelfUtilization `json:"utilization,omitempty"`
+	Error       string                `json:"error,omitempty"`
+}
+
+type ListShelvesResponse struct {
+	Response   []wms.Shelf `json:"response"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+	Error      string      `json:"error,omitempty"`
 }
@@ -28,3 +28,9 @@ type ShelfBlockResponse struct {
 	Response string `json:"response,omitempty"`
 	Error    string `json:"error,omitempty"`
 }
+
+type ListShelfBlocksResponse struct {
+	Response   []wms.ShelfBlock `json:"response"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
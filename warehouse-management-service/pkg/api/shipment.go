@@ -0,0 +1,23 @@
+package api
+
+import wms "warehouse-management-service"
+
+type CreateShipmentRequest struct {
+	OrderId string `json:"orderId" validate:"nonzero"`
+	Carrier string `json:"carrier" validate:"nonzero"`
+}
+
+type PackItemsRequest struct {
+	TrackingNumber string   `json:"trackingNumber" validate:"nonzero"`
+	ItemIds        []string `json:"itemIds" validate:"min=1,max=1000"`
+}
+
+type ShipmentResponse struct {
+	Response *wms.Shipment `json:"response,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+type PackageResponse struct {
+	Response *wms.Package `json:"response,omitempty"`
+	Error    string       `json:"error,omitempty"`
+}
@@ -0,0 +1,13 @@
+package api
+
+import wms "warehouse-management-service"
+
+type ProductStockResponse struct {
+	Response *wms.ProductStock `json:"response,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type WarehouseInventoryResponse struct {
+	Response *wms.WarehouseInventory `json:"response,omitempty"`
+	Error    string                  `json:"error,omitempty"`
+}
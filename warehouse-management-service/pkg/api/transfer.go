@@ -0,0 +1,20 @@
+package api
+
+import wms "warehouse-management-service"
+
+// CreateTransferRequest moves either the items ItemIds, or Quantity items of
+// Sku from the shelf FromShelfId, onto ToShelfId.
+type CreateTransferRequest struct {
+	ToShelfId   string   `json:"toShelfId" validate:"nonzero"`
+	ItemIds     []string `json:"itemIds" validate:"max=10000"`
+	FromShelfId string   `json:"fromShelfId"`
+	Sku         string   `json:"sku"`
+	Quantity    int      `json:"quantity" validate:"min=0,max=10000"`
+}
+
+type TransferResponse struct {
+	Response *wms.Transfer `json:"response,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	// Shortages says how many items of the sku could have been moved.
+	Shortages []wms.StockShortage `json:"shortages,omitempty"`
+}
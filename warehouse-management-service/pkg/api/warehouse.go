@@ -26,3 +26,19 @@ type UpdateWarehouseRequest struct {
 	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
 	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
 }
+
+type ListWarehousesResponse struct {
+	Response   []warehousemanagementservice.Warehouse `json:"response"`
+	NextCursor string                                 `json:"nextCursor,omitempty"`
+	Error      string                                 `json:"error,omitempty"`
+}
+
+type NearestWarehousesResponse struct {
+	Response []warehousemanagementservice.NearbyWarehouse `json:"response"`
+	Error    string                                       `json:"error,omitempty"`
+}
+
+type GetWarehouseLayoutResponse struct {
+	Response warehousemanagementservice.WarehouseLayout `json:"response,omitempty"`
+	Error    string                                     `json:"error,omitempty"`
+}
@@ -0,0 +1,328 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/asn.go" -destination="./pkg/database/postgres/asn_mock.go"
+type asnQueries interface {
+	createASNTx(ctx context.Context, tx *sql.Tx, asn wms.ASN) error
+	getASNByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.ASN, error)
+	lockASNTx(ctx context.Context, tx *sql.Tx, id string) (wms.ASN, error)
+	closeASNTx(ctx context.Context, tx *sql.Tx, id string) error
+	receiveASNLineTx(ctx context.Context, tx *sql.Tx, asnId string, lineId string, quantity int) (wms.ASNLine, error)
+	shelfInWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string, warehouseId string) (bool, error)
+	createASNItemTx(ctx context.Context, tx *sql.Tx, lineId string, item wms.Item) error
+	checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) error
+	checkTrackingTx(ctx context.Context, tx *sql.Tx, sku string, quantity int, lot string, serialNumbers []string) error
+	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error)
+}
+
+// asnQueriesImpl borrows the existence and capacity checks of the shelf
+// block and item queries.
+type asnQueriesImpl struct {
+	shelfBlockQueriesImpl
+	itemQueriesImpl
+}
+
+type ASNService struct {
+	queries asnQueries
+	db      *sql.DB
+}
+
+func NewASNService(db *sql.DB) *ASNService {
+	return &ASNService{
+		queries: new(asnQueriesImpl),
+		db:      db,
+	}
+}
+
+func (a *ASNService) CreateASN(ctx context.Context, asn wms.ASN) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if warehouseExists, err := a.queries.warehouseExistsTx(ctx, tx, asn.WarehouseId); err != nil {
+		return err
+	} else if !warehouseExists {
+		return wms.WarehouseDoesNotExist
+	}
+	for _, line := range asn.Lines {
+		if productExists, err := a.queries.productExistsTx(ctx, tx, line.Sku); err != nil {
+			return err
+		} else if !productExists {
+			return wms.InvalidProduct
+		}
+	}
+
+	err = a.queries.createASNTx(ctx, tx, asn)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (a *ASNService) GetASNById(ctx context.Context, id string) (wms.ASN, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.ASN{}, err
+	}
+	defer tx.Rollback()
+
+	asn, err := a.queries.getASNByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return asn, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.ASN{}, wms.ASNDoesNotExist
+	default:
+		return wms.ASN{}, err
+	}
+}
+
+// ReceiveASNLine records quantity more units of an ASN line as received onto
+// a shelf of the ASN's warehouse, creating one item per unit. Receiving past
+// the expected quantity is allowed, the line then reports itself as over.
+// Every item takes lot, which lot tracked products cannot be received
+// without, and a serial number of its own from serialNumbers, which
+// serialized products need one of per item.
+func (a *ASNService) ReceiveASNLine(
+	ctx context.Context,
+	asnId string,
+	lineId string,
+	quantity int,
+	shelfId string,
+	expirationDate *time.Time,
+	lot string,
+	serialNumbers []string,
+) (wms.ASNLine, []wms.Item, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.ASNLine{}, nil, err
+	}
+	defer tx.Rollback()
+
+	asn, err := a.queries.lockASNTx(ctx, tx, asnId)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.ASNLine{}, nil, wms.ASNDoesNotExist
+	default:
+		return wms.ASNLine{}, nil, err
+	}
+	if asn.Status != wms.ASNOpen {
+		return wms.ASNLine{}, nil, wms.ASNClosedForReceipt
+	}
+
+	if inWarehouse, err := a.queries.shelfInWarehouseTx(ctx, tx, shelfId, asn.WarehouseId); err != nil {
+		return wms.ASNLine{}, nil, err
+	} else if !inWarehouse {
+		return wms.ASNLine{}, nil, wms.InvalidShelf
+	}
+
+	line, err := a.queries.receiveASNLineTx(ctx, tx, asnId, lineId, quantity)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.ASNLine{}, nil, wms.ASNLineDoesNotExist
+	default:
+		return wms.ASNLine{}, nil, err
+	}
+
+	err = a.queries.checkTrackingTx(ctx, tx, line.Sku, quantity, lot, serialNumbers)
+	if err != nil {
+		return wms.ASNLine{}, nil, err
+	}
+	err = a.queries.checkShelfCapacityTx(ctx, tx, shelfId, line.Sku, quantity)
+	if err != nil {
+		return wms.ASNLine{}, nil, err
+	}
+
+	items := make([]wms.Item, 0, quantity)
+	for i := 0; i < quantity; i++ {
+		var serialNumber string
+		if len(serialNumbers) > 0 {
+			serialNumber = serialNumbers[i]
+		}
+		item := wms.NewItem(line.Sku, expirationDate, shelfId, lot, serialNumber)
+		err = a.queries.createASNItemTx(ctx, tx, line.Id, item)
+		if err != nil {
+			return wms.ASNLine{}, nil, err
+		}
+		items = append(items, item)
+	}
+
+	return line, items, tx.Commit()
+}
+
+// CloseASN stops an ASN from taking further receipts and returns it, so the
+// caller can see which lines arrived short or over.
+func (a *ASNService) CloseASN(ctx context.Context, id string) (wms.ASN, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.ASN{}, err
+	}
+	defer tx.Rollback()
+
+	asn, err := a.queries.lockASNTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.ASN{}, wms.ASNDoesNotExist
+	default:
+		return wms.ASN{}, err
+	}
+	if asn.Status != wms.ASNOpen {
+		return wms.ASN{}, wms.ASNClosedForReceipt
+	}
+
+	err = a.queries.closeASNTx(ctx, tx, id)
+	if err != nil {
+		return wms.ASN{}, err
+	}
+
+	asn, err = a.queries.getASNByIdTx(ctx, tx, id)
+	if err != nil {
+		return wms.ASN{}, err
+	}
+	return asn, tx.Commit()
+}
+
+func (a *asnQueriesImpl) createASNTx(ctx context.Context, tx *sql.Tx, asn wms.ASN) error {
+	query := `INSERT INTO asn(id, supplier, warehouse_id, expected_date, status, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		asn.Id,
+		asn.Supplier,
+		asn.WarehouseId,
+		asn.ExpectedDate,
+		asn.Status,
+		asn.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO asn_line(id, asn_id, sku, expected_quantity, received_quantity) VALUES ($1, $2, $3, $4, $5)`
+	for _, line := range asn.Lines {
+		_, err = tx.ExecContext(ctx, query, line.Id, line.AsnId, line.Sku, line.ExpectedQuantity, line.ReceivedQuantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *asnQueriesImpl) getASNByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.ASN, error) {
+	asn, err := a.scanASN(tx.QueryRowContext(
+		ctx,
+		`SELECT id, supplier, warehouse_id, expected_date, status, created_at FROM asn WHERE id = $1`,
+		id,
+	))
+	if err != nil {
+		return wms.ASN{}, err
+	}
+
+	query := `SELECT id, asn_id, sku, expected_quantity, received_quantity FROM asn_line WHERE asn_id = $1 ORDER BY sku`
+
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return wms.ASN{}, err
+	}
+	defer rows.Close()
+
+	asn.Lines = []wms.ASNLine{}
+	for rows.Next() {
+		var line wms.ASNLine
+		err := rows.Scan(&line.Id, &line.AsnId, &line.Sku, &line.ExpectedQuantity, &line.ReceivedQuantity)
+		if err != nil {
+			return wms.ASN{}, err
+		}
+		line.SetReceiptStatus()
+		asn.Lines = append(asn.Lines, line)
+	}
+
+	return asn, rows.Err()
+}
+
+// lockASNTx returns an ASN without its lines and locks its row, so that it
+// cannot be closed while a receipt against it is in progress.
+func (a *asnQueriesImpl) lockASNTx(ctx context.Context, tx *sql.Tx, id string) (wms.ASN, error) {
+	return a.scanASN(tx.QueryRowContext(
+		ctx,
+		`SELECT id, supplier, warehouse_id, expected_date, status, created_at FROM asn WHERE id = $1 FOR UPDATE`,
+		id,
+	))
+}
+
+func (a *asnQueriesImpl) scanASN(row *sql.Row) (wms.ASN, error) {
+	var asn wms.ASN
+	err := row.Scan(&asn.Id, &asn.Supplier, &asn.WarehouseId, &asn.ExpectedDate, &asn.Status, &asn.CreatedAt)
+	if err != nil {
+		return wms.ASN{}, err
+	}
+	return asn, nil
+}
+
+func (a *asnQueriesImpl) closeASNTx(ctx context.Context, tx *sql.Tx, id string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE asn SET status = $1 WHERE id = $2`, wms.ASNClosed, id)
+	return err
+}
+
+func (a *asnQueriesImpl) receiveASNLineTx(ctx context.Context, tx *sql.Tx, asnId string, lineId string, quantity int) (wms.ASNLine, error) {
+	query := `UPDATE asn_line SET received_quantity = received_quantity + $1 WHERE id = $2 AND asn_id = $3
+		RETURNING id, asn_id, sku, expected_quantity, received_quantity`
+
+	var line wms.ASNLine
+	row := tx.QueryRowContext(ctx, query, quantity, lineId, asnId)
+	err := row.Scan(&line.Id, &line.AsnId, &line.Sku, &line.ExpectedQuantity, &line.ReceivedQuantity)
+	if err != nil {
+		return wms.ASNLine{}, err
+	}
+	line.SetReceiptStatus()
+	return line, nil
+}
+
+func (a *asnQueriesImpl) shelfInWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string, warehouseId string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM shelf JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		WHERE shelf.id = $1 AND shelf_block.warehouse_id = $2 AND shelf.deleted_at IS NULL AND shelf_block.deleted_at IS NULL)`
+
+	var exists bool
+	row := tx.QueryRowContext(ctx, query, shelfId, warehouseId)
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (a *asnQueriesImpl) createASNItemTx(ctx context.Context, tx *sql.Tx, lineId string, item wms.Item) error {
+	query := `INSERT INTO item(id, sku, expiration_date, received_on, shelf_id, asn_line_id, lot, serial_number)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''))`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		item.Id,
+		item.Sku,
+		item.ExpirationDate,
+		item.ReceivedOn,
+		item.ShelfId,
+		lineId,
+		item.Lot,
+		item.SerialNumber,
+	)
+	if isUniqueViolation(err, itemSerialNumberIndex) {
+		return wms.DuplicateSerialNumber
+	}
+	return err
+}
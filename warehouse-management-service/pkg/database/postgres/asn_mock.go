@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/asn.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockasnQueries is a mock of asnQueries interface.
+type MockasnQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockasnQueriesMockRecorder
+}
+
+// MockasnQueriesMockRecorder is the mock recorder for MockasnQueries.
+type MockasnQueriesMockRecorder struct {
+	mock *MockasnQueries
+}
+
+// NewMockasnQueries creates a new mock instance.
+func NewMockasnQueries(ctrl *gomock.Controller) *MockasnQueries {
+	mock := &MockasnQueries{ctrl: ctrl}
+	mock.recorder = &MockasnQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockasnQueries) EXPECT() *MockasnQueriesMockRecorder {
+	return m.recorder
+}
+
+// checkShelfCapacityTx mocks base method.
+func (m *MockasnQueries) checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId, sku string, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkShelfCapacityTx", ctx, tx, shelfId, sku, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// checkShelfCapacityTx indicates an expected call of checkShelfCapacityTx.
+func (mr *MockasnQueriesMockRecorder) checkShelfCapacityTx(ctx, tx, shelfId, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkShelfCapacityTx", reflect.TypeOf((*MockasnQueries)(nil).checkShelfCapacityTx), ctx, tx, shelfId, sku, quantity)
+}
+
+// checkTrackingTx mocks base method.
+func (m *MockasnQueries) checkTrackingTx(ctx context.Context, tx *sql.Tx, sku string, quantity int, lot string, serialNumbers []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkTrackingTx", ctx, tx, sku, quantity, lot, serialNumbers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// checkTrackingTx indicates an expected call of checkTrackingTx.
+func (mr *MockasnQueriesMockRecorder) checkTrackingTx(ctx, tx, sku, quantity, lot, serialNumbers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkTrackingTx", reflect.TypeOf((*MockasnQueries)(nil).checkTrackingTx), ctx, tx, sku, quantity, lot, serialNumbers)
+}
+
+// closeASNTx mocks base method.
+func (m *MockasnQueries) closeASNTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "closeASNTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// closeASNTx indicates an expected call of closeASNTx.
+func (mr *MockasnQueriesMockRecorder) closeASNTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "closeASNTx", reflect.TypeOf((*MockasnQueries)(nil).closeASNTx), ctx, tx, id)
+}
+
+// createASNItemTx mocks base method.
+func (m *MockasnQueries) createASNItemTx(ctx context.Context, tx *sql.Tx, lineId string, item warehousemanagementservice.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createASNItemTx", ctx, tx, lineId, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createASNItemTx indicates an expected call of createASNItemTx.
+func (mr *MockasnQueriesMockRecorder) createASNItemTx(ctx, tx, lineId, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createASNItemTx", reflect.TypeOf((*MockasnQueries)(nil).createASNItemTx), ctx, tx, lineId, item)
+}
+
+// createASNTx mocks base method.
+func (m *MockasnQueries) createASNTx(ctx context.Context, tx *sql.Tx, asn warehousemanagementservice.ASN) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createASNTx", ctx, tx, asn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createASNTx indicates an expected call of createASNTx.
+func (mr *MockasnQueriesMockRecorder) createASNTx(ctx, tx, asn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createASNTx", reflect.TypeOf((*MockasnQueries)(nil).createASNTx), ctx, tx, asn)
+}
+
+// getASNByIdTx mocks base method.
+func (m *MockasnQueries) getASNByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.ASN, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getASNByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ASN)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getASNByIdTx indicates an expected call of getASNByIdTx.
+func (mr *MockasnQueriesMockRecorder) getASNByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getASNByIdTx", reflect.TypeOf((*MockasnQueries)(nil).getASNByIdTx), ctx, tx, id)
+}
+
+// lockASNTx mocks base method.
+func (m *MockasnQueries) lockASNTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.ASN, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockASNTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ASN)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockASNTx indicates an expected call of lockASNTx.
+func (mr *MockasnQueriesMockRecorder) lockASNTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockASNTx", reflect.TypeOf((*MockasnQueries)(nil).lockASNTx), ctx, tx, id)
+}
+
+// productExistsTx mocks base method.
+func (m *MockasnQueries) productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "productExistsTx", ctx, tx, sku)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// productExistsTx indicates an expected call of productExistsTx.
+func (mr *MockasnQueriesMockRecorder) productExistsTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "productExistsTx", reflect.TypeOf((*MockasnQueries)(nil).productExistsTx), ctx, tx, sku)
+}
+
+// receiveASNLineTx mocks base method.
+func (m *MockasnQueries) receiveASNLineTx(ctx context.Context, tx *sql.Tx, asnId, lineId string, quantity int) (warehousemanagementservice.ASNLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "receiveASNLineTx", ctx, tx, asnId, lineId, quantity)
+	ret0, _ := ret[0].(warehousemanagementservice.ASNLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// receiveASNLineTx indicates an expected call of receiveASNLineTx.
+func (mr *MockasnQueriesMockRecorder) receiveASNLineTx(ctx, tx, asnId, lineId, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "receiveASNLineTx", reflect.TypeOf((*MockasnQueries)(nil).receiveASNLineTx), ctx, tx, asnId, lineId, quantity)
+}
+
+// shelfInWarehouseTx mocks base method.
+func (m *MockasnQueries) shelfInWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId, warehouseId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfInWarehouseTx", ctx, tx, shelfId, warehouseId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfInWarehouseTx indicates an expected call of shelfInWarehouseTx.
+func (mr *MockasnQueriesMockRecorder) shelfInWarehouseTx(ctx, tx, shelfId, warehouseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfInWarehouseTx", reflect.TypeOf((*MockasnQueries)(nil).shelfInWarehouseTx), ctx, tx, shelfId, warehouseId)
+}
+
+// warehouseExistsTx mocks base method.
+func (m *MockasnQueries) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseExistsTx indicates an expected call of warehouseExistsTx.
+func (mr *MockasnQueriesMockRecorder) warehouseExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseExistsTx", reflect.TypeOf((*MockasnQueries)(nil).warehouseExistsTx), ctx, tx, id)
+}
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func testASN() wms.ASN {
+	asn := wms.NewASN(
+		"acme",
+		fixtureWarehouse.Id,
+		time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+		[]wms.ASNLine{{Sku: testProduct.Sku, ExpectedQuantity: 3}},
+	)
+	asn.CreatedAt = time.Date(2023, time.January, 20, 8, 0, 0, 0, time.UTC)
+	return asn
+}
+
+func TestReceiveASNLineTx(t *testing.T) {
+	ctx := context.Background()
+	asn := testASN()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := asnQueriesImpl{}
+	err = queries.createASNTx(ctx, tx, asn)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	inWarehouse, err := queries.shelfInWarehouseTx(ctx, tx, fixtureShelf.Id, asn.WarehouseId)
+	if err != nil || !inWarehouse {
+		t.Errorf("expected shelf %s in warehouse %s, got: %v, %v", fixtureShelf.Id, asn.WarehouseId, inWarehouse, err)
+	}
+
+	line, err := queries.receiveASNLineTx(ctx, tx, asn.Id, asn.Lines[0].Id, 2)
+	if err != nil || line.ReceivedQuantity != 2 || line.ReceiptStatus != wms.LineShort {
+		t.Errorf("expected 2 received and short, got: %v, %v", line, err)
+	}
+	err = queries.createASNItemTx(ctx, tx, line.Id, wms.NewItem(line.Sku, nil, fixtureShelf.Id, "", ""))
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = queries.receiveASNLineTx(ctx, tx, asn.Id, "missing", 1)
+	if err != sql.ErrNoRows {
+		t.Errorf("want: %v, got: %v", sql.ErrNoRows, err)
+	}
+
+	err = queries.closeASNTx(ctx, tx, asn.Id)
+	if err != nil {
+		t.Error(err)
+	}
+	asnFromDB, err := queries.getASNByIdTx(ctx, tx, asn.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if asnFromDB.Status != wms.ASNClosed ||
+		!asnFromDB.ExpectedDate.Equal(asn.ExpectedDate) ||
+		len(asnFromDB.Lines) != 1 ||
+		asnFromDB.Lines[0].ReceivedQuantity != 2 {
+		t.Errorf("expected closed asn with 2 received, got: %v", asnFromDB)
+	}
+}
+
+func TestReceiveASNLine(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockasnQueries(mockCtrl)
+
+	as := ASNService{db: warehouseService.db, queries: mockObj}
+	open := wms.ASN{Id: "a", WarehouseId: "w", Status: wms.ASNOpen}
+	line := wms.ASNLine{Id: "l", AsnId: "a", Sku: "sku", ExpectedQuantity: 2, ReceivedQuantity: 3, ReceiptStatus: wms.LineOver}
+	capacityErr := &wms.ShelfCapacityError{ShelfId: "s", Exceeded: []string{"4 of 3 items"}}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockASNTx(ctx, gomock.Any(), "a").Return(open, nil),
+		mockObj.EXPECT().shelfInWarehouseTx(ctx, gomock.Any(), "s", "w").Return(true, nil),
+		mockObj.EXPECT().receiveASNLineTx(ctx, gomock.Any(), "a", "l", 3).Return(line, nil),
+		mockObj.EXPECT().checkTrackingTx(ctx, gomock.Any(), "sku", 3, "L1", nil).Return(nil),
+		mockObj.EXPECT().checkShelfCapacityTx(ctx, gomock.Any(), "s", "sku", 3).Return(nil),
+		mockObj.EXPECT().createASNItemTx(ctx, gomock.Any(), "l", gomock.Any()).Return(nil).Times(3),
+	)
+	gotLine, items, err := as.ReceiveASNLine(ctx, "a", "l", 3, "s", nil, "L1", nil)
+	if err != nil || gotLine != line || len(items) != 3 {
+		t.Errorf("expected: %v and 3 items, got: %v, %v, %v", line, gotLine, items, err)
+	}
+	for _, item := range items {
+		if item.Lot != "L1" {
+			t.Errorf("want: item of lot L1, got: %v", item)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		expect  func()
+		wantErr error
+	}{
+		{
+			name: "missing asn",
+			expect: func() {
+				mockObj.EXPECT().lockASNTx(ctx, gomock.Any(), "a").Return(wms.ASN{}, sql.ErrNoRows)
+			},
+			wantErr: wms.ASNDoesNotExist,
+		},
+		{
+			name: "closed asn",
+			expect: func() {
+				mockObj.EXPECT().lockASNTx(ctx, gomock.Any(), "a").Return(wms.ASN{Id: "a", Status: wms.ASNClosed}, nil)
+			},
+			wantErr: wms.ASNClosedForReceipt,
+		},
+		{
+			name: "shelf in another warehouse",
+			expect: func() {
+				mockObj.EXPECT().lockASNTx(ctx, gomock.Any(), "a").Return(open, nil)
+				mockObj.EXPECT().shelfInWarehouseTx(ctx, gomock.Any(), "s", "w").Return(false, nil)
+			},
+			wantErr: wms.InvalidShelf,
+		},
+		{
+			name: "missing line",
+			expect: func() {
+				mockObj.EXPECT().lockASNTx(ctx, gomock.Any(), "a").Return(open, nil)
+				mockObj.EXPECT().shelfInWarehouseTx(ctx, gomock.Any(), "s", "w").Return(true, nil)
+				mockObj.EXPECT().receiveASNLineTx(ctx, gomock.Any(), "a", "l", 3).Return(wms.ASNLine{}, sql.ErrNoRows)
+			},
+			wantErr: wms.ASNLineDoesNotExist,
+		},
+		{
+			name: "lot tracked product without a lot",
+			expect: func() {
+				mockObj.EXPECT().lockASNTx(ctx, gomock.Any(), "a").Return(open, nil)
+				mockObj.EXPECT().shelfInWarehouseTx(ctx, gomock.Any(), "s", "w").Return(true, nil)
+				mockObj.EXPECT().receiveASNLineTx(ctx, gomock.Any(), "a", "l", 3).Return(line, nil)
+				mockObj.EXPECT().checkTrackingTx(ctx, gomock.Any(), "sku", 3, "", nil).Return(wms.LotRequired)
+			},
+			wantErr: wms.LotRequired,
+		},
+		{
+			name: "shelf full",
+			expect: func() {
+				mockObj.EXPECT().lockASNTx(ctx, gomock.Any(), "a").Return(open, nil)
+				mockObj.EXPECT().shelfInWarehouseTx(ctx, gomock.Any(), "s", "w").Return(true, nil)
+				mockObj.EXPECT().receiveASNLineTx(ctx, gomock.Any(), "a", "l", 3).Return(line, nil)
+				mockObj.EXPECT().checkTrackingTx(ctx, gomock.Any(), "sku", 3, "", nil).Return(nil)
+				mockObj.EXPECT().checkShelfCapacityTx(ctx, gomock.Any(), "s", "sku", 3).Return(capacityErr)
+			},
+			wantErr: capacityErr,
+		},
+	}
+	for _, test := range tests {
+		test.expect()
+		_, _, err := as.ReceiveASNLine(ctx, "a", "l", 3, "s", nil, "", nil)
+		if err != test.wantErr {
+			t.Errorf("%s: want: %v, got: %v", test.name, test.wantErr, err)
+		}
+	}
+}
+
+func TestCreateASN(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockasnQueries(mockCtrl)
+
+	as := ASNService{db: warehouseService.db, queries: mockObj}
+	asn := testASN()
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), asn.WarehouseId).Return(false, nil)
+	err := as.CreateASN(ctx, asn)
+	if err != wms.WarehouseDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.WarehouseDoesNotExist, err)
+	}
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), asn.WarehouseId).Return(true, nil)
+	mockObj.EXPECT().productExistsTx(ctx, gomock.Any(), testProduct.Sku).Return(false, nil)
+	err = as.CreateASN(ctx, asn)
+	if err != wms.InvalidProduct {
+		t.Errorf("want: %v, got: %v", wms.InvalidProduct, err)
+	}
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), asn.WarehouseId).Return(true, nil)
+	mockObj.EXPECT().productExistsTx(ctx, gomock.Any(), testProduct.Sku).Return(true, nil)
+	mockObj.EXPECT().createASNTx(ctx, gomock.Any(), asn).Return(nil)
+	err = as.CreateASN(ctx, asn)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/audit.go" -destination="./pkg/database/postgres/audit_mock.go"
+type auditQueries interface {
+	listAuditEventsTx(ctx context.Context, tx *sql.Tx, entity string, entityId string, after int64, limit int) ([]wms.AuditEvent, error)
+}
+
+type auditQueriesImpl struct{}
+
+type AuditService struct {
+	queries auditQueries
+	db      *sql.DB
+}
+
+func NewAuditService(db *sql.DB) *AuditService {
+	return &AuditService{
+		queries: new(auditQueriesImpl),
+		db:      db,
+	}
+}
+
+// ListAuditEvents returns up to limit audit events of a row, oldest first,
+// starting after the event with id after.
+func (a *AuditService) ListAuditEvents(ctx context.Context, entity string, entityId string, after int64, limit int) ([]wms.AuditEvent, error) {
+	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	events, err := a.queries.listAuditEventsTx(ctx, tx, entity, entityId, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, tx.Commit()
+}
+
+func (a *auditQueriesImpl) listAuditEventsTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	entity string,
+	entityId string,
+	after int64,
+	limit int,
+) ([]wms.AuditEvent, error) {
+	query := `SELECT id, entity, entity_id, action, before, after, coalesce(actor, ''), created_at
+		FROM audit_event WHERE entity = $1 AND entity_id = $2 AND id > $3 ORDER BY id LIMIT $4`
+
+	rows, err := tx.QueryContext(ctx, query, entity, entityId, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]wms.AuditEvent, 0, limit)
+	for rows.Next() {
+		var event wms.AuditEvent
+		var before, after []byte
+		err := rows.Scan(
+			&event.Id,
+			&event.Entity,
+			&event.EntityId,
+			&event.Action,
+			&before,
+			&after,
+			&event.Actor,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if before != nil {
+			event.Before = before
+		}
+		if after != nil {
+			event.After = after
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// auditedExecTx runs statement, an INSERT, UPDATE or DELETE on the table
+// named entity, and records an audit event for every row it changes. The
+// rows an UPDATE or DELETE changes are those matching where, which is added
+// to statement and must be empty for inserts, so that the rows audited are
+// always the rows changed. Like a plain Exec, the result counts the changed
+// rows.
+func auditedExecTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	entity string,
+	action string,
+	statement string,
+	where string,
+	args ...interface{},
+) (sql.Result, error) {
+	if where == "" {
+		where = "false"
+	} else {
+		statement += " WHERE " + where
+	}
+
+	// DELETE ... RETURNING hands back the row as it was, there is no after
+	after := "to_jsonb(new_rows)"
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(statement)), "DELETE") {
+		after = "NULL"
+	}
+
+	// old_rows locks the matching rows, so their before image cannot change
+	// under statement. Only rows statement returns are audited and counted: a
+	// row statement skipped, e.g. after a concurrent version bump, is not.
+	n := len(args)
+	query := fmt.Sprintf(`WITH old_rows AS (SELECT * FROM %[1]s WHERE %[2]s FOR UPDATE),
+		new_rows AS (%[3]s RETURNING *)
+		INSERT INTO audit_event (entity, entity_id, action, before, after, actor)
+		SELECT $%[4]d::text, new_rows.id, $%[5]d::text, to_jsonb(old_rows), %[7]s, NULLIF($%[6]d::text, '')
+		FROM new_rows LEFT JOIN old_rows ON old_rows.id = new_rows.id`,
+		entity, where, statement, n+1, n+2, n+3, after,
+	)
+
+	args = append(args, entity, action, wms.ActorFromContext(ctx))
+	return tx.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/audit.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockauditQueries is a mock of auditQueries interface.
+type MockauditQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditQueriesMockRecorder
+}
+
+// MockauditQueriesMockRecorder is the mock recorder for MockauditQueries.
+type MockauditQueriesMockRecorder struct {
+	mock *MockauditQueries
+}
+
+// NewMockauditQueries creates a new mock instance.
+func NewMockauditQueries(ctrl *gomock.Controller) *MockauditQueries {
+	mock := &MockauditQueries{ctrl: ctrl}
+	mock.recorder = &MockauditQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditQueries) EXPECT() *MockauditQueriesMockRecorder {
+	return m.recorder
+}
+
+// listAuditEventsTx mocks base method.
+func (m *MockauditQueries) listAuditEventsTx(ctx context.Context, tx *sql.Tx, entity, entityId string, after int64, limit int) ([]warehousemanagementservice.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listAuditEventsTx", ctx, tx, entity, entityId, after, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listAuditEventsTx indicates an expected call of listAuditEventsTx.
+func (mr *MockauditQueriesMockRecorder) listAuditEventsTx(ctx, tx, entity, entityId, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listAuditEventsTx", reflect.TypeOf((*MockauditQueries)(nil).listAuditEventsTx), ctx, tx, entity, entityId, after, limit)
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	wms "warehouse-management-service"
+)
+
+func TestAuditedWarehouseMutationsTx(t *testing.T) {
+	ctx := wms.WithActor(context.Background(), "alice")
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	warehouse := wms.Warehouse{
+		Id:        "a2a3e3b6-6a6e-4d6e-9d8e-2b0f7d1c0a01",
+		Name:      "test_audit",
+		Latitude:  12.9716,
+		Longitude: 77.5946,
+	}
+	err = warehouseService.queries.createWarehouseTx(ctx, tx, &warehouse)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	warehouse.Name = "test_audit_renamed"
+	err = warehouseService.queries.updateWarehouseTx(ctx, tx, &warehouse)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = warehouseService.queries.deleteWarehouseTx(ctx, tx, warehouse.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := auditQueriesImpl{}
+	events, err := queries.listAuditEventsTx(ctx, tx, wms.EntityWarehouse, warehouse.Id, 0, 10)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wantActions := []string{wms.AuditCreate, wms.AuditUpdate, wms.AuditDelete}
+	if len(events) != len(wantActions) {
+		t.Errorf("want: %d events, got: %v", len(wantActions), events)
+		return
+	}
+	for i, event := range events {
+		if event.Action != wantActions[i] || event.Actor != "alice" {
+			t.Errorf("want: %v by alice, got: %v", wantActions[i], event)
+		}
+	}
+	if events[0].Before != nil || events[0].After == nil {
+		t.Errorf("want create event with only an after, got: %v", events[0])
+	}
+	if events[1].Before == nil || events[1].After == nil {
+		t.Errorf("want update event with a before and an after, got: %v", events[1])
+	}
+}
@@ -0,0 +1,546 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/cycle_count.go" -destination="./pkg/database/postgres/cycle_count_mock.go"
+type cycleCountQueries interface {
+	shelfWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string) (string, error)
+	shelfBlockWarehouseTx(ctx context.Context, tx *sql.Tx, shelfBlockId string) (string, error)
+	productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error)
+	createCycleCountTx(ctx context.Context, tx *sql.Tx, cycleCount wms.CycleCount) error
+	getCycleCountByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.CycleCount, error)
+	lockCycleCountTx(ctx context.Context, tx *sql.Tx, id string) (wms.CycleCount, error)
+	shelfInCycleCountTx(ctx context.Context, tx *sql.Tx, cycleCount wms.CycleCount, shelfId string) (bool, error)
+	saveCountTx(ctx context.Context, tx *sql.Tx, cycleCountId string, count wms.CycleCountLine) error
+	lockCycleCountItemsTx(ctx context.Context, tx *sql.Tx, cycleCount wms.CycleCount) error
+	expectedStockTx(ctx context.Context, tx *sql.Tx, cycleCount wms.CycleCount) ([]wms.CycleCountLine, error)
+	checkTrackingTx(ctx context.Context, tx *sql.Tx, sku string, quantity int, lot string, serialNumbers []string) error
+	checkExpirationDateTx(ctx context.Context, tx *sql.Tx, sku string, expirationDate *time.Time) error
+	createFoundItemTx(ctx context.Context, tx *sql.Tx, item wms.Item) error
+	retireItemsTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) ([]string, error)
+	recordAdjustmentTx(ctx context.Context, tx *sql.Tx, adjustment wms.StockAdjustment) error
+	approveCycleCountTx(ctx context.Context, tx *sql.Tx, id string, approvedAt time.Time) error
+}
+
+type cycleCountQueriesImpl struct {
+	transferQueriesImpl
+}
+
+type CycleCountService struct {
+	queries cycleCountQueries
+	db      *sql.DB
+}
+
+func NewCycleCountService(db *sql.DB) *CycleCountService {
+	return &CycleCountService{
+		queries: new(cycleCountQueriesImpl),
+		db:      db,
+	}
+}
+
+// CreateCycleCount opens a count of a shelf or a shelf block.
+func (c *CycleCountService) CreateCycleCount(ctx context.Context, scope wms.CycleCountScope) (wms.CycleCount, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	defer tx.Rollback()
+
+	var warehouseId string
+	if scope.ShelfId != "" {
+		warehouseId, err = c.queries.shelfWarehouseTx(ctx, tx, scope.ShelfId)
+		if err == sql.ErrNoRows {
+			return wms.CycleCount{}, wms.InvalidShelf
+		}
+	} else {
+		warehouseId, err = c.queries.shelfBlockWarehouseTx(ctx, tx, scope.ShelfBlockId)
+		if err == sql.ErrNoRows {
+			return wms.CycleCount{}, wms.InvalidShelfBlock
+		}
+	}
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+
+	cycleCount := wms.NewCycleCount(scope, warehouseId)
+	err = c.queries.createCycleCountTx(ctx, tx, cycleCount)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	return cycleCount, tx.Commit()
+}
+
+func (c *CycleCountService) GetCycleCountById(ctx context.Context, id string) (wms.CycleCount, error) {
+	tx, err := c.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	defer tx.Rollback()
+
+	cycleCount, err := c.queries.getCycleCountByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return cycleCount, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.CycleCount{}, wms.CycleCountDoesNotExist
+	default:
+		return wms.CycleCount{}, err
+	}
+}
+
+// SubmitCounts records what was counted on the shelves of an open cycle
+// count. Counting a sku on a shelf again replaces the earlier count.
+func (c *CycleCountService) SubmitCounts(ctx context.Context, id string, counts []wms.CycleCountLine) (wms.CycleCount, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	defer tx.Rollback()
+
+	cycleCount, err := c.queries.lockCycleCountTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.CycleCount{}, wms.CycleCountDoesNotExist
+	default:
+		return wms.CycleCount{}, err
+	}
+	if cycleCount.Status != wms.CycleCountOpen {
+		return wms.CycleCount{}, wms.CycleCountNotOpen
+	}
+
+	counts, err = cycleCount.CheckCounts(counts)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	for _, count := range counts {
+		if inScope, err := c.queries.shelfInCycleCountTx(ctx, tx, cycleCount, count.ShelfId); err != nil {
+			return wms.CycleCount{}, err
+		} else if !inScope {
+			return wms.CycleCount{}, wms.ShelfNotInCycleCount
+		}
+		if productExists, err := c.queries.productExistsTx(ctx, tx, count.Sku); err != nil {
+			return wms.CycleCount{}, err
+		} else if !productExists {
+			return wms.CycleCount{}, wms.InvalidProduct
+		}
+
+		err = c.queries.saveCountTx(ctx, tx, id, count)
+		if err != nil {
+			return wms.CycleCount{}, err
+		}
+	}
+
+	cycleCount, err = c.queries.getCycleCountByIdTx(ctx, tx, id)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	return cycleCount, tx.Commit()
+}
+
+// GetVarianceReport compares the counts of a cycle count with the items on
+// record now. Items in transit are not on record until received.
+func (c *CycleCountService) GetVarianceReport(ctx context.Context, id string) ([]wms.Variance, error) {
+	tx, err := c.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	cycleCount, err := c.queries.getCycleCountByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return nil, wms.CycleCountDoesNotExist
+	default:
+		return nil, err
+	}
+
+	expected, err := c.queries.expectedStockTx(ctx, tx, cycleCount)
+	if err != nil {
+		return nil, err
+	}
+	return wms.NewVarianceReport(expected, cycleCount.Counts), tx.Commit()
+}
+
+// ApproveCycleCount corrects the items on record to match the counts of an
+// open cycle count and closes it. Items found are created without an
+// expiration date, lot or serial number, so a count that finds items of a
+// lot tracked or serialized product is not approved: wms.LotRequired or
+// wms.SerialNumberRequired is returned and those items have to be received.
+// Items missing are retired quarantined and unclaimed first, dropping them
+// from any reservation or pick list if it comes to that. Each item created
+// or retired goes into the adjustment ledger with reason, or the reason
+// overriding it for its shelf and sku.
+func (c *CycleCountService) ApproveCycleCount(
+	ctx context.Context,
+	id string,
+	reason string,
+	overrides []wms.AdjustmentReason,
+) (wms.CycleCount, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	defer tx.Rollback()
+
+	cycleCount, err := c.queries.lockCycleCountTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.CycleCount{}, wms.CycleCountDoesNotExist
+	default:
+		return wms.CycleCount{}, err
+	}
+	if cycleCount.Status != wms.CycleCountOpen {
+		return wms.CycleCount{}, wms.CycleCountNotOpen
+	}
+
+	err = c.queries.lockCycleCountItemsTx(ctx, tx, cycleCount)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	expected, err := c.queries.expectedStockTx(ctx, tx, cycleCount)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	corrections, err := wms.PlanCorrections(wms.NewVarianceReport(expected, cycleCount.Counts), reason, overrides)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+
+	for _, correction := range corrections {
+		adjustment := wms.StockAdjustment{
+			CycleCountId: id,
+			Sku:          correction.Sku,
+			ShelfId:      correction.ShelfId,
+			Reason:       correction.Reason,
+		}
+		var itemIds []string
+		if correction.Quantity > 0 {
+			err = c.queries.checkTrackingTx(ctx, tx, correction.Sku, correction.Quantity, "", nil)
+			if err != nil {
+				return wms.CycleCount{}, err
+			}
+			// found items are not dated, which perishable products need
+			err = c.queries.checkExpirationDateTx(ctx, tx, correction.Sku, nil)
+			if err != nil {
+				return wms.CycleCount{}, err
+			}
+			adjustment.Quantity = 1
+			for n := 0; n < correction.Quantity; n++ {
+				item := wms.NewItem(correction.Sku, nil, correction.ShelfId, "", "")
+				err = c.queries.createFoundItemTx(ctx, tx, item)
+				if err != nil {
+					return wms.CycleCount{}, err
+				}
+				itemIds = append(itemIds, item.Id)
+			}
+		} else {
+			adjustment.Quantity = -1
+			itemIds, err = c.queries.retireItemsTx(ctx, tx, correction.ShelfId, correction.Sku, -correction.Quantity)
+			if err != nil {
+				return wms.CycleCount{}, err
+			}
+		}
+		for _, itemId := range itemIds {
+			adjustment.ItemId = itemId
+			err = c.queries.recordAdjustmentTx(ctx, tx, adjustment)
+			if err != nil {
+				return wms.CycleCount{}, err
+			}
+		}
+	}
+
+	err = c.queries.approveCycleCountTx(ctx, tx, id, time.Now().UTC())
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	cycleCount, err = c.queries.getCycleCountByIdTx(ctx, tx, id)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	return cycleCount, tx.Commit()
+}
+
+func (c *cycleCountQueriesImpl) shelfBlockWarehouseTx(ctx context.Context, tx *sql.Tx, shelfBlockId string) (string, error) {
+	query := `SELECT warehouse_id FROM shelf_block WHERE id = $1 AND deleted_at IS NULL`
+
+	var warehouseId string
+	row := tx.QueryRowContext(ctx, query, shelfBlockId)
+	err := row.Scan(&warehouseId)
+	if err != nil {
+		return "", err
+	}
+	return warehouseId, nil
+}
+
+func (c *cycleCountQueriesImpl) createCycleCountTx(ctx context.Context, tx *sql.Tx, cycleCount wms.CycleCount) error {
+	query := `INSERT INTO cycle_count(id, warehouse_id, shelf_id, shelf_block_id, status, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		cycleCount.Id,
+		cycleCount.WarehouseId,
+		cycleCount.ShelfId,
+		cycleCount.ShelfBlockId,
+		cycleCount.Status,
+		cycleCount.CreatedAt,
+	)
+	return err
+}
+
+const cycleCountColumns = `id, warehouse_id, COALESCE(shelf_id, ''), COALESCE(shelf_block_id, ''), status, created_at,
+	approved_at, COALESCE(approved_by, '')`
+
+func (c *cycleCountQueriesImpl) scanCycleCount(row *sql.Row) (wms.CycleCount, error) {
+	var cycleCount wms.CycleCount
+	var approvedAt sql.NullTime
+	err := row.Scan(
+		&cycleCount.Id,
+		&cycleCount.WarehouseId,
+		&cycleCount.ShelfId,
+		&cycleCount.ShelfBlockId,
+		&cycleCount.Status,
+		&cycleCount.CreatedAt,
+		&approvedAt,
+		&cycleCount.ApprovedBy,
+	)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	if approvedAt.Valid {
+		cycleCount.ApprovedAt = &approvedAt.Time
+	}
+	return cycleCount, nil
+}
+
+// lockCycleCountTx returns a cycle count with its counts, without its
+// adjustments, and locks its row.
+func (c *cycleCountQueriesImpl) lockCycleCountTx(ctx context.Context, tx *sql.Tx, id string) (wms.CycleCount, error) {
+	cycleCount, err := c.scanCycleCount(tx.QueryRowContext(ctx, `SELECT `+cycleCountColumns+` FROM cycle_count WHERE id = $1 FOR UPDATE`, id))
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	cycleCount.Counts, err = c.getCountsTx(ctx, tx, id)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	return cycleCount, nil
+}
+
+func (c *cycleCountQueriesImpl) getCycleCountByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.CycleCount, error) {
+	cycleCount, err := c.scanCycleCount(tx.QueryRowContext(ctx, `SELECT `+cycleCountColumns+` FROM cycle_count WHERE id = $1`, id))
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	cycleCount.Counts, err = c.getCountsTx(ctx, tx, id)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+
+	query := `SELECT id, COALESCE(cycle_count_id, ''), item_id, sku, shelf_id, quantity, reason, COALESCE(actor, ''), adjusted_at
+		FROM stock_adjustment WHERE cycle_count_id = $1 ORDER BY id`
+
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return wms.CycleCount{}, err
+	}
+	defer rows.Close()
+
+	cycleCount.Adjustments = []wms.StockAdjustment{}
+	for rows.Next() {
+		var adjustment wms.StockAdjustment
+		err := rows.Scan(
+			&adjustment.Id,
+			&adjustment.CycleCountId,
+			&adjustment.ItemId,
+			&adjustment.Sku,
+			&adjustment.ShelfId,
+			&adjustment.Quantity,
+			&adjustment.Reason,
+			&adjustment.Actor,
+			&adjustment.AdjustedAt,
+		)
+		if err != nil {
+			return wms.CycleCount{}, err
+		}
+		cycleCount.Adjustments = append(cycleCount.Adjustments, adjustment)
+	}
+
+	return cycleCount, rows.Err()
+}
+
+func (c *cycleCountQueriesImpl) getCountsTx(ctx context.Context, tx *sql.Tx, cycleCountId string) ([]wms.CycleCountLine, error) {
+	query := `SELECT shelf_id, sku, quantity, COALESCE(counted_by, ''), counted_at
+		FROM cycle_count_line WHERE cycle_count_id = $1 ORDER BY shelf_id, sku`
+
+	rows, err := tx.QueryContext(ctx, query, cycleCountId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []wms.CycleCountLine{}
+	for rows.Next() {
+		var count wms.CycleCountLine
+		err := rows.Scan(&count.ShelfId, &count.Sku, &count.Quantity, &count.CountedBy, &count.CountedAt)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
+
+func (c *cycleCountQueriesImpl) shelfInCycleCountTx(ctx context.Context, tx *sql.Tx, cycleCount wms.CycleCount, shelfId string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM shelf WHERE id = $1 AND deleted_at IS NULL AND (id = $2 OR shelf_block = $3))`
+
+	var inScope bool
+	row := tx.QueryRowContext(ctx, query, shelfId, cycleCount.ShelfId, cycleCount.ShelfBlockId)
+	err := row.Scan(&inScope)
+	if err != nil {
+		return false, err
+	}
+	return inScope, nil
+}
+
+func (c *cycleCountQueriesImpl) saveCountTx(ctx context.Context, tx *sql.Tx, cycleCountId string, count wms.CycleCountLine) error {
+	query := `INSERT INTO cycle_count_line(cycle_count_id, shelf_id, sku, quantity, counted_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		ON CONFLICT (cycle_count_id, shelf_id, sku)
+		DO UPDATE SET quantity = excluded.quantity, counted_by = excluded.counted_by, counted_at = now()`
+
+	_, err := tx.ExecContext(ctx, query, cycleCountId, count.ShelfId, count.Sku, count.Quantity, wms.ActorFromContext(ctx))
+	return err
+}
+
+// cycleCountItems selects the items on record on the shelves of a cycle
+// count, given its shelf as $1 and shelf block as $2.
+const cycleCountItems = `FROM item
+	JOIN shelf ON shelf.id = item.shelf_id
+	WHERE item.status <> 'in_transit' AND shelf.deleted_at IS NULL
+		AND (shelf.id = $1 OR shelf.shelf_block = $2)`
+
+// lockCycleCountItemsTx locks the items on record on the shelves of a
+// cycle count, so that they cannot change between working out the variance
+// and correcting it.
+func (c *cycleCountQueriesImpl) lockCycleCountItemsTx(ctx context.Context, tx *sql.Tx, cycleCount wms.CycleCount) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`SELECT item.id `+cycleCountItems+` FOR UPDATE OF item`,
+		cycleCount.ShelfId,
+		cycleCount.ShelfBlockId,
+	)
+	return err
+}
+
+func (c *cycleCountQueriesImpl) expectedStockTx(ctx context.Context, tx *sql.Tx, cycleCount wms.CycleCount) ([]wms.CycleCountLine, error) {
+	query := `SELECT item.shelf_id, item.sku, COUNT(*) ` + cycleCountItems + ` GROUP BY item.shelf_id, item.sku`
+
+	rows, err := tx.QueryContext(ctx, query, cycleCount.ShelfId, cycleCount.ShelfBlockId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expected []wms.CycleCountLine
+	for rows.Next() {
+		var line wms.CycleCountLine
+		err := rows.Scan(&line.ShelfId, &line.Sku, &line.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		expected = append(expected, line)
+	}
+
+	return expected, rows.Err()
+}
+
+// createFoundItemTx puts an item that was counted but not on record on its
+// shelf. Unlike receiving an item, it does not check the shelf's capacity:
+// the item is there already.
+func (c *cycleCountQueriesImpl) createFoundItemTx(ctx context.Context, tx *sql.Tx, item wms.Item) error {
+	query := `INSERT INTO item(id, sku, expiration_date, received_on, shelf_id, status) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		item.Id,
+		item.Sku,
+		item.ExpirationDate,
+		item.ReceivedOn,
+		item.ShelfId,
+		item.Status,
+	)
+	return err
+}
+
+// retireItemsTx deletes up to quantity items of sku on a shelf, preferring
+// quarantined items, then items not reserved or being picked, then the last
+// received, and returns their ids.
+func (c *cycleCountQueriesImpl) retireItemsTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) ([]string, error) {
+	query := `DELETE FROM item WHERE id IN (
+			SELECT item.id FROM item
+			WHERE item.shelf_id = $1 AND item.sku = $2 AND item.status <> 'in_transit'
+			ORDER BY item.status = 'quarantined' DESC,
+				EXISTS(SELECT 1 FROM reservation_item WHERE reservation_item.item_id = item.id) OR
+				EXISTS(SELECT 1 FROM pick_list_item WHERE pick_list_item.item_id = item.id),
+				item.received_on DESC, item.id
+			LIMIT $3
+		)
+		RETURNING id`
+
+	rows, err := tx.QueryContext(ctx, query, shelfId, sku, quantity)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var itemIds []string
+	for rows.Next() {
+		var itemId string
+		err := rows.Scan(&itemId)
+		if err != nil {
+			return nil, err
+		}
+		itemIds = append(itemIds, itemId)
+	}
+
+	return itemIds, rows.Err()
+}
+
+// recordAdjustmentTx adds an adjustment to the ledger, on behalf of the
+// actor of ctx.
+func (c *cycleCountQueriesImpl) recordAdjustmentTx(ctx context.Context, tx *sql.Tx, adjustment wms.StockAdjustment) error {
+	query := `INSERT INTO stock_adjustment(cycle_count_id, item_id, sku, shelf_id, quantity, reason, actor)
+		VALUES (NULLIF($1, ''), $2, $3, $4, $5, $6, NULLIF($7, ''))`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		adjustment.CycleCountId,
+		adjustment.ItemId,
+		adjustment.Sku,
+		adjustment.ShelfId,
+		adjustment.Quantity,
+		adjustment.Reason,
+		wms.ActorFromContext(ctx),
+	)
+	return err
+}
+
+func (c *cycleCountQueriesImpl) approveCycleCountTx(ctx context.Context, tx *sql.Tx, id string, approvedAt time.Time) error {
+	query := `UPDATE cycle_count SET status = $1, approved_at = $2, approved_by = NULLIF($3, '') WHERE id = $4`
+
+	_, err := tx.ExecContext(ctx, query, wms.CycleCountApproved, approvedAt, wms.ActorFromContext(ctx), id)
+	return err
+}
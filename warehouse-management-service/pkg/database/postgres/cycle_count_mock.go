@@ -0,0 +1,270 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/cycle_count.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcycleCountQueries is a mock of cycleCountQueries interface.
+type MockcycleCountQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockcycleCountQueriesMockRecorder
+}
+
+// MockcycleCountQueriesMockRecorder is the mock recorder for MockcycleCountQueries.
+type MockcycleCountQueriesMockRecorder struct {
+	mock *MockcycleCountQueries
+}
+
+// NewMockcycleCountQueries creates a new mock instance.
+func NewMockcycleCountQueries(ctrl *gomock.Controller) *MockcycleCountQueries {
+	mock := &MockcycleCountQueries{ctrl: ctrl}
+	mock.recorder = &MockcycleCountQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcycleCountQueries) EXPECT() *MockcycleCountQueriesMockRecorder {
+	return m.recorder
+}
+
+// approveCycleCountTx mocks base method.
+func (m *MockcycleCountQueries) approveCycleCountTx(ctx context.Context, tx *sql.Tx, id string, approvedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "approveCycleCountTx", ctx, tx, id, approvedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// approveCycleCountTx indicates an expected call of approveCycleCountTx.
+func (mr *MockcycleCountQueriesMockRecorder) approveCycleCountTx(ctx, tx, id, approvedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "approveCycleCountTx", reflect.TypeOf((*MockcycleCountQueries)(nil).approveCycleCountTx), ctx, tx, id, approvedAt)
+}
+
+// checkExpirationDateTx mocks base method.
+func (m *MockcycleCountQueries) checkExpirationDateTx(ctx context.Context, tx *sql.Tx, sku string, expirationDate *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkExpirationDateTx", ctx, tx, sku, expirationDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// checkExpirationDateTx indicates an expected call of checkExpirationDateTx.
+func (mr *MockcycleCountQueriesMockRecorder) checkExpirationDateTx(ctx, tx, sku, expirationDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkExpirationDateTx", reflect.TypeOf((*MockcycleCountQueries)(nil).checkExpirationDateTx), ctx, tx, sku, expirationDate)
+}
+
+// checkTrackingTx mocks base method.
+func (m *MockcycleCountQueries) checkTrackingTx(ctx context.Context, tx *sql.Tx, sku string, quantity int, lot string, serialNumbers []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkTrackingTx", ctx, tx, sku, quantity, lot, serialNumbers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// checkTrackingTx indicates an expected call of checkTrackingTx.
+func (mr *MockcycleCountQueriesMockRecorder) checkTrackingTx(ctx, tx, sku, quantity, lot, serialNumbers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkTrackingTx", reflect.TypeOf((*MockcycleCountQueries)(nil).checkTrackingTx), ctx, tx, sku, quantity, lot, serialNumbers)
+}
+
+// createCycleCountTx mocks base method.
+func (m *MockcycleCountQueries) createCycleCountTx(ctx context.Context, tx *sql.Tx, cycleCount warehousemanagementservice.CycleCount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createCycleCountTx", ctx, tx, cycleCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createCycleCountTx indicates an expected call of createCycleCountTx.
+func (mr *MockcycleCountQueriesMockRecorder) createCycleCountTx(ctx, tx, cycleCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createCycleCountTx", reflect.TypeOf((*MockcycleCountQueries)(nil).createCycleCountTx), ctx, tx, cycleCount)
+}
+
+// createFoundItemTx mocks base method.
+func (m *MockcycleCountQueries) createFoundItemTx(ctx context.Context, tx *sql.Tx, item warehousemanagementservice.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createFoundItemTx", ctx, tx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createFoundItemTx indicates an expected call of createFoundItemTx.
+func (mr *MockcycleCountQueriesMockRecorder) createFoundItemTx(ctx, tx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createFoundItemTx", reflect.TypeOf((*MockcycleCountQueries)(nil).createFoundItemTx), ctx, tx, item)
+}
+
+// expectedStockTx mocks base method.
+func (m *MockcycleCountQueries) expectedStockTx(ctx context.Context, tx *sql.Tx, cycleCount warehousemanagementservice.CycleCount) ([]warehousemanagementservice.CycleCountLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "expectedStockTx", ctx, tx, cycleCount)
+	ret0, _ := ret[0].([]warehousemanagementservice.CycleCountLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// expectedStockTx indicates an expected call of expectedStockTx.
+func (mr *MockcycleCountQueriesMockRecorder) expectedStockTx(ctx, tx, cycleCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "expectedStockTx", reflect.TypeOf((*MockcycleCountQueries)(nil).expectedStockTx), ctx, tx, cycleCount)
+}
+
+// getCycleCountByIdTx mocks base method.
+func (m *MockcycleCountQueries) getCycleCountByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getCycleCountByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getCycleCountByIdTx indicates an expected call of getCycleCountByIdTx.
+func (mr *MockcycleCountQueriesMockRecorder) getCycleCountByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getCycleCountByIdTx", reflect.TypeOf((*MockcycleCountQueries)(nil).getCycleCountByIdTx), ctx, tx, id)
+}
+
+// lockCycleCountItemsTx mocks base method.
+func (m *MockcycleCountQueries) lockCycleCountItemsTx(ctx context.Context, tx *sql.Tx, cycleCount warehousemanagementservice.CycleCount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockCycleCountItemsTx", ctx, tx, cycleCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// lockCycleCountItemsTx indicates an expected call of lockCycleCountItemsTx.
+func (mr *MockcycleCountQueriesMockRecorder) lockCycleCountItemsTx(ctx, tx, cycleCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockCycleCountItemsTx", reflect.TypeOf((*MockcycleCountQueries)(nil).lockCycleCountItemsTx), ctx, tx, cycleCount)
+}
+
+// lockCycleCountTx mocks base method.
+func (m *MockcycleCountQueries) lockCycleCountTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockCycleCountTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockCycleCountTx indicates an expected call of lockCycleCountTx.
+func (mr *MockcycleCountQueriesMockRecorder) lockCycleCountTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockCycleCountTx", reflect.TypeOf((*MockcycleCountQueries)(nil).lockCycleCountTx), ctx, tx, id)
+}
+
+// productExistsTx mocks base method.
+func (m *MockcycleCountQueries) productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "productExistsTx", ctx, tx, sku)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// productExistsTx indicates an expected call of productExistsTx.
+func (mr *MockcycleCountQueriesMockRecorder) productExistsTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "productExistsTx", reflect.TypeOf((*MockcycleCountQueries)(nil).productExistsTx), ctx, tx, sku)
+}
+
+// recordAdjustmentTx mocks base method.
+func (m *MockcycleCountQueries) recordAdjustmentTx(ctx context.Context, tx *sql.Tx, adjustment warehousemanagementservice.StockAdjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "recordAdjustmentTx", ctx, tx, adjustment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// recordAdjustmentTx indicates an expected call of recordAdjustmentTx.
+func (mr *MockcycleCountQueriesMockRecorder) recordAdjustmentTx(ctx, tx, adjustment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "recordAdjustmentTx", reflect.TypeOf((*MockcycleCountQueries)(nil).recordAdjustmentTx), ctx, tx, adjustment)
+}
+
+// retireItemsTx mocks base method.
+func (m *MockcycleCountQueries) retireItemsTx(ctx context.Context, tx *sql.Tx, shelfId, sku string, quantity int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "retireItemsTx", ctx, tx, shelfId, sku, quantity)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// retireItemsTx indicates an expected call of retireItemsTx.
+func (mr *MockcycleCountQueriesMockRecorder) retireItemsTx(ctx, tx, shelfId, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "retireItemsTx", reflect.TypeOf((*MockcycleCountQueries)(nil).retireItemsTx), ctx, tx, shelfId, sku, quantity)
+}
+
+// saveCountTx mocks base method.
+func (m *MockcycleCountQueries) saveCountTx(ctx context.Context, tx *sql.Tx, cycleCountId string, count warehousemanagementservice.CycleCountLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "saveCountTx", ctx, tx, cycleCountId, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// saveCountTx indicates an expected call of saveCountTx.
+func (mr *MockcycleCountQueriesMockRecorder) saveCountTx(ctx, tx, cycleCountId, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "saveCountTx", reflect.TypeOf((*MockcycleCountQueries)(nil).saveCountTx), ctx, tx, cycleCountId, count)
+}
+
+// shelfBlockWarehouseTx mocks base method.
+func (m *MockcycleCountQueries) shelfBlockWarehouseTx(ctx context.Context, tx *sql.Tx, shelfBlockId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfBlockWarehouseTx", ctx, tx, shelfBlockId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfBlockWarehouseTx indicates an expected call of shelfBlockWarehouseTx.
+func (mr *MockcycleCountQueriesMockRecorder) shelfBlockWarehouseTx(ctx, tx, shelfBlockId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfBlockWarehouseTx", reflect.TypeOf((*MockcycleCountQueries)(nil).shelfBlockWarehouseTx), ctx, tx, shelfBlockId)
+}
+
+// shelfInCycleCountTx mocks base method.
+func (m *MockcycleCountQueries) shelfInCycleCountTx(ctx context.Context, tx *sql.Tx, cycleCount warehousemanagementservice.CycleCount, shelfId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfInCycleCountTx", ctx, tx, cycleCount, shelfId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfInCycleCountTx indicates an expected call of shelfInCycleCountTx.
+func (mr *MockcycleCountQueriesMockRecorder) shelfInCycleCountTx(ctx, tx, cycleCount, shelfId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfInCycleCountTx", reflect.TypeOf((*MockcycleCountQueries)(nil).shelfInCycleCountTx), ctx, tx, cycleCount, shelfId)
+}
+
+// shelfWarehouseTx mocks base method.
+func (m *MockcycleCountQueries) shelfWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfWarehouseTx", ctx, tx, shelfId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfWarehouseTx indicates an expected call of shelfWarehouseTx.
+func (mr *MockcycleCountQueriesMockRecorder) shelfWarehouseTx(ctx, tx, shelfId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfWarehouseTx", reflect.TypeOf((*MockcycleCountQueries)(nil).shelfWarehouseTx), ctx, tx, shelfId)
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func TestCorrectCycleCountTx(t *testing.T) {
+	ctx := wms.WithActor(context.Background(), "alice")
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, id := range []string{testItem().Id, "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f15"} {
+		item := testItem()
+		item.Id = id
+		err = itemService.queries.createItemTx(ctx, tx, item)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	queries := cycleCountQueriesImpl{}
+	warehouseId, err := queries.shelfBlockWarehouseTx(ctx, tx, fixtureShelfBlock.Id)
+	if err != nil || warehouseId != fixtureWarehouse.Id {
+		t.Errorf("want shelf block in warehouse %s, got: %v, %v", fixtureWarehouse.Id, warehouseId, err)
+	}
+
+	cycleCount := wms.NewCycleCount(wms.CycleCountScope{ShelfBlockId: fixtureShelfBlock.Id}, fixtureWarehouse.Id)
+	err = queries.createCycleCountTx(ctx, tx, cycleCount)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	inScope, err := queries.shelfInCycleCountTx(ctx, tx, cycleCount, fixtureShelf.Id)
+	if err != nil || !inScope {
+		t.Errorf("want shelf %s covered by the count, got: %v, %v", fixtureShelf.Id, inScope, err)
+	}
+
+	for _, quantity := range []int{3, 1} {
+		err = queries.saveCountTx(ctx, tx, cycleCount.Id, wms.CycleCountLine{ShelfId: fixtureShelf.Id, Sku: testProduct.Sku, Quantity: quantity})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	cycleCount, err = queries.lockCycleCountTx(ctx, tx, cycleCount.Id)
+	if err != nil || len(cycleCount.Counts) != 1 || cycleCount.Counts[0].Quantity != 1 || cycleCount.Counts[0].CountedBy != "alice" {
+		t.Errorf("want 1 item counted by alice, got: %v, %v", cycleCount, err)
+		return
+	}
+
+	err = queries.lockCycleCountItemsTx(ctx, tx, cycleCount)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected, err := queries.expectedStockTx(ctx, tx, cycleCount)
+	if err != nil || len(expected) != 1 || expected[0].Quantity != 2 {
+		t.Errorf("want 2 items on record, got: %v, %v", expected, err)
+		return
+	}
+
+	itemIds, err := queries.retireItemsTx(ctx, tx, fixtureShelf.Id, testProduct.Sku, 1)
+	if err != nil || len(itemIds) != 1 || itemIds[0] != testItem().Id {
+		t.Errorf("want item %s retired, got: %v, %v", testItem().Id, itemIds, err)
+		return
+	}
+	err = queries.recordAdjustmentTx(ctx, tx, wms.StockAdjustment{
+		CycleCountId: cycleCount.Id,
+		ItemId:       itemIds[0],
+		Sku:          testProduct.Sku,
+		ShelfId:      fixtureShelf.Id,
+		Quantity:     -1,
+		Reason:       wms.AdjustmentLost,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.approveCycleCountTx(ctx, tx, cycleCount.Id, time.Now().UTC())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	cycleCount, err = queries.getCycleCountByIdTx(ctx, tx, cycleCount.Id)
+	if err != nil || cycleCount.Status != wms.CycleCountApproved || cycleCount.ApprovedBy != "alice" || cycleCount.ApprovedAt == nil {
+		t.Errorf("want a cycle count approved by alice, got: %v, %v", cycleCount, err)
+	}
+	if len(cycleCount.Adjustments) != 1 || cycleCount.Adjustments[0].ItemId != testItem().Id || cycleCount.Adjustments[0].Reason != wms.AdjustmentLost {
+		t.Errorf("want item %s retired as lost, got: %v", testItem().Id, cycleCount.Adjustments)
+	}
+	_, err = itemService.queries.getItemByIdTx(ctx, tx, testItem().Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("want the retired item gone, got: %v", err)
+	}
+}
+
+func TestApproveCycleCount(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockcycleCountQueries(mockCtrl)
+
+	cs := CycleCountService{db: warehouseService.db, queries: mockObj}
+	cycleCount := wms.CycleCount{
+		Id:      "c",
+		ShelfId: "s",
+		Status:  wms.CycleCountOpen,
+		Counts:  []wms.CycleCountLine{{ShelfId: "s", Sku: "a", Quantity: 1}, {ShelfId: "s", Sku: "b", Quantity: 2}},
+	}
+	expected := []wms.CycleCountLine{{ShelfId: "s", Sku: "a", Quantity: 3}, {ShelfId: "s", Sku: "b", Quantity: 1}}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockCycleCountTx(ctx, gomock.Any(), "c").Return(cycleCount, nil),
+		mockObj.EXPECT().lockCycleCountItemsTx(ctx, gomock.Any(), cycleCount).Return(nil),
+		mockObj.EXPECT().expectedStockTx(ctx, gomock.Any(), cycleCount).Return(expected, nil),
+		mockObj.EXPECT().retireItemsTx(ctx, gomock.Any(), "s", "a", 2).Return([]string{"i1", "i2"}, nil),
+		mockObj.EXPECT().recordAdjustmentTx(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *sql.Tx, adjustment wms.StockAdjustment) error {
+				if adjustment.Quantity != -1 || adjustment.Reason != wms.AdjustmentDamaged {
+					t.Errorf("want an item retired as damaged, got: %v", adjustment)
+				}
+				return nil
+			},
+		).Times(2),
+		mockObj.EXPECT().checkTrackingTx(ctx, gomock.Any(), "b", 1, "", gomock.Nil()).Return(nil),
+		mockObj.EXPECT().checkExpirationDateTx(ctx, gomock.Any(), "b", gomock.Nil()).Return(nil),
+		mockObj.EXPECT().createFoundItemTx(ctx, gomock.Any(), gomock.Any()).Return(nil),
+		mockObj.EXPECT().recordAdjustmentTx(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *sql.Tx, adjustment wms.StockAdjustment) error {
+				if adjustment.Quantity != 1 || adjustment.Reason != wms.AdjustmentFound || adjustment.Sku != "b" {
+					t.Errorf("want an item of b found, got: %v", adjustment)
+				}
+				return nil
+			},
+		),
+		mockObj.EXPECT().approveCycleCountTx(ctx, gomock.Any(), "c", gomock.Any()).Return(nil),
+		mockObj.EXPECT().getCycleCountByIdTx(ctx, gomock.Any(), "c").Return(wms.CycleCount{Id: "c", Status: wms.CycleCountApproved}, nil),
+	)
+	approved, err := cs.ApproveCycleCount(ctx, "c", wms.AdjustmentDamaged, []wms.AdjustmentReason{{ShelfId: "s", Sku: "b", Reason: wms.AdjustmentFound}})
+	if err != nil || approved.Status != wms.CycleCountApproved {
+		t.Errorf("want an approved cycle count, got: %v, %v", approved, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockCycleCountTx(ctx, gomock.Any(), "c").Return(cycleCount, nil),
+		mockObj.EXPECT().lockCycleCountItemsTx(ctx, gomock.Any(), cycleCount).Return(nil),
+		mockObj.EXPECT().expectedStockTx(ctx, gomock.Any(), cycleCount).Return(expected, nil),
+	)
+	_, err = cs.ApproveCycleCount(ctx, "c", "misplaced", nil)
+	if err != wms.InvalidAdjustmentReason {
+		t.Errorf("want: %v, got: %v", wms.InvalidAdjustmentReason, err)
+	}
+
+	// found items of a serialized product cannot be made up without serials
+	gomock.InOrder(
+		mockObj.EXPECT().lockCycleCountTx(ctx, gomock.Any(), "c").Return(cycleCount, nil),
+		mockObj.EXPECT().lockCycleCountItemsTx(ctx, gomock.Any(), cycleCount).Return(nil),
+		mockObj.EXPECT().expectedStockTx(ctx, gomock.Any(), cycleCount).Return(expected, nil),
+		mockObj.EXPECT().retireItemsTx(ctx, gomock.Any(), "s", "a", 2).Return([]string{"i1", "i2"}, nil),
+		mockObj.EXPECT().recordAdjustmentTx(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(2),
+		mockObj.EXPECT().checkTrackingTx(ctx, gomock.Any(), "b", 1, "", gomock.Nil()).Return(wms.SerialNumberRequired),
+	)
+	_, err = cs.ApproveCycleCount(ctx, "c", wms.AdjustmentLost, nil)
+	if err != wms.SerialNumberRequired {
+		t.Errorf("want: %v, got: %v", wms.SerialNumberRequired, err)
+	}
+
+	// nor can found items of a perishable product without an expiration date
+	gomock.InOrder(
+		mockObj.EXPECT().lockCycleCountTx(ctx, gomock.Any(), "c").Return(cycleCount, nil),
+		mockObj.EXPECT().lockCycleCountItemsTx(ctx, gomock.Any(), cycleCount).Return(nil),
+		mockObj.EXPECT().expectedStockTx(ctx, gomock.Any(), cycleCount).Return(expected, nil),
+		mockObj.EXPECT().retireItemsTx(ctx, gomock.Any(), "s", "a", 2).Return([]string{"i1", "i2"}, nil),
+		mockObj.EXPECT().recordAdjustmentTx(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(2),
+		mockObj.EXPECT().checkTrackingTx(ctx, gomock.Any(), "b", 1, "", gomock.Nil()).Return(nil),
+		mockObj.EXPECT().checkExpirationDateTx(ctx, gomock.Any(), "b", gomock.Nil()).Return(wms.ExpirationDateRequired),
+	)
+	_, err = cs.ApproveCycleCount(ctx, "c", wms.AdjustmentLost, nil)
+	if err != wms.ExpirationDateRequired {
+		t.Errorf("want: %v, got: %v", wms.ExpirationDateRequired, err)
+	}
+
+	mockObj.EXPECT().lockCycleCountTx(ctx, gomock.Any(), "c").Return(wms.CycleCount{Id: "c", Status: wms.CycleCountApproved}, nil)
+	_, err = cs.ApproveCycleCount(ctx, "c", wms.AdjustmentLost, nil)
+	if err != wms.CycleCountNotOpen {
+		t.Errorf("want: %v, got: %v", wms.CycleCountNotOpen, err)
+	}
+
+	mockObj.EXPECT().lockCycleCountTx(ctx, gomock.Any(), "c").Return(wms.CycleCount{}, sql.ErrNoRows)
+	_, err = cs.SubmitCounts(ctx, "c", []wms.CycleCountLine{{Sku: "a"}})
+	if err != wms.CycleCountDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.CycleCountDoesNotExist, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockCycleCountTx(ctx, gomock.Any(), "c").Return(wms.CycleCount{Id: "c", ShelfBlockId: "b", Status: wms.CycleCountOpen}, nil),
+		mockObj.EXPECT().shelfInCycleCountTx(ctx, gomock.Any(), gomock.Any(), "elsewhere").Return(false, nil),
+	)
+	_, err = cs.SubmitCounts(ctx, "c", []wms.CycleCountLine{{ShelfId: "elsewhere", Sku: "a"}})
+	if err != wms.ShelfNotInCycleCount {
+		t.Errorf("want: %v, got: %v", wms.ShelfNotInCycleCount, err)
+	}
+}
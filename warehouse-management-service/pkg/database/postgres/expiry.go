@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/expiry.go" -destination="./pkg/database/postgres/expiry_mock.go"
+type expiryQueries interface {
+	flagNearExpiryTx(ctx context.Context, tx *sql.Tx, now time.Time, until time.Time) (int, error)
+	quarantineExpiredTx(ctx context.Context, tx *sql.Tx, now time.Time) (int, error)
+	listExpiringItemsTx(ctx context.Context, tx *sql.Tx, warehouseId string, until time.Time) ([]wms.ExpiringItem, error)
+	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+}
+
+type expiryQueriesImpl struct {
+	shelfBlockQueriesImpl
+}
+
+type ExpiryService struct {
+	queries expiryQueries
+	db      *sql.DB
+}
+
+func NewExpiryService(db *sql.DB) *ExpiryService {
+	return &ExpiryService{
+		queries: new(expiryQueriesImpl),
+		db:      db,
+	}
+}
+
+// SweepExpiry flags the available items that expire within window of now
+// and quarantines the ones that have expired by now. Quarantined items are
+// no longer reserved or picked, though reservations and pick lists that
+// already hold them keep them.
+func (e *ExpiryService) SweepExpiry(ctx context.Context, now time.Time, window time.Duration) (wms.ExpirySweep, error) {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.ExpirySweep{}, err
+	}
+	defer tx.Rollback()
+
+	var sweep wms.ExpirySweep
+	sweep.Quarantined, err = e.queries.quarantineExpiredTx(ctx, tx, now)
+	if err != nil {
+		return wms.ExpirySweep{}, err
+	}
+	sweep.Flagged, err = e.queries.flagNearExpiryTx(ctx, tx, now, now.Add(window))
+	if err != nil {
+		return wms.ExpirySweep{}, err
+	}
+	return sweep, tx.Commit()
+}
+
+// ListExpiringItems returns the items of a warehouse that expire within
+// withinDays of now, including those already expired, soonest first.
+func (e *ExpiryService) ListExpiringItems(ctx context.Context, warehouseId string, now time.Time, withinDays int) ([]wms.ExpiringItem, error) {
+	tx, err := e.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if warehouseExists, err := e.queries.warehouseExistsTx(ctx, tx, warehouseId); err != nil {
+		return nil, err
+	} else if !warehouseExists {
+		return nil, wms.WarehouseDoesNotExist
+	}
+
+	items, err := e.queries.listExpiringItemsTx(ctx, tx, warehouseId, now.AddDate(0, 0, withinDays))
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].SetDaysLeft(now)
+	}
+	return items, tx.Commit()
+}
+
+func (e *expiryQueriesImpl) flagNearExpiryTx(ctx context.Context, tx *sql.Tx, now time.Time, until time.Time) (int, error) {
+	query := `UPDATE item SET near_expiry = true
+		WHERE status = $1 AND NOT near_expiry AND expiration_date > $2 AND expiration_date <= $3`
+
+	return execRowsAffectedTx(ctx, tx, query, wms.ItemAvailable, now, until)
+}
+
+func (e *expiryQueriesImpl) quarantineExpiredTx(ctx context.Context, tx *sql.Tx, now time.Time) (int, error) {
+	query := `UPDATE item SET status = $1 WHERE status = $2 AND expiration_date <= $3`
+
+	return execRowsAffectedTx(ctx, tx, query, wms.ItemQuarantined, wms.ItemAvailable, now)
+}
+
+func (e *expiryQueriesImpl) listExpiringItemsTx(ctx context.Context, tx *sql.Tx, warehouseId string, until time.Time) ([]wms.ExpiringItem, error) {
+	query := `SELECT item.id, item.sku, product.name, item.expiration_date, item.status,
+			shelf.id, shelf.label, shelf_block.aisle, shelf_block.rack
+		FROM item
+		JOIN product ON product.sku = item.sku
+		JOIN shelf ON shelf.id = item.shelf_id
+		JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		WHERE shelf_block.warehouse_id = $1 AND item.expiration_date <= $2
+			AND shelf.deleted_at IS NULL AND shelf_block.deleted_at IS NULL
+		ORDER BY item.expiration_date, item.sku, item.id`
+
+	rows, err := tx.QueryContext(ctx, query, warehouseId, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []wms.ExpiringItem{}
+	for rows.Next() {
+		var item wms.ExpiringItem
+		err := rows.Scan(
+			&item.ItemId,
+			&item.Sku,
+			&item.ProductName,
+			&item.ExpirationDate,
+			&item.Status,
+			&item.ShelfId,
+			&item.ShelfLabel,
+			&item.Aisle,
+			&item.Rack,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
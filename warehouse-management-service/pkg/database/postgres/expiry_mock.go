@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/expiry.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockexpiryQueries is a mock of expiryQueries interface.
+type MockexpiryQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockexpiryQueriesMockRecorder
+}
+
+// MockexpiryQueriesMockRecorder is the mock recorder for MockexpiryQueries.
+type MockexpiryQueriesMockRecorder struct {
+	mock *MockexpiryQueries
+}
+
+// NewMockexpiryQueries creates a new mock instance.
+func NewMockexpiryQueries(ctrl *gomock.Controller) *MockexpiryQueries {
+	mock := &MockexpiryQueries{ctrl: ctrl}
+	mock.recorder = &MockexpiryQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexpiryQueries) EXPECT() *MockexpiryQueriesMockRecorder {
+	return m.recorder
+}
+
+// flagNearExpiryTx mocks base method.
+func (m *MockexpiryQueries) flagNearExpiryTx(ctx context.Context, tx *sql.Tx, now, until time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "flagNearExpiryTx", ctx, tx, now, until)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// flagNearExpiryTx indicates an expected call of flagNearExpiryTx.
+func (mr *MockexpiryQueriesMockRecorder) flagNearExpiryTx(ctx, tx, now, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "flagNearExpiryTx", reflect.TypeOf((*MockexpiryQueries)(nil).flagNearExpiryTx), ctx, tx, now, until)
+}
+
+// listExpiringItemsTx mocks base method.
+func (m *MockexpiryQueries) listExpiringItemsTx(ctx context.Context, tx *sql.Tx, warehouseId string, until time.Time) ([]warehousemanagementservice.ExpiringItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listExpiringItemsTx", ctx, tx, warehouseId, until)
+	ret0, _ := ret[0].([]warehousemanagementservice.ExpiringItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listExpiringItemsTx indicates an expected call of listExpiringItemsTx.
+func (mr *MockexpiryQueriesMockRecorder) listExpiringItemsTx(ctx, tx, warehouseId, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listExpiringItemsTx", reflect.TypeOf((*MockexpiryQueries)(nil).listExpiringItemsTx), ctx, tx, warehouseId, until)
+}
+
+// quarantineExpiredTx mocks base method.
+func (m *MockexpiryQueries) quarantineExpiredTx(ctx context.Context, tx *sql.Tx, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "quarantineExpiredTx", ctx, tx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// quarantineExpiredTx indicates an expected call of quarantineExpiredTx.
+func (mr *MockexpiryQueriesMockRecorder) quarantineExpiredTx(ctx, tx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "quarantineExpiredTx", reflect.TypeOf((*MockexpiryQueries)(nil).quarantineExpiredTx), ctx, tx, now)
+}
+
+// warehouseExistsTx mocks base method.
+func (m *MockexpiryQueries) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseExistsTx indicates an expected call of warehouseExistsTx.
+func (mr *MockexpiryQueriesMockRecorder) warehouseExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseExistsTx", reflect.TypeOf((*MockexpiryQueries)(nil).warehouseExistsTx), ctx, tx, id)
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func TestSweepExpiryTx(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2030, time.January, 28, 0, 0, 0, 0, time.UTC)
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// testItem expires on 2030-01-31, three days after now.
+	expired := testItem()
+	expired.Id = "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f14"
+	expiredOn := now.AddDate(0, 0, -1)
+	expired.ExpirationDate = &expiredOn
+	for _, item := range []wms.Item{testItem(), expired} {
+		err = itemService.queries.createItemTx(ctx, tx, item)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	queries := expiryQueriesImpl{}
+	quarantined, err := queries.quarantineExpiredTx(ctx, tx, now)
+	if err != nil || quarantined != 1 {
+		t.Errorf("want 1 item quarantined, got: %v, %v", quarantined, err)
+	}
+	flagged, err := queries.flagNearExpiryTx(ctx, tx, now, now.AddDate(0, 0, 2))
+	if err != nil || flagged != 0 {
+		t.Errorf("want no item expiring within 2 days, got: %v, %v", flagged, err)
+	}
+	flagged, err = queries.flagNearExpiryTx(ctx, tx, now, now.AddDate(0, 0, 7))
+	if err != nil || flagged != 1 {
+		t.Errorf("want 1 item expiring within 7 days, got: %v, %v", flagged, err)
+	}
+
+	item, err := itemService.queries.getItemByIdTx(ctx, tx, expired.Id)
+	if err != nil || item.Status != wms.ItemQuarantined {
+		t.Errorf("want item %s quarantined, got: %v, %v", expired.Id, item, err)
+	}
+	item, err = itemService.queries.getItemByIdTx(ctx, tx, testItem().Id)
+	if err != nil || item.Status != wms.ItemAvailable || !item.NearExpiry {
+		t.Errorf("want item %s available and near expiry, got: %v, %v", testItem().Id, item, err)
+	}
+
+	items, err := queries.listExpiringItemsTx(ctx, tx, fixtureWarehouse.Id, now.AddDate(0, 0, 7))
+	if err != nil ||
+		len(items) != 2 ||
+		items[0].ItemId != expired.Id || items[0].Status != wms.ItemQuarantined ||
+		items[1].ItemId != testItem().Id || items[1].ProductName != testProduct.Name {
+		t.Errorf("want both items, expired first, got: %v, %v", items, err)
+	}
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	wms "warehouse-management-service"
+)
+
+// exportBatchSize is how many rows an export fetches from its cursor at once.
+const exportBatchSize = 500
+
+// mockgen -source="./pkg/database/postgres/export.go" -destination="./pkg/database/postgres/export_mock.go"
+type exportQueries interface {
+	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	exportWarehouseTx(ctx context.Context, tx *sql.Tx, warehouseId string, each func(wms.ExportRow) error) error
+}
+
+type exportQueriesImpl struct {
+	shelfBlockQueriesImpl
+}
+
+type ExportService struct {
+	queries exportQueries
+	db      *sql.DB
+}
+
+func NewExportService(db *sql.DB) *ExportService {
+	return &ExportService{
+		queries: new(exportQueriesImpl),
+		db:      db,
+	}
+}
+
+// ExportWarehouse calls each for every line of the export of a warehouse, in
+// layout order. The rows come from a single snapshot of the database, and
+// each is not called at all if the warehouse does not exist.
+func (e *ExportService) ExportWarehouse(ctx context.Context, warehouseId string, each func(wms.ExportRow) error) error {
+	tx, err := e.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	exists, err := e.queries.warehouseExistsTx(ctx, tx, warehouseId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return wms.WarehouseDoesNotExist
+	}
+
+	err = e.queries.exportWarehouseTx(ctx, tx, warehouseId, each)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (e *exportQueriesImpl) exportWarehouseTx(ctx context.Context, tx *sql.Tx, warehouseId string, each func(wms.ExportRow) error) error {
+	query := `SELECT w.id, coalesce(w.name, ''),
+			coalesce(b.id, ''), coalesce(b.aisle, ''), coalesce(b.rack, ''), coalesce(b.storage_type, ''),
+			coalesce(s.id, ''), coalesce(s.label, ''), coalesce(s.section, ''), coalesce(s.level, ''),
+			coalesce(i.id, ''), coalesce(i.sku, ''), i.expiration_date, i.received_on
+		FROM warehouse w
+		LEFT JOIN shelf_block b ON b.warehouse_id = w.id AND b.deleted_at IS NULL
+		LEFT JOIN shelf s ON s.shelf_block = b.id AND s.deleted_at IS NULL
+		LEFT JOIN item i ON i.shelf_id = s.id
+		WHERE w.id = $1 AND w.deleted_at IS NULL
+		ORDER BY b.aisle, b.rack, b.id, s.section, s.level, s.id, i.id`
+
+	scan := func(rows *sql.Rows) error {
+		var row wms.ExportRow
+		err := rows.Scan(
+			&row.WarehouseId,
+			&row.WarehouseName,
+			&row.ShelfBlockId,
+			&row.Aisle,
+			&row.Rack,
+			&row.StorageType,
+			&row.ShelfId,
+			&row.ShelfLabel,
+			&row.Section,
+			&row.Level,
+			&row.ItemId,
+			&row.Sku,
+			&row.ExpirationDate,
+			&row.ReceivedOn,
+		)
+		if err != nil {
+			return err
+		}
+		return each(row)
+	}
+
+	return forEachRowTx(ctx, tx, "warehouse_export", exportBatchSize, scan, query, warehouseId)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/export.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockexportQueries is a mock of exportQueries interface.
+type MockexportQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockexportQueriesMockRecorder
+}
+
+// MockexportQueriesMockRecorder is the mock recorder for MockexportQueries.
+type MockexportQueriesMockRecorder struct {
+	mock *MockexportQueries
+}
+
+// NewMockexportQueries creates a new mock instance.
+func NewMockexportQueries(ctrl *gomock.Controller) *MockexportQueries {
+	mock := &MockexportQueries{ctrl: ctrl}
+	mock.recorder = &MockexportQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexportQueries) EXPECT() *MockexportQueriesMockRecorder {
+	return m.recorder
+}
+
+// exportWarehouseTx mocks base method.
+func (m *MockexportQueries) exportWarehouseTx(ctx context.Context, tx *sql.Tx, warehouseId string, each func(warehousemanagementservice.ExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "exportWarehouseTx", ctx, tx, warehouseId, each)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// exportWarehouseTx indicates an expected call of exportWarehouseTx.
+func (mr *MockexportQueriesMockRecorder) exportWarehouseTx(ctx, tx, warehouseId, each interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "exportWarehouseTx", reflect.TypeOf((*MockexportQueries)(nil).exportWarehouseTx), ctx, tx, warehouseId, each)
+}
+
+// warehouseExistsTx mocks base method.
+func (m *MockexportQueries) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseExistsTx indicates an expected call of warehouseExistsTx.
+func (mr *MockexportQueriesMockRecorder) warehouseExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseExistsTx", reflect.TypeOf((*MockexportQueries)(nil).warehouseExistsTx), ctx, tx, id)
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"testing"
+	wms "warehouse-management-service"
+)
+
+func TestForEachRowTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	var got []int
+	scan := func(rows *sql.Rows) error {
+		var n int
+		err := rows.Scan(&n)
+		got = append(got, n)
+		return err
+	}
+	err = forEachRowTx(ctx, tx, "test_cursor", 2, scan, `SELECT generate_series(1, $1::int)`, 5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(got) != 5 || got[0] != 1 || got[4] != 5 {
+		t.Errorf("expected: %v, got: %v", []int{1, 2, 3, 4, 5}, got)
+	}
+}
+
+func TestExportWarehouseTx(t *testing.T) {
+	ctx := context.Background()
+	item := testItem()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	emptyBlock := wms.NewShelfBlock("9", "9", "regular", fixtureWarehouse.Id)
+	err = shelfBlockService.queries.createShelfBlockTx(ctx, tx, emptyBlock)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var rows []wms.ExportRow
+	queries := exportQueriesImpl{}
+	err = queries.exportWarehouseTx(ctx, tx, fixtureWarehouse.Id, func(row wms.ExportRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected: 2 rows, got: %v", rows)
+	}
+	if rows[0].ShelfId != fixtureShelf.Id || rows[0].ItemId != item.Id || rows[0].ReceivedOn == nil {
+		t.Errorf("expected the item on %v, got: %v", fixtureShelf.Id, rows[0])
+	}
+	if rows[1].ShelfBlockId != emptyBlock.Id || rows[1].ShelfId != "" || rows[1].ReceivedOn != nil {
+		t.Errorf("expected the empty shelf block %v, got: %v", emptyBlock.Id, rows[1])
+	}
+}
+
+func TestExportWarehouse(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockexportQueries(mockCtrl)
+
+	es := ExportService{db: warehouseService.db, queries: mockObj}
+	each := func(wms.ExportRow) error { return nil }
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "missing").Return(false, nil)
+	err := es.ExportWarehouse(ctx, "missing", each)
+	if err != wms.WarehouseDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.WarehouseDoesNotExist, err)
+	}
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "w").Return(true, nil)
+	mockObj.EXPECT().exportWarehouseTx(ctx, gomock.Any(), "w", gomock.Any()).Return(sql.ErrConnDone)
+	err = es.ExportWarehouse(ctx, "w", each)
+	if err != sql.ErrConnDone {
+		t.Errorf("want: %v, got: %v", sql.ErrConnDone, err)
+	}
+}
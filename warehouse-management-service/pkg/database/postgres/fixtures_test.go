@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+var fixtureWarehouse = wms.Warehouse{
+	Id:        "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e01",
+	Name:      "fixture_warehouse",
+	Latitude:  12.9716,
+	Longitude: 77.5946,
+	Version:   1,
+}
+
+var fixtureShelfBlock = wms.ShelfBlock{
+	Id:          "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e02",
+	Aisle:       "1",
+	Rack:        "1",
+	StorageType: "regular",
+	WarehouseId: fixtureWarehouse.Id,
+	Version:     1,
+}
+
+var fixtureShelf = wms.Shelf{
+	Id:           "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e03",
+	Label:        "1A",
+	Section:      "A",
+	Level:        "1",
+	ShelfBlockId: fixtureShelfBlock.Id,
+	Version:      1,
+}
+
+// fixtureDestination is a second warehouse, with fixtureDestinationShelf in
+// it, for tests that transfer items between warehouses.
+var fixtureDestination = wms.Warehouse{
+	Id:        "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e04",
+	Name:      "fixture_destination",
+	Latitude:  13.0827,
+	Longitude: 80.2707,
+	Version:   1,
+}
+
+var fixtureDestinationShelfBlock = wms.ShelfBlock{
+	Id:          "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e05",
+	Aisle:       "1",
+	Rack:        "1",
+	StorageType: "regular",
+	WarehouseId: fixtureDestination.Id,
+	Version:     1,
+}
+
+var fixtureDestinationShelf = wms.Shelf{
+	Id:           "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e06",
+	Label:        "1A",
+	Section:      "A",
+	Level:        "1",
+	ShelfBlockId: fixtureDestinationShelfBlock.Id,
+	Version:      1,
+}
+
+// insertFixtures inserts a warehouse, a shelf block and a shelf inside tx,
+// for tests that need a location to hang items off.
+func insertFixtures(t *testing.T, tx *sql.Tx) bool {
+	t.Helper()
+	return insertLocation(t, tx, fixtureWarehouse, fixtureShelfBlock, fixtureShelf)
+}
+
+// insertDestinationFixtures inserts fixtureDestination, its shelf block and
+// its shelf inside tx.
+func insertDestinationFixtures(t *testing.T, tx *sql.Tx) bool {
+	t.Helper()
+	return insertLocation(t, tx, fixtureDestination, fixtureDestinationShelfBlock, fixtureDestinationShelf)
+}
+
+func insertLocation(t *testing.T, tx *sql.Tx, warehouse wms.Warehouse, block wms.ShelfBlock, shelf wms.Shelf) bool {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO warehouse (id, name, geolocation) VALUES ($1, $2, point($3, $4))",
+		warehouse.Id,
+		warehouse.Name,
+		warehouse.Longitude,
+		warehouse.Latitude,
+	)
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO shelf_block(id, aisle, rack, storage_type, warehouse_id) VALUES ($1, $2, $3, $4, $5)",
+		block.Id,
+		block.Aisle,
+		block.Rack,
+		block.StorageType,
+		block.WarehouseId,
+	)
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO shelf(id, label, section, level, shelf_block) VALUES ($1, $2, $3, $4, $5)",
+		shelf.Id,
+		shelf.Label,
+		shelf.Section,
+		shelf.Level,
+		shelf.ShelfBlockId,
+	)
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+
+	return true
+}
+
+// commitFixtures inserts the fixtures and testProduct and commits them, for
+// tests that need two transactions to see the same rows. The returned func
+// removes them again, along with what the test hung off them.
+func commitFixtures(t *testing.T) (func(), bool) {
+	t.Helper()
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return nil, false
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return nil, false
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return nil, false
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Error(err)
+		return nil, false
+	}
+
+	return removeCommittedFixtures, true
+}
+
+func removeCommittedFixtures() {
+	warehouseIds := []interface{}{fixtureWarehouse.Id, fixtureDestination.Id}
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{query: `DELETE FROM sales_order WHERE warehouse_id IN ($1, $2)`, args: warehouseIds},
+		{query: `DELETE FROM reservation WHERE warehouse_id IN ($1, $2)`, args: warehouseIds},
+		{query: `DELETE FROM cycle_count WHERE warehouse_id IN ($1, $2)`, args: warehouseIds},
+		{query: `DELETE FROM transfer WHERE from_warehouse_id IN ($1, $2) OR to_warehouse_id IN ($1, $2)`, args: warehouseIds},
+		{query: `DELETE FROM item WHERE sku = $1`, args: []interface{}{testProduct.Sku}},
+		{query: `DELETE FROM stock_movement WHERE sku = $1`, args: []interface{}{testProduct.Sku}},
+		{query: `DELETE FROM stock_adjustment WHERE sku = $1`, args: []interface{}{testProduct.Sku}},
+		{query: `DELETE FROM shelf WHERE shelf_block IN (SELECT id FROM shelf_block WHERE warehouse_id IN ($1, $2))`, args: warehouseIds},
+		{query: `DELETE FROM shelf_block WHERE warehouse_id IN ($1, $2)`, args: warehouseIds},
+		{query: `DELETE FROM warehouse WHERE id IN ($1, $2)`, args: warehouseIds},
+		{query: `DELETE FROM product WHERE sku = $1`, args: []interface{}{testProduct.Sku}},
+		{query: `DELETE FROM audit_event WHERE entity_id IN ($1, $2, $3)`, args: []interface{}{fixtureWarehouse.Id, fixtureShelfBlock.Id, fixtureShelf.Id}},
+	}
+	for _, statement := range statements {
+		warehouseService.db.Exec(statement.query, statement.args...)
+	}
+}
+
+// awaitBlocked waits until some transaction is queued on a lock, so a test
+// can end the transaction holding it knowing the other one has got that far.
+func awaitBlocked(t *testing.T) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		var blocked bool
+		err := warehouseService.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM pg_locks WHERE NOT granted)`).Scan(&blocked)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if blocked {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("no transaction is waiting on a lock")
+}
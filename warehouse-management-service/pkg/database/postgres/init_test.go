@@ -16,6 +16,8 @@ const (
 var warehouseService *WarehouseService
 var shelfBlockService *ShelfBlockService
 var shelfService *ShelfService
+var productService *ProductService
+var itemService *ItemService
 var postgres *Postgres
 
 func TestMain(m *testing.M) {
@@ -45,6 +47,8 @@ func TestMain(m *testing.M) {
 	warehouseService = NewWarehouseService(db)
 	shelfBlockService = NewShelfBlockService(db)
 	shelfService = NewShelfService(db)
+	productService = NewProductService(db)
+	itemService = NewItemService(db)
 
 	mockDB, err := postgres.Open()
 	if err != nil {
@@ -0,0 +1,398 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/item.go" -destination="./pkg/database/postgres/item_mock.go"
+type itemQueries interface {
+	createItemTx(ctx context.Context, tx *sql.Tx, item wms.Item) error
+	getItemByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Item, error)
+	getItemBySerialNumberTx(ctx context.Context, tx *sql.Tx, serialNumber string) (wms.Item, error)
+	updateItemShelfTx(ctx context.Context, tx *sql.Tx, id string, shelfId string) error
+	deleteItemTx(ctx context.Context, tx *sql.Tx, id string) error
+	productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error)
+	shelfExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) error
+}
+
+type itemQueriesImpl struct{}
+
+type ItemService struct {
+	queries itemQueries
+	db      *sql.DB
+}
+
+var InvalidProduct = errors.New("invalid sku")
+var InvalidShelf = errors.New("invalid shelfId")
+
+func NewItemService(db *sql.DB) *ItemService {
+	return &ItemService{
+		queries: new(itemQueriesImpl),
+		db:      db,
+	}
+}
+
+func (i *ItemService) GetItemById(ctx context.Context, id string) (wms.Item, error) {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Item{}, err
+	}
+	defer tx.Rollback()
+
+	item, err := i.queries.getItemByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return item, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.Item{}, wms.ItemDoesNotExist
+	default:
+		return wms.Item{}, err
+	}
+}
+
+func (i *ItemService) GetItemBySerialNumber(ctx context.Context, serialNumber string) (wms.Item, error) {
+	tx, err := i.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wms.Item{}, err
+	}
+	defer tx.Rollback()
+
+	item, err := i.queries.getItemBySerialNumberTx(ctx, tx, serialNumber)
+	switch err {
+	case nil:
+		return item, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.Item{}, wms.ItemDoesNotExist
+	default:
+		return wms.Item{}, err
+	}
+}
+
+func (i *ItemService) CreateItem(ctx context.Context, item wms.Item) error {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = i.queries.createItemTx(ctx, tx, item)
+	switch err {
+	case nil:
+		return tx.Commit()
+	case InvalidProduct:
+		return wms.InvalidProduct
+	case InvalidShelf:
+		return wms.InvalidShelf
+	default:
+		return err
+	}
+}
+
+func (i *ItemService) MoveItem(ctx context.Context, id string, shelfId string) error {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = i.queries.updateItemShelfTx(ctx, tx, id, shelfId)
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.ItemDoesNotExist
+	case InvalidShelf:
+		return wms.InvalidShelf
+	default:
+		return err
+	}
+}
+
+func (i *ItemService) DeleteItemById(ctx context.Context, id string) error {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = i.queries.deleteItemTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.ItemDoesNotExist
+	default:
+		return err
+	}
+}
+
+func (i *itemQueriesImpl) createItemTx(ctx context.Context, tx *sql.Tx, item wms.Item) error {
+	if productExists, err := i.productExistsTx(ctx, tx, item.Sku); err != nil {
+		return err
+	} else if !productExists {
+		return InvalidProduct
+	}
+	var serialNumbers []string
+	if item.SerialNumber != "" {
+		serialNumbers = []string{item.SerialNumber}
+	}
+	err := i.checkTrackingTx(ctx, tx, item.Sku, 1, item.Lot, serialNumbers)
+	if err != nil {
+		return err
+	}
+
+	if shelfExists, err := i.shelfExistsTx(ctx, tx, item.ShelfId); err != nil {
+		return err
+	} else if !shelfExists {
+		return InvalidShelf
+	}
+
+	err = i.checkShelfCapacityTx(ctx, tx, item.ShelfId, item.Sku, 1)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO item(id, sku, expiration_date, received_on, shelf_id, lot, serial_number)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''))`
+
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		item.Id,
+		item.Sku,
+		item.ExpirationDate,
+		item.ReceivedOn,
+		item.ShelfId,
+		item.Lot,
+		item.SerialNumber,
+	)
+	if isUniqueViolation(err, itemSerialNumberIndex) {
+		return wms.DuplicateSerialNumber
+	}
+	return err
+}
+
+// itemSerialNumberIndex keeps serial numbers unique among items. It settles
+// the race between two receipts of one serial number that checkTrackingTx
+// cannot see.
+const itemSerialNumberIndex = "item_serial_number_idx"
+
+const itemColumns = `id, sku, expiration_date, received_on, shelf_id, status, near_expiry, COALESCE(lot, ''), COALESCE(serial_number, '')`
+
+func (i *itemQueriesImpl) getItemByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Item, error) {
+	return i.scanItem(tx.QueryRowContext(ctx, `SELECT `+itemColumns+` FROM item WHERE id=$1`, id))
+}
+
+func (i *itemQueriesImpl) getItemBySerialNumberTx(ctx context.Context, tx *sql.Tx, serialNumber string) (wms.Item, error) {
+	return i.scanItem(tx.QueryRowContext(ctx, `SELECT `+itemColumns+` FROM item WHERE serial_number=$1`, serialNumber))
+}
+
+func (i *itemQueriesImpl) scanItem(row *sql.Row) (wms.Item, error) {
+	var item wms.Item
+	var expirationDate sql.NullTime
+
+	err := row.Scan(
+		&item.Id,
+		&item.Sku,
+		&expirationDate,
+		&item.ReceivedOn,
+		&item.ShelfId,
+		&item.Status,
+		&item.NearExpiry,
+		&item.Lot,
+		&item.SerialNumber,
+	)
+	if err != nil {
+		return wms.Item{}, err
+	}
+	if expirationDate.Valid {
+		item.ExpirationDate = &expirationDate.Time
+	}
+	return item, nil
+}
+
+func (i *itemQueriesImpl) updateItemShelfTx(ctx context.Context, tx *sql.Tx, id string, shelfId string) error {
+	toWarehouseId, err := i.shelfWarehouseTx(ctx, tx, shelfId)
+	if err == sql.ErrNoRows {
+		return InvalidShelf
+	}
+	if err != nil {
+		return err
+	}
+
+	item, err := i.lockTransferItemTx(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return RowDoesNotExist
+	}
+	if err != nil {
+		return err
+	}
+	if item.ShelfId == shelfId {
+		return nil
+	}
+
+	// a move is held to the same rules as a transfer within the warehouse
+	err = item.CheckMove(shelfId, toWarehouseId)
+	if err != nil {
+		return err
+	}
+	err = i.checkShelfCapacityTx(ctx, tx, shelfId, item.Sku, 1)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE item SET shelf_id = $1 WHERE id = $2`, shelfId, id)
+	if err != nil {
+		return err
+	}
+
+	return recordMovementTx(ctx, tx, wms.StockMovement{
+		ItemId:      id,
+		Sku:         item.Sku,
+		FromShelfId: item.ShelfId,
+		ToShelfId:   shelfId,
+		Kind:        wms.MovementMove,
+	})
+}
+
+// deleteItemTx deletes an item that is not claimed, and records it leaving
+// its shelf in the stock ledger.
+func (i *itemQueriesImpl) deleteItemTx(ctx context.Context, tx *sql.Tx, id string) error {
+	item, err := i.lockTransferItemTx(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return RowDoesNotExist
+	}
+	if err != nil {
+		return err
+	}
+	if item.Claimed {
+		return wms.ItemClaimed
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM item WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return recordMovementTx(ctx, tx, wms.StockMovement{
+		ItemId:      id,
+		Sku:         item.Sku,
+		FromShelfId: item.ShelfId,
+		Kind:        wms.MovementRemove,
+	})
+}
+
+func (i *itemQueriesImpl) productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM product WHERE sku = $1)`
+
+	var exists bool
+	row := tx.QueryRowContext(ctx, query, sku)
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (i *itemQueriesImpl) shelfExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM shelf WHERE id = $1 AND deleted_at IS NULL)`
+
+	var exists bool
+	row := tx.QueryRowContext(ctx, query, id)
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// checkTrackingTx checks that quantity items of sku come with the lot and
+// serial numbers their product asks for, and that none of the serial
+// numbers is on an item already.
+func (i *itemQueriesImpl) checkTrackingTx(ctx context.Context, tx *sql.Tx, sku string, quantity int, lot string, serialNumbers []string) error {
+	var product wms.Product
+	row := tx.QueryRowContext(ctx, `SELECT lot_tracked, serialized FROM product WHERE sku = $1`, sku)
+	err := row.Scan(&product.LotTracked, &product.Serialized)
+	if err == sql.ErrNoRows {
+		return InvalidProduct
+	}
+	if err != nil {
+		return err
+	}
+
+	err = product.CheckLot(lot)
+	if err != nil {
+		return err
+	}
+	err = product.CheckSerialNumbers(quantity, serialNumbers)
+	if err != nil {
+		return err
+	}
+
+	for _, serialNumber := range serialNumbers {
+		var exists bool
+		row := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM item WHERE serial_number = $1)`, serialNumber)
+		err := row.Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return wms.DuplicateSerialNumber
+		}
+	}
+	return nil
+}
+
+// checkExpirationDateTx checks that an item of sku comes with an expiration
+// date if the product is perishable.
+func (i *itemQueriesImpl) checkExpirationDateTx(ctx context.Context, tx *sql.Tx, sku string, expirationDate *time.Time) error {
+	var product wms.Product
+	row := tx.QueryRowContext(ctx, `SELECT perishable FROM product WHERE sku = $1`, sku)
+	err := row.Scan(&product.Perishable)
+	if err == sql.ErrNoRows {
+		return InvalidProduct
+	}
+	if err != nil {
+		return err
+	}
+	return product.CheckExpirationDate(expirationDate)
+}
+
+// checkShelfCapacityTx checks that quantity more items of sku fit on a
+// shelf. It locks the shelf row first, so that placements onto the same
+// shelf are checked one after the other.
+func (i *itemQueriesImpl) checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) error {
+	row := tx.QueryRowContext(ctx, `SELECT `+shelfColumns+` FROM shelf WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, shelfId)
+	shelf, err := scanShelf(row.Scan)
+	if err == sql.ErrNoRows {
+		return InvalidShelf
+	}
+	if err != nil {
+		return err
+	}
+
+	used, err := queryShelfUtilizationTx(ctx, tx, shelfId)
+	if err != nil {
+		return err
+	}
+
+	query := `SELECT COALESCE(length_in_cm, 0), COALESCE(width_in_cm, 0), COALESCE(breadth_in_cm, 0), COALESCE(weight_in_kg, 0)
+		FROM product WHERE sku = $1`
+
+	var product wms.Product
+	row = tx.QueryRowContext(ctx, query, sku)
+	err = row.Scan(&product.LengthInCm, &product.WidthInCm, &product.BreadthInCm, &product.WeightInKg)
+	if err == sql.ErrNoRows {
+		return InvalidProduct
+	}
+	if err != nil {
+		return err
+	}
+
+	return shelf.CheckCapacity(used, product, quantity)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/item.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockitemQueries is a mock of itemQueries interface.
+type MockitemQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockitemQueriesMockRecorder
+}
+
+// MockitemQueriesMockRecorder is the mock recorder for MockitemQueries.
+type MockitemQueriesMockRecorder struct {
+	mock *MockitemQueries
+}
+
+// NewMockitemQueries creates a new mock instance.
+func NewMockitemQueries(ctrl *gomock.Controller) *MockitemQueries {
+	mock := &MockitemQueries{ctrl: ctrl}
+	mock.recorder = &MockitemQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockitemQueries) EXPECT() *MockitemQueriesMockRecorder {
+	return m.recorder
+}
+
+// checkShelfCapacityTx mocks base method.
+func (m *MockitemQueries) checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId, sku string, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkShelfCapacityTx", ctx, tx, shelfId, sku, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// checkShelfCapacityTx indicates an expected call of checkShelfCapacityTx.
+func (mr *MockitemQueriesMockRecorder) checkShelfCapacityTx(ctx, tx, shelfId, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkShelfCapacityTx", reflect.TypeOf((*MockitemQueries)(nil).checkShelfCapacityTx), ctx, tx, shelfId, sku, quantity)
+}
+
+// createItemTx mocks base method.
+func (m *MockitemQueries) createItemTx(ctx context.Context, tx *sql.Tx, item warehousemanagementservice.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createItemTx", ctx, tx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createItemTx indicates an expected call of createItemTx.
+func (mr *MockitemQueriesMockRecorder) createItemTx(ctx, tx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createItemTx", reflect.TypeOf((*MockitemQueries)(nil).createItemTx), ctx, tx, item)
+}
+
+// deleteItemTx mocks base method.
+func (m *MockitemQueries) deleteItemTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deleteItemTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// deleteItemTx indicates an expected call of deleteItemTx.
+func (mr *MockitemQueriesMockRecorder) deleteItemTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteItemTx", reflect.TypeOf((*MockitemQueries)(nil).deleteItemTx), ctx, tx, id)
+}
+
+// getItemByIdTx mocks base method.
+func (m *MockitemQueries) getItemByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getItemByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getItemByIdTx indicates an expected call of getItemByIdTx.
+func (mr *MockitemQueriesMockRecorder) getItemByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getItemByIdTx", reflect.TypeOf((*MockitemQueries)(nil).getItemByIdTx), ctx, tx, id)
+}
+
+// getItemBySerialNumberTx mocks base method.
+func (m *MockitemQueries) getItemBySerialNumberTx(ctx context.Context, tx *sql.Tx, serialNumber string) (warehousemanagementservice.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getItemBySerialNumberTx", ctx, tx, serialNumber)
+	ret0, _ := ret[0].(warehousemanagementservice.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getItemBySerialNumberTx indicates an expected call of getItemBySerialNumberTx.
+func (mr *MockitemQueriesMockRecorder) getItemBySerialNumberTx(ctx, tx, serialNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getItemBySerialNumberTx", reflect.TypeOf((*MockitemQueries)(nil).getItemBySerialNumberTx), ctx, tx, serialNumber)
+}
+
+// productExistsTx mocks base method.
+func (m *MockitemQueries) productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "productExistsTx", ctx, tx, sku)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// productExistsTx indicates an expected call of productExistsTx.
+func (mr *MockitemQueriesMockRecorder) productExistsTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "productExistsTx", reflect.TypeOf((*MockitemQueries)(nil).productExistsTx), ctx, tx, sku)
+}
+
+// shelfExistsTx mocks base method.
+func (m *MockitemQueries) shelfExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfExistsTx indicates an expected call of shelfExistsTx.
+func (mr *MockitemQueriesMockRecorder) shelfExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfExistsTx", reflect.TypeOf((*MockitemQueries)(nil).shelfExistsTx), ctx, tx, id)
+}
+
+// updateItemShelfTx mocks base method.
+func (m *MockitemQueries) updateItemShelfTx(ctx context.Context, tx *sql.Tx, id, shelfId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "updateItemShelfTx", ctx, tx, id, shelfId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// updateItemShelfTx indicates an expected call of updateItemShelfTx.
+func (mr *MockitemQueriesMockRecorder) updateItemShelfTx(ctx, tx, id, shelfId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "updateItemShelfTx", reflect.TypeOf((*MockitemQueries)(nil).updateItemShelfTx), ctx, tx, id, shelfId)
+}
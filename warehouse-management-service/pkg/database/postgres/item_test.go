@@ -0,0 +1,629 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func testItem() wms.Item {
+	expirationDate := time.Date(2030, time.January, 31, 0, 0, 0, 0, time.UTC)
+	return wms.Item{
+		Id:             "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f10",
+		Sku:            testProduct.Sku,
+		ExpirationDate: &expirationDate,
+		ReceivedOn:     time.Date(2023, time.January, 10, 9, 30, 0, 0, time.UTC),
+		ShelfId:        fixtureShelf.Id,
+		Status:         wms.ItemAvailable,
+	}
+}
+
+func TestCreateItemTx(t *testing.T) {
+	ctx := context.Background()
+	item := testItem()
+
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	itemFromDB, err := itemService.queries.getItemByIdTx(ctx, tx, item.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if itemFromDB.Id != item.Id ||
+		itemFromDB.Sku != item.Sku ||
+		itemFromDB.ShelfId != item.ShelfId ||
+		itemFromDB.Status != wms.ItemAvailable ||
+		!itemFromDB.ReceivedOn.Equal(item.ReceivedOn) ||
+		itemFromDB.ExpirationDate == nil ||
+		!itemFromDB.ExpirationDate.Equal(*item.ExpirationDate) {
+		t.Errorf("expected: %v, got: %v", item, itemFromDB)
+	}
+}
+
+func TestCreateItemTxError(t *testing.T) {
+	ctx := context.Background()
+	item := testItem()
+
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != InvalidProduct {
+		t.Errorf("expected: %v, got: %v", InvalidProduct, err)
+	}
+
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != InvalidShelf {
+		t.Errorf("expected: %v, got: %v", InvalidShelf, err)
+	}
+}
+
+func TestUpdateItemShelfTx(t *testing.T) {
+	ctx := context.Background()
+	item := testItem()
+
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO shelf(id, label, section, level, shelf_block) VALUES ($1, $2, $3, $4, $5)",
+		"move_target",
+		"1B",
+		"B",
+		"1",
+		fixtureShelfBlock.Id,
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = itemService.queries.updateItemShelfTx(ctx, tx, item.Id, "non-existent-shelf")
+	if err != InvalidShelf {
+		t.Errorf("expected: %v, got: %v", InvalidShelf, err)
+	}
+
+	err = itemService.queries.updateItemShelfTx(ctx, tx, "non-existent-item", "move_target")
+	if err != RowDoesNotExist {
+		t.Errorf("expected: %v, got: %v", RowDoesNotExist, err)
+	}
+
+	err = itemService.queries.updateItemShelfTx(ctx, tx, item.Id, "move_target")
+	if err != nil {
+		t.Error(err)
+	}
+
+	itemFromDB, err := itemService.queries.getItemByIdTx(ctx, tx, item.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if itemFromDB.ShelfId != "move_target" {
+		t.Errorf("expected: %v, got: %v", "move_target", itemFromDB.ShelfId)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE item SET status = $1 WHERE id = $2", wms.ItemQuarantined, item.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.updateItemShelfTx(ctx, tx, item.Id, fixtureShelf.Id)
+	if !errors.Is(err, wms.ItemNotTransferable) {
+		t.Errorf("want a quarantined item rejected, got: %v", err)
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE item SET status = $1 WHERE id = $2", wms.ItemAvailable, item.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	elsewhere := wms.Warehouse{Id: "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e21", Name: "move_elsewhere", Latitude: 13.0827, Longitude: 80.2707}
+	err = warehouseService.queries.createWarehouseTx(ctx, tx, &elsewhere)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	block := wms.ShelfBlock{
+		Id:          "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e22",
+		Aisle:       "1",
+		Rack:        "1",
+		StorageType: fixtureShelfBlock.StorageType,
+		WarehouseId: elsewhere.Id,
+	}
+	err = shelfBlockService.queries.createShelfBlockTx(ctx, tx, block)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	shelf := wms.Shelf{Id: "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e23", Label: "1A", Section: "A", Level: "1", ShelfBlockId: block.Id}
+	err = shelfService.queries.createShelfTx(ctx, tx, shelf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.updateItemShelfTx(ctx, tx, item.Id, shelf.Id)
+	if !errors.Is(err, wms.ItemNotTransferable) {
+		t.Errorf("want a move into another warehouse rejected, got: %v", err)
+	}
+}
+
+func TestDeleteItemTx(t *testing.T) {
+	ctx := context.Background()
+	item := testItem()
+
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = itemService.queries.deleteItemTx(ctx, tx, item.Id)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = itemService.queries.getItemByIdTx(ctx, tx, item.Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("expected: %v, got: %v", sql.ErrNoRows, err)
+	}
+
+	var fromShelfId, kind string
+	var toShelfId sql.NullString
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT from_shelf_id, to_shelf_id, kind FROM stock_movement WHERE item_id = $1`,
+		item.Id,
+	).Scan(&fromShelfId, &toShelfId, &kind)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if fromShelfId != item.ShelfId || toShelfId.Valid || kind != wms.MovementRemove {
+		t.Errorf("want the item recorded leaving shelf %s for nowhere, got: %s, %v, %s", item.ShelfId, fromShelfId, toShelfId, kind)
+	}
+}
+
+func TestDeleteItemTxClaimed(t *testing.T) {
+	ctx := context.Background()
+	item := testItem()
+
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	reservations := reservationQueriesImpl{}
+	reservation := wms.NewReservation(fixtureWarehouse.Id, testProduct.Sku, 1, "", time.Minute)
+	err = reservations.createReservationTx(ctx, tx, reservation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO reservation_item(item_id, reservation_id) VALUES ($1, $2)`, item.Id, reservation.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = itemService.queries.deleteItemTx(ctx, tx, item.Id)
+	if err != wms.ItemClaimed {
+		t.Errorf("expected: %v, got: %v", wms.ItemClaimed, err)
+	}
+}
+
+func TestDeleteItemTxError(t *testing.T) {
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = itemService.queries.deleteItemTx(context.Background(), tx, "non-existent-id")
+	if err != RowDoesNotExist {
+		t.Errorf("expected: %v, got: %v", RowDoesNotExist, err)
+	}
+}
+
+func TestGetItemById(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockitemQueries(mockCtrl)
+
+	ctx := context.Background()
+	item := testItem()
+
+	tests := []struct {
+		getItemByIdTxResponse wms.Item
+		getItemByIdTxErr      error
+		wantResponse          wms.Item
+		wantErr               error
+	}{
+		{getItemByIdTxResponse: item, getItemByIdTxErr: nil, wantResponse: item, wantErr: nil},
+		{getItemByIdTxResponse: wms.Item{}, getItemByIdTxErr: sql.ErrNoRows, wantResponse: wms.Item{}, wantErr: wms.ItemDoesNotExist},
+		{getItemByIdTxResponse: wms.Item{}, getItemByIdTxErr: sql.ErrConnDone, wantResponse: wms.Item{}, wantErr: sql.ErrConnDone},
+		{getItemByIdTxResponse: wms.Item{}, getItemByIdTxErr: context.Canceled, wantResponse: wms.Item{}, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().getItemByIdTx(ctx, gomock.Any(), item.Id).Return(test.getItemByIdTxResponse, test.getItemByIdTxErr)
+
+		mockItemService := &ItemService{
+			queries: mockObj,
+			db:      itemService.db,
+		}
+
+		response, err := mockItemService.GetItemById(ctx, item.Id)
+
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+
+		if response != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, response)
+		}
+	}
+}
+
+func TestCreateItem(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockitemQueries(mockCtrl)
+
+	ctx := context.Background()
+	item := testItem()
+
+	tests := []struct {
+		createItemTxErr error
+		wantErr         error
+	}{
+		{createItemTxErr: nil, wantErr: nil},
+		{createItemTxErr: InvalidProduct, wantErr: wms.InvalidProduct},
+		{createItemTxErr: InvalidShelf, wantErr: wms.InvalidShelf},
+		{createItemTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+		{createItemTxErr: context.Canceled, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().createItemTx(ctx, gomock.Any(), item).Return(test.createItemTxErr)
+
+		mockItemService := &ItemService{
+			queries: mockObj,
+			db:      itemService.db,
+		}
+
+		err := mockItemService.CreateItem(ctx, item)
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+	}
+}
+
+func TestMoveItem(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockitemQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		updateItemShelfTxErr error
+		wantErr              error
+	}{
+		{updateItemShelfTxErr: nil, wantErr: nil},
+		{updateItemShelfTxErr: RowDoesNotExist, wantErr: wms.ItemDoesNotExist},
+		{updateItemShelfTxErr: InvalidShelf, wantErr: wms.InvalidShelf},
+		{updateItemShelfTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+		{updateItemShelfTxErr: context.Canceled, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().updateItemShelfTx(ctx, gomock.Any(), "item", "shelf").Return(test.updateItemShelfTxErr)
+
+		mockItemService := &ItemService{
+			queries: mockObj,
+			db:      itemService.db,
+		}
+
+		err := mockItemService.MoveItem(ctx, "item", "shelf")
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+	}
+}
+
+func TestDeleteItemById(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockitemQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		deleteItemTxErr error
+		wantErr         error
+	}{
+		{deleteItemTxErr: nil, wantErr: nil},
+		{deleteItemTxErr: RowDoesNotExist, wantErr: wms.ItemDoesNotExist},
+		{deleteItemTxErr: wms.ItemClaimed, wantErr: wms.ItemClaimed},
+		{deleteItemTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+		{deleteItemTxErr: context.Canceled, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().deleteItemTx(ctx, gomock.Any(), "item").Return(test.deleteItemTxErr)
+
+		mockItemService := &ItemService{
+			queries: mockObj,
+			db:      itemService.db,
+		}
+
+		err := mockItemService.DeleteItemById(ctx, "item")
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+	}
+}
+
+func TestCreateItemTxOverCapacity(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE shelf SET max_items = 1 WHERE id = $1`, fixtureShelf.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	used, err := queryShelfUtilizationTx(ctx, tx, fixtureShelf.Id)
+	if err != nil || used.ItemCount != 1 || used.WeightInKg != testProduct.WeightInKg {
+		t.Errorf("want 1 item of %v kg, got: %v, %v", testProduct.WeightInKg, used, err)
+	}
+
+	err = itemService.queries.createItemTx(ctx, tx, wms.NewItem(testProduct.Sku, nil, fixtureShelf.Id, "", ""))
+	if !errors.Is(err, wms.ShelfOverCapacity) {
+		t.Errorf("want: %v, got: %v", wms.ShelfOverCapacity, err)
+	}
+}
+
+func TestCreateSerializedItemTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	product := testProduct
+	product.Serialized = true
+	err = productService.queries.createProductTx(ctx, tx, product)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != wms.SerialNumberRequired {
+		t.Errorf("want: %v, got: %v", wms.SerialNumberRequired, err)
+	}
+
+	item := testItem()
+	item.SerialNumber = "SN1"
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, wms.NewItem(product.Sku, nil, fixtureShelf.Id, "", "SN1"))
+	if err != wms.DuplicateSerialNumber {
+		t.Errorf("want: %v, got: %v", wms.DuplicateSerialNumber, err)
+	}
+
+	got, err := itemService.queries.getItemBySerialNumberTx(ctx, tx, "SN1")
+	if err != nil || got.Id != item.Id || got.SerialNumber != "SN1" {
+		t.Errorf("want item %s with serial number SN1, got: %v, %v", item.Id, got, err)
+	}
+	_, err = itemService.queries.getItemBySerialNumberTx(ctx, tx, "SN2")
+	if err != sql.ErrNoRows {
+		t.Errorf("want: %v, got: %v", sql.ErrNoRows, err)
+	}
+
+	// a restock is not checked up front, the unique index turns it away; this
+	// aborts tx, so it comes last
+	err = new(returnQueriesImpl).restockItemTx(ctx, tx, wms.NewItem(product.Sku, nil, fixtureShelf.Id, "", "SN1"))
+	if err != wms.DuplicateSerialNumber {
+		t.Errorf("want: %v, got: %v", wms.DuplicateSerialNumber, err)
+	}
+}
+
+func TestCreateSerializedItemTxConcurrentReceipt(t *testing.T) {
+	ctx := context.Background()
+	cleanup, ok := commitFixtures(t)
+	if !ok {
+		return
+	}
+	defer cleanup()
+
+	_, err := itemService.db.Exec(`UPDATE product SET serialized = true WHERE sku = $1`, testProduct.Sku)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	first, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer first.Rollback()
+	second, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer second.Rollback()
+
+	item := testItem()
+	item.SerialNumber = "SN1"
+	err = itemService.queries.createItemTx(ctx, first, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// second cannot see the serial number first has not committed, passes
+	// the up front check and waits on the shelf first has locked
+	done := make(chan error, 1)
+	go func() {
+		done <- itemService.queries.createItemTx(ctx, second, wms.NewItem(testProduct.Sku, item.ExpirationDate, fixtureShelf.Id, "", "SN1"))
+	}()
+	awaitBlocked(t)
+
+	err = first.Commit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = <-done
+	if err != wms.DuplicateSerialNumber {
+		t.Errorf("want: %v, got: %v", wms.DuplicateSerialNumber, err)
+	}
+}
+
+func TestGetItemBySerialNumber(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockitemQueries(mockCtrl)
+	mockItemService := &ItemService{queries: mockObj, db: itemService.db}
+
+	ctx := context.Background()
+	item := testItem()
+	item.SerialNumber = "SN1"
+
+	mockObj.EXPECT().getItemBySerialNumberTx(ctx, gomock.Any(), "SN1").Return(item, nil)
+	got, err := mockItemService.GetItemBySerialNumber(ctx, "SN1")
+	if err != nil || got != item {
+		t.Errorf("want: %v, got: %v, %v", item, got, err)
+	}
+
+	mockObj.EXPECT().getItemBySerialNumberTx(ctx, gomock.Any(), "SN2").Return(wms.Item{}, sql.ErrNoRows)
+	_, err = mockItemService.GetItemBySerialNumber(ctx, "SN2")
+	if err != wms.ItemDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.ItemDoesNotExist, err)
+	}
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/layout_import.go" -destination="./pkg/database/postgres/layout_import_mock.go"
+type layoutImportQueries interface {
+	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	lockWarehouseShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]wms.ShelfBlock, error)
+	createShelfBlockTx(ctx context.Context, tx *sql.Tx, block wms.ShelfBlock) error
+	createShelfTx(ctx context.Context, tx *sql.Tx, shelf wms.Shelf) error
+}
+
+// layoutImportQueriesImpl creates rows with the same queries as the shelf
+// block and shelf services, so an import is audited like single creates.
+type layoutImportQueriesImpl struct {
+	shelfBlockQueriesImpl
+	shelfQueriesImpl
+}
+
+type LayoutImportService struct {
+	queries layoutImportQueries
+	db      *sql.DB
+}
+
+func NewLayoutImportService(db *sql.DB) *LayoutImportService {
+	return &LayoutImportService{
+		queries: new(layoutImportQueriesImpl),
+		db:      db,
+	}
+}
+
+// ImportLayout creates the shelf blocks and shelves described by rows in a
+// warehouse, all in one transaction. Every row is checked before anything is
+// written; rejected rows come back in a *wms.LayoutImportError.
+func (l *LayoutImportService) ImportLayout(ctx context.Context, warehouseId string, rows []wms.LayoutImportRow) (wms.LayoutImport, error) {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.LayoutImport{}, err
+	}
+	defer tx.Rollback()
+
+	exists, err := l.queries.warehouseExistsTx(ctx, tx, warehouseId)
+	if err != nil {
+		return wms.LayoutImport{}, err
+	}
+	if !exists {
+		return wms.LayoutImport{}, wms.WarehouseDoesNotExist
+	}
+
+	existing, err := l.queries.lockWarehouseShelfBlocksTx(ctx, tx, warehouseId)
+	if err != nil {
+		return wms.LayoutImport{}, err
+	}
+
+	layoutImport, err := wms.NewLayoutImport(warehouseId, rows, existing)
+	if err != nil {
+		return wms.LayoutImport{}, err
+	}
+
+	for _, block := range layoutImport.ShelfBlocks {
+		err = l.queries.createShelfBlockTx(ctx, tx, block)
+		if err != nil {
+			return wms.LayoutImport{}, err
+		}
+	}
+	for _, shelf := range layoutImport.Shelves {
+		err = l.queries.createShelfTx(ctx, tx, shelf)
+		if err != nil {
+			return wms.LayoutImport{}, err
+		}
+	}
+
+	return layoutImport, tx.Commit()
+}
+
+// lockWarehouseShelfBlocksTx returns the shelf blocks of a warehouse and
+// locks the warehouse row, so that two imports into it cannot both add a
+// block at the same aisle and rack.
+func (l *layoutImportQueriesImpl) lockWarehouseShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]wms.ShelfBlock, error) {
+	_, err := tx.ExecContext(ctx, `SELECT 1 FROM warehouse WHERE id = $1 FOR UPDATE`, warehouseId)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, aisle, rack, storage_type, warehouse_id, version FROM shelf_block
+		WHERE warehouse_id = $1 AND deleted_at IS NULL ORDER BY id`
+
+	rows, err := tx.QueryContext(ctx, query, warehouseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shelfBlocks []wms.ShelfBlock
+	for rows.Next() {
+		var shelfBlock wms.ShelfBlock
+		err := rows.Scan(&shelfBlock.Id, &shelfBlock.Aisle, &shelfBlock.Rack, &shelfBlock.StorageType, &shelfBlock.WarehouseId, &shelfBlock.Version)
+		if err != nil {
+			return nil, err
+		}
+		shelfBlocks = append(shelfBlocks, shelfBlock)
+	}
+
+	return shelfBlocks, rows.Err()
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/layout_import.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocklayoutImportQueries is a mock of layoutImportQueries interface.
+type MocklayoutImportQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MocklayoutImportQueriesMockRecorder
+}
+
+// MocklayoutImportQueriesMockRecorder is the mock recorder for MocklayoutImportQueries.
+type MocklayoutImportQueriesMockRecorder struct {
+	mock *MocklayoutImportQueries
+}
+
+// NewMocklayoutImportQueries creates a new mock instance.
+func NewMocklayoutImportQueries(ctrl *gomock.Controller) *MocklayoutImportQueries {
+	mock := &MocklayoutImportQueries{ctrl: ctrl}
+	mock.recorder = &MocklayoutImportQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklayoutImportQueries) EXPECT() *MocklayoutImportQueriesMockRecorder {
+	return m.recorder
+}
+
+// createShelfBlockTx mocks base method.
+func (m *MocklayoutImportQueries) createShelfBlockTx(ctx context.Context, tx *sql.Tx, block warehousemanagementservice.ShelfBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createShelfBlockTx", ctx, tx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createShelfBlockTx indicates an expected call of createShelfBlockTx.
+func (mr *MocklayoutImportQueriesMockRecorder) createShelfBlockTx(ctx, tx, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createShelfBlockTx", reflect.TypeOf((*MocklayoutImportQueries)(nil).createShelfBlockTx), ctx, tx, block)
+}
+
+// createShelfTx mocks base method.
+func (m *MocklayoutImportQueries) createShelfTx(ctx context.Context, tx *sql.Tx, shelf warehousemanagementservice.Shelf) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createShelfTx", ctx, tx, shelf)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createShelfTx indicates an expected call of createShelfTx.
+func (mr *MocklayoutImportQueriesMockRecorder) createShelfTx(ctx, tx, shelf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createShelfTx", reflect.TypeOf((*MocklayoutImportQueries)(nil).createShelfTx), ctx, tx, shelf)
+}
+
+// lockWarehouseShelfBlocksTx mocks base method.
+func (m *MocklayoutImportQueries) lockWarehouseShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]warehousemanagementservice.ShelfBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockWarehouseShelfBlocksTx", ctx, tx, warehouseId)
+	ret0, _ := ret[0].([]warehousemanagementservice.ShelfBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockWarehouseShelfBlocksTx indicates an expected call of lockWarehouseShelfBlocksTx.
+func (mr *MocklayoutImportQueriesMockRecorder) lockWarehouseShelfBlocksTx(ctx, tx, warehouseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockWarehouseShelfBlocksTx", reflect.TypeOf((*MocklayoutImportQueries)(nil).lockWarehouseShelfBlocksTx), ctx, tx, warehouseId)
+}
+
+// warehouseExistsTx mocks base method.
+func (m *MocklayoutImportQueries) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseExistsTx indicates an expected call of warehouseExistsTx.
+func (mr *MocklayoutImportQueriesMockRecorder) warehouseExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseExistsTx", reflect.TypeOf((*MocklayoutImportQueries)(nil).warehouseExistsTx), ctx, tx, id)
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"testing"
+	wms "warehouse-management-service"
+)
+
+var layoutImportRows = []wms.LayoutImportRow{
+	{Aisle: "7", Rack: "1", StorageType: "regular", Section: "A", Level: "1"},
+	{Aisle: "7", Rack: "1", StorageType: "regular", Section: "A", Level: "2"},
+	{Aisle: "7", Rack: "2", StorageType: "cold", Section: "A", Level: "1"},
+}
+
+func TestLockWarehouseShelfBlocksTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+
+	queries := layoutImportQueriesImpl{}
+	shelfBlocks, err := queries.lockWarehouseShelfBlocksTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil || len(shelfBlocks) != 1 || shelfBlocks[0] != fixtureShelfBlock {
+		t.Errorf("expected: %v, got: %v, %v", fixtureShelfBlock, shelfBlocks, err)
+	}
+
+	layoutImport, err := wms.NewLayoutImport(fixtureWarehouse.Id, layoutImportRows, shelfBlocks)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, block := range layoutImport.ShelfBlocks {
+		err = queries.createShelfBlockTx(ctx, tx, block)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	for _, shelf := range layoutImport.Shelves {
+		err = queries.createShelfTx(ctx, tx, shelf)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	shelfBlocks, err = queries.lockWarehouseShelfBlocksTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil || len(shelfBlocks) != 3 {
+		t.Errorf("expected: 3 shelf blocks, got: %v, %v", shelfBlocks, err)
+	}
+}
+
+func TestImportLayout(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMocklayoutImportQueries(mockCtrl)
+
+	ls := LayoutImportService{db: warehouseService.db, queries: mockObj}
+
+	gomock.InOrder(
+		mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "w").Return(true, nil),
+		mockObj.EXPECT().lockWarehouseShelfBlocksTx(ctx, gomock.Any(), "w").Return(nil, nil),
+		mockObj.EXPECT().createShelfBlockTx(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(2),
+		mockObj.EXPECT().createShelfTx(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(3),
+	)
+	layoutImport, err := ls.ImportLayout(ctx, "w", layoutImportRows)
+	if err != nil || len(layoutImport.ShelfBlocks) != 2 || len(layoutImport.Shelves) != 3 {
+		t.Errorf("expected: 2 shelf blocks and 3 shelves, got: %v, %v", layoutImport, err)
+	}
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "missing").Return(false, nil)
+	_, err = ls.ImportLayout(ctx, "missing", layoutImportRows)
+	if err != wms.WarehouseDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.WarehouseDoesNotExist, err)
+	}
+
+	// rows clashing with an existing block are rejected before anything is written
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "w").Return(true, nil)
+	mockObj.EXPECT().lockWarehouseShelfBlocksTx(ctx, gomock.Any(), "w").Return(
+		[]wms.ShelfBlock{{Id: "b", Aisle: "7", Rack: "2"}},
+		nil,
+	)
+	_, err = ls.ImportLayout(ctx, "w", layoutImportRows)
+	if !errors.Is(err, wms.InvalidLayoutImport) {
+		t.Errorf("want: %v, got: %v", wms.InvalidLayoutImport, err)
+	}
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "w").Return(true, nil)
+	mockObj.EXPECT().lockWarehouseShelfBlocksTx(ctx, gomock.Any(), "w").Return(nil, nil)
+	mockObj.EXPECT().createShelfBlockTx(ctx, gomock.Any(), gomock.Any()).Return(sql.ErrConnDone)
+	_, err = ls.ImportLayout(ctx, "w", layoutImportRows)
+	if err != sql.ErrConnDone {
+		t.Errorf("want: %v, got: %v", sql.ErrConnDone, err)
+	}
+}
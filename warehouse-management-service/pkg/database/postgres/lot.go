@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/lot.go" -destination="./pkg/database/postgres/lot_mock.go"
+type lotQueries interface {
+	listLotShelvesTx(ctx context.Context, tx *sql.Tx, lot string) ([]wms.LotShelf, error)
+	listLotShipmentsTx(ctx context.Context, tx *sql.Tx, lot string) ([]wms.LotShipment, error)
+}
+
+type lotQueriesImpl struct{}
+
+type LotService struct {
+	queries lotQueries
+	db      *sql.DB
+}
+
+func NewLotService(db *sql.DB) *LotService {
+	return &LotService{
+		queries: new(lotQueriesImpl),
+		db:      db,
+	}
+}
+
+// GetLotRecall finds every shelf and shipment holding items of a lot.
+func (l *LotService) GetLotRecall(ctx context.Context, lot string) (wms.LotRecall, error) {
+	tx, err := l.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wms.LotRecall{}, err
+	}
+	defer tx.Rollback()
+
+	recall := wms.LotRecall{Lot: lot}
+	recall.Shelves, err = l.queries.listLotShelvesTx(ctx, tx, lot)
+	if err != nil {
+		return wms.LotRecall{}, err
+	}
+	recall.Shipments, err = l.queries.listLotShipmentsTx(ctx, tx, lot)
+	if err != nil {
+		return wms.LotRecall{}, err
+	}
+	if !recall.Found() {
+		return wms.LotRecall{}, wms.LotDoesNotExist
+	}
+	return recall, tx.Commit()
+}
+
+// listLotShelvesTx groups the items of a lot still in stock by shelf and
+// sku. Items packed into a shipment are left to listLotShipmentsTx even
+// before it is dispatched.
+func (l *lotQueriesImpl) listLotShelvesTx(ctx context.Context, tx *sql.Tx, lot string) ([]wms.LotShelf, error) {
+	query := `SELECT shelf_block.warehouse_id, item.shelf_id, item.sku, item.id
+		FROM item
+		JOIN shelf ON shelf.id = item.shelf_id
+		JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		WHERE item.lot = $1 AND NOT EXISTS(SELECT 1 FROM package_item WHERE package_item.item_id = item.id)
+		ORDER BY shelf_block.warehouse_id, item.shelf_id, item.sku, item.id`
+
+	rows, err := tx.QueryContext(ctx, query, lot)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shelves := []wms.LotShelf{}
+	for rows.Next() {
+		var shelf wms.LotShelf
+		var itemId string
+		err := rows.Scan(&shelf.WarehouseId, &shelf.ShelfId, &shelf.Sku, &itemId)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(shelves); n == 0 || shelves[n-1].ShelfId != shelf.ShelfId || shelves[n-1].Sku != shelf.Sku {
+			shelves = append(shelves, shelf)
+		}
+		last := &shelves[len(shelves)-1]
+		last.ItemIds = append(last.ItemIds, itemId)
+	}
+
+	return shelves, rows.Err()
+}
+
+// listLotShipmentsTx groups the packed items of a lot by package and sku,
+// with the order and customer of the shipment they went out with.
+func (l *lotQueriesImpl) listLotShipmentsTx(ctx context.Context, tx *sql.Tx, lot string) ([]wms.LotShipment, error) {
+	query := `SELECT shipment.id, shipment.order_id, sales_order.customer, shipment.status,
+			package.id, package.tracking_number, package_item.sku, package_item.item_id
+		FROM package_item
+		JOIN package ON package.id = package_item.package_id
+		JOIN shipment ON shipment.id = package.shipment_id
+		JOIN sales_order ON sales_order.id = shipment.order_id
+		WHERE package_item.lot = $1
+		ORDER BY shipment.created_at, shipment.id, package.id, package_item.sku, package_item.item_id`
+
+	rows, err := tx.QueryContext(ctx, query, lot)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shipments := []wms.LotShipment{}
+	for rows.Next() {
+		var shipment wms.LotShipment
+		var itemId string
+		err := rows.Scan(
+			&shipment.ShipmentId,
+			&shipment.OrderId,
+			&shipment.Customer,
+			&shipment.Status,
+			&shipment.PackageId,
+			&shipment.TrackingNumber,
+			&shipment.Sku,
+			&itemId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(shipments); n == 0 || shipments[n-1].PackageId != shipment.PackageId || shipments[n-1].Sku != shipment.Sku {
+			shipments = append(shipments, shipment)
+		}
+		last := &shipments[len(shipments)-1]
+		last.ItemIds = append(last.ItemIds, itemId)
+	}
+
+	return shipments, rows.Err()
+}
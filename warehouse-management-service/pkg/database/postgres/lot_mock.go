@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/lot.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocklotQueries is a mock of lotQueries interface.
+type MocklotQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MocklotQueriesMockRecorder
+}
+
+// MocklotQueriesMockRecorder is the mock recorder for MocklotQueries.
+type MocklotQueriesMockRecorder struct {
+	mock *MocklotQueries
+}
+
+// NewMocklotQueries creates a new mock instance.
+func NewMocklotQueries(ctrl *gomock.Controller) *MocklotQueries {
+	mock := &MocklotQueries{ctrl: ctrl}
+	mock.recorder = &MocklotQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklotQueries) EXPECT() *MocklotQueriesMockRecorder {
+	return m.recorder
+}
+
+// listLotShelvesTx mocks base method.
+func (m *MocklotQueries) listLotShelvesTx(ctx context.Context, tx *sql.Tx, lot string) ([]warehousemanagementservice.LotShelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listLotShelvesTx", ctx, tx, lot)
+	ret0, _ := ret[0].([]warehousemanagementservice.LotShelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listLotShelvesTx indicates an expected call of listLotShelvesTx.
+func (mr *MocklotQueriesMockRecorder) listLotShelvesTx(ctx, tx, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listLotShelvesTx", reflect.TypeOf((*MocklotQueries)(nil).listLotShelvesTx), ctx, tx, lot)
+}
+
+// listLotShipmentsTx mocks base method.
+func (m *MocklotQueries) listLotShipmentsTx(ctx context.Context, tx *sql.Tx, lot string) ([]warehousemanagementservice.LotShipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listLotShipmentsTx", ctx, tx, lot)
+	ret0, _ := ret[0].([]warehousemanagementservice.LotShipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listLotShipmentsTx indicates an expected call of listLotShipmentsTx.
+func (mr *MocklotQueriesMockRecorder) listLotShipmentsTx(ctx, tx, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listLotShipmentsTx", reflect.TypeOf((*MocklotQueries)(nil).listLotShipmentsTx), ctx, tx, lot)
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"reflect"
+	"testing"
+	wms "warehouse-management-service"
+)
+
+func TestLotRecallTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	product := testProduct
+	product.LotTracked = true
+	err = productService.queries.createProductTx(ctx, tx, product)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != wms.LotRequired {
+		t.Errorf("want: %v, got: %v", wms.LotRequired, err)
+	}
+	first := testItem()
+	first.Lot = "L1"
+	second := testItem()
+	second.Id = "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f16"
+	second.Lot = "L1"
+	for _, item := range []wms.Item{first, second} {
+		err = itemService.queries.createItemTx(ctx, tx, item)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	got, err := itemService.queries.getItemByIdTx(ctx, tx, first.Id)
+	if err != nil || got.Lot != "L1" {
+		t.Errorf("want item %s of lot L1, got: %v, %v", first.Id, got, err)
+	}
+
+	queries := lotQueriesImpl{}
+	shelves, err := queries.listLotShelvesTx(ctx, tx, "L1")
+	if err != nil || len(shelves) != 1 || len(shelves[0].ItemIds) != 2 || shelves[0].WarehouseId != fixtureWarehouse.Id {
+		t.Errorf("want 2 items of lot L1 on %s, got: %v, %v", fixtureShelf.Id, shelves, err)
+	}
+
+	shipments := shipmentQueriesImpl{}
+	order := wms.NewOrder("customer", fixtureWarehouse.Id, []wms.OrderLine{{Sku: product.Sku, Quantity: 1}})
+	err = shipments.createOrderTx(ctx, tx, order)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	entries, err := shipments.allocateItemsTx(ctx, tx, fixtureWarehouse.Id, order.Lines[0])
+	if err != nil || len(entries) != 1 {
+		t.Errorf("want 1 item allocated, got: %v, %v", entries, err)
+		return
+	}
+	shipment := wms.NewShipment(order.Id, "carrier")
+	err = shipments.createShipmentTx(ctx, tx, shipment)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	packed, err := shipments.pickedItemTx(ctx, tx, order.Id, entries[0].ItemId)
+	if err != nil || packed.Lot != "L1" {
+		t.Errorf("want a picked item of lot L1, got: %v, %v", packed, err)
+		return
+	}
+	pkg, err := wms.NewPackage(shipment.Id, "TRK1", []wms.PackedItem{packed})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = shipments.createPackageTx(ctx, tx, pkg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	shelves, err = queries.listLotShelvesTx(ctx, tx, "L1")
+	if err != nil || len(shelves) != 1 || len(shelves[0].ItemIds) != 1 {
+		t.Errorf("want 1 item of lot L1 left on the shelf, got: %v, %v", shelves, err)
+	}
+	lotShipments, err := queries.listLotShipmentsTx(ctx, tx, "L1")
+	if err != nil || len(lotShipments) != 1 {
+		t.Errorf("want 1 shipment of lot L1, got: %v, %v", lotShipments, err)
+		return
+	}
+	want := wms.LotShipment{
+		ShipmentId:     shipment.Id,
+		OrderId:        order.Id,
+		Customer:       "customer",
+		Status:         wms.ShipmentOpen,
+		PackageId:      pkg.Id,
+		TrackingNumber: "TRK1",
+		Sku:            product.Sku,
+		ItemIds:        []string{packed.ItemId},
+	}
+	if !reflect.DeepEqual(lotShipments[0], want) {
+		t.Errorf("want: %v, got: %v", want, lotShipments[0])
+	}
+}
+
+func TestGetLotRecall(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMocklotQueries(mockCtrl)
+
+	ls := LotService{db: warehouseService.db, queries: mockObj}
+
+	gomock.InOrder(
+		mockObj.EXPECT().listLotShelvesTx(ctx, gomock.Any(), "L1").Return([]wms.LotShelf{}, nil),
+		mockObj.EXPECT().listLotShipmentsTx(ctx, gomock.Any(), "L1").Return([]wms.LotShipment{}, nil),
+	)
+	_, err := ls.GetLotRecall(ctx, "L1")
+	if err != wms.LotDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.LotDoesNotExist, err)
+	}
+
+	shelves := []wms.LotShelf{{ShelfId: "s", WarehouseId: "w", Sku: "a", ItemIds: []string{"i"}}}
+	gomock.InOrder(
+		mockObj.EXPECT().listLotShelvesTx(ctx, gomock.Any(), "L1").Return(shelves, nil),
+		mockObj.EXPECT().listLotShipmentsTx(ctx, gomock.Any(), "L1").Return([]wms.LotShipment{}, nil),
+	)
+	recall, err := ls.GetLotRecall(ctx, "L1")
+	if err != nil || recall.Lot != "L1" || len(recall.Shelves) != 1 {
+		t.Errorf("want lot L1 on shelf s, got: %v, %v", recall, err)
+	}
+}
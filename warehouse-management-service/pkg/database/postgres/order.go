@@ -0,0 +1,308 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/order.go" -destination="./pkg/database/postgres/order_mock.go"
+type orderQueries interface {
+	createOrderTx(ctx context.Context, tx *sql.Tx, order wms.Order) error
+	getOrderByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Order, error)
+	lockOrderTx(ctx context.Context, tx *sql.Tx, id string) (wms.Order, error)
+	setOrderStatusTx(ctx context.Context, tx *sql.Tx, id string, status string) error
+	allocateItemsTx(ctx context.Context, tx *sql.Tx, warehouseId string, line wms.OrderLine) ([]wms.PickListEntry, error)
+	getPickListEntriesTx(ctx context.Context, tx *sql.Tx, orderId string) ([]wms.PickListEntry, error)
+	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error)
+}
+
+type orderQueriesImpl struct {
+	shelfBlockQueriesImpl
+	itemQueriesImpl
+}
+
+type OrderService struct {
+	queries orderQueries
+	db      *sql.DB
+}
+
+func NewOrderService(db *sql.DB) *OrderService {
+	return &OrderService{
+		queries: new(orderQueriesImpl),
+		db:      db,
+	}
+}
+
+func (o *OrderService) CreateOrder(ctx context.Context, order wms.Order) error {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if warehouseExists, err := o.queries.warehouseExistsTx(ctx, tx, order.WarehouseId); err != nil {
+		return err
+	} else if !warehouseExists {
+		return wms.WarehouseDoesNotExist
+	}
+	for _, line := range order.Lines {
+		if productExists, err := o.queries.productExistsTx(ctx, tx, line.Sku); err != nil {
+			return err
+		} else if !productExists {
+			return wms.InvalidProduct
+		}
+	}
+
+	err = o.queries.createOrderTx(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (o *OrderService) GetOrderById(ctx context.Context, id string) (wms.Order, error) {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Order{}, err
+	}
+	defer tx.Rollback()
+
+	order, err := o.queries.getOrderByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return order, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.Order{}, wms.OrderDoesNotExist
+	default:
+		return wms.Order{}, err
+	}
+}
+
+// GeneratePickList allocates items of the order's warehouse to every line of
+// an open order and moves the order on to picking. Perishable stock is
+// allocated first expired first out, everything else first in first out.
+// Either every line is covered or nothing is allocated and the shortages are
+// returned as a *wms.InsufficientStockError.
+func (o *OrderService) GeneratePickList(ctx context.Context, orderId string) (wms.PickList, error) {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.PickList{}, err
+	}
+	defer tx.Rollback()
+
+	order, err := o.queries.lockOrderTx(ctx, tx, orderId)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.PickList{}, wms.OrderDoesNotExist
+	default:
+		return wms.PickList{}, err
+	}
+	if order.Status != wms.OrderOpen {
+		return wms.PickList{}, wms.OrderNotOpen
+	}
+
+	var entries []wms.PickListEntry
+	var shortages []wms.StockShortage
+	for _, line := range order.Lines {
+		allocated, err := o.queries.allocateItemsTx(ctx, tx, order.WarehouseId, line)
+		if err != nil {
+			return wms.PickList{}, err
+		}
+		if len(allocated) < line.Quantity {
+			shortages = append(shortages, wms.StockShortage{
+				Sku:       line.Sku,
+				Requested: line.Quantity,
+				Available: len(allocated),
+			})
+		}
+		entries = append(entries, allocated...)
+	}
+	if len(shortages) > 0 {
+		return wms.PickList{}, &wms.InsufficientStockError{Shortages: shortages}
+	}
+
+	err = o.queries.setOrderStatusTx(ctx, tx, orderId, wms.OrderPicking)
+	if err != nil {
+		return wms.PickList{}, err
+	}
+	return wms.NewPickList(orderId, entries), tx.Commit()
+}
+
+// GetPickList returns the pick list generated for an order, which is empty
+// while the order is still open.
+func (o *OrderService) GetPickList(ctx context.Context, orderId string) (wms.PickList, error) {
+	tx, err := o.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wms.PickList{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = o.queries.getOrderByIdTx(ctx, tx, orderId)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.PickList{}, wms.OrderDoesNotExist
+	default:
+		return wms.PickList{}, err
+	}
+
+	entries, err := o.queries.getPickListEntriesTx(ctx, tx, orderId)
+	if err != nil {
+		return wms.PickList{}, err
+	}
+	return wms.NewPickList(orderId, entries), tx.Commit()
+}
+
+func (o *orderQueriesImpl) createOrderTx(ctx context.Context, tx *sql.Tx, order wms.Order) error {
+	query := `INSERT INTO sales_order(id, customer, warehouse_id, status, created_at) VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		order.Id,
+		order.Customer,
+		order.WarehouseId,
+		order.Status,
+		order.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO order_line(id, order_id, sku, quantity) VALUES ($1, $2, $3, $4)`
+	for _, line := range order.Lines {
+		_, err = tx.ExecContext(ctx, query, line.Id, line.OrderId, line.Sku, line.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *orderQueriesImpl) getOrderByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Order, error) {
+	return o.queryOrderTx(ctx, tx, `SELECT id, customer, warehouse_id, status, created_at FROM sales_order WHERE id = $1`, id)
+}
+
+// lockOrderTx returns an order with its lines and locks its row, so that two
+// pick lists cannot be generated for it at once.
+func (o *orderQueriesImpl) lockOrderTx(ctx context.Context, tx *sql.Tx, id string) (wms.Order, error) {
+	return o.queryOrderTx(ctx, tx, `SELECT id, customer, warehouse_id, status, created_at FROM sales_order WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (o *orderQueriesImpl) queryOrderTx(ctx context.Context, tx *sql.Tx, query string, id string) (wms.Order, error) {
+	var order wms.Order
+	row := tx.QueryRowContext(ctx, query, id)
+	err := row.Scan(&order.Id, &order.Customer, &order.WarehouseId, &order.Status, &order.CreatedAt)
+	if err != nil {
+		return wms.Order{}, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, order_id, sku, quantity FROM order_line WHERE order_id = $1 ORDER BY sku`, id)
+	if err != nil {
+		return wms.Order{}, err
+	}
+	defer rows.Close()
+
+	order.Lines = []wms.OrderLine{}
+	for rows.Next() {
+		var line wms.OrderLine
+		err := rows.Scan(&line.Id, &line.OrderId, &line.Sku, &line.Quantity)
+		if err != nil {
+			return wms.Order{}, err
+		}
+		order.Lines = append(order.Lines, line)
+	}
+
+	return order, rows.Err()
+}
+
+func (o *orderQueriesImpl) setOrderStatusTx(ctx context.Context, tx *sql.Tx, id string, status string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE sales_order SET status = $1 WHERE id = $2`, status, id)
+	return err
+}
+
+// pickListEntryColumns are the columns queryPickListEntriesTx reads, from
+// item joined to its shelf and shelf block, followed by the order line the
+// item is allocated to.
+const pickListEntryColumns = `item.id, item.sku, item.expiration_date, item.received_on,
+	shelf.id, shelf.label, shelf.section, shelf.level, shelf_block.aisle, shelf_block.rack`
+
+// allocateItemsTx claims up to line.Quantity available items of the line's
+// sku in a warehouse for the line and returns them. Items reserved for the
+// line's order are taken before any others.
+func (o *orderQueriesImpl) allocateItemsTx(ctx context.Context, tx *sql.Tx, warehouseId string, line wms.OrderLine) ([]wms.PickListEntry, error) {
+	query := `SELECT ` + pickListEntryColumns + `, $5::text ` + availableItemsQuery
+
+	var entries []wms.PickListEntry
+	_, err := selectUnclaimedTx(func() ([]string, error) {
+		var err error
+		entries, err = o.queryPickListEntriesTx(ctx, tx, query, line.Sku, warehouseId, line.Quantity, line.OrderId, line.Id)
+		if err != nil {
+			return nil, err
+		}
+		itemIds := make([]string, len(entries))
+		for i, entry := range entries {
+			itemIds[i] = entry.ItemId
+		}
+		return itemIds, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		_, err = tx.ExecContext(ctx, `INSERT INTO pick_list_item(item_id, order_line_id) VALUES ($1, $2)`, entry.ItemId, line.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func (o *orderQueriesImpl) getPickListEntriesTx(ctx context.Context, tx *sql.Tx, orderId string) ([]wms.PickListEntry, error) {
+	query := `SELECT ` + pickListEntryColumns + `, pick_list_item.order_line_id
+		FROM pick_list_item
+		JOIN order_line ON order_line.id = pick_list_item.order_line_id
+		JOIN item ON item.id = pick_list_item.item_id
+		JOIN shelf ON shelf.id = item.shelf_id
+		JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		WHERE order_line.order_id = $1`
+
+	return o.queryPickListEntriesTx(ctx, tx, query, orderId)
+}
+
+func (o *orderQueriesImpl) queryPickListEntriesTx(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]wms.PickListEntry, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []wms.PickListEntry{}
+	for rows.Next() {
+		var entry wms.PickListEntry
+		err := rows.Scan(
+			&entry.ItemId,
+			&entry.Sku,
+			&entry.ExpirationDate,
+			&entry.ReceivedOn,
+			&entry.ShelfId,
+			&entry.ShelfLabel,
+			&entry.Section,
+			&entry.Level,
+			&entry.Aisle,
+			&entry.Rack,
+			&entry.OrderLineId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/order.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockorderQueries is a mock of orderQueries interface.
+type MockorderQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderQueriesMockRecorder
+}
+
+// MockorderQueriesMockRecorder is the mock recorder for MockorderQueries.
+type MockorderQueriesMockRecorder struct {
+	mock *MockorderQueries
+}
+
+// NewMockorderQueries creates a new mock instance.
+func NewMockorderQueries(ctrl *gomock.Controller) *MockorderQueries {
+	mock := &MockorderQueries{ctrl: ctrl}
+	mock.recorder = &MockorderQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderQueries) EXPECT() *MockorderQueriesMockRecorder {
+	return m.recorder
+}
+
+// allocateItemsTx mocks base method.
+func (m *MockorderQueries) allocateItemsTx(ctx context.Context, tx *sql.Tx, warehouseId string, line warehousemanagementservice.OrderLine) ([]warehousemanagementservice.PickListEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "allocateItemsTx", ctx, tx, warehouseId, line)
+	ret0, _ := ret[0].([]warehousemanagementservice.PickListEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// allocateItemsTx indicates an expected call of allocateItemsTx.
+func (mr *MockorderQueriesMockRecorder) allocateItemsTx(ctx, tx, warehouseId, line interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "allocateItemsTx", reflect.TypeOf((*MockorderQueries)(nil).allocateItemsTx), ctx, tx, warehouseId, line)
+}
+
+// createOrderTx mocks base method.
+func (m *MockorderQueries) createOrderTx(ctx context.Context, tx *sql.Tx, order warehousemanagementservice.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createOrderTx", ctx, tx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createOrderTx indicates an expected call of createOrderTx.
+func (mr *MockorderQueriesMockRecorder) createOrderTx(ctx, tx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createOrderTx", reflect.TypeOf((*MockorderQueries)(nil).createOrderTx), ctx, tx, order)
+}
+
+// getOrderByIdTx mocks base method.
+func (m *MockorderQueries) getOrderByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getOrderByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getOrderByIdTx indicates an expected call of getOrderByIdTx.
+func (mr *MockorderQueriesMockRecorder) getOrderByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getOrderByIdTx", reflect.TypeOf((*MockorderQueries)(nil).getOrderByIdTx), ctx, tx, id)
+}
+
+// getPickListEntriesTx mocks base method.
+func (m *MockorderQueries) getPickListEntriesTx(ctx context.Context, tx *sql.Tx, orderId string) ([]warehousemanagementservice.PickListEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPickListEntriesTx", ctx, tx, orderId)
+	ret0, _ := ret[0].([]warehousemanagementservice.PickListEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getPickListEntriesTx indicates an expected call of getPickListEntriesTx.
+func (mr *MockorderQueriesMockRecorder) getPickListEntriesTx(ctx, tx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPickListEntriesTx", reflect.TypeOf((*MockorderQueries)(nil).getPickListEntriesTx), ctx, tx, orderId)
+}
+
+// lockOrderTx mocks base method.
+func (m *MockorderQueries) lockOrderTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockOrderTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockOrderTx indicates an expected call of lockOrderTx.
+func (mr *MockorderQueriesMockRecorder) lockOrderTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockOrderTx", reflect.TypeOf((*MockorderQueries)(nil).lockOrderTx), ctx, tx, id)
+}
+
+// productExistsTx mocks base method.
+func (m *MockorderQueries) productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "productExistsTx", ctx, tx, sku)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// productExistsTx indicates an expected call of productExistsTx.
+func (mr *MockorderQueriesMockRecorder) productExistsTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "productExistsTx", reflect.TypeOf((*MockorderQueries)(nil).productExistsTx), ctx, tx, sku)
+}
+
+// setOrderStatusTx mocks base method.
+func (m *MockorderQueries) setOrderStatusTx(ctx context.Context, tx *sql.Tx, id, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "setOrderStatusTx", ctx, tx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// setOrderStatusTx indicates an expected call of setOrderStatusTx.
+func (mr *MockorderQueriesMockRecorder) setOrderStatusTx(ctx, tx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setOrderStatusTx", reflect.TypeOf((*MockorderQueries)(nil).setOrderStatusTx), ctx, tx, id, status)
+}
+
+// warehouseExistsTx mocks base method.
+func (m *MockorderQueries) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseExistsTx indicates an expected call of warehouseExistsTx.
+func (mr *MockorderQueriesMockRecorder) warehouseExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseExistsTx", reflect.TypeOf((*MockorderQueries)(nil).warehouseExistsTx), ctx, tx, id)
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func TestAllocateItemsTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The later received item expires first, so it is picked first.
+	early := testItem()
+	late := testItem()
+	late.Id = "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f11"
+	lateExpiration := early.ExpirationDate.AddDate(0, 0, -10)
+	late.ExpirationDate = &lateExpiration
+	late.ReceivedOn = early.ReceivedOn.Add(24 * time.Hour)
+	for _, item := range []wms.Item{early, late} {
+		err = itemService.queries.createItemTx(ctx, tx, item)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	queries := orderQueriesImpl{}
+	order := wms.NewOrder("acme", fixtureWarehouse.Id, []wms.OrderLine{{Sku: testProduct.Sku, Quantity: 1}})
+	err = queries.createOrderTx(ctx, tx, order)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	entries, err := queries.allocateItemsTx(ctx, tx, fixtureWarehouse.Id, order.Lines[0])
+	if err != nil || len(entries) != 1 || entries[0].ItemId != late.Id || entries[0].OrderLineId != order.Lines[0].Id {
+		t.Errorf("want item %s allocated first, got: %v, %v", late.Id, entries, err)
+	}
+
+	entries, err = queries.allocateItemsTx(ctx, tx, fixtureWarehouse.Id, wms.OrderLine{Id: order.Lines[0].Id, Sku: testProduct.Sku, Quantity: 5})
+	if err != nil || len(entries) != 1 || entries[0].ItemId != early.Id {
+		t.Errorf("want only the unallocated item %s, got: %v, %v", early.Id, entries, err)
+	}
+
+	entries, err = queries.getPickListEntriesTx(ctx, tx, order.Id)
+	if err != nil || len(entries) != 2 || entries[0].Aisle != fixtureShelfBlock.Aisle || entries[0].ShelfId != fixtureShelf.Id {
+		t.Errorf("want both items on the pick list, got: %v, %v", entries, err)
+	}
+}
+
+func TestGeneratePickList(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockorderQueries(mockCtrl)
+
+	orders := OrderService{db: warehouseService.db, queries: mockObj}
+	order := wms.Order{Id: "o", WarehouseId: "w", Status: wms.OrderOpen, Lines: []wms.OrderLine{
+		{Id: "l1", Sku: "a", Quantity: 1},
+		{Id: "l2", Sku: "b", Quantity: 2},
+	}}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockOrderTx(ctx, gomock.Any(), "o").Return(order, nil),
+		mockObj.EXPECT().allocateItemsTx(ctx, gomock.Any(), "w", order.Lines[0]).Return([]wms.PickListEntry{{ItemId: "i1"}}, nil),
+		mockObj.EXPECT().allocateItemsTx(ctx, gomock.Any(), "w", order.Lines[1]).Return([]wms.PickListEntry{{ItemId: "i2"}}, nil),
+	)
+	_, err := orders.GeneratePickList(ctx, "o")
+	var insufficientStock *wms.InsufficientStockError
+	if !errors.As(err, &insufficientStock) ||
+		len(insufficientStock.Shortages) != 1 ||
+		insufficientStock.Shortages[0] != (wms.StockShortage{Sku: "b", Requested: 2, Available: 1}) {
+		t.Errorf("want b short by one, got: %v", err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockOrderTx(ctx, gomock.Any(), "o").Return(order, nil),
+		mockObj.EXPECT().allocateItemsTx(ctx, gomock.Any(), "w", order.Lines[0]).Return([]wms.PickListEntry{{ItemId: "i1"}}, nil),
+		mockObj.EXPECT().allocateItemsTx(ctx, gomock.Any(), "w", order.Lines[1]).Return([]wms.PickListEntry{{ItemId: "i2"}, {ItemId: "i3"}}, nil),
+		mockObj.EXPECT().setOrderStatusTx(ctx, gomock.Any(), "o", wms.OrderPicking).Return(nil),
+	)
+	pickList, err := orders.GeneratePickList(ctx, "o")
+	if err != nil || len(pickList.Entries) != 3 {
+		t.Errorf("want a pick list of 3 items, got: %v, %v", pickList, err)
+	}
+
+	mockObj.EXPECT().lockOrderTx(ctx, gomock.Any(), "o").Return(wms.Order{Id: "o", Status: wms.OrderPicking}, nil)
+	_, err = orders.GeneratePickList(ctx, "o")
+	if err != wms.OrderNotOpen {
+		t.Errorf("want: %v, got: %v", wms.OrderNotOpen, err)
+	}
+}
+
+func TestGetPickList(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockorderQueries(mockCtrl)
+
+	orders := OrderService{db: warehouseService.db, queries: mockObj}
+	entries := []wms.PickListEntry{{Sequence: 1, ItemId: "i1"}}
+
+	gomock.InOrder(
+		mockObj.EXPECT().getOrderByIdTx(ctx, gomock.Any(), "o").Return(wms.Order{Id: "o", Status: wms.OrderPicking}, nil),
+		mockObj.EXPECT().getPickListEntriesTx(ctx, gomock.Any(), "o").Return(entries, nil),
+	)
+	pickList, err := orders.GetPickList(ctx, "o")
+	if err != nil || pickList.OrderId != "o" || len(pickList.Entries) != 1 {
+		t.Errorf("want a pick list of 1 item, got: %v, %v", pickList, err)
+	}
+
+	mockObj.EXPECT().getOrderByIdTx(ctx, gomock.Any(), "o").Return(wms.Order{}, sql.ErrNoRows)
+	_, err = orders.GetPickList(ctx, "o")
+	if err != wms.OrderDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.OrderDoesNotExist, err)
+	}
+
+	// against the database, the lookup has to work in a read-only transaction
+	orders = OrderService{db: warehouseService.db, queries: &orderQueriesImpl{}}
+	_, err = orders.GetPickList(ctx, "0b8f3e52-6f2d-4c1a-9e7b-3d5a1c2f4e60")
+	if err != wms.OrderDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.OrderDoesNotExist, err)
+	}
+}
@@ -1,12 +1,14 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	wms "warehouse-management-service"
 	"warehouse-management-service/internal/config"
 
-	// Loads postgres drivers
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Postgres struct {
@@ -35,3 +37,156 @@ func (p *Postgres) Open() (*sql.DB, error) {
 func buildConnectionURL(config config.PostgresConfig) string {
 	return fmt.Sprintf("postgresql://%s@%s:%s/%s?sslmode=%s", config.Username, config.Host, config.Port, config.DBName, config.SSLMode)
 }
+
+// queryDependentsTx runs a query selecting the ids of rows of type entity.
+func queryDependentsTx(ctx context.Context, tx *sql.Tx, entity string, query string, args ...interface{}) ([]wms.Dependent, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dependents []wms.Dependent
+	for rows.Next() {
+		dependent := wms.Dependent{Entity: entity}
+		err := rows.Scan(&dependent.Id)
+		if err != nil {
+			return nil, err
+		}
+		dependents = append(dependents, dependent)
+	}
+
+	return dependents, rows.Err()
+}
+
+// dependentsQuery selects the ids of the rows of type entity that depend on
+// another.
+type dependentsQuery struct {
+	entity string
+	query  string
+}
+
+// queryAllDependentsTx runs each of queries with args, and returns what they
+// find in order.
+func queryAllDependentsTx(ctx context.Context, tx *sql.Tx, queries []dependentsQuery, args ...interface{}) ([]wms.Dependent, error) {
+	var dependents []wms.Dependent
+	for _, q := range queries {
+		found, err := queryDependentsTx(ctx, tx, q.entity, q.query, args...)
+		if err != nil {
+			return nil, err
+		}
+		dependents = append(dependents, found...)
+	}
+	return dependents, nil
+}
+
+// uniqueViolation is the postgres error code for a row breaking a unique
+// constraint or index.
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether err is postgres refusing a row that
+// breaks the unique constraint or index named constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
+
+// shareLiveRowTx reports whether table has a live row with the given id, and
+// share locks it until tx ends. A delete of the row waits for tx, and tx waits
+// for a delete already under way, then finds the row gone.
+func shareLiveRowTx(ctx context.Context, tx *sql.Tx, table string, id string) (bool, error) {
+	var found string
+	row := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT id FROM %s WHERE id = $1 AND deleted_at IS NULL FOR SHARE`, table), id)
+	err := row.Scan(&found)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// lockVersionTx locks the live row of table with the given id until tx ends,
+// provided it is still at version.
+func lockVersionTx(ctx context.Context, tx *sql.Tx, table string, id string, version int) error {
+	var current int
+	row := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT version FROM %s WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, table), id)
+	err := row.Scan(&current)
+	if err == sql.ErrNoRows {
+		return RowDoesNotExist
+	}
+	if err != nil {
+		return err
+	}
+	if current != version {
+		return VersionMismatch
+	}
+
+	return nil
+}
+
+// forEachRowTx runs query through a server-side cursor and calls scan for
+// each row, fetching batchSize rows at a time so that a large result is never
+// held in memory all at once.
+func forEachRowTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	cursor string,
+	batchSize int,
+	scan func(rows *sql.Rows) error,
+	query string,
+	args ...interface{},
+) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`DECLARE %s NO SCROLL CURSOR FOR %s`, cursor, query), args...)
+	if err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf(`FETCH FORWARD %d FROM %s`, batchSize, cursor)
+	for {
+		fetched, err := fetchTx(ctx, tx, fetch, scan)
+		if err != nil {
+			return err
+		}
+		if fetched < batchSize {
+			break
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `CLOSE `+cursor)
+	return err
+}
+
+func fetchTx(ctx context.Context, tx *sql.Tx, fetch string, scan func(rows *sql.Rows) error) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		fetched++
+		err := scan(rows)
+		if err != nil {
+			return fetched, err
+		}
+	}
+
+	return fetched, rows.Err()
+}
+
+// execRowsAffectedTx runs a statement and returns the number of rows it
+// changed.
+func execRowsAffectedTx(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (int, error) {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/product.go" -destination="./pkg/database/postgres/product_mock.go"
+type productQueries interface {
+	createProductTx(ctx context.Context, tx *sql.Tx, product wms.Product) error
+	getProductBySkuTx(ctx context.Context, tx *sql.Tx, sku string) (wms.Product, error)
+	updateProductTx(ctx context.Context, tx *sql.Tx, product wms.Product) error
+	deleteProductTx(ctx context.Context, tx *sql.Tx, sku string) error
+	lockProductTx(ctx context.Context, tx *sql.Tx, sku string) error
+	productDependentsTx(ctx context.Context, tx *sql.Tx, sku string) ([]wms.Dependent, error)
+}
+
+type productQueriesImpl struct{}
+
+type ProductService struct {
+	queries productQueries
+	db      *sql.DB
+}
+
+func NewProductService(db *sql.DB) *ProductService {
+	return &ProductService{
+		queries: new(productQueriesImpl),
+		db:      db,
+	}
+}
+
+func (p *ProductService) GetProductBySku(ctx context.Context, sku string) (wms.Product, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Product{}, err
+	}
+	defer tx.Rollback()
+
+	product, err := p.queries.getProductBySkuTx(ctx, tx, sku)
+	switch err {
+	case nil:
+		return product, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.Product{}, wms.ProductDoesNotExist
+	default:
+		return wms.Product{}, err
+	}
+}
+
+func (p *ProductService) CreateProduct(ctx context.Context, product wms.Product) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = p.queries.createProductTx(ctx, tx, product)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *ProductService) UpdateProduct(ctx context.Context, product wms.Product) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = p.queries.updateProductTx(ctx, tx, product)
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.ProductDoesNotExist
+	default:
+		return err
+	}
+}
+
+// DeleteProductBySku deletes a product. A product still referenced by items,
+// ASN lines, order lines, reservations or cycle counts is left alone and a
+// *wms.DependentsError listing them is returned.
+func (p *ProductService) DeleteProductBySku(ctx context.Context, sku string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = p.queries.lockProductTx(ctx, tx, sku)
+	if err == nil {
+		err = p.deleteLockedTx(ctx, tx, sku)
+	}
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.ProductDoesNotExist
+	default:
+		return err
+	}
+}
+
+func (p *ProductService) deleteLockedTx(ctx context.Context, tx *sql.Tx, sku string) error {
+	dependents, err := p.queries.productDependentsTx(ctx, tx, sku)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return &wms.DependentsError{Dependents: dependents}
+	}
+
+	return p.queries.deleteProductTx(ctx, tx, sku)
+}
+
+func (p *productQueriesImpl) createProductTx(ctx context.Context, tx *sql.Tx, product wms.Product) error {
+	query := `INSERT INTO product(sku, name, mrp, variant, length_in_cm, width_in_cm, breadth_in_cm, weight_in_kg, perishable, lot_tracked, serialized)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		product.Sku,
+		product.Name,
+		product.MRP,
+		product.Variant,
+		product.LengthInCm,
+		product.WidthInCm,
+		product.BreadthInCm,
+		product.WeightInKg,
+		product.Perishable,
+		product.LotTracked,
+		product.Serialized,
+	)
+	return err
+}
+
+func (p *productQueriesImpl) getProductBySkuTx(ctx context.Context, tx *sql.Tx, sku string) (wms.Product, error) {
+	query := `SELECT sku, name, mrp, variant, length_in_cm, width_in_cm, breadth_in_cm, weight_in_kg, perishable, lot_tracked, serialized
+		FROM product WHERE sku=$1`
+	row := tx.QueryRowContext(ctx, query, sku)
+
+	// variant and dimensions are nullable for rows that were written by hand
+	var product wms.Product
+	var variant sql.NullString
+	var length, width, breadth, weight sql.NullFloat64
+
+	err := row.Scan(
+		&product.Sku,
+		&product.Name,
+		&product.MRP,
+		&variant,
+		&length,
+		&width,
+		&breadth,
+		&weight,
+		&product.Perishable,
+		&product.LotTracked,
+		&product.Serialized,
+	)
+	if err != nil {
+		return wms.Product{}, err
+	}
+
+	product.Variant = variant.String
+	product.LengthInCm = length.Float64
+	product.WidthInCm = width.Float64
+	product.BreadthInCm = breadth.Float64
+	product.WeightInKg = weight.Float64
+	return product, nil
+}
+
+func (p *productQueriesImpl) updateProductTx(ctx context.Context, tx *sql.Tx, product wms.Product) error {
+	query := `UPDATE product SET name = $1, mrp = $2, variant = $3, length_in_cm = $4, width_in_cm = $5,
+		breadth_in_cm = $6, weight_in_kg = $7, perishable = $8, lot_tracked = $9, serialized = $10 WHERE sku = $11`
+
+	result, err := tx.ExecContext(
+		ctx,
+		query,
+		product.Name,
+		product.MRP,
+		product.Variant,
+		product.LengthInCm,
+		product.WidthInCm,
+		product.BreadthInCm,
+		product.WeightInKg,
+		product.Perishable,
+		product.LotTracked,
+		product.Serialized,
+		product.Sku,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return RowDoesNotExist
+	}
+
+	return nil
+}
+
+func (p *productQueriesImpl) deleteProductTx(ctx context.Context, tx *sql.Tx, sku string) error {
+	query := `DELETE FROM product WHERE sku=$1`
+
+	result, err := tx.ExecContext(ctx, query, sku)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return RowDoesNotExist
+	}
+
+	return nil
+}
+
+// lockProductTx locks the product row, so that nothing new can reference the
+// product until tx ends.
+func (p *productQueriesImpl) lockProductTx(ctx context.Context, tx *sql.Tx, sku string) error {
+	var locked string
+	row := tx.QueryRowContext(ctx, `SELECT sku FROM product WHERE sku = $1 FOR UPDATE`, sku)
+	err := row.Scan(&locked)
+	if err == sql.ErrNoRows {
+		return RowDoesNotExist
+	}
+	return err
+}
+
+// productDependentsTx lists the rows that reference a product, by kind.
+// Cycle counts are listed once however many of their lines count it.
+func (p *productQueriesImpl) productDependentsTx(ctx context.Context, tx *sql.Tx, sku string) ([]wms.Dependent, error) {
+	return queryAllDependentsTx(ctx, tx, []dependentsQuery{
+		{entity: wms.EntityItem, query: `SELECT id FROM item WHERE sku = $1 ORDER BY id`},
+		{entity: wms.EntityASNLine, query: `SELECT id FROM asn_line WHERE sku = $1 ORDER BY id`},
+		{entity: wms.EntityOrderLine, query: `SELECT id FROM order_line WHERE sku = $1 ORDER BY id`},
+		{entity: wms.EntityReservation, query: `SELECT id FROM reservation WHERE sku = $1 ORDER BY id`},
+		{entity: wms.EntityCycleCount, query: `SELECT DISTINCT cycle_count_id FROM cycle_count_line WHERE sku = $1 ORDER BY cycle_count_id`},
+		{entity: wms.EntityPackageItem, query: `SELECT item_id FROM package_item WHERE sku = $1 ORDER BY item_id`},
+		{entity: wms.EntityReturnItem, query: `SELECT item_id FROM return_item WHERE sku = $1 ORDER BY item_id`},
+	}, sku)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/product.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockproductQueries is a mock of productQueries interface.
+type MockproductQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockproductQueriesMockRecorder
+}
+
+// MockproductQueriesMockRecorder is the mock recorder for MockproductQueries.
+type MockproductQueriesMockRecorder struct {
+	mock *MockproductQueries
+}
+
+// NewMockproductQueries creates a new mock instance.
+func NewMockproductQueries(ctrl *gomock.Controller) *MockproductQueries {
+	mock := &MockproductQueries{ctrl: ctrl}
+	mock.recorder = &MockproductQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockproductQueries) EXPECT() *MockproductQueriesMockRecorder {
+	return m.recorder
+}
+
+// createProductTx mocks base method.
+func (m *MockproductQueries) createProductTx(ctx context.Context, tx *sql.Tx, product warehousemanagementservice.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createProductTx", ctx, tx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createProductTx indicates an expected call of createProductTx.
+func (mr *MockproductQueriesMockRecorder) createProductTx(ctx, tx, product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createProductTx", reflect.TypeOf((*MockproductQueries)(nil).createProductTx), ctx, tx, product)
+}
+
+// deleteProductTx mocks base method.
+func (m *MockproductQueries) deleteProductTx(ctx context.Context, tx *sql.Tx, sku string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deleteProductTx", ctx, tx, sku)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// deleteProductTx indicates an expected call of deleteProductTx.
+func (mr *MockproductQueriesMockRecorder) deleteProductTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteProductTx", reflect.TypeOf((*MockproductQueries)(nil).deleteProductTx), ctx, tx, sku)
+}
+
+// getProductBySkuTx mocks base method.
+func (m *MockproductQueries) getProductBySkuTx(ctx context.Context, tx *sql.Tx, sku string) (warehousemanagementservice.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getProductBySkuTx", ctx, tx, sku)
+	ret0, _ := ret[0].(warehousemanagementservice.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getProductBySkuTx indicates an expected call of getProductBySkuTx.
+func (mr *MockproductQueriesMockRecorder) getProductBySkuTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductBySkuTx", reflect.TypeOf((*MockproductQueries)(nil).getProductBySkuTx), ctx, tx, sku)
+}
+
+// lockProductTx mocks base method.
+func (m *MockproductQueries) lockProductTx(ctx context.Context, tx *sql.Tx, sku string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockProductTx", ctx, tx, sku)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// lockProductTx indicates an expected call of lockProductTx.
+func (mr *MockproductQueriesMockRecorder) lockProductTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockProductTx", reflect.TypeOf((*MockproductQueries)(nil).lockProductTx), ctx, tx, sku)
+}
+
+// productDependentsTx mocks base method.
+func (m *MockproductQueries) productDependentsTx(ctx context.Context, tx *sql.Tx, sku string) ([]warehousemanagementservice.Dependent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "productDependentsTx", ctx, tx, sku)
+	ret0, _ := ret[0].([]warehousemanagementservice.Dependent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// productDependentsTx indicates an expected call of productDependentsTx.
+func (mr *MockproductQueriesMockRecorder) productDependentsTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "productDependentsTx", reflect.TypeOf((*MockproductQueries)(nil).productDependentsTx), ctx, tx, sku)
+}
+
+// updateProductTx mocks base method.
+func (m *MockproductQueries) updateProductTx(ctx context.Context, tx *sql.Tx, product warehousemanagementservice.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "updateProductTx", ctx, tx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// updateProductTx indicates an expected call of updateProductTx.
+func (mr *MockproductQueriesMockRecorder) updateProductTx(ctx, tx, product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "updateProductTx", reflect.TypeOf((*MockproductQueries)(nil).updateProductTx), ctx, tx, product)
+}
@@ -0,0 +1,418 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"reflect"
+	"testing"
+	wms "warehouse-management-service"
+)
+
+var testProduct = wms.Product{
+	Sku:         "0a3f4c1e-7a0b-4b86-9f0b-0a4c2d0e8a11",
+	Name:        "Basmati Rice",
+	MRP:         120.5,
+	Variant:     "1kg",
+	LengthInCm:  20,
+	WidthInCm:   10,
+	BreadthInCm: 5,
+	WeightInKg:  1,
+	Perishable:  true,
+}
+
+func TestGetProductBySkuTx(t *testing.T) {
+	tx, err := productService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = productService.queries.createProductTx(context.Background(), tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	productFromDB, err := productService.queries.getProductBySkuTx(context.Background(), tx, testProduct.Sku)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if productFromDB != testProduct {
+		t.Errorf("expected: %v, got: %v", testProduct, productFromDB)
+	}
+}
+
+func TestGetProductBySkuTxNullableColumns(t *testing.T) {
+	tx, err := productService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO product(sku, name, mrp, perishable) VALUES ($1, $2, $3, $4)"
+	_, err = tx.ExecContext(context.Background(), query, "hand_written", "Salt", 20, false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := wms.Product{Sku: "hand_written", Name: "Salt", MRP: 20}
+	productFromDB, err := productService.queries.getProductBySkuTx(context.Background(), tx, "hand_written")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if productFromDB != want {
+		t.Errorf("expected: %v, got: %v", want, productFromDB)
+	}
+}
+
+func TestGetProductBySkuTxError(t *testing.T) {
+	tx, err := productService.db.Begin()
+	if err != nil {
+		t.Error(err)
+	}
+	defer tx.Rollback()
+
+	_, err = productService.queries.getProductBySkuTx(context.Background(), tx, "non-existent-sku")
+	if err != sql.ErrNoRows {
+		t.Errorf("expected: %v, got: %v", sql.ErrNoRows, err)
+	}
+}
+
+func TestUpdateProductTx(t *testing.T) {
+	productUpdated := testProduct
+	productUpdated.MRP = 130
+	productUpdated.Variant = "5kg"
+
+	tx, err := productService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = productService.queries.createProductTx(context.Background(), tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = productService.queries.updateProductTx(context.Background(), tx, productUpdated)
+	if err != nil {
+		t.Error(err)
+	}
+
+	productFromDB, err := productService.queries.getProductBySkuTx(context.Background(), tx, testProduct.Sku)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if productFromDB != productUpdated {
+		t.Errorf("expected: %v, got: %v", productUpdated, productFromDB)
+	}
+}
+
+func TestUpdateProductTxNoRowsError(t *testing.T) {
+	tx, err := productService.db.Begin()
+	if err != nil {
+		t.Error(err)
+	}
+	defer tx.Rollback()
+
+	err = productService.queries.updateProductTx(context.Background(), tx, testProduct)
+	if err != RowDoesNotExist {
+		t.Errorf("expected: %v, got: %v", RowDoesNotExist, err)
+	}
+}
+
+func TestDeleteProductTx(t *testing.T) {
+	tx, err := productService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = productService.queries.createProductTx(context.Background(), tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = productService.queries.deleteProductTx(context.Background(), tx, testProduct.Sku)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = productService.queries.deleteProductTx(context.Background(), tx, testProduct.Sku)
+	if err != RowDoesNotExist {
+		t.Errorf("expected: %v, got: %v", RowDoesNotExist, err)
+	}
+}
+
+func TestGetProductBySku(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockproductQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		getProductBySkuTxResponse wms.Product
+		getProductBySkuTxErr      error
+		wantResponse              wms.Product
+		wantErr                   error
+	}{
+		{getProductBySkuTxResponse: testProduct, getProductBySkuTxErr: nil, wantResponse: testProduct, wantErr: nil},
+		{getProductBySkuTxResponse: wms.Product{}, getProductBySkuTxErr: sql.ErrNoRows, wantResponse: wms.Product{}, wantErr: wms.ProductDoesNotExist},
+		{getProductBySkuTxResponse: wms.Product{}, getProductBySkuTxErr: sql.ErrConnDone, wantResponse: wms.Product{}, wantErr: sql.ErrConnDone},
+		{getProductBySkuTxResponse: wms.Product{}, getProductBySkuTxErr: context.Canceled, wantResponse: wms.Product{}, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().getProductBySkuTx(ctx, gomock.Any(), testProduct.Sku).Return(test.getProductBySkuTxResponse, test.getProductBySkuTxErr)
+
+		mockProductService := &ProductService{
+			queries: mockObj,
+			db:      productService.db,
+		}
+
+		response, err := mockProductService.GetProductBySku(ctx, testProduct.Sku)
+
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+
+		if response != test.wantResponse {
+			t.Errorf("want: %v, got: %v", test.wantResponse, response)
+		}
+	}
+}
+
+func TestCreateProduct(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockproductQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		createProductTxErr error
+		wantErr            error
+	}{
+		{createProductTxErr: nil, wantErr: nil},
+		{createProductTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+		{createProductTxErr: context.Canceled, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().createProductTx(ctx, gomock.Any(), testProduct).Return(test.createProductTxErr)
+
+		mockProductService := &ProductService{
+			queries: mockObj,
+			db:      productService.db,
+		}
+
+		err := mockProductService.CreateProduct(ctx, testProduct)
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+	}
+}
+
+func TestUpdateProduct(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockproductQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		updateProductTxErr error
+		wantErr            error
+	}{
+		{updateProductTxErr: nil, wantErr: nil},
+		{updateProductTxErr: RowDoesNotExist, wantErr: wms.ProductDoesNotExist},
+		{updateProductTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+		{updateProductTxErr: context.Canceled, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().updateProductTx(ctx, gomock.Any(), testProduct).Return(test.updateProductTxErr)
+
+		mockProductService := &ProductService{
+			queries: mockObj,
+			db:      productService.db,
+		}
+
+		err := mockProductService.UpdateProduct(ctx, testProduct)
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+	}
+}
+
+func TestDeleteProductBySku(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockproductQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		deleteProductTxErr error
+		wantErr            error
+	}{
+		{deleteProductTxErr: nil, wantErr: nil},
+		{deleteProductTxErr: RowDoesNotExist, wantErr: wms.ProductDoesNotExist},
+		{deleteProductTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+		{deleteProductTxErr: context.Canceled, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		gomock.InOrder(
+			mockObj.EXPECT().lockProductTx(ctx, gomock.Any(), testProduct.Sku).Return(nil),
+			mockObj.EXPECT().productDependentsTx(ctx, gomock.Any(), testProduct.Sku).Return(nil, nil),
+			mockObj.EXPECT().deleteProductTx(ctx, gomock.Any(), testProduct.Sku).Return(test.deleteProductTxErr),
+		)
+
+		mockProductService := &ProductService{
+			queries: mockObj,
+			db:      productService.db,
+		}
+
+		err := mockProductService.DeleteProductBySku(ctx, testProduct.Sku)
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+	}
+}
+
+func TestDeleteProductWithDependents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockproductQueries(mockCtrl)
+	ps := &ProductService{queries: mockObj, db: productService.db}
+	ctx := context.Background()
+
+	mockObj.EXPECT().lockProductTx(ctx, gomock.Any(), testProduct.Sku).Return(RowDoesNotExist)
+	err := ps.DeleteProductBySku(ctx, testProduct.Sku)
+	if err != wms.ProductDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.ProductDoesNotExist, err)
+	}
+
+	dependents := []wms.Dependent{{Entity: wms.EntityItem, Id: "i"}}
+	gomock.InOrder(
+		mockObj.EXPECT().lockProductTx(ctx, gomock.Any(), testProduct.Sku).Return(nil),
+		mockObj.EXPECT().productDependentsTx(ctx, gomock.Any(), testProduct.Sku).Return(dependents, nil),
+	)
+	err = ps.DeleteProductBySku(ctx, testProduct.Sku)
+	if !errors.Is(err, wms.HasDependents) {
+		t.Errorf("want: %v, got: %v", wms.HasDependents, err)
+	}
+}
+
+func TestProductDependentsTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := productService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := productQueriesImpl{}
+	err = queries.lockProductTx(ctx, tx, "non-existent-sku")
+	if err != RowDoesNotExist {
+		t.Errorf("want: %v, got: %v", RowDoesNotExist, err)
+	}
+
+	dependents, err := queries.productDependentsTx(ctx, tx, testProduct.Sku)
+	if err != nil || len(dependents) != 0 {
+		t.Errorf("want no dependents, got: %v, %v", dependents, err)
+	}
+
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	order := wms.NewOrder("acme", fixtureWarehouse.Id, []wms.OrderLine{{Sku: testProduct.Sku, Quantity: 1}})
+	err = new(orderQueriesImpl).createOrderTx(ctx, tx, order)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// an item shipped and returned is no longer in stock, but its package
+	// and return still name the sku
+	shipped := "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f21"
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{
+			query: `INSERT INTO shipment(id, order_id, carrier) VALUES ('shipment', $1, 'carrier')`,
+			args:  []interface{}{order.Id},
+		},
+		{
+			query: `INSERT INTO package(id, shipment_id, tracking_number, weight_in_kg) VALUES ('package', 'shipment', 'TRK1', 1)`,
+		},
+		{
+			query: `INSERT INTO package_item(item_id, package_id, sku, weight_in_kg) VALUES ($1, 'package', $2, 1)`,
+			args:  []interface{}{shipped, testProduct.Sku},
+		},
+		{
+			query: `INSERT INTO return_authorization(id, shipment_id, warehouse_id, reason) VALUES ('return', 'shipment', $1, 'damaged')`,
+			args:  []interface{}{fixtureWarehouse.Id},
+		},
+		{
+			query: `INSERT INTO return_item(item_id, return_id, sku) VALUES ($1, 'return', $2)`,
+			args:  []interface{}{shipped, testProduct.Sku},
+		},
+	}
+	for _, statement := range statements {
+		_, err = tx.ExecContext(ctx, statement.query, statement.args...)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	err = queries.lockProductTx(ctx, tx, testProduct.Sku)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	dependents, err = queries.productDependentsTx(ctx, tx, testProduct.Sku)
+	want := []wms.Dependent{
+		{Entity: wms.EntityItem, Id: testItem().Id},
+		{Entity: wms.EntityOrderLine, Id: order.Lines[0].Id},
+		{Entity: wms.EntityPackageItem, Id: shipped},
+		{Entity: wms.EntityReturnItem, Id: shipped},
+	}
+	if err != nil || !reflect.DeepEqual(dependents, want) {
+		t.Errorf("want: %v, got: %v, %v", want, dependents, err)
+	}
+}
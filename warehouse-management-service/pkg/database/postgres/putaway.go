@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/putaway.go" -destination="./pkg/database/postgres/putaway_mock.go"
+type putawayQueries interface {
+	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	getProductBySkuTx(ctx context.Context, tx *sql.Tx, sku string) (wms.Product, error)
+	listPutawayCandidatesTx(ctx context.Context, tx *sql.Tx, warehouseId string, sku string) ([]wms.PutawayCandidate, error)
+}
+
+type putawayQueriesImpl struct {
+	shelfBlockQueriesImpl
+	productQueriesImpl
+}
+
+type PutawayService struct {
+	queries putawayQueries
+	db      *sql.DB
+}
+
+func NewPutawayService(db *sql.DB) *PutawayService {
+	return &PutawayService{
+		queries: new(putawayQueriesImpl),
+		db:      db,
+	}
+}
+
+// SuggestPutaway proposes up to limit shelves of a warehouse for quantity
+// units of sku, best first.
+func (p *PutawayService) SuggestPutaway(ctx context.Context, warehouseId string, sku string, quantity int, limit int) ([]wms.PutawaySuggestion, error) {
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if warehouseExists, err := p.queries.warehouseExistsTx(ctx, tx, warehouseId); err != nil {
+		return nil, err
+	} else if !warehouseExists {
+		return nil, wms.WarehouseDoesNotExist
+	}
+
+	product, err := p.queries.getProductBySkuTx(ctx, tx, sku)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return nil, wms.InvalidProduct
+	default:
+		return nil, err
+	}
+
+	candidates, err := p.queries.listPutawayCandidatesTx(ctx, tx, warehouseId, sku)
+	if err != nil {
+		return nil, err
+	}
+
+	return wms.SuggestPutaway(product, quantity, candidates, limit), tx.Commit()
+}
+
+// listPutawayCandidatesTx returns every live shelf of a warehouse with its
+// utilization and how much of sku is on it and in its shelf block.
+func (p *putawayQueriesImpl) listPutawayCandidatesTx(ctx context.Context, tx *sql.Tx, warehouseId string, sku string) ([]wms.PutawayCandidate, error) {
+	query := `SELECT shelf.id, shelf.label, shelf.section, shelf.level, shelf.shelf_block,
+			shelf.max_weight_in_kg, shelf.max_volume_in_cm3, shelf.max_items, shelf.version,
+			shelf_block.aisle, shelf_block.rack, shelf_block.storage_type,
+			COUNT(item.id),
+			COALESCE(SUM(product.weight_in_kg), 0),
+			COALESCE(SUM(product.length_in_cm * product.width_in_cm * product.breadth_in_cm), 0),
+			COUNT(item.id) FILTER (WHERE item.sku = $2),
+			(SELECT COUNT(*) FROM item block_item JOIN shelf block_shelf ON block_shelf.id = block_item.shelf_id
+				WHERE block_shelf.shelf_block = shelf_block.id AND block_item.sku = $2)
+		FROM shelf
+		JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		LEFT JOIN item ON item.shelf_id = shelf.id
+		LEFT JOIN product ON product.sku = item.sku
+		WHERE shelf_block.warehouse_id = $1 AND shelf.deleted_at IS NULL AND shelf_block.deleted_at IS NULL
+		GROUP BY shelf.id, shelf_block.id`
+
+	rows, err := tx.QueryContext(ctx, query, warehouseId, sku)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []wms.PutawayCandidate
+	for rows.Next() {
+		var candidate wms.PutawayCandidate
+		err := rows.Scan(
+			&candidate.Id,
+			&candidate.Label,
+			&candidate.Section,
+			&candidate.Level,
+			&candidate.ShelfBlockId,
+			&candidate.MaxWeightInKg,
+			&candidate.MaxVolumeInCm3,
+			&candidate.MaxItems,
+			&candidate.Version,
+			&candidate.Aisle,
+			&candidate.Rack,
+			&candidate.StorageType,
+			&candidate.Used.ItemCount,
+			&candidate.Used.WeightInKg,
+			&candidate.Used.VolumeInCm3,
+			&candidate.SkuCount,
+			&candidate.SkuCountInBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
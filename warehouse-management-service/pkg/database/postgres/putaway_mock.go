@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/putaway.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockputawayQueries is a mock of putawayQueries interface.
+type MockputawayQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockputawayQueriesMockRecorder
+}
+
+// MockputawayQueriesMockRecorder is the mock recorder for MockputawayQueries.
+type MockputawayQueriesMockRecorder struct {
+	mock *MockputawayQueries
+}
+
+// NewMockputawayQueries creates a new mock instance.
+func NewMockputawayQueries(ctrl *gomock.Controller) *MockputawayQueries {
+	mock := &MockputawayQueries{ctrl: ctrl}
+	mock.recorder = &MockputawayQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockputawayQueries) EXPECT() *MockputawayQueriesMockRecorder {
+	return m.recorder
+}
+
+// getProductBySkuTx mocks base method.
+func (m *MockputawayQueries) getProductBySkuTx(ctx context.Context, tx *sql.Tx, sku string) (warehousemanagementservice.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getProductBySkuTx", ctx, tx, sku)
+	ret0, _ := ret[0].(warehousemanagementservice.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getProductBySkuTx indicates an expected call of getProductBySkuTx.
+func (mr *MockputawayQueriesMockRecorder) getProductBySkuTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductBySkuTx", reflect.TypeOf((*MockputawayQueries)(nil).getProductBySkuTx), ctx, tx, sku)
+}
+
+// listPutawayCandidatesTx mocks base method.
+func (m *MockputawayQueries) listPutawayCandidatesTx(ctx context.Context, tx *sql.Tx, warehouseId, sku string) ([]warehousemanagementservice.PutawayCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listPutawayCandidatesTx", ctx, tx, warehouseId, sku)
+	ret0, _ := ret[0].([]warehousemanagementservice.PutawayCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listPutawayCandidatesTx indicates an expected call of listPutawayCandidatesTx.
+func (mr *MockputawayQueriesMockRecorder) listPutawayCandidatesTx(ctx, tx, warehouseId, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listPutawayCandidatesTx", reflect.TypeOf((*MockputawayQueries)(nil).listPutawayCandidatesTx), ctx, tx, warehouseId, sku)
+}
+
+// warehouseExistsTx mocks base method.
+func (m *MockputawayQueries) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseExistsTx indicates an expected call of warehouseExistsTx.
+func (mr *MockputawayQueriesMockRecorder) warehouseExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseExistsTx", reflect.TypeOf((*MockputawayQueries)(nil).warehouseExistsTx), ctx, tx, id)
+}
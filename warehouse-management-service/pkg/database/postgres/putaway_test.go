@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"testing"
+	wms "warehouse-management-service"
+)
+
+func TestListPutawayCandidatesTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := putawayQueriesImpl{}
+	candidates, err := queries.listPutawayCandidatesTx(ctx, tx, fixtureWarehouse.Id, testProduct.Sku)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := wms.PutawayCandidate{
+		Shelf:       fixtureShelf,
+		Aisle:       fixtureShelfBlock.Aisle,
+		Rack:        fixtureShelfBlock.Rack,
+		StorageType: fixtureShelfBlock.StorageType,
+		Used: wms.ShelfUtilization{
+			ItemCount:   1,
+			WeightInKg:  testProduct.WeightInKg,
+			VolumeInCm3: testProduct.LengthInCm * testProduct.WidthInCm * testProduct.BreadthInCm,
+		},
+		SkuCount:        1,
+		SkuCountInBlock: 1,
+	}
+	if len(candidates) != 1 || candidates[0] != want {
+		t.Errorf("want: %v, got: %v", want, candidates)
+	}
+
+	candidates, err = queries.listPutawayCandidatesTx(ctx, tx, fixtureWarehouse.Id, "other")
+	if err != nil || len(candidates) != 1 || candidates[0].Used.ItemCount != 1 || candidates[0].SkuCountInBlock != 0 {
+		t.Errorf("want one shelf holding 1 item and none of the sku, got: %v, %v", candidates, err)
+	}
+}
+
+func TestSuggestPutaway(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockputawayQueries(mockCtrl)
+
+	ps := PutawayService{db: warehouseService.db, queries: mockObj}
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "w").Return(false, nil)
+	_, err := ps.SuggestPutaway(ctx, "w", testProduct.Sku, 1, 5)
+	if err != wms.WarehouseDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.WarehouseDoesNotExist, err)
+	}
+
+	mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "w").Return(true, nil)
+	mockObj.EXPECT().getProductBySkuTx(ctx, gomock.Any(), testProduct.Sku).Return(wms.Product{}, sql.ErrNoRows)
+	_, err = ps.SuggestPutaway(ctx, "w", testProduct.Sku, 1, 5)
+	if err != wms.InvalidProduct {
+		t.Errorf("want: %v, got: %v", wms.InvalidProduct, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().warehouseExistsTx(ctx, gomock.Any(), "w").Return(true, nil),
+		mockObj.EXPECT().getProductBySkuTx(ctx, gomock.Any(), testProduct.Sku).Return(testProduct, nil),
+		mockObj.EXPECT().listPutawayCandidatesTx(ctx, gomock.Any(), "w", testProduct.Sku).Return([]wms.PutawayCandidate{
+			{Shelf: wms.Shelf{Id: "regular"}, StorageType: "regular"},
+			{Shelf: wms.Shelf{Id: "cold"}, StorageType: wms.ColdStorage},
+		}, nil),
+	)
+	suggestions, err := ps.SuggestPutaway(ctx, "w", testProduct.Sku, 1, 5)
+	if err != nil || len(suggestions) != 1 || suggestions[0].ShelfId != "cold" {
+		t.Errorf("want only the cold shelf for a perishable product, got: %v, %v", suggestions, err)
+	}
+}
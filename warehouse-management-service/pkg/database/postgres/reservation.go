@@ -0,0 +1,401 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	wms "warehouse-management-service"
+)
+
+// availableItemsQuery selects, locks and orders the items that can still be
+// claimed by a reservation or pick list: up to $3 available, unexpired items
+// of sku $1 on live shelves of warehouse $2 that are on no pick list and not
+// reserved, unless reserved under reference $4. Reserved items come first,
+// then perishable stock is taken first expired first out and everything else
+// first in first out. Items locked by a concurrent claim are skipped rather
+// than waited for, so two claims never block each other. Under read
+// committed the statement can still miss a claim committed while it ran and
+// lock an item that claim took, so claims select through selectUnclaimedTx.
+const availableItemsQuery = `FROM item
+	JOIN shelf ON shelf.id = item.shelf_id
+	JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+	JOIN product ON product.sku = item.sku
+	LEFT JOIN reservation_item ON reservation_item.item_id = item.id
+	LEFT JOIN reservation ON reservation.id = reservation_item.reservation_id
+	WHERE item.sku = $1 AND shelf_block.warehouse_id = $2
+		AND shelf.deleted_at IS NULL AND shelf_block.deleted_at IS NULL
+		AND item.status = 'available' AND (item.expiration_date IS NULL OR item.expiration_date > now())
+		AND NOT EXISTS(SELECT 1 FROM pick_list_item WHERE pick_list_item.item_id = item.id)
+		AND (reservation.id IS NULL OR reservation.reference = $4)
+	ORDER BY reservation.id IS NULL,
+		CASE WHEN product.perishable THEN item.expiration_date END ASC NULLS LAST,
+		item.received_on, item.id
+	LIMIT $3
+	FOR UPDATE OF item SKIP LOCKED`
+
+// selectUnclaimedTx runs selectItems, a locking selection through
+// availableItemsQuery, until two runs in a row return the same item ids and
+// returns those. Every item the last run returns was locked by an earlier
+// run, before the last run took its snapshot, so no claim of it can have
+// been missed; an item some other claim took falls out and the next run
+// fills its place.
+func selectUnclaimedTx(selectItems func() ([]string, error)) ([]string, error) {
+	var previous []string
+	for {
+		itemIds, err := selectItems()
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil && sameItems(previous, itemIds) {
+			return itemIds, nil
+		}
+		previous = itemIds
+	}
+}
+
+func sameItems(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// expireReservationsBatch is how many lapsed reservations are expired per
+// statement.
+const expireReservationsBatch = 500
+
+// mockgen -source="./pkg/database/postgres/reservation.go" -destination="./pkg/database/postgres/reservation_mock.go"
+type reservationQueries interface {
+	createReservationTx(ctx context.Context, tx *sql.Tx, reservation wms.Reservation) error
+	reserveItemsTx(ctx context.Context, tx *sql.Tx, reservation wms.Reservation) ([]string, error)
+	getReservationByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Reservation, error)
+	lockReservationTx(ctx context.Context, tx *sql.Tx, id string) (wms.Reservation, error)
+	setReservationStatusTx(ctx context.Context, tx *sql.Tx, id string, status string) error
+	releaseReservedItemsTx(ctx context.Context, tx *sql.Tx, id string) error
+	expireReservationsTx(ctx context.Context, tx *sql.Tx, now time.Time, limit int) (int, error)
+	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error)
+}
+
+type reservationQueriesImpl struct {
+	shelfBlockQueriesImpl
+	itemQueriesImpl
+}
+
+type ReservationService struct {
+	queries reservationQueries
+	db      *sql.DB
+}
+
+func NewReservationService(db *sql.DB) *ReservationService {
+	return &ReservationService{
+		queries: new(reservationQueriesImpl),
+		db:      db,
+	}
+}
+
+// CreateReservation reserves reservation.Quantity items and returns the
+// reservation with their ids. Nothing is reserved when there are not enough
+// available items; a *wms.InsufficientStockError says how many there are.
+func (r *ReservationService) CreateReservation(ctx context.Context, reservation wms.Reservation) (wms.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+	defer tx.Rollback()
+
+	if warehouseExists, err := r.queries.warehouseExistsTx(ctx, tx, reservation.WarehouseId); err != nil {
+		return wms.Reservation{}, err
+	} else if !warehouseExists {
+		return wms.Reservation{}, wms.WarehouseDoesNotExist
+	}
+	if productExists, err := r.queries.productExistsTx(ctx, tx, reservation.Sku); err != nil {
+		return wms.Reservation{}, err
+	} else if !productExists {
+		return wms.Reservation{}, wms.InvalidProduct
+	}
+
+	err = r.queries.createReservationTx(ctx, tx, reservation)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+
+	itemIds, err := r.queries.reserveItemsTx(ctx, tx, reservation)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+	if len(itemIds) < reservation.Quantity {
+		return wms.Reservation{}, &wms.InsufficientStockError{Shortages: []wms.StockShortage{{
+			Sku:       reservation.Sku,
+			Requested: reservation.Quantity,
+			Available: len(itemIds),
+		}}}
+	}
+
+	reservation.ItemIds = itemIds
+	return reservation, tx.Commit()
+}
+
+func (r *ReservationService) GetReservationById(ctx context.Context, id string) (wms.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+	defer tx.Rollback()
+
+	reservation, err := r.queries.getReservationByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return reservation, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.Reservation{}, wms.ReservationDoesNotExist
+	default:
+		return wms.Reservation{}, err
+	}
+}
+
+// ConfirmReservation keeps a held reservation's items reserved until it is
+// released. A reservation that has lapsed can no longer be confirmed, even if
+// it has not been expired yet.
+func (r *ReservationService) ConfirmReservation(ctx context.Context, id string) (wms.Reservation, error) {
+	return r.transitionReservation(ctx, id, wms.ReservationConfirmed, func(reservation wms.Reservation) bool {
+		return reservation.Status == wms.ReservationHeld && !reservation.Lapsed(time.Now().UTC())
+	})
+}
+
+// ReleaseReservation gives up a held or confirmed reservation and frees its
+// items for others.
+func (r *ReservationService) ReleaseReservation(ctx context.Context, id string) (wms.Reservation, error) {
+	return r.transitionReservation(ctx, id, wms.ReservationReleased, func(reservation wms.Reservation) bool {
+		return reservation.Status == wms.ReservationHeld || reservation.Status == wms.ReservationConfirmed
+	})
+}
+
+func (r *ReservationService) transitionReservation(
+	ctx context.Context,
+	id string,
+	status string,
+	allowed func(reservation wms.Reservation) bool,
+) (wms.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+	defer tx.Rollback()
+
+	reservation, err := r.queries.lockReservationTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.Reservation{}, wms.ReservationDoesNotExist
+	default:
+		return wms.Reservation{}, err
+	}
+	if !allowed(reservation) {
+		return wms.Reservation{}, wms.ReservationNotHeld
+	}
+
+	err = r.queries.setReservationStatusTx(ctx, tx, id, status)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+	if status == wms.ReservationReleased {
+		err = r.queries.releaseReservedItemsTx(ctx, tx, id)
+		if err != nil {
+			return wms.Reservation{}, err
+		}
+	}
+
+	reservation, err = r.queries.getReservationByIdTx(ctx, tx, id)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+	return reservation, tx.Commit()
+}
+
+// ExpireReservations marks every held reservation that has lapsed by now as
+// expired, frees its items and returns how many were expired. Reservations
+// locked by a concurrent confirm or release are left for the next run.
+func (r *ReservationService) ExpireReservations(ctx context.Context, now time.Time) (int, error) {
+	total := 0
+	for {
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return total, err
+		}
+
+		expired, err := r.queries.expireReservationsTx(ctx, tx, now, expireReservationsBatch)
+		if err != nil {
+			tx.Rollback()
+			return total, err
+		}
+		err = tx.Commit()
+		if err != nil {
+			return total, err
+		}
+
+		total += expired
+		if expired < expireReservationsBatch {
+			return total, nil
+		}
+	}
+}
+
+func (r *reservationQueriesImpl) createReservationTx(ctx context.Context, tx *sql.Tx, reservation wms.Reservation) error {
+	query := `INSERT INTO reservation(id, warehouse_id, sku, quantity, reference, status, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		reservation.Id,
+		reservation.WarehouseId,
+		reservation.Sku,
+		reservation.Quantity,
+		reservation.Reference,
+		reservation.Status,
+		reservation.ExpiresAt,
+		reservation.CreatedAt,
+	)
+	return err
+}
+
+// reserveItemsTx claims up to reservation.Quantity unreserved items for a
+// reservation and returns their ids. Items already reserved are never moved
+// between reservations, even under the same reference.
+func (r *reservationQueriesImpl) reserveItemsTx(ctx context.Context, tx *sql.Tx, reservation wms.Reservation) ([]string, error) {
+	itemIds, err := selectUnclaimedTx(func() ([]string, error) {
+		return r.availableItemIdsTx(ctx, tx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, itemId := range itemIds {
+		_, err = tx.ExecContext(ctx, `INSERT INTO reservation_item(item_id, reservation_id) VALUES ($1, $2)`, itemId, reservation.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return itemIds, nil
+}
+
+func (r *reservationQueriesImpl) availableItemIdsTx(ctx context.Context, tx *sql.Tx, reservation wms.Reservation) ([]string, error) {
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT item.id `+availableItemsQuery,
+		reservation.Sku,
+		reservation.WarehouseId,
+		reservation.Quantity,
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	itemIds := []string{}
+	for rows.Next() {
+		var itemId string
+		err := rows.Scan(&itemId)
+		if err != nil {
+			return nil, err
+		}
+		itemIds = append(itemIds, itemId)
+	}
+	return itemIds, rows.Err()
+}
+
+func (r *reservationQueriesImpl) getReservationByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Reservation, error) {
+	reservation, err := r.scanReservation(tx.QueryRowContext(
+		ctx,
+		`SELECT id, warehouse_id, sku, quantity, COALESCE(reference, ''), status, expires_at, created_at FROM reservation WHERE id = $1`,
+		id,
+	))
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT item_id FROM reservation_item WHERE reservation_id = $1 ORDER BY item_id`, id)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+	defer rows.Close()
+
+	reservation.ItemIds = []string{}
+	for rows.Next() {
+		var itemId string
+		err := rows.Scan(&itemId)
+		if err != nil {
+			return wms.Reservation{}, err
+		}
+		reservation.ItemIds = append(reservation.ItemIds, itemId)
+	}
+
+	return reservation, rows.Err()
+}
+
+// lockReservationTx returns a reservation without its items and locks its
+// row, so that it cannot be confirmed, released and expired at once.
+func (r *reservationQueriesImpl) lockReservationTx(ctx context.Context, tx *sql.Tx, id string) (wms.Reservation, error) {
+	return r.scanReservation(tx.QueryRowContext(
+		ctx,
+		`SELECT id, warehouse_id, sku, quantity, COALESCE(reference, ''), status, expires_at, created_at FROM reservation WHERE id = $1 FOR UPDATE`,
+		id,
+	))
+}
+
+func (r *reservationQueriesImpl) scanReservation(row *sql.Row) (wms.Reservation, error) {
+	var reservation wms.Reservation
+	err := row.Scan(
+		&reservation.Id,
+		&reservation.WarehouseId,
+		&reservation.Sku,
+		&reservation.Quantity,
+		&reservation.Reference,
+		&reservation.Status,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+	)
+	if err != nil {
+		return wms.Reservation{}, err
+	}
+	return reservation, nil
+}
+
+func (r *reservationQueriesImpl) setReservationStatusTx(ctx context.Context, tx *sql.Tx, id string, status string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE reservation SET status = $1 WHERE id = $2`, status, id)
+	return err
+}
+
+func (r *reservationQueriesImpl) releaseReservedItemsTx(ctx context.Context, tx *sql.Tx, id string) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM reservation_item WHERE reservation_id = $1`, id)
+	return err
+}
+
+// expireReservationsTx expires up to limit held reservations that lapsed by
+// now, skipping any that another transaction holds, and frees their items.
+func (r *reservationQueriesImpl) expireReservationsTx(ctx context.Context, tx *sql.Tx, now time.Time, limit int) (int, error) {
+	query := `WITH lapsed AS (
+			SELECT id FROM reservation WHERE status = $1 AND expires_at <= $2
+			ORDER BY expires_at LIMIT $3 FOR UPDATE SKIP LOCKED
+		), expired AS (
+			UPDATE reservation SET status = $4 FROM lapsed WHERE reservation.id = lapsed.id RETURNING reservation.id
+		), released AS (
+			DELETE FROM reservation_item USING expired WHERE reservation_item.reservation_id = expired.id
+		)
+		SELECT COUNT(*) FROM expired`
+
+	var expired int
+	row := tx.QueryRowContext(ctx, query, wms.ReservationHeld, now, limit, wms.ReservationExpired)
+	err := row.Scan(&expired)
+	if err != nil {
+		return 0, err
+	}
+	return expired, nil
+}
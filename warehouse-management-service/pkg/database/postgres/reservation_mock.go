@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/reservation.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockreservationQueries is a mock of reservationQueries interface.
+type MockreservationQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockreservationQueriesMockRecorder
+}
+
+// MockreservationQueriesMockRecorder is the mock recorder for MockreservationQueries.
+type MockreservationQueriesMockRecorder struct {
+	mock *MockreservationQueries
+}
+
+// NewMockreservationQueries creates a new mock instance.
+func NewMockreservationQueries(ctrl *gomock.Controller) *MockreservationQueries {
+	mock := &MockreservationQueries{ctrl: ctrl}
+	mock.recorder = &MockreservationQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreservationQueries) EXPECT() *MockreservationQueriesMockRecorder {
+	return m.recorder
+}
+
+// createReservationTx mocks base method.
+func (m *MockreservationQueries) createReservationTx(ctx context.Context, tx *sql.Tx, reservation warehousemanagementservice.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createReservationTx", ctx, tx, reservation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createReservationTx indicates an expected call of createReservationTx.
+func (mr *MockreservationQueriesMockRecorder) createReservationTx(ctx, tx, reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createReservationTx", reflect.TypeOf((*MockreservationQueries)(nil).createReservationTx), ctx, tx, reservation)
+}
+
+// expireReservationsTx mocks base method.
+func (m *MockreservationQueries) expireReservationsTx(ctx context.Context, tx *sql.Tx, now time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "expireReservationsTx", ctx, tx, now, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// expireReservationsTx indicates an expected call of expireReservationsTx.
+func (mr *MockreservationQueriesMockRecorder) expireReservationsTx(ctx, tx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "expireReservationsTx", reflect.TypeOf((*MockreservationQueries)(nil).expireReservationsTx), ctx, tx, now, limit)
+}
+
+// getReservationByIdTx mocks base method.
+func (m *MockreservationQueries) getReservationByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getReservationByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getReservationByIdTx indicates an expected call of getReservationByIdTx.
+func (mr *MockreservationQueriesMockRecorder) getReservationByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getReservationByIdTx", reflect.TypeOf((*MockreservationQueries)(nil).getReservationByIdTx), ctx, tx, id)
+}
+
+// lockReservationTx mocks base method.
+func (m *MockreservationQueries) lockReservationTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockReservationTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockReservationTx indicates an expected call of lockReservationTx.
+func (mr *MockreservationQueriesMockRecorder) lockReservationTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockReservationTx", reflect.TypeOf((*MockreservationQueries)(nil).lockReservationTx), ctx, tx, id)
+}
+
+// productExistsTx mocks base method.
+func (m *MockreservationQueries) productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "productExistsTx", ctx, tx, sku)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// productExistsTx indicates an expected call of productExistsTx.
+func (mr *MockreservationQueriesMockRecorder) productExistsTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "productExistsTx", reflect.TypeOf((*MockreservationQueries)(nil).productExistsTx), ctx, tx, sku)
+}
+
+// releaseReservedItemsTx mocks base method.
+func (m *MockreservationQueries) releaseReservedItemsTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "releaseReservedItemsTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// releaseReservedItemsTx indicates an expected call of releaseReservedItemsTx.
+func (mr *MockreservationQueriesMockRecorder) releaseReservedItemsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "releaseReservedItemsTx", reflect.TypeOf((*MockreservationQueries)(nil).releaseReservedItemsTx), ctx, tx, id)
+}
+
+// reserveItemsTx mocks base method.
+func (m *MockreservationQueries) reserveItemsTx(ctx context.Context, tx *sql.Tx, reservation warehousemanagementservice.Reservation) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "reserveItemsTx", ctx, tx, reservation)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// reserveItemsTx indicates an expected call of reserveItemsTx.
+func (mr *MockreservationQueriesMockRecorder) reserveItemsTx(ctx, tx, reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "reserveItemsTx", reflect.TypeOf((*MockreservationQueries)(nil).reserveItemsTx), ctx, tx, reservation)
+}
+
+// setReservationStatusTx mocks base method.
+func (m *MockreservationQueries) setReservationStatusTx(ctx context.Context, tx *sql.Tx, id, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "setReservationStatusTx", ctx, tx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// setReservationStatusTx indicates an expected call of setReservationStatusTx.
+func (mr *MockreservationQueriesMockRecorder) setReservationStatusTx(ctx, tx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setReservationStatusTx", reflect.TypeOf((*MockreservationQueries)(nil).setReservationStatusTx), ctx, tx, id, status)
+}
+
+// warehouseExistsTx mocks base method.
+func (m *MockreservationQueries) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseExistsTx indicates an expected call of warehouseExistsTx.
+func (mr *MockreservationQueriesMockRecorder) warehouseExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseExistsTx", reflect.TypeOf((*MockreservationQueries)(nil).warehouseExistsTx), ctx, tx, id)
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func TestReserveItemsTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := reservationQueriesImpl{}
+	reservation := wms.NewReservation(fixtureWarehouse.Id, testProduct.Sku, 2, "order", time.Minute)
+	err = queries.createReservationTx(ctx, tx, reservation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	itemIds, err := queries.reserveItemsTx(ctx, tx, reservation)
+	if err != nil || len(itemIds) != 1 || itemIds[0] != testItem().Id {
+		t.Errorf("want item %s reserved, got: %v, %v", testItem().Id, itemIds, err)
+	}
+
+	other := wms.NewReservation(fixtureWarehouse.Id, testProduct.Sku, 1, "order", time.Minute)
+	err = queries.createReservationTx(ctx, tx, other)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	itemIds, err = queries.reserveItemsTx(ctx, tx, other)
+	if err != nil || len(itemIds) != 0 {
+		t.Errorf("want no item left to reserve, got: %v, %v", itemIds, err)
+	}
+
+	orders := orderQueriesImpl{}
+	entries, err := orders.allocateItemsTx(ctx, tx, fixtureWarehouse.Id, wms.OrderLine{Id: "l", OrderId: "other", Sku: testProduct.Sku, Quantity: 1})
+	if err != nil || len(entries) != 0 {
+		t.Errorf("want the reserved item kept from other orders, got: %v, %v", entries, err)
+	}
+
+	expired, err := queries.expireReservationsTx(ctx, tx, other.ExpiresAt.Add(time.Second), 10)
+	if err != nil || expired != 2 {
+		t.Errorf("want 2 reservations expired, got: %v, %v", expired, err)
+	}
+	reservationFromDB, err := queries.getReservationByIdTx(ctx, tx, reservation.Id)
+	if err != nil || reservationFromDB.Status != wms.ReservationExpired || len(reservationFromDB.ItemIds) != 0 {
+		t.Errorf("want an expired reservation without items, got: %v, %v", reservationFromDB, err)
+	}
+}
+
+func TestReserveItemsTxConcurrentClaims(t *testing.T) {
+	ctx := context.Background()
+	cleanup, ok := commitFixtures(t)
+	if !ok {
+		return
+	}
+	defer cleanup()
+
+	older := testItem()
+	newer := testItem()
+	newer.Id = "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f11"
+	expirationDate := older.ExpirationDate.AddDate(0, 1, 0)
+	newer.ExpirationDate = &expirationDate
+
+	tx, err := itemService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+	for _, item := range []wms.Item{older, newer} {
+		err = itemService.queries.createItemTx(ctx, tx, item)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	first, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer first.Rollback()
+	second, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer second.Rollback()
+
+	queries := reservationQueriesImpl{}
+	reserve := func(tx *sql.Tx, quantity int, reference string) ([]string, error) {
+		reservation := wms.NewReservation(fixtureWarehouse.Id, testProduct.Sku, quantity, reference, time.Minute)
+		err := queries.createReservationTx(ctx, tx, reservation)
+		if err != nil {
+			return nil, err
+		}
+		return queries.reserveItemsTx(ctx, tx, reservation)
+	}
+
+	itemIds, err := reserve(first, 1, "first")
+	if err != nil || !sameItems(itemIds, []string{older.Id}) {
+		t.Errorf("want item %s reserved, got: %v, %v", older.Id, itemIds, err)
+		return
+	}
+
+	// second skips the item first holds rather than waiting for first to end
+	itemIds, err = reserve(second, 2, "second")
+	if err != nil || !sameItems(itemIds, []string{newer.Id}) {
+		t.Errorf("want only item %s reserved, got: %v, %v", newer.Id, itemIds, err)
+		return
+	}
+
+	err = first.Commit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// once first commits, its item is no longer locked but still claimed
+	itemIds, err = reserve(second, 1, "third")
+	if err != nil || len(itemIds) != 0 {
+		t.Errorf("want no item left to reserve, got: %v, %v", itemIds, err)
+	}
+}
+
+func TestSelectUnclaimedTx(t *testing.T) {
+	// i2 is taken by a claim the first run missed, i3 fills its place
+	runs := [][]string{{"i1", "i2"}, {"i1", "i3"}, {"i1", "i3"}}
+	n := 0
+	itemIds, err := selectUnclaimedTx(func() ([]string, error) {
+		n++
+		return runs[n-1], nil
+	})
+	if err != nil || n != len(runs) || !sameItems(itemIds, []string{"i1", "i3"}) {
+		t.Errorf("want i1 and i3 after %d runs, got: %v after %d, %v", len(runs), itemIds, n, err)
+	}
+
+	_, err = selectUnclaimedTx(func() ([]string, error) {
+		return nil, sql.ErrConnDone
+	})
+	if err != sql.ErrConnDone {
+		t.Errorf("want: %v, got: %v", sql.ErrConnDone, err)
+	}
+}
+
+func TestConfirmReservation(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockreservationQueries(mockCtrl)
+
+	rs := ReservationService{db: warehouseService.db, queries: mockObj}
+	held := wms.Reservation{Id: "r", Status: wms.ReservationHeld, ExpiresAt: time.Now().UTC().Add(time.Minute)}
+	confirmed := held
+	confirmed.Status = wms.ReservationConfirmed
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockReservationTx(ctx, gomock.Any(), "r").Return(held, nil),
+		mockObj.EXPECT().setReservationStatusTx(ctx, gomock.Any(), "r", wms.ReservationConfirmed).Return(nil),
+		mockObj.EXPECT().getReservationByIdTx(ctx, gomock.Any(), "r").Return(confirmed, nil),
+	)
+	reservation, err := rs.ConfirmReservation(ctx, "r")
+	if err != nil || reservation.Status != wms.ReservationConfirmed {
+		t.Errorf("want a confirmed reservation, got: %v, %v", reservation, err)
+	}
+
+	lapsed := held
+	lapsed.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+	mockObj.EXPECT().lockReservationTx(ctx, gomock.Any(), "r").Return(lapsed, nil)
+	_, err = rs.ConfirmReservation(ctx, "r")
+	if err != wms.ReservationNotHeld {
+		t.Errorf("want: %v, got: %v", wms.ReservationNotHeld, err)
+	}
+
+	mockObj.EXPECT().lockReservationTx(ctx, gomock.Any(), "r").Return(wms.Reservation{}, sql.ErrNoRows)
+	_, err = rs.ReleaseReservation(ctx, "r")
+	if err != wms.ReservationDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.ReservationDoesNotExist, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockReservationTx(ctx, gomock.Any(), "r").Return(confirmed, nil),
+		mockObj.EXPECT().setReservationStatusTx(ctx, gomock.Any(), "r", wms.ReservationReleased).Return(nil),
+		mockObj.EXPECT().releaseReservedItemsTx(ctx, gomock.Any(), "r").Return(nil),
+		mockObj.EXPECT().getReservationByIdTx(ctx, gomock.Any(), "r").Return(wms.Reservation{Id: "r", Status: wms.ReservationReleased}, nil),
+	)
+	reservation, err = rs.ReleaseReservation(ctx, "r")
+	if err != nil || reservation.Status != wms.ReservationReleased {
+		t.Errorf("want a released reservation, got: %v, %v", reservation, err)
+	}
+}
@@ -0,0 +1,476 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/return_authorization.go" -destination="./pkg/database/postgres/return_authorization_mock.go"
+type returnQueries interface {
+	lockShipmentTx(ctx context.Context, tx *sql.Tx, id string) (wms.Shipment, error)
+	getOrderByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Order, error)
+	shippedItemTx(ctx context.Context, tx *sql.Tx, shipmentId string, itemId string) (wms.ReturnItem, error)
+	itemReturnedTx(ctx context.Context, tx *sql.Tx, itemId string) (bool, error)
+	createReturnTx(ctx context.Context, tx *sql.Tx, authorization wms.ReturnAuthorization) error
+	getReturnByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.ReturnAuthorization, error)
+	lockReturnTx(ctx context.Context, tx *sql.Tx, id string) (wms.ReturnAuthorization, error)
+	saveReturnItemTx(ctx context.Context, tx *sql.Tx, item wms.ReturnItem) error
+	getProductBySkuTx(ctx context.Context, tx *sql.Tx, sku string) (wms.Product, error)
+	listPutawayCandidatesTx(ctx context.Context, tx *sql.Tx, warehouseId string, sku string) ([]wms.PutawayCandidate, error)
+	shelfWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string) (string, error)
+	checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) error
+	restockItemTx(ctx context.Context, tx *sql.Tx, item wms.Item) error
+	recordReturnMovementTx(ctx context.Context, tx *sql.Tx, item wms.ReturnItem) error
+	closeReturnTx(ctx context.Context, tx *sql.Tx, id string, closedAt time.Time) error
+}
+
+type returnQueriesImpl struct {
+	shipmentQueriesImpl
+	transferQueriesImpl
+	putawayQueriesImpl
+}
+
+type ReturnService struct {
+	queries returnQueries
+	db      *sql.DB
+}
+
+func NewReturnService(db *sql.DB) *ReturnService {
+	return &ReturnService{
+		queries: new(returnQueriesImpl),
+		db:      db,
+	}
+}
+
+// CreateReturn authorizes the return of items of a dispatched shipment into
+// the warehouse they were shipped from.
+func (r *ReturnService) CreateReturn(ctx context.Context, shipmentId string, reason string, itemIds []string) (wms.ReturnAuthorization, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.ReturnAuthorization{}, err
+	}
+	defer tx.Rollback()
+
+	shipment, err := r.queries.lockShipmentTx(ctx, tx, shipmentId)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.ReturnAuthorization{}, wms.ShipmentDoesNotExist
+	default:
+		return wms.ReturnAuthorization{}, err
+	}
+	if shipment.Status != wms.ShipmentDispatched {
+		return wms.ReturnAuthorization{}, wms.ShipmentNotDispatched
+	}
+	order, err := r.queries.getOrderByIdTx(ctx, tx, shipment.OrderId)
+	if err != nil {
+		return wms.ReturnAuthorization{}, err
+	}
+
+	items := make([]wms.ReturnItem, 0, len(itemIds))
+	for _, itemId := range itemIds {
+		item, err := r.queries.shippedItemTx(ctx, tx, shipmentId, itemId)
+		switch err {
+		case nil:
+		case sql.ErrNoRows:
+			return wms.ReturnAuthorization{}, wms.ItemNotShipped
+		default:
+			return wms.ReturnAuthorization{}, err
+		}
+		if returned, err := r.queries.itemReturnedTx(ctx, tx, itemId); err != nil {
+			return wms.ReturnAuthorization{}, err
+		} else if returned {
+			return wms.ReturnAuthorization{}, wms.ItemAlreadyReturned
+		}
+		items = append(items, item)
+	}
+
+	authorization, err := wms.NewReturnAuthorization(shipmentId, order.WarehouseId, reason, items)
+	if err != nil {
+		return wms.ReturnAuthorization{}, err
+	}
+	err = r.queries.createReturnTx(ctx, tx, authorization)
+	if err != nil {
+		return wms.ReturnAuthorization{}, err
+	}
+	return authorization, tx.Commit()
+}
+
+func (r *ReturnService) GetReturnById(ctx context.Context, id string) (wms.ReturnAuthorization, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wms.ReturnAuthorization{}, err
+	}
+	defer tx.Rollback()
+
+	authorization, err := r.queries.getReturnByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return authorization, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.ReturnAuthorization{}, wms.ReturnDoesNotExist
+	default:
+		return wms.ReturnAuthorization{}, err
+	}
+}
+
+// InspectReturnItem records the condition a returned item came back in.
+func (r *ReturnService) InspectReturnItem(ctx context.Context, returnId string, itemId string, condition string, notes string) (wms.ReturnItem, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+	defer tx.Rollback()
+
+	item, _, err := r.lockReturnItemTx(ctx, tx, returnId, itemId)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+	err = item.Inspect(condition, notes, time.Now().UTC())
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+
+	err = r.queries.saveReturnItemTx(ctx, tx, item)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+	return item, tx.Commit()
+}
+
+// ResolveReturnItem gives an inspected item its outcome. A restocked item
+// goes onto shelfId, or the best shelf for it by putaway when shelfId is
+// empty, and a quarantined one onto shelfId, either way as a new item with
+// the expiration date it was shipped with. Every outcome is recorded in the
+// stock ledger, and the return closes with its last item.
+func (r *ReturnService) ResolveReturnItem(ctx context.Context, returnId string, itemId string, outcome string, shelfId string) (wms.ReturnItem, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+	defer tx.Rollback()
+
+	item, authorization, err := r.lockReturnItemTx(ctx, tx, returnId, itemId)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+	now := time.Now().UTC()
+	err = item.Resolve(outcome, shelfId, now)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+
+	if item.Outcome == wms.ReturnRestock && item.ShelfId == "" {
+		item.ShelfId, err = r.suggestShelfTx(ctx, tx, authorization.WarehouseId, item.Sku)
+		if err != nil {
+			return wms.ReturnItem{}, err
+		}
+	}
+	if item.ShelfId != "" {
+		warehouseId, err := r.queries.shelfWarehouseTx(ctx, tx, item.ShelfId)
+		if err == sql.ErrNoRows || (err == nil && warehouseId != authorization.WarehouseId) {
+			return wms.ReturnItem{}, wms.InvalidShelf
+		} else if err != nil {
+			return wms.ReturnItem{}, err
+		}
+		err = r.queries.checkShelfCapacityTx(ctx, tx, item.ShelfId, item.Sku, 1)
+		if err != nil {
+			return wms.ReturnItem{}, err
+		}
+
+		stocked := wms.NewItem(item.Sku, item.ExpirationDate, item.ShelfId, item.Lot, item.SerialNumber)
+		if item.Outcome == wms.ReturnQuarantine {
+			stocked.Status = wms.ItemQuarantined
+		}
+		err = r.queries.restockItemTx(ctx, tx, stocked)
+		if err != nil {
+			return wms.ReturnItem{}, err
+		}
+		item.NewItemId = stocked.Id
+	}
+
+	err = r.queries.recordReturnMovementTx(ctx, tx, item)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+	err = r.queries.saveReturnItemTx(ctx, tx, item)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+
+	for i := range authorization.Items {
+		if authorization.Items[i].ItemId == item.ItemId {
+			authorization.Items[i] = item
+		}
+	}
+	if authorization.Resolved() {
+		err = r.queries.closeReturnTx(ctx, tx, returnId, now)
+		if err != nil {
+			return wms.ReturnItem{}, err
+		}
+	}
+	return item, tx.Commit()
+}
+
+// lockReturnItemTx locks a return that is still open and returns it along
+// with its item itemId.
+func (r *ReturnService) lockReturnItemTx(ctx context.Context, tx *sql.Tx, returnId string, itemId string) (wms.ReturnItem, wms.ReturnAuthorization, error) {
+	authorization, err := r.queries.lockReturnTx(ctx, tx, returnId)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.ReturnItem{}, wms.ReturnAuthorization{}, wms.ReturnDoesNotExist
+	default:
+		return wms.ReturnItem{}, wms.ReturnAuthorization{}, err
+	}
+	if authorization.Status != wms.ReturnAuthorized {
+		return wms.ReturnItem{}, wms.ReturnAuthorization{}, wms.ReturnAlreadyClosed
+	}
+
+	item, err := authorization.Item(itemId)
+	if err != nil {
+		return wms.ReturnItem{}, wms.ReturnAuthorization{}, err
+	}
+	return item, authorization, nil
+}
+
+// suggestShelfTx returns the shelf of a warehouse that putaway ranks best
+// for one item of sku.
+func (r *ReturnService) suggestShelfTx(ctx context.Context, tx *sql.Tx, warehouseId string, sku string) (string, error) {
+	product, err := r.queries.getProductBySkuTx(ctx, tx, sku)
+	if err != nil {
+		return "", err
+	}
+	candidates, err := r.queries.listPutawayCandidatesTx(ctx, tx, warehouseId, sku)
+	if err != nil {
+		return "", err
+	}
+
+	suggestions := wms.SuggestPutaway(product, 1, candidates, 1)
+	if len(suggestions) == 0 {
+		return "", wms.NoPutawayShelf
+	}
+	return suggestions[0].ShelfId, nil
+}
+
+// shippedItemTx returns an item packed into a shipment as an item to be
+// returned.
+func (r *returnQueriesImpl) shippedItemTx(ctx context.Context, tx *sql.Tx, shipmentId string, itemId string) (wms.ReturnItem, error) {
+	query := `SELECT package_item.item_id, package_item.sku, COALESCE(package_item.lot, ''), COALESCE(package_item.serial_number, ''),
+			package_item.expiration_date
+		FROM package_item
+		JOIN package ON package.id = package_item.package_id
+		WHERE package.shipment_id = $1 AND package_item.item_id = $2`
+
+	var item wms.ReturnItem
+	var expirationDate sql.NullTime
+	row := tx.QueryRowContext(ctx, query, shipmentId, itemId)
+	err := row.Scan(&item.ItemId, &item.Sku, &item.Lot, &item.SerialNumber, &expirationDate)
+	if err != nil {
+		return wms.ReturnItem{}, err
+	}
+	if expirationDate.Valid {
+		item.ExpirationDate = &expirationDate.Time
+	}
+	return item, nil
+}
+
+func (r *returnQueriesImpl) itemReturnedTx(ctx context.Context, tx *sql.Tx, itemId string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM return_item WHERE item_id = $1)`
+
+	var returned bool
+	row := tx.QueryRowContext(ctx, query, itemId)
+	err := row.Scan(&returned)
+	if err != nil {
+		return false, err
+	}
+	return returned, nil
+}
+
+func (r *returnQueriesImpl) createReturnTx(ctx context.Context, tx *sql.Tx, authorization wms.ReturnAuthorization) error {
+	query := `INSERT INTO return_authorization(id, shipment_id, warehouse_id, reason, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		authorization.Id,
+		authorization.ShipmentId,
+		authorization.WarehouseId,
+		authorization.Reason,
+		authorization.Status,
+		authorization.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range authorization.Items {
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO return_item(item_id, return_id, sku, lot, serial_number, expiration_date)
+				VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)`,
+			item.ItemId,
+			authorization.Id,
+			item.Sku,
+			item.Lot,
+			item.SerialNumber,
+			item.ExpirationDate,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const returnColumns = `id, shipment_id, warehouse_id, reason, status, created_at, closed_at`
+
+func (r *returnQueriesImpl) getReturnByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.ReturnAuthorization, error) {
+	return r.queryReturnTx(ctx, tx, `SELECT `+returnColumns+` FROM return_authorization WHERE id = $1`, id)
+}
+
+// lockReturnTx returns a return with its items and locks its row, so that
+// its items are inspected and resolved one at a time.
+func (r *returnQueriesImpl) lockReturnTx(ctx context.Context, tx *sql.Tx, id string) (wms.ReturnAuthorization, error) {
+	return r.queryReturnTx(ctx, tx, `SELECT `+returnColumns+` FROM return_authorization WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *returnQueriesImpl) queryReturnTx(ctx context.Context, tx *sql.Tx, query string, id string) (wms.ReturnAuthorization, error) {
+	var authorization wms.ReturnAuthorization
+	var closedAt sql.NullTime
+	row := tx.QueryRowContext(ctx, query, id)
+	err := row.Scan(
+		&authorization.Id,
+		&authorization.ShipmentId,
+		&authorization.WarehouseId,
+		&authorization.Reason,
+		&authorization.Status,
+		&authorization.CreatedAt,
+		&closedAt,
+	)
+	if err != nil {
+		return wms.ReturnAuthorization{}, err
+	}
+	if closedAt.Valid {
+		authorization.ClosedAt = &closedAt.Time
+	}
+
+	itemsQuery := `SELECT return_id, item_id, sku, COALESCE(lot, ''), COALESCE(serial_number, ''), expiration_date, COALESCE(condition, ''), COALESCE(notes, ''), inspected_at,
+			COALESCE(outcome, ''), COALESCE(shelf_id, ''), COALESCE(new_item_id, ''), resolved_at
+		FROM return_item WHERE return_id = $1 ORDER BY item_id`
+
+	rows, err := tx.QueryContext(ctx, itemsQuery, id)
+	if err != nil {
+		return wms.ReturnAuthorization{}, err
+	}
+	defer rows.Close()
+
+	authorization.Items = []wms.ReturnItem{}
+	for rows.Next() {
+		var item wms.ReturnItem
+		var expirationDate, inspectedAt, resolvedAt sql.NullTime
+		err := rows.Scan(
+			&item.ReturnId,
+			&item.ItemId,
+			&item.Sku,
+			&item.Lot,
+			&item.SerialNumber,
+			&expirationDate,
+			&item.Condition,
+			&item.Notes,
+			&inspectedAt,
+			&item.Outcome,
+			&item.ShelfId,
+			&item.NewItemId,
+			&resolvedAt,
+		)
+		if err != nil {
+			return wms.ReturnAuthorization{}, err
+		}
+		if expirationDate.Valid {
+			item.ExpirationDate = &expirationDate.Time
+		}
+		if inspectedAt.Valid {
+			item.InspectedAt = &inspectedAt.Time
+		}
+		if resolvedAt.Valid {
+			item.ResolvedAt = &resolvedAt.Time
+		}
+		authorization.Items = append(authorization.Items, item)
+	}
+
+	return authorization, rows.Err()
+}
+
+// saveReturnItemTx stores the inspection and outcome of a returned item.
+func (r *returnQueriesImpl) saveReturnItemTx(ctx context.Context, tx *sql.Tx, item wms.ReturnItem) error {
+	query := `UPDATE return_item SET condition = NULLIF($1, ''), notes = NULLIF($2, ''), inspected_at = $3,
+			outcome = NULLIF($4, ''), shelf_id = NULLIF($5, ''), new_item_id = NULLIF($6, ''), resolved_at = $7
+		WHERE return_id = $8 AND item_id = $9`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		item.Condition,
+		item.Notes,
+		item.InspectedAt,
+		item.Outcome,
+		item.ShelfId,
+		item.NewItemId,
+		item.ResolvedAt,
+		item.ReturnId,
+		item.ItemId,
+	)
+	return err
+}
+
+// restockItemTx puts a returned item back on a shelf. The shelf's capacity
+// is checked by the caller.
+func (r *returnQueriesImpl) restockItemTx(ctx context.Context, tx *sql.Tx, item wms.Item) error {
+	query := `INSERT INTO item(id, sku, expiration_date, received_on, shelf_id, status, lot, serial_number)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''))`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		item.Id,
+		item.Sku,
+		item.ExpirationDate,
+		item.ReceivedOn,
+		item.ShelfId,
+		item.Status,
+		item.Lot,
+		item.SerialNumber,
+	)
+	if isUniqueViolation(err, itemSerialNumberIndex) {
+		return wms.DuplicateSerialNumber
+	}
+	return err
+}
+
+// recordReturnMovementTx records the outcome of a returned item in the stock
+// ledger: the new item arriving on its shelf, or the returned item written
+// off.
+func (r *returnQueriesImpl) recordReturnMovementTx(ctx context.Context, tx *sql.Tx, item wms.ReturnItem) error {
+	movement := wms.StockMovement{ItemId: item.NewItemId, Sku: item.Sku, ToShelfId: item.ShelfId}
+	switch item.Outcome {
+	case wms.ReturnRestock:
+		movement.Kind = wms.MovementRestock
+	case wms.ReturnQuarantine:
+		movement.Kind = wms.MovementQuarantine
+	default:
+		movement.ItemId = item.ItemId
+		movement.Kind = wms.MovementWriteOff
+	}
+	return recordMovementTx(ctx, tx, movement)
+}
+
+func (r *returnQueriesImpl) closeReturnTx(ctx context.Context, tx *sql.Tx, id string, closedAt time.Time) error {
+	query := `UPDATE return_authorization SET status = $1, closed_at = $2 WHERE id = $3`
+
+	_, err := tx.ExecContext(ctx, query, wms.ReturnClosed, closedAt, id)
+	return err
+}
@@ -0,0 +1,257 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/return_authorization.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockreturnQueries is a mock of returnQueries interface.
+type MockreturnQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockreturnQueriesMockRecorder
+}
+
+// MockreturnQueriesMockRecorder is the mock recorder for MockreturnQueries.
+type MockreturnQueriesMockRecorder struct {
+	mock *MockreturnQueries
+}
+
+// NewMockreturnQueries creates a new mock instance.
+func NewMockreturnQueries(ctrl *gomock.Controller) *MockreturnQueries {
+	mock := &MockreturnQueries{ctrl: ctrl}
+	mock.recorder = &MockreturnQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreturnQueries) EXPECT() *MockreturnQueriesMockRecorder {
+	return m.recorder
+}
+
+// checkShelfCapacityTx mocks base method.
+func (m *MockreturnQueries) checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId, sku string, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkShelfCapacityTx", ctx, tx, shelfId, sku, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// checkShelfCapacityTx indicates an expected call of checkShelfCapacityTx.
+func (mr *MockreturnQueriesMockRecorder) checkShelfCapacityTx(ctx, tx, shelfId, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkShelfCapacityTx", reflect.TypeOf((*MockreturnQueries)(nil).checkShelfCapacityTx), ctx, tx, shelfId, sku, quantity)
+}
+
+// closeReturnTx mocks base method.
+func (m *MockreturnQueries) closeReturnTx(ctx context.Context, tx *sql.Tx, id string, closedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "closeReturnTx", ctx, tx, id, closedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// closeReturnTx indicates an expected call of closeReturnTx.
+func (mr *MockreturnQueriesMockRecorder) closeReturnTx(ctx, tx, id, closedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "closeReturnTx", reflect.TypeOf((*MockreturnQueries)(nil).closeReturnTx), ctx, tx, id, closedAt)
+}
+
+// createReturnTx mocks base method.
+func (m *MockreturnQueries) createReturnTx(ctx context.Context, tx *sql.Tx, authorization warehousemanagementservice.ReturnAuthorization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createReturnTx", ctx, tx, authorization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createReturnTx indicates an expected call of createReturnTx.
+func (mr *MockreturnQueriesMockRecorder) createReturnTx(ctx, tx, authorization interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createReturnTx", reflect.TypeOf((*MockreturnQueries)(nil).createReturnTx), ctx, tx, authorization)
+}
+
+// getOrderByIdTx mocks base method.
+func (m *MockreturnQueries) getOrderByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getOrderByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getOrderByIdTx indicates an expected call of getOrderByIdTx.
+func (mr *MockreturnQueriesMockRecorder) getOrderByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getOrderByIdTx", reflect.TypeOf((*MockreturnQueries)(nil).getOrderByIdTx), ctx, tx, id)
+}
+
+// getProductBySkuTx mocks base method.
+func (m *MockreturnQueries) getProductBySkuTx(ctx context.Context, tx *sql.Tx, sku string) (warehousemanagementservice.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getProductBySkuTx", ctx, tx, sku)
+	ret0, _ := ret[0].(warehousemanagementservice.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getProductBySkuTx indicates an expected call of getProductBySkuTx.
+func (mr *MockreturnQueriesMockRecorder) getProductBySkuTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductBySkuTx", reflect.TypeOf((*MockreturnQueries)(nil).getProductBySkuTx), ctx, tx, sku)
+}
+
+// getReturnByIdTx mocks base method.
+func (m *MockreturnQueries) getReturnByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getReturnByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getReturnByIdTx indicates an expected call of getReturnByIdTx.
+func (mr *MockreturnQueriesMockRecorder) getReturnByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getReturnByIdTx", reflect.TypeOf((*MockreturnQueries)(nil).getReturnByIdTx), ctx, tx, id)
+}
+
+// itemReturnedTx mocks base method.
+func (m *MockreturnQueries) itemReturnedTx(ctx context.Context, tx *sql.Tx, itemId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "itemReturnedTx", ctx, tx, itemId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// itemReturnedTx indicates an expected call of itemReturnedTx.
+func (mr *MockreturnQueriesMockRecorder) itemReturnedTx(ctx, tx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "itemReturnedTx", reflect.TypeOf((*MockreturnQueries)(nil).itemReturnedTx), ctx, tx, itemId)
+}
+
+// listPutawayCandidatesTx mocks base method.
+func (m *MockreturnQueries) listPutawayCandidatesTx(ctx context.Context, tx *sql.Tx, warehouseId, sku string) ([]warehousemanagementservice.PutawayCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listPutawayCandidatesTx", ctx, tx, warehouseId, sku)
+	ret0, _ := ret[0].([]warehousemanagementservice.PutawayCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listPutawayCandidatesTx indicates an expected call of listPutawayCandidatesTx.
+func (mr *MockreturnQueriesMockRecorder) listPutawayCandidatesTx(ctx, tx, warehouseId, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listPutawayCandidatesTx", reflect.TypeOf((*MockreturnQueries)(nil).listPutawayCandidatesTx), ctx, tx, warehouseId, sku)
+}
+
+// lockReturnTx mocks base method.
+func (m *MockreturnQueries) lockReturnTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockReturnTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockReturnTx indicates an expected call of lockReturnTx.
+func (mr *MockreturnQueriesMockRecorder) lockReturnTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockReturnTx", reflect.TypeOf((*MockreturnQueries)(nil).lockReturnTx), ctx, tx, id)
+}
+
+// lockShipmentTx mocks base method.
+func (m *MockreturnQueries) lockShipmentTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockShipmentTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockShipmentTx indicates an expected call of lockShipmentTx.
+func (mr *MockreturnQueriesMockRecorder) lockShipmentTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockShipmentTx", reflect.TypeOf((*MockreturnQueries)(nil).lockShipmentTx), ctx, tx, id)
+}
+
+// recordReturnMovementTx mocks base method.
+func (m *MockreturnQueries) recordReturnMovementTx(ctx context.Context, tx *sql.Tx, item warehousemanagementservice.ReturnItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "recordReturnMovementTx", ctx, tx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// recordReturnMovementTx indicates an expected call of recordReturnMovementTx.
+func (mr *MockreturnQueriesMockRecorder) recordReturnMovementTx(ctx, tx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "recordReturnMovementTx", reflect.TypeOf((*MockreturnQueries)(nil).recordReturnMovementTx), ctx, tx, item)
+}
+
+// restockItemTx mocks base method.
+func (m *MockreturnQueries) restockItemTx(ctx context.Context, tx *sql.Tx, item warehousemanagementservice.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "restockItemTx", ctx, tx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// restockItemTx indicates an expected call of restockItemTx.
+func (mr *MockreturnQueriesMockRecorder) restockItemTx(ctx, tx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "restockItemTx", reflect.TypeOf((*MockreturnQueries)(nil).restockItemTx), ctx, tx, item)
+}
+
+// saveReturnItemTx mocks base method.
+func (m *MockreturnQueries) saveReturnItemTx(ctx context.Context, tx *sql.Tx, item warehousemanagementservice.ReturnItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "saveReturnItemTx", ctx, tx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// saveReturnItemTx indicates an expected call of saveReturnItemTx.
+func (mr *MockreturnQueriesMockRecorder) saveReturnItemTx(ctx, tx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "saveReturnItemTx", reflect.TypeOf((*MockreturnQueries)(nil).saveReturnItemTx), ctx, tx, item)
+}
+
+// shelfWarehouseTx mocks base method.
+func (m *MockreturnQueries) shelfWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfWarehouseTx", ctx, tx, shelfId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfWarehouseTx indicates an expected call of shelfWarehouseTx.
+func (mr *MockreturnQueriesMockRecorder) shelfWarehouseTx(ctx, tx, shelfId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfWarehouseTx", reflect.TypeOf((*MockreturnQueries)(nil).shelfWarehouseTx), ctx, tx, shelfId)
+}
+
+// shippedItemTx mocks base method.
+func (m *MockreturnQueries) shippedItemTx(ctx context.Context, tx *sql.Tx, shipmentId, itemId string) (warehousemanagementservice.ReturnItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shippedItemTx", ctx, tx, shipmentId, itemId)
+	ret0, _ := ret[0].(warehousemanagementservice.ReturnItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shippedItemTx indicates an expected call of shippedItemTx.
+func (mr *MockreturnQueriesMockRecorder) shippedItemTx(ctx, tx, shipmentId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shippedItemTx", reflect.TypeOf((*MockreturnQueries)(nil).shippedItemTx), ctx, tx, shipmentId, itemId)
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func TestReturnAndRestockTx(t *testing.T) {
+	ctx := wms.WithActor(context.Background(), "alice")
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	shipments := shipmentQueriesImpl{}
+	order := wms.NewOrder("customer", fixtureWarehouse.Id, []wms.OrderLine{{Sku: testProduct.Sku, Quantity: 1}})
+	err = shipments.createOrderTx(ctx, tx, order)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = shipments.allocateItemsTx(ctx, tx, fixtureWarehouse.Id, order.Lines[0])
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	shipment := wms.NewShipment(order.Id, "carrier")
+	err = shipments.createShipmentTx(ctx, tx, shipment)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	packed, err := shipments.pickedItemTx(ctx, tx, order.Id, testItem().Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pkg, err := wms.NewPackage(shipment.Id, "TRK1", []wms.PackedItem{packed})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = shipments.createPackageTx(ctx, tx, pkg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = shipments.removePackedItemsTx(ctx, tx, shipment.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := returnQueriesImpl{}
+	_, err = queries.shippedItemTx(ctx, tx, "other", testItem().Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("want the item out of other shipments, got: %v", err)
+	}
+	shipped, err := queries.shippedItemTx(ctx, tx, shipment.Id, testItem().Id)
+	if err != nil || shipped.Sku != testProduct.Sku || shipped.ExpirationDate == nil {
+		t.Errorf("want item %s shipped with its expiration date, got: %v, %v", testItem().Id, shipped, err)
+		return
+	}
+
+	authorization, err := wms.NewReturnAuthorization(shipment.Id, fixtureWarehouse.Id, "wrong size", []wms.ReturnItem{shipped})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.createReturnTx(ctx, tx, authorization)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	returned, err := queries.itemReturnedTx(ctx, tx, testItem().Id)
+	if err != nil || !returned {
+		t.Errorf("want item %s returned, got: %v, %v", testItem().Id, returned, err)
+	}
+
+	authorization, err = queries.lockReturnTx(ctx, tx, authorization.Id)
+	if err != nil || len(authorization.Items) != 1 {
+		t.Errorf("want a return of 1 item, got: %v, %v", authorization, err)
+		return
+	}
+	item := authorization.Items[0]
+	now := time.Now().UTC()
+	err = item.Inspect(wms.ConditionResellable, "unopened", now)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = item.Resolve(wms.ReturnRestock, fixtureShelf.Id, now)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	restocked := wms.NewItem(item.Sku, item.ExpirationDate, item.ShelfId, item.Lot, item.SerialNumber)
+	err = queries.restockItemTx(ctx, tx, restocked)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	item.NewItemId = restocked.Id
+	err = queries.recordReturnMovementTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.saveReturnItemTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.closeReturnTx(ctx, tx, authorization.Id, now)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	stocked, err := itemService.queries.getItemByIdTx(ctx, tx, restocked.Id)
+	if err != nil || stocked.ShelfId != fixtureShelf.Id || stocked.Status != wms.ItemAvailable {
+		t.Errorf("want item %s available on %s, got: %v, %v", restocked.Id, fixtureShelf.Id, stocked, err)
+	}
+
+	authorization, err = queries.getReturnByIdTx(ctx, tx, authorization.Id)
+	if err != nil || authorization.Status != wms.ReturnClosed || authorization.ClosedAt == nil {
+		t.Errorf("want a closed return, got: %v, %v", authorization, err)
+		return
+	}
+	got := authorization.Items[0]
+	if got.Condition != wms.ConditionResellable || got.Notes != "unopened" || got.Outcome != wms.ReturnRestock || got.NewItemId != restocked.Id {
+		t.Errorf("want item restocked as %s, got: %v", restocked.Id, got)
+	}
+}
+
+func TestResolveReturnItem(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockreturnQueries(mockCtrl)
+
+	rs := ReturnService{db: warehouseService.db, queries: mockObj}
+	inspectedAt := time.Now().UTC()
+	open := func(condition string) wms.ReturnAuthorization {
+		return wms.ReturnAuthorization{
+			Id:          "r",
+			WarehouseId: "w",
+			Status:      wms.ReturnAuthorized,
+			Items: []wms.ReturnItem{
+				{ReturnId: "r", ItemId: "i", Sku: "a", Condition: condition, InspectedAt: &inspectedAt},
+				{ReturnId: "r", ItemId: "j", Sku: "a"},
+			},
+		}
+	}
+
+	mockObj.EXPECT().lockReturnTx(ctx, gomock.Any(), "r").Return(wms.ReturnAuthorization{Id: "r", Status: wms.ReturnClosed}, nil)
+	_, err := rs.ResolveReturnItem(ctx, "r", "i", wms.ReturnWriteOff, "")
+	if err != wms.ReturnAlreadyClosed {
+		t.Errorf("want: %v, got: %v", wms.ReturnAlreadyClosed, err)
+	}
+
+	mockObj.EXPECT().lockReturnTx(ctx, gomock.Any(), "r").Return(open(wms.ConditionDamaged), nil)
+	_, err = rs.ResolveReturnItem(ctx, "r", "i", wms.ReturnRestock, "s")
+	if err != wms.ItemNotResellable {
+		t.Errorf("want: %v, got: %v", wms.ItemNotResellable, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockReturnTx(ctx, gomock.Any(), "r").Return(open(wms.ConditionResellable), nil),
+		mockObj.EXPECT().getProductBySkuTx(ctx, gomock.Any(), "a").Return(wms.Product{Sku: "a"}, nil),
+		mockObj.EXPECT().listPutawayCandidatesTx(ctx, gomock.Any(), "w", "a").Return(nil, nil),
+	)
+	_, err = rs.ResolveReturnItem(ctx, "r", "i", wms.ReturnRestock, "")
+	if err != wms.NoPutawayShelf {
+		t.Errorf("want: %v, got: %v", wms.NoPutawayShelf, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockReturnTx(ctx, gomock.Any(), "r").Return(open(wms.ConditionDamaged), nil),
+		mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "q").Return("other", nil),
+	)
+	_, err = rs.ResolveReturnItem(ctx, "r", "i", wms.ReturnQuarantine, "q")
+	if err != wms.InvalidShelf {
+		t.Errorf("want: %v, got: %v", wms.InvalidShelf, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockReturnTx(ctx, gomock.Any(), "r").Return(open(wms.ConditionDamaged), nil),
+		mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "q").Return("w", nil),
+		mockObj.EXPECT().checkShelfCapacityTx(ctx, gomock.Any(), "q", "a", 1).Return(nil),
+		mockObj.EXPECT().restockItemTx(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *sql.Tx, item wms.Item) error {
+				if item.Status != wms.ItemQuarantined || item.ShelfId != "q" {
+					t.Errorf("want a quarantined item on q, got: %v", item)
+				}
+				return nil
+			},
+		),
+		mockObj.EXPECT().recordReturnMovementTx(ctx, gomock.Any(), gomock.Any()).Return(nil),
+		mockObj.EXPECT().saveReturnItemTx(ctx, gomock.Any(), gomock.Any()).Return(nil),
+	)
+	item, err := rs.ResolveReturnItem(ctx, "r", "i", wms.ReturnQuarantine, "q")
+	if err != nil || item.Outcome != wms.ReturnQuarantine || item.NewItemId == "" {
+		t.Errorf("want item quarantined as a new item, got: %v, %v", item, err)
+	}
+
+	closing := open(wms.ConditionDefective)
+	closing.Items = closing.Items[:1]
+	gomock.InOrder(
+		mockObj.EXPECT().lockReturnTx(ctx, gomock.Any(), "r").Return(closing, nil),
+		mockObj.EXPECT().recordReturnMovementTx(ctx, gomock.Any(), gomock.Any()).Return(nil),
+		mockObj.EXPECT().saveReturnItemTx(ctx, gomock.Any(), gomock.Any()).Return(nil),
+		mockObj.EXPECT().closeReturnTx(ctx, gomock.Any(), "r", gomock.Any()).Return(nil),
+	)
+	item, err = rs.ResolveReturnItem(ctx, "r", "i", wms.ReturnWriteOff, "")
+	if err != nil || item.Outcome != wms.ReturnWriteOff || item.NewItemId != "" {
+		t.Errorf("want item written off, got: %v, %v", item, err)
+	}
+
+	mockObj.EXPECT().lockShipmentTx(ctx, gomock.Any(), "s").Return(wms.Shipment{Id: "s", Status: wms.ShipmentOpen}, nil)
+	_, err = rs.CreateReturn(ctx, "s", "wrong size", []string{"i"})
+	if err != wms.ShipmentNotDispatched {
+		t.Errorf("want: %v, got: %v", wms.ShipmentNotDispatched, err)
+	}
+}
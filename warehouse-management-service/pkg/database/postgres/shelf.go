@@ -12,7 +12,14 @@ type shelfQueries interface {
 	getShelfByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Shelf, error)
 	updateShelfTx(ctx context.Context, tx *sql.Tx, shelf wms.Shelf) error
 	deleteShelfTx(ctx context.Context, tx *sql.Tx, id string) error
+	lockShelfTx(ctx context.Context, tx *sql.Tx, id string, version int) error
+	shelfDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]wms.Dependent, error)
+	cascadeDeleteShelfTx(ctx context.Context, tx *sql.Tx, id string) error
+	restoreShelfTx(ctx context.Context, tx *sql.Tx, id string) error
+	purgeShelfTx(ctx context.Context, tx *sql.Tx, id string) error
 	shelfBlockExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	listShelvesTx(ctx context.Context, tx *sql.Tx, shelfBlockId string, after string, limit int) ([]wms.Shelf, error)
+	shelfUtilizationTx(ctx context.Context, tx *sql.Tx, id string) (wms.ShelfUtilization, error)
 }
 
 type shelfQueriesImpl struct{}
@@ -31,6 +38,8 @@ func NewShelfService(db *sql.DB) *ShelfService {
 
 var InvalidShelfBlock = errors.New("invalid shelfBlockId")
 
+const shelfColumns = `id, label, section, level, shelf_block, max_weight_in_kg, max_volume_in_cm3, max_items, version`
+
 func (s *ShelfService) GetShelfById(ctx context.Context, id string) (wms.Shelf, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -48,6 +57,31 @@ func (s *ShelfService) GetShelfById(ctx context.Context, id string) (wms.Shelf,
 	}
 }
 
+// GetShelfUtilization returns how much of a shelf is taken up by the items
+// on it.
+func (s *ShelfService) GetShelfUtilization(ctx context.Context, id string) (wms.ShelfUtilization, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.ShelfUtilization{}, err
+	}
+	defer tx.Rollback()
+
+	shelf, err := s.queries.getShelfByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.ShelfUtilization{}, wms.ShelfDoesNotExist
+	default:
+		return wms.ShelfUtilization{}, err
+	}
+
+	used, err := s.queries.shelfUtilizationTx(ctx, tx, id)
+	if err != nil {
+		return wms.ShelfUtilization{}, err
+	}
+	return shelf.Utilization(used), tx.Commit()
+}
+
 func (s *ShelfService) CreateShelf(ctx context.Context, shelf wms.Shelf) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -75,6 +109,74 @@ func (s *ShelfService) UpdateShelf(ctx context.Context, shelf wms.Shelf) error {
 
 	err = s.queries.updateShelfTx(ctx, tx, shelf)
 	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.ShelfDoesNotExist
+	case VersionMismatch:
+		return wms.VersionMismatch
+	case InvalidShelfBlock:
+		return wms.InvalidShelfBlock
+	default:
+		return err
+	}
+}
+
+// DeleteShelfById soft-deletes a shelf, it can be brought back with
+// RestoreShelfById until it is purged. Unless cascade is set, a shelf that
+// still holds items is left alone and a *wms.DependentsError listing them is
+// returned. With cascade, the shelf is deleted and the items stay on it.
+// wms.VersionMismatch is returned if the shelf is no longer at version.
+func (s *ShelfService) DeleteShelfById(ctx context.Context, id string, version int, cascade bool) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.queries.lockShelfTx(ctx, tx, id, version)
+	if err == nil {
+		err = s.deleteLockedTx(ctx, tx, id, cascade)
+	}
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.ShelfDoesNotExist
+	case VersionMismatch:
+		return wms.VersionMismatch
+	default:
+		return err
+	}
+}
+
+func (s *ShelfService) deleteLockedTx(ctx context.Context, tx *sql.Tx, id string, cascade bool) error {
+	if cascade {
+		return s.queries.cascadeDeleteShelfTx(ctx, tx, id)
+	}
+
+	dependents, err := s.queries.shelfDependentsTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return &wms.DependentsError{Dependents: dependents}
+	}
+
+	return s.queries.deleteShelfTx(ctx, tx, id)
+}
+
+// RestoreShelfById undoes DeleteShelfById. A shelf cannot be restored into a
+// deleted shelf block.
+func (s *ShelfService) RestoreShelfById(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.queries.restoreShelfTx(ctx, tx, id)
+	switch err {
 	case nil:
 		return tx.Commit()
 	case RowDoesNotExist:
@@ -86,14 +188,17 @@ func (s *ShelfService) UpdateShelf(ctx context.Context, shelf wms.Shelf) error {
 	}
 }
 
-func (s *ShelfService) DeleteShelfById(ctx context.Context, id string) error {
+// PurgeShelfById permanently deletes a soft-deleted shelf along with the
+// transfers into it. It refuses while the shelf holds items or has transfers
+// in transit to it, or while cycle counts of the shelf would go with it.
+func (s *ShelfService) PurgeShelfById(ctx context.Context, id string) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	err = s.queries.deleteShelfTx(ctx, tx, id)
+	err = s.queries.purgeShelfTx(ctx, tx, id)
 	switch err {
 	case nil:
 		return tx.Commit()
@@ -104,73 +209,98 @@ func (s *ShelfService) DeleteShelfById(ctx context.Context, id string) error {
 	}
 }
 
+// ListShelves returns up to limit shelves of a shelf block ordered by id,
+// starting after the shelf with id after.
+func (s *ShelfService) ListShelves(ctx context.Context, shelfBlockId string, after string, limit int) ([]wms.Shelf, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	shelves, err := s.queries.listShelvesTx(ctx, tx, shelfBlockId, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return shelves, tx.Commit()
+}
+
 func (s *shelfQueriesImpl) createShelfTx(ctx context.Context, tx *sql.Tx, shelf wms.Shelf) error {
-	if shelfBlockExists, err := s.shelfBlockExistsTx(ctx, tx, shelf.ShelfBlockId); err != nil {
+	if shelfBlockExists, err := s.shareShelfBlockTx(ctx, tx, shelf.ShelfBlockId); err != nil {
 		return err
 	} else if !shelfBlockExists {
 		return InvalidShelfBlock
 	}
 
-	query := "INSERT INTO shelf(id, label, section, level, shelf_block) VALUES ($1, $2, $3, $4, $5)"
+	query := `INSERT INTO shelf(id, label, section, level, shelf_block, max_weight_in_kg, max_volume_in_cm3, max_items)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
-	_, err := tx.ExecContext(
+	_, err := auditedExecTx(
 		ctx,
+		tx,
+		wms.EntityShelf,
+		wms.AuditCreate,
 		query,
+		"",
 		shelf.Id,
 		shelf.Label,
 		shelf.Section,
 		shelf.Level,
 		shelf.ShelfBlockId,
+		shelf.MaxWeightInKg,
+		shelf.MaxVolumeInCm3,
+		shelf.MaxItems,
 	)
 	return err
 }
 
 func (s *shelfQueriesImpl) getShelfByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Shelf, error) {
-	row := tx.QueryRowContext(ctx, `SELECT id, label, section, level, shelf_block FROM shelf WHERE id=$1`, id)
+	row := tx.QueryRowContext(ctx, `SELECT `+shelfColumns+` FROM shelf WHERE id=$1 AND deleted_at IS NULL`, id)
 
-	var shelf wms.Shelf
-
-	err := row.Scan(&shelf.Id, &shelf.Label, &shelf.Section, &shelf.Level, &shelf.ShelfBlockId)
-	if err != nil {
-		return wms.Shelf{}, err
-	}
-	return shelf, nil
+	return scanShelf(row.Scan)
 }
 
 func (s *shelfQueriesImpl) updateShelfTx(ctx context.Context, tx *sql.Tx, shelf wms.Shelf) error {
-	if shelfBlockExists, err := s.shelfBlockExistsTx(ctx, tx, shelf.ShelfBlockId); err != nil {
+	if shelfBlockExists, err := s.shareShelfBlockTx(ctx, tx, shelf.ShelfBlockId); err != nil {
 		return err
 	} else if !shelfBlockExists {
 		return InvalidShelfBlock
 	}
 
-	query := `UPDATE shelf SET label = $1, section = $2, level = $3, shelf_block = $4 where id = $5`
+	err := lockVersionTx(ctx, tx, "shelf", shelf.Id, shelf.Version)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE shelf SET label = $1, section = $2, level = $3, shelf_block = $4,
+		max_weight_in_kg = $5, max_volume_in_cm3 = $6, max_items = $7, version = version + 1`
 
-	result, err := tx.ExecContext(
+	_, err = auditedExecTx(
 		ctx,
+		tx,
+		wms.EntityShelf,
+		wms.AuditUpdate,
 		query,
+		"id = $8 AND deleted_at IS NULL",
 		shelf.Label,
 		shelf.Section,
 		shelf.Level,
 		shelf.ShelfBlockId,
+		shelf.MaxWeightInKg,
+		shelf.MaxVolumeInCm3,
+		shelf.MaxItems,
 		shelf.Id)
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return RowDoesNotExist
-	}
 	return nil
 }
 
 func (s *shelfQueriesImpl) deleteShelfTx(ctx context.Context, tx *sql.Tx, id string) error {
-	query := `DELETE FROM shelf WHERE id=$1`
+	query := `UPDATE shelf SET deleted_at = now(), version = version + 1`
 
-	result, err := tx.ExecContext(ctx, query, id)
+	result, err := auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditDelete, query, "id=$1 AND deleted_at IS NULL", id)
 	if err != nil {
 		return err
 	}
@@ -186,7 +316,7 @@ func (s *shelfQueriesImpl) deleteShelfTx(ctx context.Context, tx *sql.Tx, id str
 }
 
 func (s *shelfQueriesImpl) shelfBlockExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM shelf_block WHERE id = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM shelf_block WHERE id = $1 AND deleted_at IS NULL)`
 
 	var exists bool
 	row := tx.QueryRowContext(ctx, query, id)
@@ -196,3 +326,152 @@ func (s *shelfQueriesImpl) shelfBlockExistsTx(ctx context.Context, tx *sql.Tx, i
 	}
 	return exists, nil
 }
+
+// shareShelfBlockTx reports whether the shelf block is live and, if it is,
+// share locks its row until tx ends, so that it cannot be deleted while a
+// shelf is put under it.
+func (s *shelfQueriesImpl) shareShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	return shareLiveRowTx(ctx, tx, "shelf_block", id)
+}
+
+func (s *shelfQueriesImpl) listShelvesTx(ctx context.Context, tx *sql.Tx, shelfBlockId string, after string, limit int) ([]wms.Shelf, error) {
+	query := `SELECT ` + shelfColumns + ` FROM shelf
+		WHERE shelf_block = $1 AND id > $2 AND deleted_at IS NULL ORDER BY id LIMIT $3`
+
+	rows, err := tx.QueryContext(ctx, query, shelfBlockId, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shelves := make([]wms.Shelf, 0, limit)
+	for rows.Next() {
+		shelf, err := scanShelf(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		shelves = append(shelves, shelf)
+	}
+
+	return shelves, rows.Err()
+}
+
+func (s *shelfQueriesImpl) shelfUtilizationTx(ctx context.Context, tx *sql.Tx, id string) (wms.ShelfUtilization, error) {
+	return queryShelfUtilizationTx(ctx, tx, id)
+}
+
+// queryShelfUtilizationTx adds up the items on a shelf and the weight and
+// volume of their products. Products without dimensions count as taking no
+// space.
+func queryShelfUtilizationTx(ctx context.Context, tx *sql.Tx, id string) (wms.ShelfUtilization, error) {
+	query := `SELECT COUNT(item.id),
+			COALESCE(SUM(product.weight_in_kg), 0),
+			COALESCE(SUM(product.length_in_cm * product.width_in_cm * product.breadth_in_cm), 0)
+		FROM item JOIN product ON product.sku = item.sku WHERE item.shelf_id = $1`
+
+	var used wms.ShelfUtilization
+	row := tx.QueryRowContext(ctx, query, id)
+	err := row.Scan(&used.ItemCount, &used.WeightInKg, &used.VolumeInCm3)
+	if err != nil {
+		return wms.ShelfUtilization{}, err
+	}
+	return used, nil
+}
+
+// scanShelf scans the shelfColumns of a row.
+func scanShelf(scan func(dest ...interface{}) error) (wms.Shelf, error) {
+	var shelf wms.Shelf
+	err := scan(
+		&shelf.Id,
+		&shelf.Label,
+		&shelf.Section,
+		&shelf.Level,
+		&shelf.ShelfBlockId,
+		&shelf.MaxWeightInKg,
+		&shelf.MaxVolumeInCm3,
+		&shelf.MaxItems,
+		&shelf.Version,
+	)
+	if err != nil {
+		return wms.Shelf{}, err
+	}
+	return shelf, nil
+}
+
+// lockShelfTx locks the shelf row, so that it does not change and no item
+// can be placed on it until tx ends.
+func (s *shelfQueriesImpl) lockShelfTx(ctx context.Context, tx *sql.Tx, id string, version int) error {
+	return lockVersionTx(ctx, tx, "shelf", id, version)
+}
+
+func (s *shelfQueriesImpl) shelfDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]wms.Dependent, error) {
+	return queryDependentsTx(ctx, tx, wms.EntityItem, `SELECT id FROM item WHERE shelf_id = $1 ORDER BY id`, id)
+}
+
+// cascadeDeleteShelfTx soft-deletes the shelf. Items are not soft-deleted,
+// they stay on the shelf so that restoring it brings them back.
+func (s *shelfQueriesImpl) cascadeDeleteShelfTx(ctx context.Context, tx *sql.Tx, id string) error {
+	return s.deleteShelfTx(ctx, tx, id)
+}
+
+func (s *shelfQueriesImpl) restoreShelfTx(ctx context.Context, tx *sql.Tx, id string) error {
+	var shelfBlockId string
+	row := tx.QueryRowContext(ctx, `SELECT shelf_block FROM shelf WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id)
+	err := row.Scan(&shelfBlockId)
+	if err == sql.ErrNoRows {
+		return RowDoesNotExist
+	}
+	if err != nil {
+		return err
+	}
+
+	if shelfBlockExists, err := s.shareShelfBlockTx(ctx, tx, shelfBlockId); err != nil {
+		return err
+	} else if !shelfBlockExists {
+		return InvalidShelfBlock
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditRestore,
+		`UPDATE shelf SET deleted_at = NULL, version = version + 1`,
+		`id = $1`,
+		id)
+	return err
+}
+
+func (s *shelfQueriesImpl) purgeShelfTx(ctx context.Context, tx *sql.Tx, id string) error {
+	var deleted bool
+	row := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM shelf WHERE id = $1 AND deleted_at IS NOT NULL)`, id)
+	err := row.Scan(&deleted)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return RowDoesNotExist
+	}
+
+	dependents, err := queryAllDependentsTx(ctx, tx, []dependentsQuery{
+		{entity: wms.EntityItem, query: `SELECT id FROM item WHERE shelf_id = $1 ORDER BY id`},
+		{entity: wms.EntityTransfer, query: `SELECT id FROM transfer WHERE to_shelf_id = $1 AND status = 'in_transit' ORDER BY id`},
+		{entity: wms.EntityCycleCount, query: `SELECT id FROM cycle_count WHERE shelf_id = $1
+			UNION SELECT cycle_count_id FROM cycle_count_line WHERE shelf_id = $1 ORDER BY 1`},
+	}, id)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return &wms.DependentsError{Dependents: dependents}
+	}
+
+	// completed transfers into the shelf, from any warehouse, go with it, their
+	// movements stay in the stock ledger
+	_, err = tx.ExecContext(ctx, `DELETE FROM transfer WHERE to_shelf_id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditPurge,
+		`DELETE FROM shelf`,
+		`id = $1`,
+		id)
+	return err
+}
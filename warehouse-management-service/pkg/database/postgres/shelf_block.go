@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 	wms "warehouse-management-service"
 )
 
@@ -13,7 +14,13 @@ type shelfBlockQueries interface {
 	getShelfBlockByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.ShelfBlock, error)
 	updateShelfBlockTx(ctx context.Context, tx *sql.Tx, block wms.ShelfBlock) error
 	deleteShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error
+	lockShelfBlockTx(ctx context.Context, tx *sql.Tx, id string, version int) error
+	shelfBlockDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]wms.Dependent, error)
+	cascadeDeleteShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error
+	restoreShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error
+	purgeShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error
 	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	listShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId string, after string, limit int) ([]wms.ShelfBlock, error)
 }
 
 type shelfBlockQueriesImpl struct{}
@@ -80,6 +87,8 @@ func (s *ShelfBlockService) UpdateShelfBlock(ctx context.Context, shelfBlock wms
 		return tx.Commit()
 	case RowDoesNotExist:
 		return wms.ShelfBlockDoesNotExist
+	case VersionMismatch:
+		return wms.VersionMismatch
 	case InvalidWarehouse:
 		return wms.InvalidWarehouse
 	default:
@@ -87,26 +96,115 @@ func (s *ShelfBlockService) UpdateShelfBlock(ctx context.Context, shelfBlock wms
 	}
 }
 
-func (s *ShelfBlockService) DeleteShelfBlockById(ctx context.Context, id string) error {
+// DeleteShelfBlockById soft-deletes a shelf block, it can be brought back
+// with RestoreShelfBlockById until it is purged. Unless cascade is set, a
+// shelf block that still has shelves is left alone and a *wms.DependentsError
+// listing them is returned. With cascade, the shelves are deleted along with
+// the shelf block, items stay on their shelves. wms.VersionMismatch is
+// returned if the shelf block is no longer at version.
+func (s *ShelfBlockService) DeleteShelfBlockById(ctx context.Context, id string, version int, cascade bool) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	err = s.queries.deleteShelfBlockTx(ctx, tx, id)
+	err = s.queries.lockShelfBlockTx(ctx, tx, id, version)
+	if err == nil {
+		err = s.deleteLockedTx(ctx, tx, id, cascade)
+	}
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.ShelfBlockDoesNotExist
+	case VersionMismatch:
+		return wms.VersionMismatch
+	default:
+		return err
+	}
+}
+
+func (s *ShelfBlockService) deleteLockedTx(ctx context.Context, tx *sql.Tx, id string, cascade bool) error {
+	if cascade {
+		return s.queries.cascadeDeleteShelfBlockTx(ctx, tx, id)
+	}
+
+	dependents, err := s.queries.shelfBlockDependentsTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return &wms.DependentsError{Dependents: dependents}
+	}
+
+	return s.queries.deleteShelfBlockTx(ctx, tx, id)
+}
+
+// RestoreShelfBlockById undoes DeleteShelfBlockById, along with the shelves
+// that were deleted with it. A shelf block cannot be restored into a deleted
+// warehouse.
+func (s *ShelfBlockService) RestoreShelfBlockById(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.queries.restoreShelfBlockTx(ctx, tx, id)
 	switch err {
 	case nil:
 		return tx.Commit()
 	case RowDoesNotExist:
 		return wms.ShelfBlockDoesNotExist
+	case InvalidWarehouse:
+		return wms.InvalidWarehouse
 	default:
 		return err
 	}
 }
 
+// PurgeShelfBlockById permanently deletes a soft-deleted shelf block, along
+// with its shelves and the transfers into them. It refuses while the shelves
+// hold items or have transfers in transit to them, or while cycle counts of
+// the block or its shelves would go with it.
+func (s *ShelfBlockService) PurgeShelfBlockById(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.queries.purgeShelfBlockTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.ShelfBlockDoesNotExist
+	default:
+		return err
+	}
+}
+
+// ListShelfBlocks returns up to limit shelf blocks of a warehouse ordered by
+// id, starting after the shelf block with id after.
+func (s *ShelfBlockService) ListShelfBlocks(ctx context.Context, warehouseId string, after string, limit int) ([]wms.ShelfBlock, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	shelfBlocks, err := s.queries.listShelfBlocksTx(ctx, tx, warehouseId, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return shelfBlocks, tx.Commit()
+}
+
 func (s *shelfBlockQueriesImpl) createShelfBlockTx(ctx context.Context, tx *sql.Tx, block wms.ShelfBlock) error {
-	if warehouseExists, err := s.warehouseExistsTx(ctx, tx, block.WarehouseId); err != nil {
+	if warehouseExists, err := s.shareWarehouseTx(ctx, tx, block.WarehouseId); err != nil {
 		return err
 	} else if !warehouseExists {
 		return InvalidWarehouse
@@ -114,9 +212,13 @@ func (s *shelfBlockQueriesImpl) createShelfBlockTx(ctx context.Context, tx *sql.
 
 	query := "INSERT INTO shelf_block(id, aisle, rack, storage_type, warehouse_id) VALUES ($1, $2, $3, $4, $5)"
 
-	_, err := tx.ExecContext(
+	_, err := auditedExecTx(
 		ctx,
+		tx,
+		wms.EntityShelfBlock,
+		wms.AuditCreate,
 		query,
+		"",
 		block.Id,
 		block.Aisle,
 		block.Rack,
@@ -127,11 +229,11 @@ func (s *shelfBlockQueriesImpl) createShelfBlockTx(ctx context.Context, tx *sql.
 }
 
 func (s *shelfBlockQueriesImpl) getShelfBlockByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.ShelfBlock, error) {
-	row := tx.QueryRowContext(ctx, `SELECT id, aisle, rack, storage_type, warehouse_id FROM shelf_block WHERE id=$1`, id)
+	row := tx.QueryRowContext(ctx, `SELECT id, aisle, rack, storage_type, warehouse_id, version FROM shelf_block WHERE id=$1 AND deleted_at IS NULL`, id)
 
 	var shelfBlock wms.ShelfBlock
 
-	err := row.Scan(&shelfBlock.Id, &shelfBlock.Aisle, &shelfBlock.Rack, &shelfBlock.StorageType, &shelfBlock.WarehouseId)
+	err := row.Scan(&shelfBlock.Id, &shelfBlock.Aisle, &shelfBlock.Rack, &shelfBlock.StorageType, &shelfBlock.WarehouseId, &shelfBlock.Version)
 	if err != nil {
 		return wms.ShelfBlock{}, err
 	}
@@ -139,16 +241,21 @@ func (s *shelfBlockQueriesImpl) getShelfBlockByIdTx(ctx context.Context, tx *sql
 }
 
 func (s *shelfBlockQueriesImpl) updateShelfBlockTx(ctx context.Context, tx *sql.Tx, block wms.ShelfBlock) error {
-	if warehouseExists, err := s.warehouseExistsTx(ctx, tx, block.WarehouseId); err != nil {
+	if warehouseExists, err := s.shareWarehouseTx(ctx, tx, block.WarehouseId); err != nil {
 		return err
 	} else if !warehouseExists {
 		return InvalidWarehouse
 	}
 
-	query := `UPDATE shelf_block SET aisle = $1, rack = $2, storage_type=$3, warehouse_id=$4 WHERE id = $5;`
+	err := lockVersionTx(ctx, tx, "shelf_block", block.Id, block.Version)
+	if err != nil {
+		return err
+	}
 
-	result, err := tx.ExecContext(ctx,
-		query,
+	query := `UPDATE shelf_block SET aisle = $1, rack = $2, storage_type=$3, warehouse_id=$4, version = version + 1`
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelfBlock, wms.AuditUpdate,
+		query, "id = $5 AND deleted_at IS NULL",
 		block.Aisle,
 		block.Rack,
 		block.StorageType,
@@ -157,21 +264,14 @@ func (s *shelfBlockQueriesImpl) updateShelfBlockTx(ctx context.Context, tx *sql.
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return RowDoesNotExist
-	}
 
 	return nil
 }
 
 func (s *shelfBlockQueriesImpl) deleteShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error {
-	query := `DELETE FROM shelf_block WHERE id=$1`
+	query := `UPDATE shelf_block SET deleted_at = now(), version = version + 1`
 
-	result, err := tx.ExecContext(ctx, query, id)
+	result, err := auditedExecTx(ctx, tx, wms.EntityShelfBlock, wms.AuditDelete, query, "id=$1 AND deleted_at IS NULL", id)
 	if err != nil {
 		return err
 	}
@@ -187,7 +287,7 @@ func (s *shelfBlockQueriesImpl) deleteShelfBlockTx(ctx context.Context, tx *sql.
 }
 
 func (s *shelfBlockQueriesImpl) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM warehouse WHERE id = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM warehouse WHERE id = $1 AND deleted_at IS NULL)`
 
 	var exists bool
 	row := tx.QueryRowContext(ctx, query, id)
@@ -197,3 +297,138 @@ func (s *shelfBlockQueriesImpl) warehouseExistsTx(ctx context.Context, tx *sql.T
 	}
 	return exists, nil
 }
+
+// shareWarehouseTx reports whether the warehouse is live and, if it is,
+// share locks its row until tx ends, so that it cannot be deleted while a
+// shelf block is put under it.
+func (s *shelfBlockQueriesImpl) shareWarehouseTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	return shareLiveRowTx(ctx, tx, "warehouse", id)
+}
+
+func (s *shelfBlockQueriesImpl) listShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId string, after string, limit int) ([]wms.ShelfBlock, error) {
+	query := `SELECT id, aisle, rack, storage_type, warehouse_id, version FROM shelf_block
+		WHERE warehouse_id = $1 AND id > $2 AND deleted_at IS NULL ORDER BY id LIMIT $3`
+
+	rows, err := tx.QueryContext(ctx, query, warehouseId, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shelfBlocks := make([]wms.ShelfBlock, 0, limit)
+	for rows.Next() {
+		var shelfBlock wms.ShelfBlock
+		err := rows.Scan(&shelfBlock.Id, &shelfBlock.Aisle, &shelfBlock.Rack, &shelfBlock.StorageType, &shelfBlock.WarehouseId, &shelfBlock.Version)
+		if err != nil {
+			return nil, err
+		}
+		shelfBlocks = append(shelfBlocks, shelfBlock)
+	}
+
+	return shelfBlocks, rows.Err()
+}
+
+// lockShelfBlockTx locks the shelf block row, so that it does not change and
+// no shelf can be added to it until tx ends.
+func (s *shelfBlockQueriesImpl) lockShelfBlockTx(ctx context.Context, tx *sql.Tx, id string, version int) error {
+	return lockVersionTx(ctx, tx, "shelf_block", id, version)
+}
+
+func (s *shelfBlockQueriesImpl) shelfBlockDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]wms.Dependent, error) {
+	return queryDependentsTx(ctx, tx, wms.EntityShelf, `SELECT id FROM shelf WHERE shelf_block = $1 AND deleted_at IS NULL ORDER BY id`, id)
+}
+
+// cascadeDeleteShelfBlockTx soft-deletes the shelf block with its shelves,
+// all sharing the same deleted_at.
+func (s *shelfBlockQueriesImpl) cascadeDeleteShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error {
+	_, err := auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditDelete,
+		`UPDATE shelf SET deleted_at = now(), version = version + 1`,
+		`shelf_block = $1 AND deleted_at IS NULL`,
+		id)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteShelfBlockTx(ctx, tx, id)
+}
+
+func (s *shelfBlockQueriesImpl) restoreShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error {
+	var deletedAt time.Time
+	var warehouseId string
+	row := tx.QueryRowContext(ctx, `SELECT deleted_at, warehouse_id FROM shelf_block
+		WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id)
+	err := row.Scan(&deletedAt, &warehouseId)
+	if err == sql.ErrNoRows {
+		return RowDoesNotExist
+	}
+	if err != nil {
+		return err
+	}
+
+	if warehouseExists, err := s.shareWarehouseTx(ctx, tx, warehouseId); err != nil {
+		return err
+	} else if !warehouseExists {
+		return InvalidWarehouse
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelfBlock, wms.AuditRestore,
+		`UPDATE shelf_block SET deleted_at = NULL, version = version + 1`,
+		`id = $1`,
+		id)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditRestore,
+		`UPDATE shelf SET deleted_at = NULL, version = version + 1`,
+		`shelf_block = $1 AND deleted_at = $2`,
+		id, deletedAt)
+	return err
+}
+
+func (s *shelfBlockQueriesImpl) purgeShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error {
+	var deleted bool
+	row := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM shelf_block WHERE id = $1 AND deleted_at IS NOT NULL)`, id)
+	err := row.Scan(&deleted)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return RowDoesNotExist
+	}
+
+	dependents, err := queryAllDependentsTx(ctx, tx, []dependentsQuery{
+		{entity: wms.EntityItem, query: `SELECT id FROM item
+			WHERE shelf_id IN (SELECT id FROM shelf WHERE shelf_block = $1) ORDER BY id`},
+		{entity: wms.EntityTransfer, query: `SELECT id FROM transfer
+			WHERE to_shelf_id IN (SELECT id FROM shelf WHERE shelf_block = $1) AND status = 'in_transit' ORDER BY id`},
+		{entity: wms.EntityCycleCount, query: `SELECT id FROM cycle_count
+			WHERE shelf_block_id = $1 OR shelf_id IN (SELECT id FROM shelf WHERE shelf_block = $1) ORDER BY id`},
+	}, id)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return &wms.DependentsError{Dependents: dependents}
+	}
+
+	// completed transfers go, their movements stay in the stock ledger
+	_, err = tx.ExecContext(ctx, `DELETE FROM transfer WHERE to_shelf_id IN (SELECT id FROM shelf WHERE shelf_block = $1)`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditPurge,
+		`DELETE FROM shelf`,
+		`shelf_block = $1`,
+		id)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelfBlock, wms.AuditPurge,
+		`DELETE FROM shelf_block`,
+		`id = $1`,
+		id)
+	return err
+}
@@ -36,6 +36,20 @@ func (m *MockshelfBlockQueries) EXPECT() *MockshelfBlockQueriesMockRecorder {
 	return m.recorder
 }
 
+// cascadeDeleteShelfBlockTx mocks base method.
+func (m *MockshelfBlockQueries) cascadeDeleteShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "cascadeDeleteShelfBlockTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// cascadeDeleteShelfBlockTx indicates an expected call of cascadeDeleteShelfBlockTx.
+func (mr *MockshelfBlockQueriesMockRecorder) cascadeDeleteShelfBlockTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "cascadeDeleteShelfBlockTx", reflect.TypeOf((*MockshelfBlockQueries)(nil).cascadeDeleteShelfBlockTx), ctx, tx, id)
+}
+
 // createShelfBlockTx mocks base method.
 func (m *MockshelfBlockQueries) createShelfBlockTx(ctx context.Context, tx *sql.Tx, block warehousemanagementservice.ShelfBlock) error {
 	m.ctrl.T.Helper()
@@ -79,6 +93,78 @@ func (mr *MockshelfBlockQueriesMockRecorder) getShelfBlockByIdTx(ctx, tx, id int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getShelfBlockByIdTx", reflect.TypeOf((*MockshelfBlockQueries)(nil).getShelfBlockByIdTx), ctx, tx, id)
 }
 
+// listShelfBlocksTx mocks base method.
+func (m *MockshelfBlockQueries) listShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId, after string, limit int) ([]warehousemanagementservice.ShelfBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listShelfBlocksTx", ctx, tx, warehouseId, after, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.ShelfBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listShelfBlocksTx indicates an expected call of listShelfBlocksTx.
+func (mr *MockshelfBlockQueriesMockRecorder) listShelfBlocksTx(ctx, tx, warehouseId, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listShelfBlocksTx", reflect.TypeOf((*MockshelfBlockQueries)(nil).listShelfBlocksTx), ctx, tx, warehouseId, after, limit)
+}
+
+// lockShelfBlockTx mocks base method.
+func (m *MockshelfBlockQueries) lockShelfBlockTx(ctx context.Context, tx *sql.Tx, id string, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockShelfBlockTx", ctx, tx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// lockShelfBlockTx indicates an expected call of lockShelfBlockTx.
+func (mr *MockshelfBlockQueriesMockRecorder) lockShelfBlockTx(ctx, tx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockShelfBlockTx", reflect.TypeOf((*MockshelfBlockQueries)(nil).lockShelfBlockTx), ctx, tx, id, version)
+}
+
+// purgeShelfBlockTx mocks base method.
+func (m *MockshelfBlockQueries) purgeShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "purgeShelfBlockTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// purgeShelfBlockTx indicates an expected call of purgeShelfBlockTx.
+func (mr *MockshelfBlockQueriesMockRecorder) purgeShelfBlockTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "purgeShelfBlockTx", reflect.TypeOf((*MockshelfBlockQueries)(nil).purgeShelfBlockTx), ctx, tx, id)
+}
+
+// restoreShelfBlockTx mocks base method.
+func (m *MockshelfBlockQueries) restoreShelfBlockTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "restoreShelfBlockTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// restoreShelfBlockTx indicates an expected call of restoreShelfBlockTx.
+func (mr *MockshelfBlockQueriesMockRecorder) restoreShelfBlockTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "restoreShelfBlockTx", reflect.TypeOf((*MockshelfBlockQueries)(nil).restoreShelfBlockTx), ctx, tx, id)
+}
+
+// shelfBlockDependentsTx mocks base method.
+func (m *MockshelfBlockQueries) shelfBlockDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]warehousemanagementservice.Dependent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfBlockDependentsTx", ctx, tx, id)
+	ret0, _ := ret[0].([]warehousemanagementservice.Dependent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfBlockDependentsTx indicates an expected call of shelfBlockDependentsTx.
+func (mr *MockshelfBlockQueriesMockRecorder) shelfBlockDependentsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfBlockDependentsTx", reflect.TypeOf((*MockshelfBlockQueries)(nil).shelfBlockDependentsTx), ctx, tx, id)
+}
+
 // updateShelfBlockTx mocks base method.
 func (m *MockshelfBlockQueries) updateShelfBlockTx(ctx context.Context, tx *sql.Tx, block warehousemanagementservice.ShelfBlock) error {
 	m.ctrl.T.Helper()
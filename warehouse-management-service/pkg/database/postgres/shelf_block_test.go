@@ -23,6 +23,7 @@ func TestGetShelfBlockByIdTx(t *testing.T) {
 		Rack:        "1",
 		StorageType: "regular",
 		WarehouseId: "85bd3b85-ad4d-4224-b589-fb2a80a6ce45",
+		Version:     1,
 	}
 
 	tx, err := shelfBlockService.db.Begin()
@@ -195,6 +196,7 @@ func TestUpdateShelfBlockTx(t *testing.T) {
 		Rack:        "3",
 		StorageType: "refrigerated",
 		WarehouseId: "85bd3b85-ad4d-4224-b589-fb2a80a6ce45",
+		Version:     1,
 	}
 
 	tx, err := shelfBlockService.db.Begin()
@@ -240,7 +242,7 @@ func TestUpdateShelfBlockTx(t *testing.T) {
 	}
 
 	// check shelf_block in db
-	shelfBlockUpdatedQuery := "SELECT id, aisle, rack, storage_type, warehouse_id FROM shelf_block WHERE id=$1"
+	shelfBlockUpdatedQuery := "SELECT id, aisle, rack, storage_type, warehouse_id, version FROM shelf_block WHERE id=$1"
 	row := tx.QueryRowContext(context.Background(), shelfBlockUpdatedQuery, shelfBlock.Id)
 
 	var shelfBlockFromDB wms.ShelfBlock
@@ -250,14 +252,18 @@ func TestUpdateShelfBlockTx(t *testing.T) {
 		&shelfBlockFromDB.Rack,
 		&shelfBlockFromDB.StorageType,
 		&shelfBlockFromDB.WarehouseId,
+		&shelfBlockFromDB.Version,
 	)
 	if err != nil {
 		t.Error(err)
 		return
 	}
 
-	if shelfBlockFromDB != shelfBlockUpdated {
-		t.Errorf("want: %v, got: %v", shelfBlockUpdated, shelfBlockFromDB)
+	// the update bumps the version
+	want := shelfBlockUpdated
+	want.Version = 2
+	if shelfBlockFromDB != want {
+		t.Errorf("want: %v, got: %v", want, shelfBlockFromDB)
 	}
 }
 
@@ -657,6 +663,8 @@ func TestDeleteShelfBlock(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
+		mockObj.EXPECT().lockShelfBlockTx(ctx, gomock.Any(), request, 1).Return(nil)
+		mockObj.EXPECT().shelfBlockDependentsTx(ctx, gomock.Any(), request).Return(nil, nil)
 		mockObj.EXPECT().deleteShelfBlockTx(
 			ctx,
 			gomock.Any(),
@@ -668,10 +676,226 @@ func TestDeleteShelfBlock(t *testing.T) {
 			db:      shelfBlockService.db,
 		}
 
-		err := mockShelfBlockService.DeleteShelfBlockById(ctx, request)
+		err := mockShelfBlockService.DeleteShelfBlockById(ctx, request, 1, false)
 
 		if err != test.wantErr {
 			t.Errorf("want: %v, got: %v", test.wantErr, err)
 		}
 	}
 }
+
+func TestListShelfBlocksTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := shelfBlockService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	secondShelfBlock := fixtureShelfBlock
+	secondShelfBlock.Id = fixtureShelfBlock.Id + "-2"
+	secondShelfBlock.Rack = "2"
+	err = shelfBlockService.queries.createShelfBlockTx(ctx, tx, secondShelfBlock)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	firstPage, err := shelfBlockService.queries.listShelfBlocksTx(ctx, tx, fixtureWarehouse.Id, "", 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(firstPage) != 1 || firstPage[0] != fixtureShelfBlock {
+		t.Errorf("expected: %v, got: %v", fixtureShelfBlock, firstPage)
+	}
+
+	secondPage, err := shelfBlockService.queries.listShelfBlocksTx(ctx, tx, fixtureWarehouse.Id, firstPage[0].Id, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(secondPage) != 1 || secondPage[0] != secondShelfBlock {
+		t.Errorf("expected: %v, got: %v", secondShelfBlock, secondPage)
+	}
+
+	otherWarehouse, err := shelfBlockService.queries.listShelfBlocksTx(ctx, tx, "non-existent-id", "", 10)
+	if err != nil || len(otherWarehouse) != 0 {
+		t.Errorf("expected: %v, got: %v, %v", "no shelf blocks", otherWarehouse, err)
+	}
+}
+
+func TestListShelfBlocks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockshelfBlockQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		listShelfBlocksTxReturns []wms.ShelfBlock
+		listShelfBlocksTxErr     error
+		wantErr                  error
+	}{
+		{listShelfBlocksTxReturns: []wms.ShelfBlock{fixtureShelfBlock}, listShelfBlocksTxErr: nil, wantErr: nil},
+		{listShelfBlocksTxReturns: nil, listShelfBlocksTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().listShelfBlocksTx(ctx, gomock.Any(), "foo", "", 10).Return(test.listShelfBlocksTxReturns, test.listShelfBlocksTxErr)
+
+		mockShelfBlockService := &ShelfBlockService{
+			queries: mockObj,
+			db:      shelfBlockService.db,
+		}
+
+		got, err := mockShelfBlockService.ListShelfBlocks(ctx, "foo", "", 10)
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+		if len(got) != len(test.listShelfBlocksTxReturns) {
+			t.Errorf("want: %v, got: %v", test.listShelfBlocksTxReturns, got)
+		}
+	}
+}
+
+func TestShelfBlockDependentsTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := shelfBlockService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+
+	got, err := shelfBlockService.queries.shelfBlockDependentsTx(ctx, tx, fixtureShelfBlock.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []wms.Dependent{{Entity: wms.EntityShelf, Id: fixtureShelf.Id}}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	err = shelfBlockService.queries.cascadeDeleteShelfBlockTx(ctx, tx, fixtureShelfBlock.Id)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRestoreShelfBlock(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockshelfBlockQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	request := "test_restore"
+	tests := []struct {
+		restoreShelfBlockTxErr error
+		wantErr                error
+	}{
+		{
+			restoreShelfBlockTxErr: nil,
+			wantErr:                nil,
+		},
+		{
+			restoreShelfBlockTxErr: RowDoesNotExist,
+			wantErr:                wms.ShelfBlockDoesNotExist,
+		},
+		{
+			restoreShelfBlockTxErr: InvalidWarehouse,
+			wantErr:                wms.InvalidWarehouse,
+		},
+		{
+			restoreShelfBlockTxErr: sql.ErrConnDone,
+			wantErr:                sql.ErrConnDone,
+		},
+	}
+	for _, test := range tests {
+		mockObj.EXPECT().restoreShelfBlockTx(ctx, gomock.Any(), request).Return(test.restoreShelfBlockTxErr)
+
+		mockShelfBlockService := &ShelfBlockService{
+			queries: mockObj,
+			db:      shelfBlockService.db,
+		}
+
+		err := mockShelfBlockService.RestoreShelfBlockById(ctx, request)
+
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+	}
+}
+
+func TestCreateShelfBlockTxConcurrentDelete(t *testing.T) {
+	ctx := context.Background()
+	cleanup, ok := commitFixtures(t)
+	if !ok {
+		return
+	}
+	defer cleanup()
+
+	deleting, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer deleting.Rollback()
+	creating, err := shelfBlockService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer creating.Rollback()
+
+	err = warehouseService.queries.lockWarehouseTx(ctx, deleting, fixtureWarehouse.Id, fixtureWarehouse.Version)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = warehouseService.queries.cascadeDeleteWarehouseTx(ctx, deleting, fixtureWarehouse.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// creating blocks on the warehouse deleting has locked, and has to find it
+	// gone once deleting commits
+	block := wms.ShelfBlock{
+		Id:          "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e31",
+		Aisle:       "2",
+		Rack:        "1",
+		StorageType: "regular",
+		WarehouseId: fixtureWarehouse.Id,
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- shelfBlockService.queries.createShelfBlockTx(ctx, creating, block)
+	}()
+
+	err = deleting.Commit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = <-done
+	if err != InvalidWarehouse {
+		t.Errorf("expected: %v, got: %v", InvalidWarehouse, err)
+	}
+}
@@ -36,6 +36,20 @@ func (m *MockshelfQueries) EXPECT() *MockshelfQueriesMockRecorder {
 	return m.recorder
 }
 
+// cascadeDeleteShelfTx mocks base method.
+func (m *MockshelfQueries) cascadeDeleteShelfTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "cascadeDeleteShelfTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// cascadeDeleteShelfTx indicates an expected call of cascadeDeleteShelfTx.
+func (mr *MockshelfQueriesMockRecorder) cascadeDeleteShelfTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "cascadeDeleteShelfTx", reflect.TypeOf((*MockshelfQueries)(nil).cascadeDeleteShelfTx), ctx, tx, id)
+}
+
 // createShelfTx mocks base method.
 func (m *MockshelfQueries) createShelfTx(ctx context.Context, tx *sql.Tx, shelf warehousemanagementservice.Shelf) error {
 	m.ctrl.T.Helper()
@@ -79,6 +93,63 @@ func (mr *MockshelfQueriesMockRecorder) getShelfByIdTx(ctx, tx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getShelfByIdTx", reflect.TypeOf((*MockshelfQueries)(nil).getShelfByIdTx), ctx, tx, id)
 }
 
+// listShelvesTx mocks base method.
+func (m *MockshelfQueries) listShelvesTx(ctx context.Context, tx *sql.Tx, shelfBlockId, after string, limit int) ([]warehousemanagementservice.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listShelvesTx", ctx, tx, shelfBlockId, after, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listShelvesTx indicates an expected call of listShelvesTx.
+func (mr *MockshelfQueriesMockRecorder) listShelvesTx(ctx, tx, shelfBlockId, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listShelvesTx", reflect.TypeOf((*MockshelfQueries)(nil).listShelvesTx), ctx, tx, shelfBlockId, after, limit)
+}
+
+// lockShelfTx mocks base method.
+func (m *MockshelfQueries) lockShelfTx(ctx context.Context, tx *sql.Tx, id string, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockShelfTx", ctx, tx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// lockShelfTx indicates an expected call of lockShelfTx.
+func (mr *MockshelfQueriesMockRecorder) lockShelfTx(ctx, tx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockShelfTx", reflect.TypeOf((*MockshelfQueries)(nil).lockShelfTx), ctx, tx, id, version)
+}
+
+// purgeShelfTx mocks base method.
+func (m *MockshelfQueries) purgeShelfTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "purgeShelfTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// purgeShelfTx indicates an expected call of purgeShelfTx.
+func (mr *MockshelfQueriesMockRecorder) purgeShelfTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "purgeShelfTx", reflect.TypeOf((*MockshelfQueries)(nil).purgeShelfTx), ctx, tx, id)
+}
+
+// restoreShelfTx mocks base method.
+func (m *MockshelfQueries) restoreShelfTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "restoreShelfTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// restoreShelfTx indicates an expected call of restoreShelfTx.
+func (mr *MockshelfQueriesMockRecorder) restoreShelfTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "restoreShelfTx", reflect.TypeOf((*MockshelfQueries)(nil).restoreShelfTx), ctx, tx, id)
+}
+
 // shelfBlockExistsTx mocks base method.
 func (m *MockshelfQueries) shelfBlockExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +165,36 @@ func (mr *MockshelfQueriesMockRecorder) shelfBlockExistsTx(ctx, tx, id interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfBlockExistsTx", reflect.TypeOf((*MockshelfQueries)(nil).shelfBlockExistsTx), ctx, tx, id)
 }
 
+// shelfDependentsTx mocks base method.
+func (m *MockshelfQueries) shelfDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]warehousemanagementservice.Dependent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfDependentsTx", ctx, tx, id)
+	ret0, _ := ret[0].([]warehousemanagementservice.Dependent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfDependentsTx indicates an expected call of shelfDependentsTx.
+func (mr *MockshelfQueriesMockRecorder) shelfDependentsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfDependentsTx", reflect.TypeOf((*MockshelfQueries)(nil).shelfDependentsTx), ctx, tx, id)
+}
+
+// shelfUtilizationTx mocks base method.
+func (m *MockshelfQueries) shelfUtilizationTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.ShelfUtilization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfUtilizationTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.ShelfUtilization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfUtilizationTx indicates an expected call of shelfUtilizationTx.
+func (mr *MockshelfQueriesMockRecorder) shelfUtilizationTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfUtilizationTx", reflect.TypeOf((*MockshelfQueries)(nil).shelfUtilizationTx), ctx, tx, id)
+}
+
 // updateShelfTx mocks base method.
 func (m *MockshelfQueries) updateShelfTx(ctx context.Context, tx *sql.Tx, shelf warehousemanagementservice.Shelf) error {
 	m.ctrl.T.Helper()
@@ -30,6 +30,7 @@ func TestGetShelfByIdTx(t *testing.T) {
 		Section:      "A",
 		Level:        "12",
 		ShelfBlockId: "863e835b-a05b-4554-b0af-a45389ebbb78",
+		Version:      1,
 	}
 
 	tx, err := shelfService.db.Begin()
@@ -244,6 +245,7 @@ func TestUpdateShelfTx(t *testing.T) {
 		Section:      "G",
 		Level:        "11",
 		ShelfBlockId: "863e835b-a05b-4554-b0af-a45389ebbb78",
+		Version:      1,
 	}
 
 	tx, err := shelfService.db.Begin()
@@ -303,7 +305,7 @@ func TestUpdateShelfTx(t *testing.T) {
 	}
 
 	// check shelf in db
-	shelfUpdatedQuery := "SELECT id, label, section, level, shelf_block FROM shelf WHERE id=$1"
+	shelfUpdatedQuery := "SELECT id, label, section, level, shelf_block, version FROM shelf WHERE id=$1"
 	row := tx.QueryRowContext(context.Background(), shelfUpdatedQuery, shelf.Id)
 
 	var shelfFromDB wms.Shelf
@@ -313,14 +315,18 @@ func TestUpdateShelfTx(t *testing.T) {
 		&shelfFromDB.Section,
 		&shelfFromDB.Level,
 		&shelfFromDB.ShelfBlockId,
+		&shelfFromDB.Version,
 	)
 	if err != nil {
 		t.Error(err)
 		return
 	}
 
-	if shelfFromDB != shelfUpdated {
-		t.Errorf("want: %v, got: %v", shelfUpdated, shelfFromDB)
+	// the update bumps the version
+	want := shelfUpdated
+	want.Version = 2
+	if shelfFromDB != want {
+		t.Errorf("want: %v, got: %v", want, shelfFromDB)
 	}
 }
 
@@ -760,6 +766,8 @@ func TestDeleteShelfById(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
+		mockObj.EXPECT().lockShelfTx(ctx, gomock.Any(), request, 1).Return(nil)
+		mockObj.EXPECT().shelfDependentsTx(ctx, gomock.Any(), request).Return(nil, nil)
 		mockObj.EXPECT().deleteShelfTx(
 			ctx,
 			gomock.Any(),
@@ -771,10 +779,157 @@ func TestDeleteShelfById(t *testing.T) {
 			db:      shelfService.db,
 		}
 
-		err := mockShelfService.DeleteShelfById(ctx, request)
+		err := mockShelfService.DeleteShelfById(ctx, request, 1, false)
 
 		if err != test.wantErr {
 			t.Errorf("want: %v, got: %v", test.wantErr, err)
 		}
 	}
 }
+
+func TestListShelvesTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := shelfService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	secondShelf := fixtureShelf
+	secondShelf.Id = fixtureShelf.Id + "-2"
+	secondShelf.Label = "1B"
+	err = shelfService.queries.createShelfTx(ctx, tx, secondShelf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	shelves, err := shelfService.queries.listShelvesTx(ctx, tx, fixtureShelfBlock.Id, "", 10)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(shelves) != 2 || shelves[0] != fixtureShelf || shelves[1] != secondShelf {
+		t.Errorf("expected: %v, got: %v", []wms.Shelf{fixtureShelf, secondShelf}, shelves)
+	}
+
+	shelves, err = shelfService.queries.listShelvesTx(ctx, tx, fixtureShelfBlock.Id, fixtureShelf.Id, 10)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(shelves) != 1 || shelves[0] != secondShelf {
+		t.Errorf("expected: %v, got: %v", secondShelf, shelves)
+	}
+}
+
+func TestListShelves(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockObj := NewMockshelfQueries(mockCtrl)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		listShelvesTxReturns []wms.Shelf
+		listShelvesTxErr     error
+		wantErr              error
+	}{
+		{listShelvesTxReturns: []wms.Shelf{fixtureShelf}, listShelvesTxErr: nil, wantErr: nil},
+		{listShelvesTxReturns: nil, listShelvesTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().listShelvesTx(ctx, gomock.Any(), "foo", "", 10).Return(test.listShelvesTxReturns, test.listShelvesTxErr)
+
+		mockShelfService := &ShelfService{
+			queries: mockObj,
+			db:      shelfService.db,
+		}
+
+		got, err := mockShelfService.ListShelves(ctx, "foo", "", 10)
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+		if len(got) != len(test.listShelvesTxReturns) {
+			t.Errorf("want: %v, got: %v", test.listShelvesTxReturns, got)
+		}
+	}
+}
+
+func TestShelfDependentsTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := shelfService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+
+	got, err := shelfService.queries.shelfDependentsTx(ctx, tx, fixtureShelf.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("want no dependents, got: %v", got)
+	}
+
+	err = shelfService.queries.cascadeDeleteShelfTx(ctx, tx, fixtureShelf.Id)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRestoreShelfTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := shelfService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+
+	err = shelfService.queries.deleteShelfTx(ctx, tx, fixtureShelf.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = shelfService.queries.getShelfByIdTx(ctx, tx, fixtureShelf.Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("want: %v, got: %v", sql.ErrNoRows, err)
+	}
+
+	err = shelfService.queries.restoreShelfTx(ctx, tx, fixtureShelf.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = shelfService.queries.getShelfByIdTx(ctx, tx, fixtureShelf.Id)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = shelfService.queries.restoreShelfTx(ctx, tx, fixtureShelf.Id)
+	if err != RowDoesNotExist {
+		t.Errorf("want: %v, got: %v", RowDoesNotExist, err)
+	}
+}
@@ -0,0 +1,422 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/shipment.go" -destination="./pkg/database/postgres/shipment_mock.go"
+type shipmentQueries interface {
+	lockOrderTx(ctx context.Context, tx *sql.Tx, id string) (wms.Order, error)
+	setOrderStatusTx(ctx context.Context, tx *sql.Tx, id string, status string) error
+	orderHasShipmentTx(ctx context.Context, tx *sql.Tx, orderId string) (bool, error)
+	createShipmentTx(ctx context.Context, tx *sql.Tx, shipment wms.Shipment) error
+	getShipmentByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Shipment, error)
+	lockShipmentTx(ctx context.Context, tx *sql.Tx, id string) (wms.Shipment, error)
+	pickedItemTx(ctx context.Context, tx *sql.Tx, orderId string, itemId string) (wms.PackedItem, error)
+	itemPackedTx(ctx context.Context, tx *sql.Tx, itemId string) (bool, error)
+	createPackageTx(ctx context.Context, tx *sql.Tx, pkg wms.Package) error
+	countUnpackedItemsTx(ctx context.Context, tx *sql.Tx, orderId string) (int, error)
+	removePackedItemsTx(ctx context.Context, tx *sql.Tx, shipmentId string) error
+	dispatchShipmentTx(ctx context.Context, tx *sql.Tx, id string, dispatchedAt time.Time) error
+}
+
+type shipmentQueriesImpl struct {
+	orderQueriesImpl
+}
+
+type ShipmentService struct {
+	queries shipmentQueries
+	db      *sql.DB
+}
+
+func NewShipmentService(db *sql.DB) *ShipmentService {
+	return &ShipmentService{
+		queries: new(shipmentQueriesImpl),
+		db:      db,
+	}
+}
+
+// CreateShipment opens the shipment of an order that is being picked. An
+// order has one shipment.
+func (s *ShipmentService) CreateShipment(ctx context.Context, shipment wms.Shipment) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	order, err := s.queries.lockOrderTx(ctx, tx, shipment.OrderId)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.OrderDoesNotExist
+	default:
+		return err
+	}
+	if order.Status != wms.OrderPicking {
+		return wms.OrderNotPicked
+	}
+	if hasShipment, err := s.queries.orderHasShipmentTx(ctx, tx, order.Id); err != nil {
+		return err
+	} else if hasShipment {
+		return wms.ShipmentExists
+	}
+
+	err = s.queries.createShipmentTx(ctx, tx, shipment)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *ShipmentService) GetShipmentById(ctx context.Context, id string) (wms.Shipment, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+	defer tx.Rollback()
+
+	shipment, err := s.queries.getShipmentByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return shipment, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.Shipment{}, wms.ShipmentDoesNotExist
+	default:
+		return wms.Shipment{}, err
+	}
+}
+
+// PackItems packs items picked for the order of an open shipment into a new
+// package.
+func (s *ShipmentService) PackItems(ctx context.Context, shipmentId string, trackingNumber string, itemIds []string) (wms.Package, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Package{}, err
+	}
+	defer tx.Rollback()
+
+	shipment, err := s.queries.lockShipmentTx(ctx, tx, shipmentId)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.Package{}, wms.ShipmentDoesNotExist
+	default:
+		return wms.Package{}, err
+	}
+	if shipment.Status != wms.ShipmentOpen {
+		return wms.Package{}, wms.ShipmentNotOpen
+	}
+
+	items := make([]wms.PackedItem, 0, len(itemIds))
+	for _, itemId := range itemIds {
+		item, err := s.queries.pickedItemTx(ctx, tx, shipment.OrderId, itemId)
+		switch err {
+		case nil:
+		case sql.ErrNoRows:
+			return wms.Package{}, wms.ItemNotOnPickList
+		default:
+			return wms.Package{}, err
+		}
+		if packed, err := s.queries.itemPackedTx(ctx, tx, itemId); err != nil {
+			return wms.Package{}, err
+		} else if packed {
+			return wms.Package{}, wms.ItemAlreadyPacked
+		}
+		items = append(items, item)
+	}
+
+	pkg, err := wms.NewPackage(shipmentId, trackingNumber, items)
+	if err != nil {
+		return wms.Package{}, err
+	}
+	err = s.queries.createPackageTx(ctx, tx, pkg)
+	if err != nil {
+		return wms.Package{}, err
+	}
+	return pkg, tx.Commit()
+}
+
+// DispatchShipment hands a shipment to its carrier once every item picked
+// for its order is packed. The packed items leave stock, and the order is
+// shipped.
+func (s *ShipmentService) DispatchShipment(ctx context.Context, id string) (wms.Shipment, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+	defer tx.Rollback()
+
+	shipment, err := s.queries.lockShipmentTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.Shipment{}, wms.ShipmentDoesNotExist
+	default:
+		return wms.Shipment{}, err
+	}
+	if shipment.Status != wms.ShipmentOpen {
+		return wms.Shipment{}, wms.ShipmentNotOpen
+	}
+	if unpacked, err := s.queries.countUnpackedItemsTx(ctx, tx, shipment.OrderId); err != nil {
+		return wms.Shipment{}, err
+	} else if unpacked > 0 {
+		return wms.Shipment{}, wms.ShipmentNotPacked
+	}
+
+	err = s.queries.removePackedItemsTx(ctx, tx, id)
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+	err = s.queries.setOrderStatusTx(ctx, tx, shipment.OrderId, wms.OrderShipped)
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+	err = s.queries.dispatchShipmentTx(ctx, tx, id, time.Now().UTC())
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+
+	shipment, err = s.queries.getShipmentByIdTx(ctx, tx, id)
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+	return shipment, tx.Commit()
+}
+
+func (s *shipmentQueriesImpl) orderHasShipmentTx(ctx context.Context, tx *sql.Tx, orderId string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM shipment WHERE order_id = $1)`
+
+	var exists bool
+	row := tx.QueryRowContext(ctx, query, orderId)
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (s *shipmentQueriesImpl) createShipmentTx(ctx context.Context, tx *sql.Tx, shipment wms.Shipment) error {
+	query := `INSERT INTO shipment(id, order_id, carrier, status, created_at) VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := tx.ExecContext(ctx, query, shipment.Id, shipment.OrderId, shipment.Carrier, shipment.Status, shipment.CreatedAt)
+	return err
+}
+
+const shipmentColumns = `id, order_id, carrier, status, created_at, dispatched_at`
+
+func (s *shipmentQueriesImpl) scanShipment(row *sql.Row) (wms.Shipment, error) {
+	var shipment wms.Shipment
+	var dispatchedAt sql.NullTime
+	err := row.Scan(
+		&shipment.Id,
+		&shipment.OrderId,
+		&shipment.Carrier,
+		&shipment.Status,
+		&shipment.CreatedAt,
+		&dispatchedAt,
+	)
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+	if dispatchedAt.Valid {
+		shipment.DispatchedAt = &dispatchedAt.Time
+	}
+	return shipment, nil
+}
+
+// lockShipmentTx returns a shipment without its packages and locks its row,
+// so that packing and dispatching it happen one at a time.
+func (s *shipmentQueriesImpl) lockShipmentTx(ctx context.Context, tx *sql.Tx, id string) (wms.Shipment, error) {
+	return s.scanShipment(tx.QueryRowContext(ctx, `SELECT `+shipmentColumns+` FROM shipment WHERE id = $1 FOR UPDATE`, id))
+}
+
+func (s *shipmentQueriesImpl) getShipmentByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Shipment, error) {
+	shipment, err := s.scanShipment(tx.QueryRowContext(ctx, `SELECT `+shipmentColumns+` FROM shipment WHERE id = $1`, id))
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+
+	query := `SELECT package.id, package.shipment_id, package.tracking_number, package.weight_in_kg, package.created_at,
+			package_item.item_id, package_item.sku, COALESCE(package_item.lot, ''), COALESCE(package_item.serial_number, ''),
+			package_item.expiration_date, package_item.weight_in_kg
+		FROM package
+		JOIN package_item ON package_item.package_id = package.id
+		WHERE package.shipment_id = $1
+		ORDER BY package.created_at, package.id, package_item.item_id`
+
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return wms.Shipment{}, err
+	}
+	defer rows.Close()
+
+	shipment.Packages = []wms.Package{}
+	for rows.Next() {
+		var pkg wms.Package
+		var item wms.PackedItem
+		var expirationDate sql.NullTime
+		err := rows.Scan(
+			&pkg.Id,
+			&pkg.ShipmentId,
+			&pkg.TrackingNumber,
+			&pkg.WeightInKg,
+			&pkg.CreatedAt,
+			&item.ItemId,
+			&item.Sku,
+			&item.Lot,
+			&item.SerialNumber,
+			&expirationDate,
+			&item.WeightInKg,
+		)
+		if err != nil {
+			return wms.Shipment{}, err
+		}
+		if expirationDate.Valid {
+			item.ExpirationDate = &expirationDate.Time
+		}
+		if n := len(shipment.Packages); n == 0 || shipment.Packages[n-1].Id != pkg.Id {
+			shipment.WeightInKg += pkg.WeightInKg
+			shipment.Packages = append(shipment.Packages, pkg)
+		}
+		last := &shipment.Packages[len(shipment.Packages)-1]
+		last.Items = append(last.Items, item)
+	}
+
+	return shipment, rows.Err()
+}
+
+// pickedItemTx returns an item on the pick list of an order, weighing what
+// a unit of its product does.
+func (s *shipmentQueriesImpl) pickedItemTx(ctx context.Context, tx *sql.Tx, orderId string, itemId string) (wms.PackedItem, error) {
+	query := `SELECT item.id, item.sku, COALESCE(item.lot, ''), COALESCE(item.serial_number, ''), item.expiration_date,
+			COALESCE(product.weight_in_kg, 0)
+		FROM pick_list_item
+		JOIN order_line ON order_line.id = pick_list_item.order_line_id
+		JOIN item ON item.id = pick_list_item.item_id
+		JOIN product ON product.sku = item.sku
+		WHERE order_line.order_id = $1 AND pick_list_item.item_id = $2`
+
+	var item wms.PackedItem
+	var expirationDate sql.NullTime
+	row := tx.QueryRowContext(ctx, query, orderId, itemId)
+	err := row.Scan(&item.ItemId, &item.Sku, &item.Lot, &item.SerialNumber, &expirationDate, &item.WeightInKg)
+	if err != nil {
+		return wms.PackedItem{}, err
+	}
+	if expirationDate.Valid {
+		item.ExpirationDate = &expirationDate.Time
+	}
+	return item, nil
+}
+
+func (s *shipmentQueriesImpl) itemPackedTx(ctx context.Context, tx *sql.Tx, itemId string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM package_item WHERE item_id = $1)`
+
+	var packed bool
+	row := tx.QueryRowContext(ctx, query, itemId)
+	err := row.Scan(&packed)
+	if err != nil {
+		return false, err
+	}
+	return packed, nil
+}
+
+func (s *shipmentQueriesImpl) createPackageTx(ctx context.Context, tx *sql.Tx, pkg wms.Package) error {
+	query := `INSERT INTO package(id, shipment_id, tracking_number, weight_in_kg, created_at) VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := tx.ExecContext(ctx, query, pkg.Id, pkg.ShipmentId, pkg.TrackingNumber, pkg.WeightInKg, pkg.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range pkg.Items {
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO package_item(item_id, package_id, sku, lot, serial_number, expiration_date, weight_in_kg)
+				VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)`,
+			item.ItemId,
+			pkg.Id,
+			item.Sku,
+			item.Lot,
+			item.SerialNumber,
+			item.ExpirationDate,
+			item.WeightInKg,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countUnpackedItemsTx counts the items on the pick list of an order that
+// are in no package.
+func (s *shipmentQueriesImpl) countUnpackedItemsTx(ctx context.Context, tx *sql.Tx, orderId string) (int, error) {
+	query := `SELECT COUNT(*)
+		FROM pick_list_item
+		JOIN order_line ON order_line.id = pick_list_item.order_line_id
+		WHERE order_line.order_id = $1
+			AND NOT EXISTS(SELECT 1 FROM package_item WHERE package_item.item_id = pick_list_item.item_id)`
+
+	var count int
+	row := tx.QueryRowContext(ctx, query, orderId)
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// removePackedItemsTx deletes the items packed into a shipment from stock,
+// along with their place on the pick list, and records each leaving its
+// shelf for no other in the stock ledger.
+func (s *shipmentQueriesImpl) removePackedItemsTx(ctx context.Context, tx *sql.Tx, shipmentId string) error {
+	movements, err := deletePackedItemsTx(ctx, tx, shipmentId)
+	if err != nil {
+		return err
+	}
+	for _, movement := range movements {
+		err = recordMovementTx(ctx, tx, movement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deletePackedItemsTx(ctx context.Context, tx *sql.Tx, shipmentId string) ([]wms.StockMovement, error) {
+	query := `DELETE FROM item WHERE id IN (
+			SELECT package_item.item_id FROM package_item
+			JOIN package ON package.id = package_item.package_id
+			WHERE package.shipment_id = $1
+		)
+		RETURNING id, sku, shelf_id`
+
+	rows, err := tx.QueryContext(ctx, query, shipmentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movements []wms.StockMovement
+	for rows.Next() {
+		movement := wms.StockMovement{Kind: wms.MovementDispatch}
+		err = rows.Scan(&movement.ItemId, &movement.Sku, &movement.FromShelfId)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	return movements, rows.Err()
+}
+
+func (s *shipmentQueriesImpl) dispatchShipmentTx(ctx context.Context, tx *sql.Tx, id string, dispatchedAt time.Time) error {
+	query := `UPDATE shipment SET status = $1, dispatched_at = $2 WHERE id = $3`
+
+	_, err := tx.ExecContext(ctx, query, wms.ShipmentDispatched, dispatchedAt, id)
+	return err
+}
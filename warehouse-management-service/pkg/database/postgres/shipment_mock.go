@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/shipment.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockshipmentQueries is a mock of shipmentQueries interface.
+type MockshipmentQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockshipmentQueriesMockRecorder
+}
+
+// MockshipmentQueriesMockRecorder is the mock recorder for MockshipmentQueries.
+type MockshipmentQueriesMockRecorder struct {
+	mock *MockshipmentQueries
+}
+
+// NewMockshipmentQueries creates a new mock instance.
+func NewMockshipmentQueries(ctrl *gomock.Controller) *MockshipmentQueries {
+	mock := &MockshipmentQueries{ctrl: ctrl}
+	mock.recorder = &MockshipmentQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshipmentQueries) EXPECT() *MockshipmentQueriesMockRecorder {
+	return m.recorder
+}
+
+// countUnpackedItemsTx mocks base method.
+func (m *MockshipmentQueries) countUnpackedItemsTx(ctx context.Context, tx *sql.Tx, orderId string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "countUnpackedItemsTx", ctx, tx, orderId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// countUnpackedItemsTx indicates an expected call of countUnpackedItemsTx.
+func (mr *MockshipmentQueriesMockRecorder) countUnpackedItemsTx(ctx, tx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "countUnpackedItemsTx", reflect.TypeOf((*MockshipmentQueries)(nil).countUnpackedItemsTx), ctx, tx, orderId)
+}
+
+// createPackageTx mocks base method.
+func (m *MockshipmentQueries) createPackageTx(ctx context.Context, tx *sql.Tx, pkg warehousemanagementservice.Package) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createPackageTx", ctx, tx, pkg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createPackageTx indicates an expected call of createPackageTx.
+func (mr *MockshipmentQueriesMockRecorder) createPackageTx(ctx, tx, pkg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createPackageTx", reflect.TypeOf((*MockshipmentQueries)(nil).createPackageTx), ctx, tx, pkg)
+}
+
+// createShipmentTx mocks base method.
+func (m *MockshipmentQueries) createShipmentTx(ctx context.Context, tx *sql.Tx, shipment warehousemanagementservice.Shipment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createShipmentTx", ctx, tx, shipment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createShipmentTx indicates an expected call of createShipmentTx.
+func (mr *MockshipmentQueriesMockRecorder) createShipmentTx(ctx, tx, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createShipmentTx", reflect.TypeOf((*MockshipmentQueries)(nil).createShipmentTx), ctx, tx, shipment)
+}
+
+// dispatchShipmentTx mocks base method.
+func (m *MockshipmentQueries) dispatchShipmentTx(ctx context.Context, tx *sql.Tx, id string, dispatchedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "dispatchShipmentTx", ctx, tx, id, dispatchedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// dispatchShipmentTx indicates an expected call of dispatchShipmentTx.
+func (mr *MockshipmentQueriesMockRecorder) dispatchShipmentTx(ctx, tx, id, dispatchedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "dispatchShipmentTx", reflect.TypeOf((*MockshipmentQueries)(nil).dispatchShipmentTx), ctx, tx, id, dispatchedAt)
+}
+
+// getShipmentByIdTx mocks base method.
+func (m *MockshipmentQueries) getShipmentByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getShipmentByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getShipmentByIdTx indicates an expected call of getShipmentByIdTx.
+func (mr *MockshipmentQueriesMockRecorder) getShipmentByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getShipmentByIdTx", reflect.TypeOf((*MockshipmentQueries)(nil).getShipmentByIdTx), ctx, tx, id)
+}
+
+// itemPackedTx mocks base method.
+func (m *MockshipmentQueries) itemPackedTx(ctx context.Context, tx *sql.Tx, itemId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "itemPackedTx", ctx, tx, itemId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// itemPackedTx indicates an expected call of itemPackedTx.
+func (mr *MockshipmentQueriesMockRecorder) itemPackedTx(ctx, tx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "itemPackedTx", reflect.TypeOf((*MockshipmentQueries)(nil).itemPackedTx), ctx, tx, itemId)
+}
+
+// lockOrderTx mocks base method.
+func (m *MockshipmentQueries) lockOrderTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockOrderTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockOrderTx indicates an expected call of lockOrderTx.
+func (mr *MockshipmentQueriesMockRecorder) lockOrderTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockOrderTx", reflect.TypeOf((*MockshipmentQueries)(nil).lockOrderTx), ctx, tx, id)
+}
+
+// lockShipmentTx mocks base method.
+func (m *MockshipmentQueries) lockShipmentTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockShipmentTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockShipmentTx indicates an expected call of lockShipmentTx.
+func (mr *MockshipmentQueriesMockRecorder) lockShipmentTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockShipmentTx", reflect.TypeOf((*MockshipmentQueries)(nil).lockShipmentTx), ctx, tx, id)
+}
+
+// orderHasShipmentTx mocks base method.
+func (m *MockshipmentQueries) orderHasShipmentTx(ctx context.Context, tx *sql.Tx, orderId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "orderHasShipmentTx", ctx, tx, orderId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// orderHasShipmentTx indicates an expected call of orderHasShipmentTx.
+func (mr *MockshipmentQueriesMockRecorder) orderHasShipmentTx(ctx, tx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "orderHasShipmentTx", reflect.TypeOf((*MockshipmentQueries)(nil).orderHasShipmentTx), ctx, tx, orderId)
+}
+
+// pickedItemTx mocks base method.
+func (m *MockshipmentQueries) pickedItemTx(ctx context.Context, tx *sql.Tx, orderId, itemId string) (warehousemanagementservice.PackedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "pickedItemTx", ctx, tx, orderId, itemId)
+	ret0, _ := ret[0].(warehousemanagementservice.PackedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// pickedItemTx indicates an expected call of pickedItemTx.
+func (mr *MockshipmentQueriesMockRecorder) pickedItemTx(ctx, tx, orderId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "pickedItemTx", reflect.TypeOf((*MockshipmentQueries)(nil).pickedItemTx), ctx, tx, orderId, itemId)
+}
+
+// removePackedItemsTx mocks base method.
+func (m *MockshipmentQueries) removePackedItemsTx(ctx context.Context, tx *sql.Tx, shipmentId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "removePackedItemsTx", ctx, tx, shipmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// removePackedItemsTx indicates an expected call of removePackedItemsTx.
+func (mr *MockshipmentQueriesMockRecorder) removePackedItemsTx(ctx, tx, shipmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "removePackedItemsTx", reflect.TypeOf((*MockshipmentQueries)(nil).removePackedItemsTx), ctx, tx, shipmentId)
+}
+
+// setOrderStatusTx mocks base method.
+func (m *MockshipmentQueries) setOrderStatusTx(ctx context.Context, tx *sql.Tx, id, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "setOrderStatusTx", ctx, tx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// setOrderStatusTx indicates an expected call of setOrderStatusTx.
+func (mr *MockshipmentQueriesMockRecorder) setOrderStatusTx(ctx, tx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setOrderStatusTx", reflect.TypeOf((*MockshipmentQueries)(nil).setOrderStatusTx), ctx, tx, id, status)
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func TestPackAndDispatchTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := shipmentQueriesImpl{}
+	order := wms.NewOrder("customer", fixtureWarehouse.Id, []wms.OrderLine{{Sku: testProduct.Sku, Quantity: 1}})
+	err = queries.createOrderTx(ctx, tx, order)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	entries, err := queries.allocateItemsTx(ctx, tx, fixtureWarehouse.Id, order.Lines[0])
+	if err != nil || len(entries) != 1 {
+		t.Errorf("want 1 item allocated, got: %v, %v", entries, err)
+		return
+	}
+
+	shipment := wms.NewShipment(order.Id, "carrier")
+	err = queries.createShipmentTx(ctx, tx, shipment)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	hasShipment, err := queries.orderHasShipmentTx(ctx, tx, order.Id)
+	if err != nil || !hasShipment {
+		t.Errorf("want order %s to have a shipment, got: %v, %v", order.Id, hasShipment, err)
+	}
+
+	unpacked, err := queries.countUnpackedItemsTx(ctx, tx, order.Id)
+	if err != nil || unpacked != 1 {
+		t.Errorf("want 1 item left to pack, got: %v, %v", unpacked, err)
+	}
+	_, err = queries.pickedItemTx(ctx, tx, "other", testItem().Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("want the item off the pick list of other orders, got: %v", err)
+	}
+	item, err := queries.pickedItemTx(ctx, tx, order.Id, testItem().Id)
+	if err != nil || item.WeightInKg != testProduct.WeightInKg {
+		t.Errorf("want item weighing %v, got: %v, %v", testProduct.WeightInKg, item, err)
+		return
+	}
+
+	pkg, err := wms.NewPackage(shipment.Id, "TRK1", []wms.PackedItem{item})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.createPackageTx(ctx, tx, pkg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	packed, err := queries.itemPackedTx(ctx, tx, testItem().Id)
+	if err != nil || !packed {
+		t.Errorf("want item %s packed, got: %v, %v", testItem().Id, packed, err)
+	}
+	unpacked, err = queries.countUnpackedItemsTx(ctx, tx, order.Id)
+	if err != nil || unpacked != 0 {
+		t.Errorf("want nothing left to pack, got: %v, %v", unpacked, err)
+	}
+
+	err = queries.removePackedItemsTx(ctx, tx, shipment.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.dispatchShipmentTx(ctx, tx, shipment.Id, time.Now().UTC())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = itemService.queries.getItemByIdTx(ctx, tx, testItem().Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("want the dispatched item out of stock, got: %v", err)
+	}
+	var fromShelfId, kind string
+	var toShelfId sql.NullString
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT from_shelf_id, to_shelf_id, kind FROM stock_movement WHERE item_id = $1`,
+		testItem().Id,
+	).Scan(&fromShelfId, &toShelfId, &kind)
+	if err != nil || fromShelfId != testItem().ShelfId || toShelfId.Valid || kind != wms.MovementDispatch {
+		t.Errorf("want the item recorded leaving shelf %s for nowhere, got: %s, %v, %s, %v", testItem().ShelfId, fromShelfId, toShelfId, kind, err)
+	}
+
+	shipment, err = queries.getShipmentByIdTx(ctx, tx, shipment.Id)
+	if err != nil || shipment.Status != wms.ShipmentDispatched || shipment.DispatchedAt == nil {
+		t.Errorf("want a dispatched shipment, got: %v, %v", shipment, err)
+		return
+	}
+	if len(shipment.Packages) != 1 || len(shipment.Packages[0].Items) != 1 || shipment.WeightInKg != testProduct.WeightInKg {
+		t.Errorf("want 1 package of item %s, got: %v", testItem().Id, shipment)
+	}
+}
+
+func TestDispatchShipment(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockshipmentQueries(mockCtrl)
+
+	ss := ShipmentService{db: warehouseService.db, queries: mockObj}
+	open := wms.Shipment{Id: "s", OrderId: "o", Status: wms.ShipmentOpen}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockShipmentTx(ctx, gomock.Any(), "s").Return(open, nil),
+		mockObj.EXPECT().countUnpackedItemsTx(ctx, gomock.Any(), "o").Return(2, nil),
+	)
+	_, err := ss.DispatchShipment(ctx, "s")
+	if err != wms.ShipmentNotPacked {
+		t.Errorf("want: %v, got: %v", wms.ShipmentNotPacked, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockShipmentTx(ctx, gomock.Any(), "s").Return(open, nil),
+		mockObj.EXPECT().countUnpackedItemsTx(ctx, gomock.Any(), "o").Return(0, nil),
+		mockObj.EXPECT().removePackedItemsTx(ctx, gomock.Any(), "s").Return(nil),
+		mockObj.EXPECT().setOrderStatusTx(ctx, gomock.Any(), "o", wms.OrderShipped).Return(nil),
+		mockObj.EXPECT().dispatchShipmentTx(ctx, gomock.Any(), "s", gomock.Any()).Return(nil),
+		mockObj.EXPECT().getShipmentByIdTx(ctx, gomock.Any(), "s").Return(wms.Shipment{Id: "s", Status: wms.ShipmentDispatched}, nil),
+	)
+	shipment, err := ss.DispatchShipment(ctx, "s")
+	if err != nil || shipment.Status != wms.ShipmentDispatched {
+		t.Errorf("want a dispatched shipment, got: %v, %v", shipment, err)
+	}
+
+	mockObj.EXPECT().lockShipmentTx(ctx, gomock.Any(), "s").Return(wms.Shipment{Id: "s", Status: wms.ShipmentDispatched}, nil)
+	_, err = ss.PackItems(ctx, "s", "TRK1", []string{"i"})
+	if err != wms.ShipmentNotOpen {
+		t.Errorf("want: %v, got: %v", wms.ShipmentNotOpen, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().lockShipmentTx(ctx, gomock.Any(), "s").Return(open, nil),
+		mockObj.EXPECT().pickedItemTx(ctx, gomock.Any(), "o", "i").Return(wms.PackedItem{ItemId: "i"}, nil),
+		mockObj.EXPECT().itemPackedTx(ctx, gomock.Any(), "i").Return(true, nil),
+	)
+	_, err = ss.PackItems(ctx, "s", "TRK1", []string{"i"})
+	if err != wms.ItemAlreadyPacked {
+		t.Errorf("want: %v, got: %v", wms.ItemAlreadyPacked, err)
+	}
+
+	mockObj.EXPECT().lockOrderTx(ctx, gomock.Any(), "o").Return(wms.Order{Id: "o", Status: wms.OrderOpen}, nil)
+	err = ss.CreateShipment(ctx, wms.NewShipment("o", "carrier"))
+	if err != wms.OrderNotPicked {
+		t.Errorf("want: %v, got: %v", wms.OrderNotPicked, err)
+	}
+}
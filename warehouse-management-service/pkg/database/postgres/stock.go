@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/stock.go" -destination="./pkg/database/postgres/stock_mock.go"
+type stockQueries interface {
+	listStockTx(ctx context.Context, tx *sql.Tx, sku string, warehouseId string) ([]wms.StockRow, error)
+	warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error)
+	productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error)
+}
+
+type stockQueriesImpl struct {
+	shelfBlockQueriesImpl
+	itemQueriesImpl
+}
+
+type StockService struct {
+	queries stockQueries
+	db      *sql.DB
+}
+
+func NewStockService(db *sql.DB) *StockService {
+	return &StockService{
+		queries: new(stockQueriesImpl),
+		db:      db,
+	}
+}
+
+func (s *StockService) GetProductStock(ctx context.Context, sku string) (wms.ProductStock, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wms.ProductStock{}, err
+	}
+	defer tx.Rollback()
+
+	if productExists, err := s.queries.productExistsTx(ctx, tx, sku); err != nil {
+		return wms.ProductStock{}, err
+	} else if !productExists {
+		return wms.ProductStock{}, wms.ProductDoesNotExist
+	}
+
+	rows, err := s.queries.listStockTx(ctx, tx, sku, "")
+	if err != nil {
+		return wms.ProductStock{}, err
+	}
+	return wms.NewProductStock(sku, rows), tx.Commit()
+}
+
+func (s *StockService) GetWarehouseInventory(ctx context.Context, warehouseId string) (wms.WarehouseInventory, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wms.WarehouseInventory{}, err
+	}
+	defer tx.Rollback()
+
+	if warehouseExists, err := s.queries.warehouseExistsTx(ctx, tx, warehouseId); err != nil {
+		return wms.WarehouseInventory{}, err
+	} else if !warehouseExists {
+		return wms.WarehouseInventory{}, wms.WarehouseDoesNotExist
+	}
+
+	rows, err := s.queries.listStockTx(ctx, tx, "", warehouseId)
+	if err != nil {
+		return wms.WarehouseInventory{}, err
+	}
+	return wms.NewWarehouseInventory(warehouseId, rows), tx.Commit()
+}
+
+// listStockTx counts the items on every live shelf by sku, for one sku or
+// one warehouse when the other is empty. Rows are ordered by warehouse name,
+// aisle, rack, shelf label and sku.
+func (s *stockQueriesImpl) listStockTx(ctx context.Context, tx *sql.Tx, sku string, warehouseId string) ([]wms.StockRow, error) {
+	query := `SELECT warehouse.id, warehouse.name, shelf_block.id, shelf_block.aisle, shelf_block.rack,
+			shelf.id, shelf.label, item.sku,
+			COUNT(*) FILTER (WHERE item.status <> 'in_transit'),
+			COUNT(*) FILTER (WHERE claimed.reserved),
+			COUNT(*) FILTER (WHERE NOT claimed.reserved AND item.status = 'available'
+				AND (item.expiration_date IS NULL OR item.expiration_date > now())),
+			COUNT(*) FILTER (WHERE item.status = 'in_transit')
+		FROM item
+		JOIN shelf ON shelf.id = item.shelf_id
+		JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		JOIN warehouse ON warehouse.id = shelf_block.warehouse_id
+		CROSS JOIN LATERAL (SELECT
+			EXISTS(SELECT 1 FROM reservation_item WHERE reservation_item.item_id = item.id) OR
+			EXISTS(SELECT 1 FROM pick_list_item WHERE pick_list_item.item_id = item.id) AS reserved
+		) claimed
+		WHERE ($1 = '' OR item.sku = $1) AND ($2 = '' OR warehouse.id = $2)
+			AND shelf.deleted_at IS NULL AND shelf_block.deleted_at IS NULL AND warehouse.deleted_at IS NULL
+		GROUP BY warehouse.id, shelf_block.id, shelf.id, item.sku
+		ORDER BY warehouse.name, warehouse.id, shelf_block.aisle, shelf_block.rack, shelf_block.id, shelf.label, shelf.id, item.sku`
+
+	rows, err := tx.QueryContext(ctx, query, sku, warehouseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stock []wms.StockRow
+	for rows.Next() {
+		var row wms.StockRow
+		err := rows.Scan(
+			&row.WarehouseId,
+			&row.WarehouseName,
+			&row.ShelfBlockId,
+			&row.Aisle,
+			&row.Rack,
+			&row.ShelfId,
+			&row.ShelfLabel,
+			&row.Sku,
+			&row.OnHand,
+			&row.Reserved,
+			&row.Available,
+			&row.InTransit,
+		)
+		if err != nil {
+			return nil, err
+		}
+		stock = append(stock, row)
+	}
+
+	return stock, rows.Err()
+}
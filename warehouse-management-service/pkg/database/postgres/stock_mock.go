@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/stock.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockstockQueries is a mock of stockQueries interface.
+type MockstockQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockstockQueriesMockRecorder
+}
+
+// MockstockQueriesMockRecorder is the mock recorder for MockstockQueries.
+type MockstockQueriesMockRecorder struct {
+	mock *MockstockQueries
+}
+
+// NewMockstockQueries creates a new mock instance.
+func NewMockstockQueries(ctrl *gomock.Controller) *MockstockQueries {
+	mock := &MockstockQueries{ctrl: ctrl}
+	mock.recorder = &MockstockQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstockQueries) EXPECT() *MockstockQueriesMockRecorder {
+	return m.recorder
+}
+
+// listStockTx mocks base method.
+func (m *MockstockQueries) listStockTx(ctx context.Context, tx *sql.Tx, sku, warehouseId string) ([]warehousemanagementservice.StockRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listStockTx", ctx, tx, sku, warehouseId)
+	ret0, _ := ret[0].([]warehousemanagementservice.StockRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listStockTx indicates an expected call of listStockTx.
+func (mr *MockstockQueriesMockRecorder) listStockTx(ctx, tx, sku, warehouseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listStockTx", reflect.TypeOf((*MockstockQueries)(nil).listStockTx), ctx, tx, sku, warehouseId)
+}
+
+// productExistsTx mocks base method.
+func (m *MockstockQueries) productExistsTx(ctx context.Context, tx *sql.Tx, sku string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "productExistsTx", ctx, tx, sku)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// productExistsTx indicates an expected call of productExistsTx.
+func (mr *MockstockQueriesMockRecorder) productExistsTx(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "productExistsTx", reflect.TypeOf((*MockstockQueries)(nil).productExistsTx), ctx, tx, sku)
+}
+
+// warehouseExistsTx mocks base method.
+func (m *MockstockQueries) warehouseExistsTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseExistsTx", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseExistsTx indicates an expected call of warehouseExistsTx.
+func (mr *MockstockQueriesMockRecorder) warehouseExistsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseExistsTx", reflect.TypeOf((*MockstockQueries)(nil).warehouseExistsTx), ctx, tx, id)
+}
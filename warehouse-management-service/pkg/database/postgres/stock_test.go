@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func TestListStockTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expired := testItem()
+	expired.Id = "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f12"
+	expiredOn := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expired.ExpirationDate = &expiredOn
+	reserved := testItem()
+	reserved.Id = "5e8d2f4a-8f4b-4f52-9d43-0c4d3b2a1f13"
+	for _, item := range []wms.Item{testItem(), expired, reserved} {
+		err = itemService.queries.createItemTx(ctx, tx, item)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	reservations := reservationQueriesImpl{}
+	reservation := wms.NewReservation(fixtureWarehouse.Id, testProduct.Sku, 1, "", time.Minute)
+	err = reservations.createReservationTx(ctx, tx, reservation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO reservation_item(item_id, reservation_id) VALUES ($1, $2)`, reserved.Id, reservation.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := stockQueriesImpl{}
+	want := wms.StockRow{
+		WarehouseId:   fixtureWarehouse.Id,
+		WarehouseName: fixtureWarehouse.Name,
+		ShelfBlockId:  fixtureShelfBlock.Id,
+		Aisle:         fixtureShelfBlock.Aisle,
+		Rack:          fixtureShelfBlock.Rack,
+		ShelfId:       fixtureShelf.Id,
+		ShelfLabel:    fixtureShelf.Label,
+		Sku:           testProduct.Sku,
+		StockLevel:    wms.StockLevel{OnHand: 3, Reserved: 1, Available: 1},
+	}
+	for _, filter := range [][2]string{{testProduct.Sku, ""}, {"", fixtureWarehouse.Id}} {
+		rows, err := queries.listStockTx(ctx, tx, filter[0], filter[1])
+		if err != nil || len(rows) != 1 || rows[0] != want {
+			t.Errorf("%v: want: %v, got: %v, %v", filter, want, rows, err)
+		}
+	}
+}
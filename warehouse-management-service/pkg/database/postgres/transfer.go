@@ -0,0 +1,431 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+	wms "warehouse-management-service"
+)
+
+// mockgen -source="./pkg/database/postgres/transfer.go" -destination="./pkg/database/postgres/transfer_mock.go"
+type transferQueries interface {
+	shelfWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string) (string, error)
+	lockTransferItemTx(ctx context.Context, tx *sql.Tx, itemId string) (wms.TransferItem, error)
+	lockShelfItemsTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) ([]wms.TransferItem, error)
+	checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) error
+	createTransferTx(ctx context.Context, tx *sql.Tx, transfer wms.Transfer) error
+	transferItemTx(ctx context.Context, tx *sql.Tx, item wms.TransferItem, transfer wms.Transfer) error
+	getTransferByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Transfer, error)
+	lockTransferTx(ctx context.Context, tx *sql.Tx, id string) (wms.Transfer, error)
+	receiveTransferTx(ctx context.Context, tx *sql.Tx, transfer wms.Transfer, receivedAt time.Time) error
+}
+
+type transferQueriesImpl struct {
+	itemQueriesImpl
+}
+
+type TransferService struct {
+	queries transferQueries
+	db      *sql.DB
+}
+
+func NewTransferService(db *sql.DB) *TransferService {
+	return &TransferService{
+		queries: new(transferQueriesImpl),
+		db:      db,
+	}
+}
+
+// CreateTransfer moves the selected items onto toShelfId and records each
+// move in the stock ledger. Items bound for another warehouse are placed on
+// the shelf in transit, taking up its capacity, until the transfer is
+// received.
+func (t *TransferService) CreateTransfer(ctx context.Context, selection wms.TransferSelection, toShelfId string) (wms.Transfer, error) {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+	defer tx.Rollback()
+
+	toWarehouseId, err := t.queries.shelfWarehouseTx(ctx, tx, toShelfId)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.Transfer{}, wms.InvalidShelf
+	default:
+		return wms.Transfer{}, err
+	}
+
+	var items []wms.TransferItem
+	if len(selection.ItemIds) > 0 {
+		// items are locked in id order, so that two transfers of the same
+		// items cannot each wait on an item the other has locked
+		itemIds := append([]string(nil), selection.ItemIds...)
+		sort.Strings(itemIds)
+		for _, itemId := range itemIds {
+			item, err := t.queries.lockTransferItemTx(ctx, tx, itemId)
+			switch err {
+			case nil:
+			case sql.ErrNoRows:
+				return wms.Transfer{}, wms.ItemDoesNotExist
+			default:
+				return wms.Transfer{}, err
+			}
+			items = append(items, item)
+		}
+	} else {
+		if _, err := t.queries.shelfWarehouseTx(ctx, tx, selection.FromShelfId); err == sql.ErrNoRows {
+			return wms.Transfer{}, wms.InvalidShelf
+		} else if err != nil {
+			return wms.Transfer{}, err
+		}
+		items, err = t.queries.lockShelfItemsTx(ctx, tx, selection.FromShelfId, selection.Sku, selection.Quantity)
+		if err != nil {
+			return wms.Transfer{}, err
+		}
+		if len(items) < selection.Quantity {
+			return wms.Transfer{}, &wms.InsufficientStockError{Shortages: []wms.StockShortage{{
+				Sku:       selection.Sku,
+				Requested: selection.Quantity,
+				Available: len(items),
+			}}}
+		}
+	}
+
+	transfer, err := wms.NewTransfer(items, toShelfId, toWarehouseId)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+
+	skuCounts := make(map[string]int)
+	var skus []string
+	for _, item := range items {
+		if skuCounts[item.Sku] == 0 {
+			skus = append(skus, item.Sku)
+		}
+		skuCounts[item.Sku]++
+	}
+	for _, sku := range skus {
+		err = t.queries.checkShelfCapacityTx(ctx, tx, toShelfId, sku, skuCounts[sku])
+		if err != nil {
+			return wms.Transfer{}, err
+		}
+	}
+
+	err = t.queries.createTransferTx(ctx, tx, transfer)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+	for _, item := range items {
+		err = t.queries.transferItemTx(ctx, tx, item, transfer)
+		if err != nil {
+			return wms.Transfer{}, err
+		}
+	}
+
+	transfer, err = t.queries.getTransferByIdTx(ctx, tx, transfer.Id)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+	return transfer, tx.Commit()
+}
+
+func (t *TransferService) GetTransferById(ctx context.Context, id string) (wms.Transfer, error) {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+	defer tx.Rollback()
+
+	transfer, err := t.queries.getTransferByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return transfer, tx.Commit()
+	case sql.ErrNoRows:
+		return wms.Transfer{}, wms.TransferDoesNotExist
+	default:
+		return wms.Transfer{}, err
+	}
+}
+
+// ReceiveTransfer makes the items of a transfer between warehouses
+// available on their new shelf and completes the transfer.
+func (t *TransferService) ReceiveTransfer(ctx context.Context, id string) (wms.Transfer, error) {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+	defer tx.Rollback()
+
+	transfer, err := t.queries.lockTransferTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.Transfer{}, wms.TransferDoesNotExist
+	default:
+		return wms.Transfer{}, err
+	}
+	if transfer.Status != wms.TransferInTransit {
+		return wms.Transfer{}, wms.TransferNotInTransit
+	}
+
+	err = t.queries.receiveTransferTx(ctx, tx, transfer, time.Now().UTC())
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+
+	transfer, err = t.queries.getTransferByIdTx(ctx, tx, id)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+	return transfer, tx.Commit()
+}
+
+func (i *itemQueriesImpl) shelfWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string) (string, error) {
+	query := `SELECT shelf_block.warehouse_id FROM shelf JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		WHERE shelf.id = $1 AND shelf.deleted_at IS NULL AND shelf_block.deleted_at IS NULL`
+
+	var warehouseId string
+	row := tx.QueryRowContext(ctx, query, shelfId)
+	err := row.Scan(&warehouseId)
+	if err != nil {
+		return "", err
+	}
+	return warehouseId, nil
+}
+
+// transferItemColumns are the columns scanTransferItem reads, from item
+// joined to its shelf and shelf block.
+const transferItemColumns = `item.id, item.sku, item.shelf_id, shelf_block.warehouse_id, item.status,
+	EXISTS(SELECT 1 FROM reservation_item WHERE reservation_item.item_id = item.id) OR
+	EXISTS(SELECT 1 FROM pick_list_item WHERE pick_list_item.item_id = item.id)`
+
+func scanTransferItem(scan func(dest ...interface{}) error) (wms.TransferItem, error) {
+	var item wms.TransferItem
+	err := scan(&item.Id, &item.Sku, &item.ShelfId, &item.WarehouseId, &item.Status, &item.Claimed)
+	if err != nil {
+		return wms.TransferItem{}, err
+	}
+	return item, nil
+}
+
+func (i *itemQueriesImpl) lockTransferItemTx(ctx context.Context, tx *sql.Tx, itemId string) (wms.TransferItem, error) {
+	query := `SELECT ` + transferItemColumns + `
+		FROM item
+		JOIN shelf ON shelf.id = item.shelf_id
+		JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		WHERE item.id = $1
+		FOR UPDATE OF item`
+
+	return scanTransferItem(tx.QueryRowContext(ctx, query, itemId).Scan)
+}
+
+// lockShelfItemsTx locks up to quantity available, unclaimed items of sku on
+// a shelf, soonest expiring or longest stored first, skipping items locked
+// by someone else.
+func (t *transferQueriesImpl) lockShelfItemsTx(ctx context.Context, tx *sql.Tx, shelfId string, sku string, quantity int) ([]wms.TransferItem, error) {
+	query := `SELECT ` + transferItemColumns + `
+		FROM item
+		JOIN shelf ON shelf.id = item.shelf_id
+		JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+		WHERE item.shelf_id = $1 AND item.sku = $2 AND item.status = 'available'
+			AND NOT EXISTS(SELECT 1 FROM reservation_item WHERE reservation_item.item_id = item.id)
+			AND NOT EXISTS(SELECT 1 FROM pick_list_item WHERE pick_list_item.item_id = item.id)
+		ORDER BY item.expiration_date ASC NULLS LAST, item.received_on, item.id
+		LIMIT $3
+		FOR UPDATE OF item SKIP LOCKED`
+
+	rows, err := tx.QueryContext(ctx, query, shelfId, sku, quantity)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []wms.TransferItem
+	for rows.Next() {
+		item, err := scanTransferItem(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (t *transferQueriesImpl) createTransferTx(ctx context.Context, tx *sql.Tx, transfer wms.Transfer) error {
+	query := `INSERT INTO transfer(id, from_warehouse_id, to_warehouse_id, to_shelf_id, status, created_at, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		transfer.Id,
+		transfer.FromWarehouseId,
+		transfer.ToWarehouseId,
+		transfer.ToShelfId,
+		transfer.Status,
+		transfer.CreatedAt,
+		transfer.ReceivedAt,
+	)
+	return err
+}
+
+// transferItemTx puts an item on the transfer's shelf, in transit if the
+// transfer is, and records the move.
+func (t *transferQueriesImpl) transferItemTx(ctx context.Context, tx *sql.Tx, item wms.TransferItem, transfer wms.Transfer) error {
+	status, kind := wms.ItemAvailable, wms.MovementMove
+	if transfer.Status == wms.TransferInTransit {
+		status, kind = wms.ItemInTransit, wms.MovementDispatch
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		`UPDATE item SET shelf_id = $1, status = $2, transfer_id = $3 WHERE id = $4`,
+		transfer.ToShelfId,
+		status,
+		transfer.Id,
+		item.Id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return recordMovementTx(ctx, tx, wms.StockMovement{
+		ItemId:      item.Id,
+		Sku:         item.Sku,
+		FromShelfId: item.ShelfId,
+		ToShelfId:   transfer.ToShelfId,
+		TransferId:  transfer.Id,
+		Kind:        kind,
+	})
+}
+
+// recordMovementTx adds a movement to the stock ledger, on behalf of the
+// actor of ctx.
+func recordMovementTx(ctx context.Context, tx *sql.Tx, movement wms.StockMovement) error {
+	query := `INSERT INTO stock_movement(item_id, sku, from_shelf_id, to_shelf_id, transfer_id, kind, actor)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, ''))`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		movement.ItemId,
+		movement.Sku,
+		movement.FromShelfId,
+		movement.ToShelfId,
+		movement.TransferId,
+		movement.Kind,
+		wms.ActorFromContext(ctx),
+	)
+	return err
+}
+
+func (t *transferQueriesImpl) getTransferByIdTx(ctx context.Context, tx *sql.Tx, id string) (wms.Transfer, error) {
+	transfer, err := t.scanTransfer(tx.QueryRowContext(ctx, `SELECT `+transferColumns+` FROM transfer WHERE id = $1`, id))
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+
+	query := `SELECT id, item_id, sku, COALESCE(from_shelf_id, ''), COALESCE(to_shelf_id, ''), COALESCE(transfer_id, ''), kind, COALESCE(actor, ''), moved_at
+		FROM stock_movement WHERE transfer_id = $1 ORDER BY id`
+
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+	defer rows.Close()
+
+	transfer.Movements = []wms.StockMovement{}
+	for rows.Next() {
+		var movement wms.StockMovement
+		err := rows.Scan(
+			&movement.Id,
+			&movement.ItemId,
+			&movement.Sku,
+			&movement.FromShelfId,
+			&movement.ToShelfId,
+			&movement.TransferId,
+			&movement.Kind,
+			&movement.Actor,
+			&movement.MovedAt,
+		)
+		if err != nil {
+			return wms.Transfer{}, err
+		}
+		transfer.Movements = append(transfer.Movements, movement)
+	}
+
+	return transfer, rows.Err()
+}
+
+// lockTransferTx returns a transfer without its movements and locks its
+// row, so that it is received only once.
+func (t *transferQueriesImpl) lockTransferTx(ctx context.Context, tx *sql.Tx, id string) (wms.Transfer, error) {
+	return t.scanTransfer(tx.QueryRowContext(ctx, `SELECT `+transferColumns+` FROM transfer WHERE id = $1 FOR UPDATE`, id))
+}
+
+const transferColumns = `id, from_warehouse_id, to_warehouse_id, to_shelf_id, status, created_at, received_at`
+
+func (t *transferQueriesImpl) scanTransfer(row *sql.Row) (wms.Transfer, error) {
+	var transfer wms.Transfer
+	var receivedAt sql.NullTime
+	err := row.Scan(
+		&transfer.Id,
+		&transfer.FromWarehouseId,
+		&transfer.ToWarehouseId,
+		&transfer.ToShelfId,
+		&transfer.Status,
+		&transfer.CreatedAt,
+		&receivedAt,
+	)
+	if err != nil {
+		return wms.Transfer{}, err
+	}
+	if receivedAt.Valid {
+		transfer.ReceivedAt = &receivedAt.Time
+	}
+	return transfer, nil
+}
+
+// receiveTransferTx makes the items still in transit under a transfer
+// available, records their arrival and completes the transfer.
+func (t *transferQueriesImpl) receiveTransferTx(ctx context.Context, tx *sql.Tx, transfer wms.Transfer, receivedAt time.Time) error {
+	query := `UPDATE item SET status = $1 WHERE transfer_id = $2 AND status = $3 RETURNING id, sku`
+
+	rows, err := tx.QueryContext(ctx, query, wms.ItemAvailable, transfer.Id, wms.ItemInTransit)
+	if err != nil {
+		return err
+	}
+	var movements []wms.StockMovement
+	for rows.Next() {
+		movement := wms.StockMovement{ToShelfId: transfer.ToShelfId, TransferId: transfer.Id, Kind: wms.MovementReceive}
+		err := rows.Scan(&movement.ItemId, &movement.Sku)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		movements = append(movements, movement)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, movement := range movements {
+		err = recordMovementTx(ctx, tx, movement)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE transfer SET status = $1, received_at = $2 WHERE id = $3`,
+		wms.TransferCompleted,
+		receivedAt,
+		transfer.Id,
+	)
+	return err
+}
@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/database/postgres/transfer.go
+
+// Package mock_postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+	warehousemanagementservice "warehouse-management-service"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocktransferQueries is a mock of transferQueries interface.
+type MocktransferQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransferQueriesMockRecorder
+}
+
+// MocktransferQueriesMockRecorder is the mock recorder for MocktransferQueries.
+type MocktransferQueriesMockRecorder struct {
+	mock *MocktransferQueries
+}
+
+// NewMocktransferQueries creates a new mock instance.
+func NewMocktransferQueries(ctrl *gomock.Controller) *MocktransferQueries {
+	mock := &MocktransferQueries{ctrl: ctrl}
+	mock.recorder = &MocktransferQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransferQueries) EXPECT() *MocktransferQueriesMockRecorder {
+	return m.recorder
+}
+
+// checkShelfCapacityTx mocks base method.
+func (m *MocktransferQueries) checkShelfCapacityTx(ctx context.Context, tx *sql.Tx, shelfId, sku string, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkShelfCapacityTx", ctx, tx, shelfId, sku, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// checkShelfCapacityTx indicates an expected call of checkShelfCapacityTx.
+func (mr *MocktransferQueriesMockRecorder) checkShelfCapacityTx(ctx, tx, shelfId, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkShelfCapacityTx", reflect.TypeOf((*MocktransferQueries)(nil).checkShelfCapacityTx), ctx, tx, shelfId, sku, quantity)
+}
+
+// createTransferTx mocks base method.
+func (m *MocktransferQueries) createTransferTx(ctx context.Context, tx *sql.Tx, transfer warehousemanagementservice.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createTransferTx", ctx, tx, transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createTransferTx indicates an expected call of createTransferTx.
+func (mr *MocktransferQueriesMockRecorder) createTransferTx(ctx, tx, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createTransferTx", reflect.TypeOf((*MocktransferQueries)(nil).createTransferTx), ctx, tx, transfer)
+}
+
+// getTransferByIdTx mocks base method.
+func (m *MocktransferQueries) getTransferByIdTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getTransferByIdTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getTransferByIdTx indicates an expected call of getTransferByIdTx.
+func (mr *MocktransferQueriesMockRecorder) getTransferByIdTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getTransferByIdTx", reflect.TypeOf((*MocktransferQueries)(nil).getTransferByIdTx), ctx, tx, id)
+}
+
+// lockShelfItemsTx mocks base method.
+func (m *MocktransferQueries) lockShelfItemsTx(ctx context.Context, tx *sql.Tx, shelfId, sku string, quantity int) ([]warehousemanagementservice.TransferItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockShelfItemsTx", ctx, tx, shelfId, sku, quantity)
+	ret0, _ := ret[0].([]warehousemanagementservice.TransferItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockShelfItemsTx indicates an expected call of lockShelfItemsTx.
+func (mr *MocktransferQueriesMockRecorder) lockShelfItemsTx(ctx, tx, shelfId, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockShelfItemsTx", reflect.TypeOf((*MocktransferQueries)(nil).lockShelfItemsTx), ctx, tx, shelfId, sku, quantity)
+}
+
+// lockTransferItemTx mocks base method.
+func (m *MocktransferQueries) lockTransferItemTx(ctx context.Context, tx *sql.Tx, itemId string) (warehousemanagementservice.TransferItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockTransferItemTx", ctx, tx, itemId)
+	ret0, _ := ret[0].(warehousemanagementservice.TransferItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockTransferItemTx indicates an expected call of lockTransferItemTx.
+func (mr *MocktransferQueriesMockRecorder) lockTransferItemTx(ctx, tx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockTransferItemTx", reflect.TypeOf((*MocktransferQueries)(nil).lockTransferItemTx), ctx, tx, itemId)
+}
+
+// lockTransferTx mocks base method.
+func (m *MocktransferQueries) lockTransferTx(ctx context.Context, tx *sql.Tx, id string) (warehousemanagementservice.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockTransferTx", ctx, tx, id)
+	ret0, _ := ret[0].(warehousemanagementservice.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockTransferTx indicates an expected call of lockTransferTx.
+func (mr *MocktransferQueriesMockRecorder) lockTransferTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockTransferTx", reflect.TypeOf((*MocktransferQueries)(nil).lockTransferTx), ctx, tx, id)
+}
+
+// receiveTransferTx mocks base method.
+func (m *MocktransferQueries) receiveTransferTx(ctx context.Context, tx *sql.Tx, transfer warehousemanagementservice.Transfer, receivedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "receiveTransferTx", ctx, tx, transfer, receivedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// receiveTransferTx indicates an expected call of receiveTransferTx.
+func (mr *MocktransferQueriesMockRecorder) receiveTransferTx(ctx, tx, transfer, receivedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "receiveTransferTx", reflect.TypeOf((*MocktransferQueries)(nil).receiveTransferTx), ctx, tx, transfer, receivedAt)
+}
+
+// shelfWarehouseTx mocks base method.
+func (m *MocktransferQueries) shelfWarehouseTx(ctx context.Context, tx *sql.Tx, shelfId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "shelfWarehouseTx", ctx, tx, shelfId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// shelfWarehouseTx indicates an expected call of shelfWarehouseTx.
+func (mr *MocktransferQueriesMockRecorder) shelfWarehouseTx(ctx, tx, shelfId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "shelfWarehouseTx", reflect.TypeOf((*MocktransferQueries)(nil).shelfWarehouseTx), ctx, tx, shelfId)
+}
+
+// transferItemTx mocks base method.
+func (m *MocktransferQueries) transferItemTx(ctx context.Context, tx *sql.Tx, item warehousemanagementservice.TransferItem, transfer warehousemanagementservice.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "transferItemTx", ctx, tx, item, transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// transferItemTx indicates an expected call of transferItemTx.
+func (mr *MocktransferQueriesMockRecorder) transferItemTx(ctx, tx, item, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "transferItemTx", reflect.TypeOf((*MocktransferQueries)(nil).transferItemTx), ctx, tx, item, transfer)
+}
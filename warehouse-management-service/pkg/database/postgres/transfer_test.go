@@ -0,0 +1,325 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+func TestTransferBetweenWarehouses(t *testing.T) {
+	ctx := wms.WithActor(context.Background(), "alice")
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	destination := wms.Warehouse{
+		Id:        "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e11",
+		Name:      "transfer_destination",
+		Latitude:  13.0827,
+		Longitude: 80.2707,
+	}
+	err = warehouseService.queries.createWarehouseTx(ctx, tx, &destination)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	block := wms.ShelfBlock{
+		Id:          "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e12",
+		Aisle:       "1",
+		Rack:        "1",
+		StorageType: fixtureShelfBlock.StorageType,
+		WarehouseId: destination.Id,
+	}
+	err = shelfBlockService.queries.createShelfBlockTx(ctx, tx, block)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	shelf := wms.Shelf{Id: "3c1d4ba0-9a5e-4a8d-8d4c-7d9b3c8f0e13", Label: "1A", Section: "A", Level: "1", ShelfBlockId: block.Id}
+	err = shelfService.queries.createShelfTx(ctx, tx, shelf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := transferQueriesImpl{}
+	warehouseId, err := queries.shelfWarehouseTx(ctx, tx, shelf.Id)
+	if err != nil || warehouseId != destination.Id {
+		t.Errorf("want shelf in warehouse %s, got: %v, %v", destination.Id, warehouseId, err)
+	}
+
+	items, err := queries.lockShelfItemsTx(ctx, tx, fixtureShelf.Id, testProduct.Sku, 2)
+	if err != nil || len(items) != 1 || items[0].Id != testItem().Id || items[0].Claimed {
+		t.Errorf("want item %s unclaimed, got: %v, %v", testItem().Id, items, err)
+		return
+	}
+
+	transfer, err := wms.NewTransfer(items, shelf.Id, destination.Id)
+	if err != nil || transfer.Status != wms.TransferInTransit {
+		t.Errorf("want a transfer in transit, got: %v, %v", transfer, err)
+		return
+	}
+	err = queries.createTransferTx(ctx, tx, transfer)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.transferItemTx(ctx, tx, items[0], transfer)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	item, err := itemService.queries.getItemByIdTx(ctx, tx, testItem().Id)
+	if err != nil || item.ShelfId != shelf.Id || item.Status != wms.ItemInTransit {
+		t.Errorf("want item in transit to shelf %s, got: %v, %v", shelf.Id, item, err)
+	}
+
+	err = queries.receiveTransferTx(ctx, tx, transfer, time.Now().UTC())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	transfer, err = queries.getTransferByIdTx(ctx, tx, transfer.Id)
+	if err != nil || transfer.Status != wms.TransferCompleted || transfer.ReceivedAt == nil {
+		t.Errorf("want a completed transfer, got: %v, %v", transfer, err)
+		return
+	}
+	wantKinds := []string{wms.MovementDispatch, wms.MovementReceive}
+	if len(transfer.Movements) != len(wantKinds) {
+		t.Errorf("want: %d movements, got: %v", len(wantKinds), transfer.Movements)
+		return
+	}
+	for i, movement := range transfer.Movements {
+		if movement.Kind != wantKinds[i] || movement.ItemId != testItem().Id || movement.Actor != "alice" {
+			t.Errorf("want: %s of %s by alice, got: %v", wantKinds[i], testItem().Id, movement)
+		}
+	}
+	if transfer.Movements[0].FromShelfId != fixtureShelf.Id {
+		t.Errorf("want dispatch from shelf %s, got: %v", fixtureShelf.Id, transfer.Movements[0])
+	}
+
+	item, err = itemService.queries.getItemByIdTx(ctx, tx, testItem().Id)
+	if err != nil || item.Status != wms.ItemAvailable {
+		t.Errorf("want a received item available, got: %v, %v", item, err)
+	}
+
+	// once the item is gone, the completed transfer into its shelf does not
+	// keep the destination from being purged
+	err = itemService.queries.deleteItemTx(ctx, tx, testItem().Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = warehouseService.queries.cascadeDeleteWarehouseTx(ctx, tx, destination.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = warehouseService.queries.purgeWarehouseTx(ctx, tx, destination.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = queries.getTransferByIdTx(ctx, tx, transfer.Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("want: %v, got: %v", sql.ErrNoRows, err)
+	}
+}
+
+func TestReceiveTransferConcurrently(t *testing.T) {
+	ctx := wms.WithActor(context.Background(), "alice")
+	cleanup, ok := commitFixtures(t)
+	if !ok {
+		return
+	}
+	defer cleanup()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertDestinationFixtures(t, tx) {
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	queries := transferQueriesImpl{}
+	items, err := queries.lockShelfItemsTx(ctx, tx, fixtureShelf.Id, testProduct.Sku, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	transfer, err := wms.NewTransfer(items, fixtureDestinationShelf.Id, fixtureDestination.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.createTransferTx(ctx, tx, transfer)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = queries.transferItemTx(ctx, tx, items[0], transfer)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	first, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer first.Rollback()
+
+	locked, err := queries.lockTransferTx(ctx, first, transfer.Id)
+	if err != nil || locked.Status != wms.TransferInTransit {
+		t.Errorf("want a transfer in transit, got: %v, %v", locked, err)
+		return
+	}
+	err = queries.receiveTransferTx(ctx, first, locked, time.Now().UTC())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the second receipt waits on the transfer first has locked, and has to
+	// find it completed once first commits
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewTransferService(warehouseService.db).ReceiveTransfer(ctx, transfer.Id)
+		done <- err
+	}()
+	awaitBlocked(t)
+
+	err = first.Commit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = <-done
+	if err != wms.TransferNotInTransit {
+		t.Errorf("want: %v, got: %v", wms.TransferNotInTransit, err)
+	}
+
+	tx, err = warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+	transfer, err = queries.getTransferByIdTx(ctx, tx, transfer.Id)
+	if err != nil || len(transfer.Movements) != 2 || transfer.Movements[1].Kind != wms.MovementReceive {
+		t.Errorf("want the item dispatched and received once, got: %v, %v", transfer.Movements, err)
+	}
+}
+
+func TestCreateTransfer(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMocktransferQueries(mockCtrl)
+
+	ts := TransferService{db: warehouseService.db, queries: mockObj}
+	item := wms.TransferItem{Id: "i", Sku: "a", ShelfId: "s1", WarehouseId: "w", Status: wms.ItemAvailable}
+
+	mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "s2").Return("", sql.ErrNoRows)
+	_, err := ts.CreateTransfer(ctx, wms.TransferSelection{ItemIds: []string{"i"}}, "s2")
+	if err != wms.InvalidShelf {
+		t.Errorf("want: %v, got: %v", wms.InvalidShelf, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "s2").Return("w", nil),
+		mockObj.EXPECT().lockTransferItemTx(ctx, gomock.Any(), "i").Return(wms.TransferItem{}, sql.ErrNoRows),
+	)
+	_, err = ts.CreateTransfer(ctx, wms.TransferSelection{ItemIds: []string{"i"}}, "s2")
+	if err != wms.ItemDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.ItemDoesNotExist, err)
+	}
+
+	// items are locked in id order, whatever order they are listed in
+	gomock.InOrder(
+		mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "s2").Return("w", nil),
+		mockObj.EXPECT().lockTransferItemTx(ctx, gomock.Any(), "h").Return(item, nil),
+		mockObj.EXPECT().lockTransferItemTx(ctx, gomock.Any(), "i").Return(wms.TransferItem{}, sql.ErrNoRows),
+	)
+	_, err = ts.CreateTransfer(ctx, wms.TransferSelection{ItemIds: []string{"i", "h"}}, "s2")
+	if err != wms.ItemDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.ItemDoesNotExist, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "s2").Return("w", nil),
+		mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "s1").Return("w", nil),
+		mockObj.EXPECT().lockShelfItemsTx(ctx, gomock.Any(), "s1", "a", 2).Return([]wms.TransferItem{item}, nil),
+	)
+	_, err = ts.CreateTransfer(ctx, wms.TransferSelection{FromShelfId: "s1", Sku: "a", Quantity: 2}, "s2")
+	if insufficient, ok := err.(*wms.InsufficientStockError); !ok || insufficient.Shortages[0].Available != 1 {
+		t.Errorf("want 1 of 2 items available, got: %v", err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "s2").Return("w", nil),
+		mockObj.EXPECT().lockTransferItemTx(ctx, gomock.Any(), "i").Return(item, nil),
+		mockObj.EXPECT().checkShelfCapacityTx(ctx, gomock.Any(), "s2", "a", 1).Return(wms.ShelfOverCapacity),
+	)
+	_, err = ts.CreateTransfer(ctx, wms.TransferSelection{ItemIds: []string{"i"}}, "s2")
+	if err != wms.ShelfOverCapacity {
+		t.Errorf("want: %v, got: %v", wms.ShelfOverCapacity, err)
+	}
+
+	gomock.InOrder(
+		mockObj.EXPECT().shelfWarehouseTx(ctx, gomock.Any(), "s2").Return("w", nil),
+		mockObj.EXPECT().lockTransferItemTx(ctx, gomock.Any(), "i").Return(item, nil),
+		mockObj.EXPECT().checkShelfCapacityTx(ctx, gomock.Any(), "s2", "a", 1).Return(nil),
+		mockObj.EXPECT().createTransferTx(ctx, gomock.Any(), gomock.Any()).Return(nil),
+		mockObj.EXPECT().transferItemTx(ctx, gomock.Any(), item, gomock.Any()).Return(nil),
+		mockObj.EXPECT().getTransferByIdTx(ctx, gomock.Any(), gomock.Any()).Return(wms.Transfer{Id: "t", Status: wms.TransferCompleted}, nil),
+	)
+	transfer, err := ts.CreateTransfer(ctx, wms.TransferSelection{ItemIds: []string{"i"}}, "s2")
+	if err != nil || transfer.Status != wms.TransferCompleted {
+		t.Errorf("want a completed transfer, got: %v, %v", transfer, err)
+	}
+
+	mockObj.EXPECT().lockTransferTx(ctx, gomock.Any(), "t").Return(wms.Transfer{Id: "t", Status: wms.TransferCompleted}, nil)
+	_, err = ts.ReceiveTransfer(ctx, "t")
+	if err != wms.TransferNotInTransit {
+		t.Errorf("want: %v, got: %v", wms.TransferNotInTransit, err)
+	}
+}
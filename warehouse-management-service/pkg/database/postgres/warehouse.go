@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 	wms "warehouse-management-service"
 
 	_ "github.com/lib/pq"
@@ -15,6 +16,16 @@ type warehouseQueries interface {
 	getWarehouseByIdTx(ctx context.Context, tx *sql.Tx, id string) (*wms.Warehouse, error)
 	updateWarehouseTx(ctx context.Context, tx *sql.Tx, warehouse *wms.Warehouse) error
 	deleteWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error
+	lockWarehouseTx(ctx context.Context, tx *sql.Tx, id string, version int) error
+	warehouseDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]wms.Dependent, error)
+	cascadeDeleteWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error
+	restoreWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error
+	purgeWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error
+	listWarehousesTx(ctx context.Context, tx *sql.Tx, after string, limit int) ([]wms.Warehouse, error)
+	nearestWarehousesTx(ctx context.Context, tx *sql.Tx, latitude, longitude float64, radiusInKm *float64, limit int) ([]wms.NearbyWarehouse, error)
+	getWarehouseShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]wms.ShelfBlock, error)
+	getWarehouseShelvesTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]wms.Shelf, error)
+	countWarehouseItemsByShelfTx(ctx context.Context, tx *sql.Tx, warehouseId string) (map[string]int, error)
 }
 
 type queriesImpl struct{}
@@ -25,6 +36,10 @@ type WarehouseService struct {
 }
 
 var RowDoesNotExist = errors.New("postgres: queried row does not exist")
+var VersionMismatch = errors.New("postgres: row version does not match")
+
+// mean radius of the earth, used for great-circle distances
+const earthRadiusInKm = 6371.0
 
 func NewWarehouseService(db *sql.DB) *WarehouseService {
 	return &WarehouseService{
@@ -79,35 +94,186 @@ func (w *WarehouseService) UpdateWarehouse(ctx context.Context, warehouse *wms.W
 		return tx.Commit()
 	case RowDoesNotExist:
 		return wms.WarehouseDoesNotExist
+	case VersionMismatch:
+		return wms.VersionMismatch
 	default:
 		return err
 	}
 }
 
-func (w *WarehouseService) DeleteWarehouse(ctx context.Context, id string) error {
+// DeleteWarehouse soft-deletes a warehouse, it can be brought back with
+// RestoreWarehouse until it is purged. Unless cascade is set, a warehouse
+// that still has shelf blocks is left alone and a *wms.DependentsError
+// listing them is returned. With cascade, the shelf blocks and their shelves
+// are deleted along with the warehouse, items stay on their shelves.
+// wms.VersionMismatch is returned if the warehouse is no longer at version.
+func (w *WarehouseService) DeleteWarehouse(ctx context.Context, id string, version int, cascade bool) error {
 	tx, err := w.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	err = w.queries.deleteWarehouseTx(ctx, tx, id)
+	err = w.queries.lockWarehouseTx(ctx, tx, id, version)
+	if err == nil {
+		err = w.deleteLockedTx(ctx, tx, id, cascade)
+	}
 	switch err {
 	case nil:
 		return tx.Commit()
 	case RowDoesNotExist:
 		return wms.WarehouseDoesNotExist
+	case VersionMismatch:
+		return wms.VersionMismatch
 	default:
 		return err
 	}
 }
 
+func (w *WarehouseService) deleteLockedTx(ctx context.Context, tx *sql.Tx, id string, cascade bool) error {
+	if cascade {
+		return w.queries.cascadeDeleteWarehouseTx(ctx, tx, id)
+	}
+
+	dependents, err := w.queries.warehouseDependentsTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return &wms.DependentsError{Dependents: dependents}
+	}
+
+	return w.queries.deleteWarehouseTx(ctx, tx, id)
+}
+
+// RestoreWarehouse undoes DeleteWarehouse, along with the shelf blocks and
+// shelves that were deleted with it.
+func (w *WarehouseService) RestoreWarehouse(ctx context.Context, id string) error {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = w.queries.restoreWarehouseTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.WarehouseDoesNotExist
+	default:
+		return err
+	}
+}
+
+// PurgeWarehouse permanently deletes a soft-deleted warehouse, along with its
+// shelf blocks, their shelves and the transfers into them. It refuses while
+// the shelves hold items, transfers to or from the warehouse are in transit,
+// or cycle counts of the warehouse would go with it.
+func (w *WarehouseService) PurgeWarehouse(ctx context.Context, id string) error {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = w.queries.purgeWarehouseTx(ctx, tx, id)
+	switch err {
+	case nil:
+		return tx.Commit()
+	case RowDoesNotExist:
+		return wms.WarehouseDoesNotExist
+	default:
+		return err
+	}
+}
+
+// ListWarehouses returns up to limit warehouses ordered by id, starting
+// after the warehouse with id after. An empty after starts from the beginning.
+func (w *WarehouseService) ListWarehouses(ctx context.Context, after string, limit int) ([]wms.Warehouse, error) {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	warehouses, err := w.queries.listWarehousesTx(ctx, tx, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return warehouses, tx.Commit()
+}
+
+// NearestWarehouses returns up to limit warehouses ordered by their
+// great-circle distance from the given point. A nil radiusInKm does not
+// restrict the search.
+func (w *WarehouseService) NearestWarehouses(
+	ctx context.Context,
+	latitude, longitude float64,
+	radiusInKm *float64,
+	limit int,
+) ([]wms.NearbyWarehouse, error) {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	warehouses, err := w.queries.nearestWarehousesTx(ctx, tx, latitude, longitude, radiusInKm, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return warehouses, tx.Commit()
+}
+
+// GetWarehouseLayout loads a warehouse with all of its shelf blocks and
+// shelves in a fixed number of queries, and the number of items on each
+// shelf when includeItemCounts is set.
+func (w *WarehouseService) GetWarehouseLayout(ctx context.Context, id string, includeItemCounts bool) (wms.WarehouseLayout, error) {
+	tx, err := w.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return wms.WarehouseLayout{}, err
+	}
+	defer tx.Rollback()
+
+	warehouse, err := w.queries.getWarehouseByIdTx(ctx, tx, id)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return wms.WarehouseLayout{}, wms.WarehouseDoesNotExist
+	default:
+		return wms.WarehouseLayout{}, err
+	}
+
+	shelfBlocks, err := w.queries.getWarehouseShelfBlocksTx(ctx, tx, id)
+	if err != nil {
+		return wms.WarehouseLayout{}, err
+	}
+
+	shelves, err := w.queries.getWarehouseShelvesTx(ctx, tx, id)
+	if err != nil {
+		return wms.WarehouseLayout{}, err
+	}
+
+	var itemCounts map[string]int
+	if includeItemCounts {
+		itemCounts, err = w.queries.countWarehouseItemsByShelfTx(ctx, tx, id)
+		if err != nil {
+			return wms.WarehouseLayout{}, err
+		}
+	}
+
+	return wms.NewWarehouseLayout(*warehouse, shelfBlocks, shelves, itemCounts), tx.Commit()
+}
+
 func (q *queriesImpl) getWarehouseByIdTx(ctx context.Context, tx *sql.Tx, id string) (*wms.Warehouse, error) {
-	row := tx.QueryRowContext(ctx, `SELECT id, name, geolocation[0], geolocation[1] FROM warehouse WHERE id=$1`, id)
+	row := tx.QueryRowContext(ctx, `SELECT id, name, geolocation[0], geolocation[1], version FROM warehouse WHERE id=$1 AND deleted_at IS NULL`, id)
 
 	var warehouse wms.Warehouse
 
-	err := row.Scan(&warehouse.Id, &warehouse.Name, &warehouse.Longitude, &warehouse.Latitude)
+	err := row.Scan(&warehouse.Id, &warehouse.Name, &warehouse.Longitude, &warehouse.Latitude, &warehouse.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -116,10 +282,10 @@ func (q *queriesImpl) getWarehouseByIdTx(ctx context.Context, tx *sql.Tx, id str
 }
 
 func (q *queriesImpl) createWarehouseTx(ctx context.Context, tx *sql.Tx, warehouse *wms.Warehouse) error {
-	query := `INSERT INTO warehouse (id, name, geolocation) VALUES ($1, $2, point($3, $4));`
+	query := `INSERT INTO warehouse (id, name, geolocation) VALUES ($1, $2, point($3, $4))`
 
-	_, err := tx.ExecContext(ctx,
-		query,
+	_, err := auditedExecTx(ctx, tx, wms.EntityWarehouse, wms.AuditCreate,
+		query, "",
 		warehouse.Id,
 		warehouse.Name,
 		warehouse.Longitude,
@@ -131,11 +297,19 @@ func (q *queriesImpl) createWarehouseTx(ctx context.Context, tx *sql.Tx, warehou
 	return nil
 }
 
+// updateWarehouseTx locks the warehouse row and checks its version before
+// updating it, so that of two updates made against the same version only the
+// first one succeeds.
 func (q *queriesImpl) updateWarehouseTx(ctx context.Context, tx *sql.Tx, warehouse *wms.Warehouse) error {
-	query := `UPDATE warehouse SET name = $1, geolocation = point($2, $3) WHERE id = $4;`
+	err := lockVersionTx(ctx, tx, "warehouse", warehouse.Id, warehouse.Version)
+	if err != nil {
+		return err
+	}
 
-	result, err := tx.ExecContext(ctx,
-		query,
+	query := `UPDATE warehouse SET name = $1, geolocation = point($2, $3), version = version + 1`
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityWarehouse, wms.AuditUpdate,
+		query, "id = $4 AND deleted_at IS NULL",
 		warehouse.Name,
 		warehouse.Longitude,
 		warehouse.Latitude,
@@ -143,6 +317,17 @@ func (q *queriesImpl) updateWarehouseTx(ctx context.Context, tx *sql.Tx, warehou
 	if err != nil {
 		return err
 	}
+
+	return nil
+}
+
+func (q *queriesImpl) deleteWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error {
+	query := `UPDATE warehouse SET deleted_at = now(), version = version + 1`
+
+	result, err := auditedExecTx(ctx, tx, wms.EntityWarehouse, wms.AuditDelete, query, "id=$1 AND deleted_at IS NULL", id)
+	if err != nil {
+		return err
+	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
@@ -154,20 +339,270 @@ func (q *queriesImpl) updateWarehouseTx(ctx context.Context, tx *sql.Tx, warehou
 	return nil
 }
 
-func (q *queriesImpl) deleteWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error {
-	query := `DELETE FROM warehouse WHERE id=$1`
+func (q *queriesImpl) listWarehousesTx(ctx context.Context, tx *sql.Tx, after string, limit int) ([]wms.Warehouse, error) {
+	query := `SELECT id, name, geolocation[0], geolocation[1], version FROM warehouse WHERE id > $1 AND deleted_at IS NULL ORDER BY id LIMIT $2`
+
+	rows, err := tx.QueryContext(ctx, query, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	warehouses := make([]wms.Warehouse, 0, limit)
+	for rows.Next() {
+		var warehouse wms.Warehouse
+		err := rows.Scan(&warehouse.Id, &warehouse.Name, &warehouse.Longitude, &warehouse.Latitude, &warehouse.Version)
+		if err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+
+	return warehouses, rows.Err()
+}
+
+func (q *queriesImpl) nearestWarehousesTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	latitude, longitude float64,
+	radiusInKm *float64,
+	limit int,
+) ([]wms.NearbyWarehouse, error) {
+	// haversine formula, geolocation is stored as point(longitude, latitude)
+	query := `SELECT id, name, latitude, longitude, version, distance FROM (
+			SELECT id, name, geolocation[1] AS latitude, geolocation[0] AS longitude, version,
+				2 * $5::float8 * asin(least(1, sqrt(
+					power(sin(radians(geolocation[1] - $1) / 2), 2) +
+					cos(radians($1)) * cos(radians(geolocation[1])) *
+					power(sin(radians(geolocation[0] - $2) / 2), 2)
+				))) AS distance
+			FROM warehouse WHERE geolocation IS NOT NULL AND deleted_at IS NULL
+		) AS nearby
+		WHERE $3::float8 IS NULL OR distance <= $3
+		ORDER BY distance, id LIMIT $4`
+
+	rows, err := tx.QueryContext(ctx, query, latitude, longitude, radiusInKm, limit, earthRadiusInKm)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	warehouses := make([]wms.NearbyWarehouse, 0, limit)
+	for rows.Next() {
+		var warehouse wms.NearbyWarehouse
+		err := rows.Scan(
+			&warehouse.Id,
+			&warehouse.Name,
+			&warehouse.Latitude,
+			&warehouse.Longitude,
+			&warehouse.Version,
+			&warehouse.DistanceInKm,
+		)
+		if err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+
+	return warehouses, rows.Err()
+}
+
+func (q *queriesImpl) getWarehouseShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]wms.ShelfBlock, error) {
+	query := `SELECT id, aisle, rack, storage_type, warehouse_id, version FROM shelf_block WHERE warehouse_id = $1 AND deleted_at IS NULL`
+
+	rows, err := tx.QueryContext(ctx, query, warehouseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shelfBlocks []wms.ShelfBlock
+	for rows.Next() {
+		var shelfBlock wms.ShelfBlock
+		err := rows.Scan(&shelfBlock.Id, &shelfBlock.Aisle, &shelfBlock.Rack, &shelfBlock.StorageType, &shelfBlock.WarehouseId, &shelfBlock.Version)
+		if err != nil {
+			return nil, err
+		}
+		shelfBlocks = append(shelfBlocks, shelfBlock)
+	}
+
+	return shelfBlocks, rows.Err()
+}
+
+func (q *queriesImpl) getWarehouseShelvesTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]wms.Shelf, error) {
+	query := `SELECT shelf.id, shelf.label, shelf.section, shelf.level, shelf.shelf_block,
+			shelf.max_weight_in_kg, shelf.max_volume_in_cm3, shelf.max_items, shelf.version
+		FROM shelf JOIN shelf_block ON shelf.shelf_block = shelf_block.id
+		WHERE shelf_block.warehouse_id = $1
+		AND shelf.deleted_at IS NULL AND shelf_block.deleted_at IS NULL`
+
+	rows, err := tx.QueryContext(ctx, query, warehouseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shelves []wms.Shelf
+	for rows.Next() {
+		shelf, err := scanShelf(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		shelves = append(shelves, shelf)
+	}
+
+	return shelves, rows.Err()
+}
+
+func (q *queriesImpl) countWarehouseItemsByShelfTx(ctx context.Context, tx *sql.Tx, warehouseId string) (map[string]int, error) {
+	query := `SELECT item.shelf_id, count(*) FROM item
+		JOIN shelf ON item.shelf_id = shelf.id
+		JOIN shelf_block ON shelf.shelf_block = shelf_block.id
+		WHERE shelf_block.warehouse_id = $1
+		AND shelf.deleted_at IS NULL AND shelf_block.deleted_at IS NULL
+		GROUP BY item.shelf_id`
+
+	rows, err := tx.QueryContext(ctx, query, warehouseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	itemCounts := make(map[string]int)
+	for rows.Next() {
+		var shelfId string
+		var count int
+		err := rows.Scan(&shelfId, &count)
+		if err != nil {
+			return nil, err
+		}
+		itemCounts[shelfId] = count
+	}
+
+	return itemCounts, rows.Err()
+}
+
+// lockWarehouseTx locks the warehouse row, so that it does not change and no
+// shelf block can be added to it until tx ends.
+func (q *queriesImpl) lockWarehouseTx(ctx context.Context, tx *sql.Tx, id string, version int) error {
+	return lockVersionTx(ctx, tx, "warehouse", id, version)
+}
 
-	result, err := tx.ExecContext(ctx, query, id)
+func (q *queriesImpl) warehouseDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]wms.Dependent, error) {
+	return queryDependentsTx(ctx, tx, wms.EntityShelfBlock, `SELECT id FROM shelf_block WHERE warehouse_id = $1 AND deleted_at IS NULL ORDER BY id`, id)
+}
+
+// cascadeDeleteWarehouseTx soft-deletes the warehouse with its shelf blocks
+// and shelves. now() is fixed for the transaction, so the rows all share the
+// same deleted_at, which is how restoreWarehouseTx finds them again.
+func (q *queriesImpl) cascadeDeleteWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error {
+	_, err := auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditDelete,
+		`UPDATE shelf SET deleted_at = now(), version = version + 1`,
+		`shelf_block IN (SELECT id FROM shelf_block WHERE warehouse_id = $1) AND deleted_at IS NULL`,
+		id)
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelfBlock, wms.AuditDelete,
+		`UPDATE shelf_block SET deleted_at = now(), version = version + 1`,
+		`warehouse_id = $1 AND deleted_at IS NULL`,
+		id)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
+
+	return q.deleteWarehouseTx(ctx, tx, id)
+}
+
+func (q *queriesImpl) restoreWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error {
+	var deletedAt time.Time
+	row := tx.QueryRowContext(ctx, `SELECT deleted_at FROM warehouse WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id)
+	err := row.Scan(&deletedAt)
+	if err == sql.ErrNoRows {
 		return RowDoesNotExist
 	}
+	if err != nil {
+		return err
+	}
 
-	return nil
+	_, err = auditedExecTx(ctx, tx, wms.EntityWarehouse, wms.AuditRestore,
+		`UPDATE warehouse SET deleted_at = NULL, version = version + 1`,
+		`id = $1`,
+		id)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelfBlock, wms.AuditRestore,
+		`UPDATE shelf_block SET deleted_at = NULL, version = version + 1`,
+		`warehouse_id = $1 AND deleted_at = $2`,
+		id, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditRestore,
+		`UPDATE shelf SET deleted_at = NULL, version = version + 1`,
+		`shelf_block IN (SELECT id FROM shelf_block WHERE warehouse_id = $1) AND deleted_at = $2`,
+		id, deletedAt)
+	return err
+}
+
+func (q *queriesImpl) purgeWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error {
+	var deleted bool
+	row := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM warehouse WHERE id = $1 AND deleted_at IS NOT NULL)`, id)
+	err := row.Scan(&deleted)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return RowDoesNotExist
+	}
+
+	dependents, err := queryAllDependentsTx(ctx, tx, []dependentsQuery{
+		{entity: wms.EntityItem, query: `SELECT item.id FROM item
+			JOIN shelf ON shelf.id = item.shelf_id
+			JOIN shelf_block ON shelf_block.id = shelf.shelf_block
+			WHERE shelf_block.warehouse_id = $1 ORDER BY item.id`},
+		{entity: wms.EntityTransfer, query: `SELECT id FROM transfer
+			WHERE (from_warehouse_id = $1 OR to_warehouse_id = $1) AND status = 'in_transit' ORDER BY id`},
+		{entity: wms.EntityCycleCount, query: `SELECT id FROM cycle_count WHERE warehouse_id = $1 ORDER BY id`},
+	}, id)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return &wms.DependentsError{Dependents: dependents}
+	}
+
+	// completed transfers go, their movements stay in the stock ledger
+	_, err = tx.ExecContext(ctx, `DELETE FROM transfer WHERE to_shelf_id IN (
+		SELECT shelf.id FROM shelf JOIN shelf_block ON shelf.shelf_block = shelf_block.id
+		WHERE shelf_block.warehouse_id = $1)`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelf, wms.AuditPurge,
+		`DELETE FROM shelf`,
+		`shelf_block IN (SELECT id FROM shelf_block WHERE warehouse_id = $1)`,
+		id)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityShelfBlock, wms.AuditPurge,
+		`DELETE FROM shelf_block`,
+		`warehouse_id = $1`,
+		id)
+	if err != nil {
+		return err
+	}
+
+	_, err = auditedExecTx(ctx, tx, wms.EntityWarehouse, wms.AuditPurge,
+		`DELETE FROM warehouse`,
+		`id = $1`,
+		id)
+	return err
 }
@@ -36,6 +36,35 @@ func (m *Mockqueries) EXPECT() *MockqueriesMockRecorder {
 	return m.recorder
 }
 
+// cascadeDeleteWarehouseTx mocks base method.
+func (m *Mockqueries) cascadeDeleteWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "cascadeDeleteWarehouseTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// cascadeDeleteWarehouseTx indicates an expected call of cascadeDeleteWarehouseTx.
+func (mr *MockqueriesMockRecorder) cascadeDeleteWarehouseTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "cascadeDeleteWarehouseTx", reflect.TypeOf((*Mockqueries)(nil).cascadeDeleteWarehouseTx), ctx, tx, id)
+}
+
+// countWarehouseItemsByShelfTx mocks base method.
+func (m *Mockqueries) countWarehouseItemsByShelfTx(ctx context.Context, tx *sql.Tx, warehouseId string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "countWarehouseItemsByShelfTx", ctx, tx, warehouseId)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// countWarehouseItemsByShelfTx indicates an expected call of countWarehouseItemsByShelfTx.
+func (mr *MockqueriesMockRecorder) countWarehouseItemsByShelfTx(ctx, tx, warehouseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "countWarehouseItemsByShelfTx", reflect.TypeOf((*Mockqueries)(nil).countWarehouseItemsByShelfTx), ctx, tx, warehouseId)
+}
+
 // createWarehouseTx mocks base method.
 func (m *Mockqueries) createWarehouseTx(ctx context.Context, tx *sql.Tx, warehouse *warehousemanagementservice.Warehouse) error {
 	m.ctrl.T.Helper()
@@ -79,6 +108,108 @@ func (mr *MockqueriesMockRecorder) getWarehouseByIdTx(ctx, tx, id interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWarehouseByIdTx", reflect.TypeOf((*Mockqueries)(nil).getWarehouseByIdTx), ctx, tx, id)
 }
 
+// getWarehouseShelfBlocksTx mocks base method.
+func (m *Mockqueries) getWarehouseShelfBlocksTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]warehousemanagementservice.ShelfBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getWarehouseShelfBlocksTx", ctx, tx, warehouseId)
+	ret0, _ := ret[0].([]warehousemanagementservice.ShelfBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getWarehouseShelfBlocksTx indicates an expected call of getWarehouseShelfBlocksTx.
+func (mr *MockqueriesMockRecorder) getWarehouseShelfBlocksTx(ctx, tx, warehouseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWarehouseShelfBlocksTx", reflect.TypeOf((*Mockqueries)(nil).getWarehouseShelfBlocksTx), ctx, tx, warehouseId)
+}
+
+// getWarehouseShelvesTx mocks base method.
+func (m *Mockqueries) getWarehouseShelvesTx(ctx context.Context, tx *sql.Tx, warehouseId string) ([]warehousemanagementservice.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getWarehouseShelvesTx", ctx, tx, warehouseId)
+	ret0, _ := ret[0].([]warehousemanagementservice.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getWarehouseShelvesTx indicates an expected call of getWarehouseShelvesTx.
+func (mr *MockqueriesMockRecorder) getWarehouseShelvesTx(ctx, tx, warehouseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWarehouseShelvesTx", reflect.TypeOf((*Mockqueries)(nil).getWarehouseShelvesTx), ctx, tx, warehouseId)
+}
+
+// listWarehousesTx mocks base method.
+func (m *Mockqueries) listWarehousesTx(ctx context.Context, tx *sql.Tx, after string, limit int) ([]warehousemanagementservice.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listWarehousesTx", ctx, tx, after, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listWarehousesTx indicates an expected call of listWarehousesTx.
+func (mr *MockqueriesMockRecorder) listWarehousesTx(ctx, tx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listWarehousesTx", reflect.TypeOf((*Mockqueries)(nil).listWarehousesTx), ctx, tx, after, limit)
+}
+
+// lockWarehouseTx mocks base method.
+func (m *Mockqueries) lockWarehouseTx(ctx context.Context, tx *sql.Tx, id string, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockWarehouseTx", ctx, tx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// lockWarehouseTx indicates an expected call of lockWarehouseTx.
+func (mr *MockqueriesMockRecorder) lockWarehouseTx(ctx, tx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockWarehouseTx", reflect.TypeOf((*Mockqueries)(nil).lockWarehouseTx), ctx, tx, id, version)
+}
+
+// nearestWarehousesTx mocks base method.
+func (m *Mockqueries) nearestWarehousesTx(ctx context.Context, tx *sql.Tx, latitude, longitude float64, radiusInKm *float64, limit int) ([]warehousemanagementservice.NearbyWarehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "nearestWarehousesTx", ctx, tx, latitude, longitude, radiusInKm, limit)
+	ret0, _ := ret[0].([]warehousemanagementservice.NearbyWarehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// nearestWarehousesTx indicates an expected call of nearestWarehousesTx.
+func (mr *MockqueriesMockRecorder) nearestWarehousesTx(ctx, tx, latitude, longitude, radiusInKm, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "nearestWarehousesTx", reflect.TypeOf((*Mockqueries)(nil).nearestWarehousesTx), ctx, tx, latitude, longitude, radiusInKm, limit)
+}
+
+// purgeWarehouseTx mocks base method.
+func (m *Mockqueries) purgeWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "purgeWarehouseTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// purgeWarehouseTx indicates an expected call of purgeWarehouseTx.
+func (mr *MockqueriesMockRecorder) purgeWarehouseTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "purgeWarehouseTx", reflect.TypeOf((*Mockqueries)(nil).purgeWarehouseTx), ctx, tx, id)
+}
+
+// restoreWarehouseTx mocks base method.
+func (m *Mockqueries) restoreWarehouseTx(ctx context.Context, tx *sql.Tx, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "restoreWarehouseTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// restoreWarehouseTx indicates an expected call of restoreWarehouseTx.
+func (mr *MockqueriesMockRecorder) restoreWarehouseTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "restoreWarehouseTx", reflect.TypeOf((*Mockqueries)(nil).restoreWarehouseTx), ctx, tx, id)
+}
+
 // updateWarehouseTx mocks base method.
 func (m *Mockqueries) updateWarehouseTx(ctx context.Context, tx *sql.Tx, warehouse *warehousemanagementservice.Warehouse) error {
 	m.ctrl.T.Helper()
@@ -92,3 +223,18 @@ func (mr *MockqueriesMockRecorder) updateWarehouseTx(ctx, tx, warehouse interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "updateWarehouseTx", reflect.TypeOf((*Mockqueries)(nil).updateWarehouseTx), ctx, tx, warehouse)
 }
+
+// warehouseDependentsTx mocks base method.
+func (m *Mockqueries) warehouseDependentsTx(ctx context.Context, tx *sql.Tx, id string) ([]warehousemanagementservice.Dependent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "warehouseDependentsTx", ctx, tx, id)
+	ret0, _ := ret[0].([]warehousemanagementservice.Dependent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// warehouseDependentsTx indicates an expected call of warehouseDependentsTx.
+func (mr *MockqueriesMockRecorder) warehouseDependentsTx(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "warehouseDependentsTx", reflect.TypeOf((*Mockqueries)(nil).warehouseDependentsTx), ctx, tx, id)
+}
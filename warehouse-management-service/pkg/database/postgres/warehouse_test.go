@@ -37,6 +37,7 @@ var updateWarehouseTests = []struct {
 	{updateWarehouseTxReturns: nil, want: nil},
 	{updateWarehouseTxReturns: genericError, want: genericError},
 	{updateWarehouseTxReturns: RowDoesNotExist, want: wms.WarehouseDoesNotExist},
+	{updateWarehouseTxReturns: VersionMismatch, want: wms.VersionMismatch},
 	{updateWarehouseTxReturns: sql.ErrConnDone, want: sql.ErrConnDone},
 	{updateWarehouseTxReturns: sql.ErrTxDone, want: sql.ErrTxDone},
 	{updateWarehouseTxReturns: context.Canceled, want: context.Canceled},
@@ -166,10 +167,12 @@ func TestDeleteWarehouse(t *testing.T) {
 	mockObj := NewMockqueries(mockCtrl)
 
 	for _, test := range deleteWarehouseTests {
+		mockObj.EXPECT().lockWarehouseTx(ctx, gomock.Any(), id, 1).Return(nil)
+		mockObj.EXPECT().warehouseDependentsTx(ctx, gomock.Any(), id).Return(nil, nil)
 		mockObj.EXPECT().deleteWarehouseTx(ctx, gomock.Any(), id).Return(test.deleteWarehouseTxReturns)
 
 		ws := WarehouseService{db: warehouseService.db, queries: mockObj}
-		err := ws.DeleteWarehouse(ctx, id)
+		err := ws.DeleteWarehouse(ctx, id, 1, false)
 
 		if err != test.want {
 			t.Error(err)
@@ -183,6 +186,7 @@ func TestGetWarehouseByIDTx(t *testing.T) {
 		Name:      "test_find_by_id",
 		Latitude:  12.9716,
 		Longitude: 77.5946,
+		Version:   1,
 	}
 
 	tx, err := warehouseService.db.Begin()
@@ -312,6 +316,7 @@ func TestUpdateWarehouseTx(t *testing.T) {
 		Name:      "test_update_post_exec",
 		Latitude:  12.9822,
 		Longitude: 77.5898,
+		Version:   1,
 	}
 
 	tx, err := warehouseService.db.Begin()
@@ -334,17 +339,125 @@ func TestUpdateWarehouseTx(t *testing.T) {
 	}
 
 	// select row
-	query = "SELECT id, name, geolocation[0], geolocation[1] from warehouse where id=$1;"
+	query = "SELECT id, name, geolocation[0], geolocation[1], version from warehouse where id=$1;"
 	row := tx.QueryRowContext(context.Background(), query, warehouse.Id)
 
 	var warehouseFromDB wms.Warehouse
-	err = row.Scan(&warehouseFromDB.Id, &warehouseFromDB.Name, &warehouseFromDB.Longitude, &warehouseFromDB.Latitude)
+	err = row.Scan(&warehouseFromDB.Id, &warehouseFromDB.Name, &warehouseFromDB.Longitude, &warehouseFromDB.Latitude, &warehouseFromDB.Version)
 	if err != nil {
 		t.Error(err)
 	}
 
-	if warehouseUpdated != warehouseFromDB {
-		t.Errorf("expected: %v, got: %v", warehouse, warehouseFromDB)
+	// the update bumps the version
+	want := warehouseUpdated
+	want.Version = 2
+	if want != warehouseFromDB {
+		t.Errorf("expected: %v, got: %v", want, warehouseFromDB)
+	}
+}
+
+func TestUpdateWarehouseTxStaleVersion(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+
+	stale := fixtureWarehouse
+	stale.Name = "stale_update"
+	stale.Version = 7
+	err = warehouseService.queries.updateWarehouseTx(ctx, tx, &stale)
+	if err != VersionMismatch {
+		t.Errorf("expected: %v, got: %v", VersionMismatch, err)
+	}
+
+	err = warehouseService.queries.lockWarehouseTx(ctx, tx, fixtureWarehouse.Id, 7)
+	if err != VersionMismatch {
+		t.Errorf("expected: %v, got: %v", VersionMismatch, err)
+	}
+
+	err = warehouseService.queries.lockWarehouseTx(ctx, tx, fixtureWarehouse.Id, 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateWarehouseTxConcurrent(t *testing.T) {
+	ctx := context.Background()
+	warehouse := wms.Warehouse{
+		Id:        "5b0e6a47-8f0d-4c55-9d3e-2f1a7c4b9e21",
+		Name:      "test_concurrent_update",
+		Latitude:  12.9716,
+		Longitude: 77.5946,
+		Version:   1,
+	}
+
+	// both transactions have to see the warehouse, so it is committed and
+	// removed again when the test ends
+	setup, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer setup.Rollback()
+	err = warehouseService.queries.createWarehouseTx(ctx, setup, &warehouse)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = setup.Commit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer warehouseService.db.Exec(`DELETE FROM audit_event WHERE entity_id = $1`, warehouse.Id)
+	defer warehouseService.db.Exec(`DELETE FROM warehouse WHERE id = $1`, warehouse.Id)
+
+	first, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer first.Rollback()
+	second, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer second.Rollback()
+
+	firstUpdate := warehouse
+	firstUpdate.Name = "first_update"
+	err = warehouseService.queries.updateWarehouseTx(ctx, first, &firstUpdate)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// second blocks on the row first has locked until first commits
+	secondUpdate := warehouse
+	secondUpdate.Name = "second_update"
+	done := make(chan error, 1)
+	go func() {
+		done <- warehouseService.queries.updateWarehouseTx(ctx, second, &secondUpdate)
+	}()
+
+	err = first.Commit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = <-done
+	if err != VersionMismatch {
+		t.Errorf("expected: %v, got: %v", VersionMismatch, err)
 	}
 }
 
@@ -417,3 +530,430 @@ func TestDeleteWarehouseTxError(t *testing.T) {
 		)
 	}
 }
+
+func TestListWarehousesTx(t *testing.T) {
+	ctx := context.Background()
+	ids := []string{"zzzz-list-1", "zzzz-list-2", "zzzz-list-3"}
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		err = warehouseService.queries.createWarehouseTx(ctx, tx, &wms.Warehouse{Id: id, Name: id})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	firstPage, err := warehouseService.queries.listWarehousesTx(ctx, tx, "zzzz-list-0", 2)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(firstPage) != 2 || firstPage[0].Id != ids[0] || firstPage[1].Id != ids[1] {
+		t.Errorf("expected: %v, got: %v", ids[:2], firstPage)
+	}
+
+	secondPage, err := warehouseService.queries.listWarehousesTx(ctx, tx, firstPage[1].Id, 2)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(secondPage) != 1 || secondPage[0].Id != ids[2] {
+		t.Errorf("expected: %v, got: %v", ids[2:], secondPage)
+	}
+}
+
+func TestListWarehouses(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockqueries(mockCtrl)
+
+	tests := []struct {
+		listWarehousesTxReturns []wms.Warehouse
+		listWarehousesTxErr     error
+		wantLength              int
+		wantErr                 error
+	}{
+		{listWarehousesTxReturns: []wms.Warehouse{warehouse}, listWarehousesTxErr: nil, wantLength: 1, wantErr: nil},
+		{listWarehousesTxReturns: nil, listWarehousesTxErr: sql.ErrConnDone, wantLength: 0, wantErr: sql.ErrConnDone},
+		{listWarehousesTxReturns: nil, listWarehousesTxErr: context.Canceled, wantLength: 0, wantErr: context.Canceled},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().listWarehousesTx(ctx, gomock.Any(), warehouse.Id, 10).Return(test.listWarehousesTxReturns, test.listWarehousesTxErr)
+
+		ws := WarehouseService{db: warehouseService.db, queries: mockObj}
+		got, err := ws.ListWarehouses(ctx, warehouse.Id, 10)
+
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+		if len(got) != test.wantLength {
+			t.Errorf("want: %v, got: %v", test.wantLength, len(got))
+		}
+	}
+}
+
+func TestNearestWarehousesTx(t *testing.T) {
+	ctx := context.Background()
+
+	bangalore := wms.Warehouse{Id: "nearest-bangalore", Name: "bangalore", Latitude: 12.9716, Longitude: 77.5946, Version: 1}
+	chennai := wms.Warehouse{Id: "nearest-chennai", Name: "chennai", Latitude: 13.0827, Longitude: 80.2707, Version: 1}
+	mumbai := wms.Warehouse{Id: "nearest-mumbai", Name: "mumbai", Latitude: 19.0760, Longitude: 72.8777, Version: 1}
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	for _, warehouse := range []wms.Warehouse{mumbai, chennai, bangalore} {
+		err = warehouseService.queries.createWarehouseTx(ctx, tx, &warehouse)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	radius := 400.0
+	nearby, err := warehouseService.queries.nearestWarehousesTx(ctx, tx, 12.9352, 77.6245, &radius, 10)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(nearby) < 2 || nearby[0].Warehouse != bangalore || nearby[1].Warehouse != chennai {
+		t.Errorf("expected: %v, got: %v", []wms.Warehouse{bangalore, chennai}, nearby)
+		return
+	}
+	// Koramangala to the city centre is roughly 5km, and to Chennai roughly 290km
+	if nearby[0].DistanceInKm < 4 || nearby[0].DistanceInKm > 6 {
+		t.Errorf("expected distance ~5km, got: %v", nearby[0].DistanceInKm)
+	}
+	if nearby[1].DistanceInKm < 280 || nearby[1].DistanceInKm > 300 {
+		t.Errorf("expected distance ~290km, got: %v", nearby[1].DistanceInKm)
+	}
+	for _, warehouse := range nearby {
+		if warehouse.Id == mumbai.Id {
+			t.Errorf("expected %v to be outside the radius", mumbai)
+		}
+	}
+
+	nearby, err = warehouseService.queries.nearestWarehousesTx(ctx, tx, 19.0, 72.8, nil, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(nearby) != 1 || nearby[0].Warehouse != mumbai {
+		t.Errorf("expected: %v, got: %v", mumbai, nearby)
+	}
+}
+
+func TestNearestWarehouses(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockqueries(mockCtrl)
+
+	nearby := []wms.NearbyWarehouse{{Warehouse: warehouse, DistanceInKm: 2}}
+	tests := []struct {
+		nearestWarehousesTxReturns []wms.NearbyWarehouse
+		nearestWarehousesTxErr     error
+		wantErr                    error
+	}{
+		{nearestWarehousesTxReturns: nearby, nearestWarehousesTxErr: nil, wantErr: nil},
+		{nearestWarehousesTxReturns: nil, nearestWarehousesTxErr: sql.ErrConnDone, wantErr: sql.ErrConnDone},
+	}
+
+	for _, test := range tests {
+		mockObj.EXPECT().nearestWarehousesTx(ctx, gomock.Any(), 12.9, 77.5, nil, 10).Return(test.nearestWarehousesTxReturns, test.nearestWarehousesTxErr)
+
+		ws := WarehouseService{db: warehouseService.db, queries: mockObj}
+		got, err := ws.NearestWarehouses(ctx, 12.9, 77.5, nil, 10)
+
+		if err != test.wantErr {
+			t.Errorf("want: %v, got: %v", test.wantErr, err)
+		}
+		if len(got) != len(test.nearestWarehousesTxReturns) {
+			t.Errorf("want: %v, got: %v", test.nearestWarehousesTxReturns, got)
+		}
+	}
+}
+
+func TestGetWarehouseLayoutTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	item := testItem()
+	err = itemService.queries.createItemTx(ctx, tx, item)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	shelfBlocks, err := warehouseService.queries.getWarehouseShelfBlocksTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil || len(shelfBlocks) != 1 || shelfBlocks[0] != fixtureShelfBlock {
+		t.Errorf("expected: %v, got: %v, %v", fixtureShelfBlock, shelfBlocks, err)
+	}
+
+	shelves, err := warehouseService.queries.getWarehouseShelvesTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil || len(shelves) != 1 || shelves[0] != fixtureShelf {
+		t.Errorf("expected: %v, got: %v, %v", fixtureShelf, shelves, err)
+	}
+
+	itemCounts, err := warehouseService.queries.countWarehouseItemsByShelfTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil || len(itemCounts) != 1 || itemCounts[fixtureShelf.Id] != 1 {
+		t.Errorf("expected: %v, got: %v, %v", map[string]int{fixtureShelf.Id: 1}, itemCounts, err)
+	}
+}
+
+func TestGetWarehouseLayout(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockqueries(mockCtrl)
+
+	ws := WarehouseService{db: warehouseService.db, queries: mockObj}
+
+	gomock.InOrder(
+		mockObj.EXPECT().getWarehouseByIdTx(ctx, gomock.Any(), fixtureWarehouse.Id).Return(&fixtureWarehouse, nil),
+		mockObj.EXPECT().getWarehouseShelfBlocksTx(ctx, gomock.Any(), fixtureWarehouse.Id).Return([]wms.ShelfBlock{fixtureShelfBlock}, nil),
+		mockObj.EXPECT().getWarehouseShelvesTx(ctx, gomock.Any(), fixtureWarehouse.Id).Return([]wms.Shelf{fixtureShelf}, nil),
+		mockObj.EXPECT().countWarehouseItemsByShelfTx(ctx, gomock.Any(), fixtureWarehouse.Id).Return(map[string]int{fixtureShelf.Id: 2}, nil),
+	)
+
+	layout, err := ws.GetWarehouseLayout(ctx, fixtureWarehouse.Id, true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	shelf := layout.Aisles[0].Racks[0].ShelfBlocks[0].Sections[0].Shelves[0]
+	if shelf.Shelf != fixtureShelf || shelf.ItemCount == nil || *shelf.ItemCount != 2 {
+		t.Errorf("expected: %v with 2 items, got: %v", fixtureShelf, shelf)
+	}
+
+	mockObj.EXPECT().getWarehouseByIdTx(ctx, gomock.Any(), "non-existent-id").Return(nil, sql.ErrNoRows)
+	_, err = ws.GetWarehouseLayout(ctx, "non-existent-id", false)
+	if err != wms.WarehouseDoesNotExist {
+		t.Errorf("want: %v, got: %v", wms.WarehouseDoesNotExist, err)
+	}
+
+	mockObj.EXPECT().getWarehouseByIdTx(ctx, gomock.Any(), fixtureWarehouse.Id).Return(&fixtureWarehouse, nil)
+	mockObj.EXPECT().getWarehouseShelfBlocksTx(ctx, gomock.Any(), fixtureWarehouse.Id).Return(nil, sql.ErrConnDone)
+	_, err = ws.GetWarehouseLayout(ctx, fixtureWarehouse.Id, false)
+	if err != sql.ErrConnDone {
+		t.Errorf("want: %v, got: %v", sql.ErrConnDone, err)
+	}
+}
+
+func TestWarehouseDependentsTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+
+	got, err := warehouseService.queries.warehouseDependentsTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []wms.Dependent{{Entity: wms.EntityShelfBlock, Id: fixtureShelfBlock.Id}}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestCascadeDeleteWarehouseTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+
+	err = warehouseService.queries.cascadeDeleteWarehouseTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var deleted bool
+	err = tx.QueryRowContext(ctx, "SELECT deleted_at IS NOT NULL FROM shelf WHERE id = $1", fixtureShelf.Id).Scan(&deleted)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !deleted {
+		t.Errorf("want shelf: %s to be deleted", fixtureShelf.Id)
+	}
+
+	err = warehouseService.queries.restoreWarehouseTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = warehouseService.queries.getWarehouseByIdTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil {
+		t.Error(err)
+	}
+	err = tx.QueryRowContext(ctx, "SELECT deleted_at IS NOT NULL FROM shelf WHERE id = $1", fixtureShelf.Id).Scan(&deleted)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if deleted {
+		t.Errorf("want shelf: %s to be restored", fixtureShelf.Id)
+	}
+}
+
+func TestPurgeWarehouseTx(t *testing.T) {
+	ctx := context.Background()
+
+	tx, err := warehouseService.db.Begin()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !insertFixtures(t, tx) {
+		return
+	}
+
+	err = warehouseService.queries.purgeWarehouseTx(ctx, tx, fixtureWarehouse.Id)
+	if err != RowDoesNotExist {
+		t.Errorf("want: %v, got: %v", RowDoesNotExist, err)
+		return
+	}
+
+	err = productService.queries.createProductTx(ctx, tx, testProduct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = itemService.queries.createItemTx(ctx, tx, testItem())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = warehouseService.queries.cascadeDeleteWarehouseTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the item on its shelf keeps the warehouse from being purged
+	err = warehouseService.queries.purgeWarehouseTx(ctx, tx, fixtureWarehouse.Id)
+	var dependentsError *wms.DependentsError
+	if !errors.As(err, &dependentsError) || len(dependentsError.Dependents) != 1 ||
+		dependentsError.Dependents[0] != (wms.Dependent{Entity: wms.EntityItem, Id: testItem().Id}) {
+		t.Errorf("want item %s as dependent, got: %v", testItem().Id, err)
+		return
+	}
+
+	err = itemService.queries.deleteItemTx(ctx, tx, testItem().Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = warehouseService.queries.purgeWarehouseTx(ctx, tx, fixtureWarehouse.Id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx, "SELECT count(*) FROM shelf WHERE id = $1", fixtureShelf.Id).Scan(&count)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if count != 0 {
+		t.Errorf("want shelf: %s to be purged", fixtureShelf.Id)
+	}
+}
+
+func TestDeleteWarehouseWithDependents(t *testing.T) {
+	ctx := context.Background()
+	id := "85bd3b85-ad4d-4224-b589-fb2a80a6ce45"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockqueries(mockCtrl)
+
+	dependents := []wms.Dependent{{Entity: wms.EntityShelfBlock, Id: "block"}}
+	mockObj.EXPECT().lockWarehouseTx(ctx, gomock.Any(), id, 1).Return(nil).Times(2)
+	mockObj.EXPECT().warehouseDependentsTx(ctx, gomock.Any(), id).Return(dependents, nil)
+
+	ws := WarehouseService{db: warehouseService.db, queries: mockObj}
+	err := ws.DeleteWarehouse(ctx, id, 1, false)
+	if !errors.Is(err, wms.HasDependents) {
+		t.Errorf("want: %v, got: %v", wms.HasDependents, err)
+	}
+
+	mockObj.EXPECT().cascadeDeleteWarehouseTx(ctx, gomock.Any(), id).Return(nil)
+
+	err = ws.DeleteWarehouse(ctx, id, 1, true)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteWarehouseVersionMismatch(t *testing.T) {
+	ctx := context.Background()
+	id := "85bd3b85-ad4d-4224-b589-fb2a80a6ce45"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockObj := NewMockqueries(mockCtrl)
+
+	mockObj.EXPECT().lockWarehouseTx(ctx, gomock.Any(), id, 1).Return(VersionMismatch)
+
+	ws := WarehouseService{db: warehouseService.db, queries: mockObj}
+	err := ws.DeleteWarehouse(ctx, id, 1, true)
+	if err != wms.VersionMismatch {
+		t.Errorf("want: %v, got: %v", wms.VersionMismatch, err)
+	}
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	wms "warehouse-management-service"
+)
+
+type csvWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+// NewCSVWriter writes rows as CSV under a header row of wms.ExportColumns.
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(row wms.ExportRow) error {
+	err := c.writeHeader()
+	if err != nil {
+		return err
+	}
+	return c.writer.Write(row.Record())
+}
+
+func (c *csvWriter) Close() error {
+	err := c.writeHeader()
+	if err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.wroteHeader {
+		return nil
+	}
+	c.wroteHeader = true
+	return c.writer.Write(wms.ExportColumns)
+}
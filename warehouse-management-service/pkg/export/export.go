@@ -0,0 +1,39 @@
+package export
+
+import (
+	"io"
+	wms "warehouse-management-service"
+)
+
+// Writer streams export rows out in some file format. Nothing is written to
+// the underlying io.Writer until the first Write, and the output is only
+// complete once Close has been called.
+type Writer interface {
+	Write(row wms.ExportRow) error
+	Close() error
+}
+
+type Format struct {
+	ContentType string
+	Extension   string
+	NewWriter   func(w io.Writer) Writer
+}
+
+// Formats are the export formats by the name clients ask for them with.
+var Formats = map[string]Format{
+	"csv": {
+		ContentType: "text/csv",
+		Extension:   "csv",
+		NewWriter:   NewCSVWriter,
+	},
+	"jsonl": {
+		ContentType: "application/jsonl",
+		Extension:   "jsonl",
+		NewWriter:   NewJSONLinesWriter,
+	},
+	"xlsx": {
+		ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		Extension:   "xlsx",
+		NewWriter:   NewXLSXWriter,
+	},
+}
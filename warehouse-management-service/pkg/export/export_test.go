@@ -0,0 +1,145 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	wms "warehouse-management-service"
+)
+
+var receivedOn = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+var rows = []wms.ExportRow{
+	{
+		WarehouseId:   "w",
+		WarehouseName: "central & co",
+		ShelfBlockId:  "b",
+		Aisle:         "1",
+		Rack:          "1",
+		StorageType:   "regular",
+		ShelfId:       "s",
+		ShelfLabel:    "1A",
+		Section:       "A",
+		Level:         "1",
+		ItemId:        "i",
+		Sku:           "sku",
+		ReceivedOn:    &receivedOn,
+	},
+	{WarehouseId: "w", WarehouseName: "central & co", ShelfBlockId: "empty"},
+}
+
+func writeAll(t *testing.T, format string) []byte {
+	t.Helper()
+
+	var out bytes.Buffer
+	writer := Formats[format].NewWriter(&out)
+	if out.Len() != 0 {
+		t.Fatalf("%s: wrote before the first row", format)
+	}
+	for _, row := range rows {
+		err := writer.Write(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func TestCSVWriter(t *testing.T) {
+	records, err := csv.NewReader(bytes.NewReader(writeAll(t, "csv"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{wms.ExportColumns, rows[0].Record(), rows[1].Record()}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("want: %v, got: %v", want, records)
+	}
+	if records[1][13] != "2023-01-02T03:04:05Z" {
+		t.Errorf("want: %v, got: %v", "2023-01-02T03:04:05Z", records[1][13])
+	}
+}
+
+func TestJSONLinesWriter(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(writeAll(t, "jsonl"))), "\n")
+	if len(lines) != len(rows) {
+		t.Fatalf("want: %d lines, got: %v", len(rows), lines)
+	}
+	for i, line := range lines {
+		var got wms.ExportRow
+		err := json.Unmarshal([]byte(line), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows[i]) {
+			t.Errorf("want: %v, got: %v", rows[i], got)
+		}
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	out := writeAll(t, "xlsx")
+	archive, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sheet []byte
+	names := map[string]bool{}
+	for _, file := range archive.File {
+		names[file.Name] = true
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sheet, err = io.ReadAll(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, part := range xlsxParts {
+		if !names[part.name] {
+			t.Errorf("missing part %s", part.name)
+		}
+	}
+
+	var worksheet struct {
+		Rows []struct {
+			Cells []struct {
+				Text string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	err = xml.Unmarshal(sheet, &worksheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{wms.ExportColumns, rows[0].Record(), rows[1].Record()}
+	if len(worksheet.Rows) != len(want) {
+		t.Fatalf("want: %d rows, got: %d", len(want), len(worksheet.Rows))
+	}
+	for i, row := range worksheet.Rows {
+		var got []string
+		for _, cell := range row.Cells {
+			got = append(got, cell.Text)
+		}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("want: %v, got: %v", want[i], got)
+		}
+	}
+}
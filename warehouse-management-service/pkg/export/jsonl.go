@@ -0,0 +1,24 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	wms "warehouse-management-service"
+)
+
+type jsonLinesWriter struct {
+	encoder *json.Encoder
+}
+
+// NewJSONLinesWriter writes every row as a JSON object on a line of its own.
+func NewJSONLinesWriter(w io.Writer) Writer {
+	return &jsonLinesWriter{encoder: json.NewEncoder(w)}
+}
+
+func (j *jsonLinesWriter) Write(row wms.ExportRow) error {
+	return j.encoder.Encode(row)
+}
+
+func (j *jsonLinesWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strings"
+	wms "warehouse-management-service"
+)
+
+// xlsxParts are the parts of a workbook with a single worksheet, apart from
+// the worksheet itself.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{
+		name: "[Content_Types].xml",
+		content: xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="export" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+const xlsxSheetStart = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+const xlsxSheetEnd = `</sheetData></worksheet>`
+
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+}
+
+// NewXLSXWriter writes rows to a single worksheet of an Office Open XML
+// workbook, under a header row of wms.ExportColumns. Cells are inline
+// strings, so the sheet is written as the rows come in rather than after a
+// shared string table has been built.
+func NewXLSXWriter(w io.Writer) Writer {
+	return &xlsxWriter{archive: zip.NewWriter(w)}
+}
+
+func (x *xlsxWriter) Write(row wms.ExportRow) error {
+	err := x.start()
+	if err != nil {
+		return err
+	}
+	return x.writeRow(row.Record())
+}
+
+func (x *xlsxWriter) Close() error {
+	err := x.start()
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(x.sheet, xlsxSheetEnd)
+	if err != nil {
+		return err
+	}
+	return x.archive.Close()
+}
+
+// start writes everything up to the header row of the worksheet, the first
+// time it is called.
+func (x *xlsxWriter) start() error {
+	if x.sheet != nil {
+		return nil
+	}
+
+	for _, part := range xlsxParts {
+		w, err := x.archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, part.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	sheet, err := x.archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(sheet, xlsxSheetStart)
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+
+	return x.writeRow(wms.ExportColumns)
+}
+
+func (x *xlsxWriter) writeRow(values []string) error {
+	var row strings.Builder
+	row.WriteString("<row>")
+	for _, value := range values {
+		if value == "" {
+			row.WriteString("<c/>")
+			continue
+		}
+		row.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		err := xml.EscapeText(&row, []byte(value))
+		if err != nil {
+			return err
+		}
+		row.WriteString("</t></is></c>")
+	}
+	row.WriteString("</row>")
+
+	_, err := io.WriteString(x.sheet, row.String())
+	return err
+}
@@ -0,0 +1,94 @@
+package wms
+
+import (
+	"errors"
+	"time"
+)
+
+type Product struct {
+	Sku         string  `json:"sku,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	MRP         float64 `json:"mrp,omitempty"`
+	Variant     string  `json:"variant,omitempty"`
+	LengthInCm  float64 `json:"lengthInCm,omitempty"`
+	WidthInCm   float64 `json:"widthInCm,omitempty"`
+	BreadthInCm float64 `json:"breadthInCm,omitempty"`
+	WeightInKg  float64 `json:"weightInKg,omitempty"`
+	Perishable  bool    `json:"perishable"`
+	// LotTracked products are received with the supplier's lot number, so
+	// that a recall can find every item of a lot.
+	LotTracked bool `json:"lotTracked"`
+	// Serialized products are received with a serial number on every item.
+	Serialized bool `json:"serialized"`
+}
+
+var ProductDoesNotExist = errors.New("product does not exist")
+
+func NewProduct(
+	name string,
+	mrp float64,
+	variant string,
+	lengthInCm, widthInCm, breadthInCm, weightInKg float64,
+	perishable bool,
+	lotTracked bool,
+	serialized bool,
+) Product {
+	return Product{
+		Sku:         generateUUID(),
+		Name:        name,
+		MRP:         mrp,
+		Variant:     variant,
+		LengthInCm:  lengthInCm,
+		WidthInCm:   widthInCm,
+		BreadthInCm: breadthInCm,
+		WeightInKg:  weightInKg,
+		Perishable:  perishable,
+		LotTracked:  lotTracked,
+		Serialized:  serialized,
+	}
+}
+
+// CheckLot checks that an item of the product is received with a lot when
+// the product is lot tracked.
+func (p Product) CheckLot(lot string) error {
+	if p.LotTracked && lot == "" {
+		return LotRequired
+	}
+	return nil
+}
+
+// CheckExpirationDate checks that an item of the product comes with an
+// expiration date when the product is perishable.
+func (p Product) CheckExpirationDate(expirationDate *time.Time) error {
+	if p.Perishable && expirationDate == nil {
+		return ExpirationDateRequired
+	}
+	return nil
+}
+
+// CheckSerialNumbers checks the serial numbers that quantity items of the
+// product are received with: none at all, or exactly one per item with no
+// serial number given twice. Serialized products cannot go without.
+func (p Product) CheckSerialNumbers(quantity int, serialNumbers []string) error {
+	if len(serialNumbers) == 0 {
+		if p.Serialized {
+			return SerialNumberRequired
+		}
+		return nil
+	}
+	if len(serialNumbers) != quantity {
+		return SerialNumberCountMismatch
+	}
+
+	seen := make(map[string]bool, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		if serialNumber == "" {
+			return SerialNumberRequired
+		}
+		if seen[serialNumber] {
+			return RepeatedSerialNumber
+		}
+		seen[serialNumber] = true
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package wms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckSerialNumbers(t *testing.T) {
+	tests := []struct {
+		serialized    bool
+		quantity      int
+		serialNumbers []string
+		want          error
+	}{
+		{serialized: true, quantity: 2, serialNumbers: []string{"SN1", "SN2"}},
+		{serialized: true, quantity: 2, want: SerialNumberRequired},
+		{serialized: true, quantity: 2, serialNumbers: []string{"SN1"}, want: SerialNumberCountMismatch},
+		{serialized: true, quantity: 2, serialNumbers: []string{"SN1", ""}, want: SerialNumberRequired},
+		{serialized: true, quantity: 2, serialNumbers: []string{"SN1", "SN1"}, want: RepeatedSerialNumber},
+		{serialized: false, quantity: 2},
+		{serialized: false, quantity: 1, serialNumbers: []string{"SN1"}},
+		{serialized: false, quantity: 2, serialNumbers: []string{"SN1"}, want: SerialNumberCountMismatch},
+	}
+
+	for _, test := range tests {
+		product := Product{Sku: "a", Serialized: test.serialized}
+		got := product.CheckSerialNumbers(test.quantity, test.serialNumbers)
+		if got != test.want {
+			t.Errorf("%v, %d, %v: want: %v, got: %v", test.serialized, test.quantity, test.serialNumbers, test.want, got)
+		}
+	}
+}
+
+func TestCheckExpirationDate(t *testing.T) {
+	expirationDate := time.Date(2030, time.January, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		perishable     bool
+		expirationDate *time.Time
+		want           error
+	}{
+		{perishable: true, expirationDate: &expirationDate},
+		{perishable: true, want: ExpirationDateRequired},
+		{perishable: false},
+		{perishable: false, expirationDate: &expirationDate},
+	}
+
+	for _, test := range tests {
+		got := Product{Sku: "a", Perishable: test.perishable}.CheckExpirationDate(test.expirationDate)
+		if got != test.want {
+			t.Errorf("%v, %v: want: %v, got: %v", test.perishable, test.expirationDate, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package wms
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ColdStorage is the storage type of shelf blocks that perishable products
+// are put away into.
+const ColdStorage = "cold"
+
+// Loads at or above these, per putaway, are kept to the lowest levels.
+const (
+	heavyLoadInKg     = 25
+	bulkyLoadInCubicM = 0.5
+)
+
+// PutawayCandidate is a shelf that stock could be put away onto, with what
+// is on it already.
+type PutawayCandidate struct {
+	Shelf
+	Aisle       string
+	Rack        string
+	StorageType string
+	// Used is what the items on the shelf take up of it.
+	Used ShelfUtilization
+	// SkuCount is the number of items of the sku being put away on the
+	// shelf, and SkuCountInBlock the number in the shelf's block.
+	SkuCount        int
+	SkuCountInBlock int
+}
+
+type PutawaySuggestion struct {
+	ShelfId     string   `json:"shelfId"`
+	Label       string   `json:"label"`
+	Aisle       string   `json:"aisle"`
+	Rack        string   `json:"rack"`
+	Section     string   `json:"section"`
+	Level       string   `json:"level"`
+	StorageType string   `json:"storageType"`
+	Score       int      `json:"score"`
+	Reasons     []string `json:"reasons"`
+}
+
+// SuggestPutaway ranks the shelves that quantity units of product could go
+// onto and returns the best limit of them. Shelves without room for them are
+// left out, perishable products only go into cold storage and everything
+// else stays out of it. After that, shelves already holding the sku come
+// first, then shelves in a block holding it, heavy or bulky loads favour low
+// levels, and emptier shelves beat fuller ones.
+func SuggestPutaway(product Product, quantity int, candidates []PutawayCandidate, limit int) []PutawaySuggestion {
+	eligible := make([]PutawayCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if product.Perishable != (candidate.StorageType == ColdStorage) {
+			continue
+		}
+		if candidate.CheckCapacity(candidate.Used, product, quantity) == nil {
+			eligible = append(eligible, candidate)
+		}
+	}
+
+	load := float64(quantity)
+	lowLevels := product.WeightInKg*load >= heavyLoadInKg ||
+		product.LengthInCm*product.WidthInCm*product.BreadthInCm*load/1e6 >= bulkyLoadInCubicM
+	levelRanks := rankLevels(eligible)
+
+	suggestions := make([]PutawaySuggestion, 0, len(eligible))
+	for _, candidate := range eligible {
+		suggestion := PutawaySuggestion{
+			ShelfId:     candidate.Id,
+			Label:       candidate.Label,
+			Aisle:       candidate.Aisle,
+			Rack:        candidate.Rack,
+			Section:     candidate.Section,
+			Level:       candidate.Level,
+			StorageType: candidate.StorageType,
+			Reasons:     []string{},
+		}
+		if product.Perishable {
+			suggestion.Reasons = append(suggestion.Reasons, "cold storage for a perishable product")
+		}
+
+		if candidate.SkuCount > 0 {
+			suggestion.Score += 50
+			suggestion.Reasons = append(suggestion.Reasons, fmt.Sprintf("already holds %d of the sku", candidate.SkuCount))
+		} else if candidate.SkuCountInBlock > 0 {
+			suggestion.Score += 25
+			suggestion.Reasons = append(suggestion.Reasons, "sku is stocked in the same shelf block")
+		}
+
+		if lowLevels {
+			if bonus := 20 - 5*levelRanks[candidate.Level]; bonus > 0 {
+				suggestion.Score += bonus
+				suggestion.Reasons = append(suggestion.Reasons, "low level for a heavy or bulky load")
+			}
+		}
+
+		if candidate.Used.ItemCount == 0 {
+			suggestion.Reasons = append(suggestion.Reasons, "empty shelf")
+		} else if candidate.Used.ItemCount < 30 {
+			suggestion.Score -= candidate.Used.ItemCount
+		} else {
+			suggestion.Score -= 30
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		for _, labels := range [][2]string{{a.Aisle, b.Aisle}, {a.Rack, b.Rack}, {a.Section, b.Section}, {a.Level, b.Level}} {
+			if labels[0] != labels[1] {
+				return lessLabel(labels[0], labels[1])
+			}
+		}
+		return a.ShelfId < b.ShelfId
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// rankLevels numbers the distinct levels of candidates from the lowest up,
+// starting at 0.
+func rankLevels(candidates []PutawayCandidate) map[string]int {
+	var levels []string
+	ranks := make(map[string]int)
+	for _, candidate := range candidates {
+		if _, ok := ranks[candidate.Level]; !ok {
+			ranks[candidate.Level] = 0
+			levels = append(levels, candidate.Level)
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool { return lessLabel(levels[i], levels[j]) })
+	for rank, level := range levels {
+		ranks[level] = rank
+	}
+	return ranks
+}
@@ -0,0 +1,75 @@
+package wms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func putawayIds(suggestions []PutawaySuggestion) []string {
+	ids := []string{}
+	for _, suggestion := range suggestions {
+		ids = append(ids, suggestion.ShelfId)
+	}
+	return ids
+}
+
+func TestSuggestPutaway(t *testing.T) {
+	candidates := []PutawayCandidate{
+		{Shelf: Shelf{Id: "cold", Level: "1"}, StorageType: ColdStorage},
+		{Shelf: Shelf{Id: "full", Level: "1"}, StorageType: "regular", Used: ShelfUtilization{ItemCount: 40}},
+		{Shelf: Shelf{Id: "empty-high", Level: "10"}, StorageType: "regular"},
+		{Shelf: Shelf{Id: "low", Level: "1"}, StorageType: "regular", Used: ShelfUtilization{ItemCount: 5}},
+		{Shelf: Shelf{Id: "same-block", Level: "3"}, StorageType: "regular", Used: ShelfUtilization{ItemCount: 5}, SkuCountInBlock: 4},
+		{Shelf: Shelf{Id: "same-shelf", Level: "3", MaxItems: 13}, StorageType: "regular", Used: ShelfUtilization{ItemCount: 10}, SkuCount: 4, SkuCountInBlock: 4},
+	}
+
+	tests := []struct {
+		name     string
+		product  Product
+		quantity int
+		limit    int
+		want     []string
+	}{
+		{
+			name:     "perishable goes to cold storage only",
+			product:  Product{Perishable: true},
+			quantity: 1,
+			limit:    10,
+			want:     []string{"cold"},
+		},
+		{
+			name:     "existing stock first, then emptier shelves",
+			product:  Product{WeightInKg: 1},
+			quantity: 1,
+			limit:    10,
+			want:     []string{"same-shelf", "same-block", "empty-high", "low", "full"},
+		},
+		{
+			name:     "heavy loads favour low levels",
+			product:  Product{WeightInKg: 10},
+			quantity: 3,
+			limit:    3,
+			want:     []string{"same-shelf", "same-block", "low"},
+		},
+		{
+			name:     "shelves without room are left out",
+			product:  Product{WeightInKg: 1},
+			quantity: 4,
+			limit:    10,
+			want:     []string{"same-block", "empty-high", "low", "full"},
+		},
+		{
+			name:     "bulky loads favour low levels",
+			product:  Product{LengthInCm: 100, WidthInCm: 100, BreadthInCm: 60},
+			quantity: 1,
+			limit:    10,
+			want:     []string{"same-shelf", "same-block", "low", "empty-high", "full"},
+		},
+	}
+	for _, test := range tests {
+		got := putawayIds(SuggestPutaway(test.product, test.quantity, candidates, test.limit))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: want: %v, got: %v", test.name, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package wms
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ReservationHeld      = "held"
+	ReservationConfirmed = "confirmed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// DefaultReservationTTL is how long a reservation is held for when no time
+// to live is asked for.
+const DefaultReservationTTL = 15 * time.Minute
+
+// Reservation holds specific items of a sku in a warehouse so that no other
+// reservation or pick list can claim them. A held reservation lapses at
+// ExpiresAt unless it is confirmed first; a confirmed one lasts until it is
+// released.
+type Reservation struct {
+	Id          string `json:"id"`
+	WarehouseId string `json:"warehouseId"`
+	Sku         string `json:"sku"`
+	Quantity    int    `json:"quantity"`
+	// Reference is what the stock is reserved for, such as an order id.
+	// Pick lists for an order with that id may use the reserved items.
+	Reference string    `json:"reference,omitempty"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+	ItemIds   []string  `json:"itemIds"`
+}
+
+var ReservationDoesNotExist = errors.New("reservation does not exist")
+var ReservationNotHeld = errors.New("reservation is not held")
+
+func NewReservation(warehouseId, sku string, quantity int, reference string, ttl time.Duration) Reservation {
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+	createdAt := time.Now().UTC()
+	return Reservation{
+		Id:          generateUUID(),
+		WarehouseId: warehouseId,
+		Sku:         sku,
+		Quantity:    quantity,
+		Reference:   reference,
+		Status:      ReservationHeld,
+		ExpiresAt:   createdAt.Add(ttl),
+		CreatedAt:   createdAt,
+		ItemIds:     []string{},
+	}
+}
+
+// Lapsed reports whether a held reservation has passed its expiry at now,
+// whether or not it has been marked expired yet.
+func (r Reservation) Lapsed(now time.Time) bool {
+	return r.Status == ReservationHeld && !now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,38 @@
+package wms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewReservation(t *testing.T) {
+	reservation := NewReservation("w", "a", 2, "", 0)
+	if reservation.Status != ReservationHeld || reservation.ExpiresAt.Sub(reservation.CreatedAt) != DefaultReservationTTL {
+		t.Errorf("want a reservation held for %v, got: %v", DefaultReservationTTL, reservation)
+	}
+
+	reservation = NewReservation("w", "a", 2, "o", time.Minute)
+	if reservation.ExpiresAt.Sub(reservation.CreatedAt) != time.Minute {
+		t.Errorf("want a reservation held for a minute, got: %v", reservation)
+	}
+}
+
+func TestReservationLapsed(t *testing.T) {
+	expiresAt := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		status string
+		now    time.Time
+		want   bool
+	}{
+		{status: ReservationHeld, now: expiresAt.Add(-time.Second), want: false},
+		{status: ReservationHeld, now: expiresAt, want: true},
+		{status: ReservationConfirmed, now: expiresAt.Add(time.Hour), want: false},
+		{status: ReservationReleased, now: expiresAt.Add(time.Hour), want: false},
+	}
+	for _, test := range tests {
+		reservation := Reservation{Status: test.status, ExpiresAt: expiresAt}
+		if got := reservation.Lapsed(test.now); got != test.want {
+			t.Errorf("%s at %v: want: %v, got: %v", test.status, test.now, test.want, got)
+		}
+	}
+}
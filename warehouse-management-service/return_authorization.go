@@ -0,0 +1,170 @@
+package wms
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ReturnAuthorized = "authorized"
+	ReturnClosed     = "closed"
+)
+
+// Conditions a returned item can be found in on inspection.
+const (
+	ConditionResellable = "resellable"
+	ConditionDamaged    = "damaged"
+	ConditionDefective  = "defective"
+)
+
+// Outcomes of a returned item: back on a shelf for sale, onto a shelf in
+// quarantine, or written off.
+const (
+	ReturnRestock    = "restock"
+	ReturnQuarantine = "quarantine"
+	ReturnWriteOff   = "write_off"
+)
+
+var itemConditions = map[string]bool{
+	ConditionResellable: true,
+	ConditionDamaged:    true,
+	ConditionDefective:  true,
+}
+
+// ReturnAuthorization lets a customer send back items of a dispatched
+// shipment. It closes once every item has an outcome.
+type ReturnAuthorization struct {
+	Id          string       `json:"id"`
+	ShipmentId  string       `json:"shipmentId"`
+	WarehouseId string       `json:"warehouseId"`
+	Reason      string       `json:"reason"`
+	Status      string       `json:"status"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	ClosedAt    *time.Time   `json:"closedAt,omitempty"`
+	Items       []ReturnItem `json:"items"`
+}
+
+// ReturnItem is a shipped item being returned. Once restocked or
+// quarantined it is back in stock as the item NewItemId on ShelfId.
+type ReturnItem struct {
+	ReturnId       string     `json:"returnId"`
+	ItemId         string     `json:"itemId"`
+	Sku            string     `json:"sku"`
+	Lot            string     `json:"lot,omitempty"`
+	SerialNumber   string     `json:"serialNumber,omitempty"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	Condition      string     `json:"condition,omitempty"`
+	Notes          string     `json:"notes,omitempty"`
+	InspectedAt    *time.Time `json:"inspectedAt,omitempty"`
+	Outcome        string     `json:"outcome,omitempty"`
+	ShelfId        string     `json:"shelfId,omitempty"`
+	NewItemId      string     `json:"newItemId,omitempty"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty"`
+}
+
+var ReturnDoesNotExist = errors.New("return does not exist")
+var ReturnItemDoesNotExist = errors.New("item is not part of the return")
+var ReturnAlreadyClosed = errors.New("return is closed")
+var ShipmentNotDispatched = errors.New("shipment has not been dispatched")
+var ItemNotShipped = errors.New("item was not shipped in the shipment")
+var ItemAlreadyReturned = errors.New("item is returned already")
+var EmptyReturn = errors.New("return has no items")
+var DuplicateReturnItem = errors.New("return lists an item more than once")
+var InvalidItemCondition = errors.New("invalid item condition")
+var InvalidReturnOutcome = errors.New("invalid return outcome")
+var ReturnItemNotInspected = errors.New("returned item has not been inspected")
+var ReturnItemResolved = errors.New("returned item already has an outcome")
+var ItemNotResellable = errors.New("only resellable items can be restocked")
+var QuarantineShelfRequired = errors.New("quarantining an item needs a shelf")
+var NoPutawayShelf = errors.New("no shelf has room for the item")
+
+func NewReturnAuthorization(shipmentId, warehouseId, reason string, items []ReturnItem) (ReturnAuthorization, error) {
+	if len(items) == 0 {
+		return ReturnAuthorization{}, EmptyReturn
+	}
+
+	authorization := ReturnAuthorization{
+		Id:          generateUUID(),
+		ShipmentId:  shipmentId,
+		WarehouseId: warehouseId,
+		Reason:      reason,
+		Status:      ReturnAuthorized,
+		CreatedAt:   time.Now().UTC(),
+		Items:       make([]ReturnItem, 0, len(items)),
+	}
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if seen[item.ItemId] {
+			return ReturnAuthorization{}, DuplicateReturnItem
+		}
+		seen[item.ItemId] = true
+		item.ReturnId = authorization.Id
+		authorization.Items = append(authorization.Items, item)
+	}
+	return authorization, nil
+}
+
+// Item returns the returned item itemId.
+func (r ReturnAuthorization) Item(itemId string) (ReturnItem, error) {
+	for _, item := range r.Items {
+		if item.ItemId == itemId {
+			return item, nil
+		}
+	}
+	return ReturnItem{}, ReturnItemDoesNotExist
+}
+
+// Resolved reports whether every item of the return has an outcome.
+func (r ReturnAuthorization) Resolved() bool {
+	for _, item := range r.Items {
+		if item.Outcome == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Inspect records the condition an item came back in. An item can be
+// inspected again until it has an outcome.
+func (i *ReturnItem) Inspect(condition, notes string, now time.Time) error {
+	if i.Outcome != "" {
+		return ReturnItemResolved
+	}
+	if !itemConditions[condition] {
+		return InvalidItemCondition
+	}
+	i.Condition = condition
+	i.Notes = notes
+	i.InspectedAt = &now
+	return nil
+}
+
+// Resolve gives an inspected item its outcome. Only resellable items are
+// restocked, and quarantining needs a shelf; restocking leaves it to the
+// caller to find one when shelfId is empty.
+func (i *ReturnItem) Resolve(outcome, shelfId string, now time.Time) error {
+	if i.Outcome != "" {
+		return ReturnItemResolved
+	}
+	if i.InspectedAt == nil {
+		return ReturnItemNotInspected
+	}
+	switch outcome {
+	case ReturnRestock:
+		if i.Condition != ConditionResellable {
+			return ItemNotResellable
+		}
+	case ReturnQuarantine:
+		if shelfId == "" {
+			return QuarantineShelfRequired
+		}
+	case ReturnWriteOff:
+		shelfId = ""
+	default:
+		return InvalidReturnOutcome
+	}
+	i.Outcome = outcome
+	i.ShelfId = shelfId
+	i.ResolvedAt = &now
+	return nil
+}
@@ -0,0 +1,86 @@
+package wms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewReturnAuthorization(t *testing.T) {
+	items := []ReturnItem{{ItemId: "i1", Sku: "a"}, {ItemId: "i2", Sku: "b"}}
+	authorization, err := NewReturnAuthorization("s", "w", "wrong size", items)
+	if err != nil || authorization.Status != ReturnAuthorized || len(authorization.Items) != 2 {
+		t.Errorf("want an authorized return of 2 items, got: %v, %v", authorization, err)
+	}
+	for _, item := range authorization.Items {
+		if item.ReturnId != authorization.Id {
+			t.Errorf("want: %v, got: %v", authorization.Id, item.ReturnId)
+		}
+	}
+
+	_, err = NewReturnAuthorization("s", "w", "wrong size", nil)
+	if err != EmptyReturn {
+		t.Errorf("want: %v, got: %v", EmptyReturn, err)
+	}
+
+	_, err = NewReturnAuthorization("s", "w", "wrong size", append(items, items[0]))
+	if err != DuplicateReturnItem {
+		t.Errorf("want: %v, got: %v", DuplicateReturnItem, err)
+	}
+}
+
+func TestResolveReturnItem(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		condition   string
+		outcome     string
+		shelfId     string
+		wantShelfId string
+		wantErr     error
+	}{
+		{condition: ConditionResellable, outcome: ReturnRestock, shelfId: "s1", wantShelfId: "s1"},
+		{condition: ConditionResellable, outcome: ReturnRestock},
+		{condition: ConditionDamaged, outcome: ReturnRestock, shelfId: "s1", wantErr: ItemNotResellable},
+		{condition: ConditionDamaged, outcome: ReturnQuarantine, shelfId: "q1", wantShelfId: "q1"},
+		{condition: ConditionDamaged, outcome: ReturnQuarantine, wantErr: QuarantineShelfRequired},
+		{condition: ConditionDefective, outcome: ReturnWriteOff, shelfId: "s1"},
+		{condition: ConditionDefective, outcome: "recycle", wantErr: InvalidReturnOutcome},
+		{outcome: ReturnWriteOff, wantErr: ReturnItemNotInspected},
+	}
+
+	for _, test := range tests {
+		item := ReturnItem{ItemId: "i1", Sku: "a"}
+		if test.condition != "" {
+			err := item.Inspect(test.condition, "", now)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		err := item.Resolve(test.outcome, test.shelfId, now)
+		if err != test.wantErr {
+			t.Errorf("%s, %s: want: %v, got: %v", test.condition, test.outcome, test.wantErr, err)
+			continue
+		}
+		if err != nil {
+			if item.Outcome != "" {
+				t.Errorf("%s, %s: want no outcome, got: %s", test.condition, test.outcome, item.Outcome)
+			}
+			continue
+		}
+		if item.Outcome != test.outcome || item.ShelfId != test.wantShelfId || item.ResolvedAt == nil {
+			t.Errorf("%s, %s: want outcome on shelf %q, got: %v", test.condition, test.outcome, test.wantShelfId, item)
+		}
+		if err := item.Resolve(ReturnWriteOff, "", now); err != ReturnItemResolved {
+			t.Errorf("want: %v, got: %v", ReturnItemResolved, err)
+		}
+		if err := item.Inspect(ConditionResellable, "", now); err != ReturnItemResolved {
+			t.Errorf("want: %v, got: %v", ReturnItemResolved, err)
+		}
+	}
+
+	item := ReturnItem{ItemId: "i1"}
+	if err := item.Inspect("soggy", "", now); err != InvalidItemCondition {
+		t.Errorf("want: %v, got: %v", InvalidItemCondition, err)
+	}
+}
@@ -2,18 +2,53 @@ package wms
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
+// Shelf is a level of a section of a shelf block. Its capacity limits are
+// optional, a zero limit is no limit.
 type Shelf struct {
-	Id           string `json:"id,omitempty"`
-	Label        string `json:"label,omitempty"`
-	Section      string `json:"section,omitempty"`
-	Level        string `json:"level,omitempty"`
-	ShelfBlockId string `json:"shelfBlockId,omitempty"`
+	Id             string  `json:"id,omitempty"`
+	Label          string  `json:"label,omitempty"`
+	Section        string  `json:"section,omitempty"`
+	Level          string  `json:"level,omitempty"`
+	ShelfBlockId   string  `json:"shelfBlockId,omitempty"`
+	MaxWeightInKg  float64 `json:"maxWeightInKg,omitempty" validate:"min=0"`
+	MaxVolumeInCm3 float64 `json:"maxVolumeInCm3,omitempty" validate:"min=0"`
+	MaxItems       int     `json:"maxItems,omitempty" validate:"min=0"`
+	Version        int     `json:"version,omitempty"`
+}
+
+// ShelfUtilization is how much of a shelf the items on it take up, going by
+// the dimensions and weight of their products.
+type ShelfUtilization struct {
+	ItemCount   int     `json:"itemCount"`
+	WeightInKg  float64 `json:"weightInKg"`
+	VolumeInCm3 float64 `json:"volumeInCm3"`
+	// Percent is the share of the most used limit of the shelf, left out
+	// when the shelf has no limits.
+	Percent *float64 `json:"percent,omitempty"`
 }
 
 var ShelfDoesNotExist = errors.New("shelf does not exist")
 var InvalidShelfBlock = errors.New("invalid shelf block")
+var ShelfOverCapacity = errors.New("shelf over capacity")
+
+// ShelfCapacityError is returned when placing items on a shelf would take
+// it past one of its limits. It matches ShelfOverCapacity with errors.Is.
+type ShelfCapacityError struct {
+	ShelfId  string
+	Exceeded []string
+}
+
+func (e *ShelfCapacityError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ShelfOverCapacity.Error(), e.ShelfId, strings.Join(e.Exceeded, ", "))
+}
+
+func (e *ShelfCapacityError) Unwrap() error {
+	return ShelfOverCapacity
+}
 
 func NewShelf(label, section, level, shelfBlockId string) Shelf {
 	return Shelf{
@@ -24,3 +59,45 @@ func NewShelf(label, section, level, shelfBlockId string) Shelf {
 		ShelfBlockId: shelfBlockId,
 	}
 }
+
+// Utilization fills in the percentage of used against the limits of the
+// shelf.
+func (s Shelf) Utilization(used ShelfUtilization) ShelfUtilization {
+	used.Percent = nil
+	share := func(amount, limit float64) {
+		if limit <= 0 {
+			return
+		}
+		percent := amount / limit * 100
+		if used.Percent == nil || percent > *used.Percent {
+			used.Percent = &percent
+		}
+	}
+	share(float64(used.ItemCount), float64(s.MaxItems))
+	share(used.WeightInKg, s.MaxWeightInKg)
+	share(used.VolumeInCm3, s.MaxVolumeInCm3)
+	return used
+}
+
+// CheckCapacity returns a *ShelfCapacityError if quantity more items of
+// product do not fit on the shelf next to what is used already.
+func (s Shelf) CheckCapacity(used ShelfUtilization, product Product, quantity int) error {
+	count := used.ItemCount + quantity
+	weight := used.WeightInKg + product.WeightInKg*float64(quantity)
+	volume := used.VolumeInCm3 + product.LengthInCm*product.WidthInCm*product.BreadthInCm*float64(quantity)
+
+	var exceeded []string
+	if s.MaxItems > 0 && count > s.MaxItems {
+		exceeded = append(exceeded, fmt.Sprintf("%d of %d items", count, s.MaxItems))
+	}
+	if s.MaxWeightInKg > 0 && weight > s.MaxWeightInKg {
+		exceeded = append(exceeded, fmt.Sprintf("%g of %g kg", weight, s.MaxWeightInKg))
+	}
+	if s.MaxVolumeInCm3 > 0 && volume > s.MaxVolumeInCm3 {
+		exceeded = append(exceeded, fmt.Sprintf("%g of %g cm³", volume, s.MaxVolumeInCm3))
+	}
+	if exceeded != nil {
+		return &ShelfCapacityError{ShelfId: s.Id, Exceeded: exceeded}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ type ShelfBlock struct {
 	Rack        string
 	StorageType string
 	WarehouseId string
+	Version     int
 }
 
 var ShelfBlockDoesNotExist = errors.New("shelf block does not exist")
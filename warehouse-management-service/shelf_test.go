@@ -0,0 +1,64 @@
+package wms
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestShelfCheckCapacity(t *testing.T) {
+	product := Product{LengthInCm: 10, WidthInCm: 10, BreadthInCm: 10, WeightInKg: 2}
+	used := ShelfUtilization{ItemCount: 3, WeightInKg: 6, VolumeInCm3: 3000}
+
+	tests := []struct {
+		shelf    Shelf
+		quantity int
+		want     []string
+	}{
+		{shelf: Shelf{}, quantity: 100},
+		{shelf: Shelf{MaxItems: 5}, quantity: 2},
+		{shelf: Shelf{MaxItems: 5}, quantity: 3, want: []string{"6 of 5 items"}},
+		{shelf: Shelf{MaxWeightInKg: 9}, quantity: 2, want: []string{"10 of 9 kg"}},
+		{
+			shelf:    Shelf{MaxItems: 4, MaxVolumeInCm3: 4500},
+			quantity: 2,
+			want:     []string{"5 of 4 items", "5000 of 4500 cm³"},
+		},
+	}
+	for _, test := range tests {
+		err := test.shelf.CheckCapacity(used, product, test.quantity)
+		if test.want == nil {
+			if err != nil {
+				t.Errorf("%v: want: no error, got: %v", test.shelf, err)
+			}
+			continue
+		}
+
+		var capacityErr *ShelfCapacityError
+		if !errors.As(err, &capacityErr) || !errors.Is(err, ShelfOverCapacity) {
+			t.Errorf("%v: want: %v, got: %v", test.shelf, ShelfOverCapacity, err)
+			continue
+		}
+		if len(capacityErr.Exceeded) != len(test.want) {
+			t.Errorf("%v: want: %v, got: %v", test.shelf, test.want, capacityErr.Exceeded)
+			continue
+		}
+		for i := range test.want {
+			if capacityErr.Exceeded[i] != test.want[i] {
+				t.Errorf("%v: want: %v, got: %v", test.shelf, test.want, capacityErr.Exceeded)
+			}
+		}
+	}
+}
+
+func TestShelfUtilization(t *testing.T) {
+	used := ShelfUtilization{ItemCount: 3, WeightInKg: 6, VolumeInCm3: 3000}
+
+	if got := (Shelf{}).Utilization(used); got.Percent != nil {
+		t.Errorf("want: no percent without limits, got: %v", *got.Percent)
+	}
+
+	got := Shelf{MaxItems: 10, MaxWeightInKg: 12}.Utilization(used)
+	if got.Percent == nil || *got.Percent != 50 {
+		t.Errorf("want: 50, got: %v", got.Percent)
+	}
+}
@@ -0,0 +1,94 @@
+package wms
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ShipmentOpen       = "open"
+	ShipmentDispatched = "dispatched"
+)
+
+// Shipment is what leaves the warehouse for an order, packed into packages
+// once the order has been picked.
+type Shipment struct {
+	Id           string     `json:"id"`
+	OrderId      string     `json:"orderId"`
+	Carrier      string     `json:"carrier"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DispatchedAt *time.Time `json:"dispatchedAt,omitempty"`
+	// WeightInKg is the weight of every package of the shipment.
+	WeightInKg float64   `json:"weightInKg"`
+	Packages   []Package `json:"packages"`
+}
+
+// Package is a parcel of a shipment, handed to the carrier under its own
+// tracking number.
+type Package struct {
+	Id             string       `json:"id"`
+	ShipmentId     string       `json:"shipmentId"`
+	TrackingNumber string       `json:"trackingNumber"`
+	WeightInKg     float64      `json:"weightInKg"`
+	CreatedAt      time.Time    `json:"createdAt"`
+	Items          []PackedItem `json:"items"`
+}
+
+// PackedItem is an item in a package. It outlives the item itself, which
+// leaves stock when the shipment is dispatched.
+type PackedItem struct {
+	ItemId         string     `json:"itemId"`
+	Sku            string     `json:"sku"`
+	Lot            string     `json:"lot,omitempty"`
+	SerialNumber   string     `json:"serialNumber,omitempty"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	WeightInKg     float64    `json:"weightInKg"`
+}
+
+var ShipmentDoesNotExist = errors.New("shipment does not exist")
+var ShipmentNotOpen = errors.New("shipment has been dispatched")
+var ShipmentExists = errors.New("order already has a shipment")
+var OrderNotPicked = errors.New("order is not being picked")
+var ItemNotOnPickList = errors.New("item is not on the pick list of the order")
+var ItemAlreadyPacked = errors.New("item is packed already")
+var EmptyPackage = errors.New("package has no items")
+var DuplicatePackageItem = errors.New("package lists an item more than once")
+var ShipmentNotPacked = errors.New("shipment has picked items left to pack")
+
+func NewShipment(orderId, carrier string) Shipment {
+	return Shipment{
+		Id:        generateUUID(),
+		OrderId:   orderId,
+		Carrier:   carrier,
+		Status:    ShipmentOpen,
+		CreatedAt: time.Now().UTC(),
+		Packages:  []Package{},
+	}
+}
+
+// NewPackage packs items into a package of a shipment, weighing what the
+// items do together.
+func NewPackage(shipmentId, trackingNumber string, items []PackedItem) (Package, error) {
+	if len(items) == 0 {
+		return Package{}, EmptyPackage
+	}
+
+	pkg := Package{
+		Id:             generateUUID(),
+		ShipmentId:     shipmentId,
+		TrackingNumber: trackingNumber,
+		CreatedAt:      time.Now().UTC(),
+		Items:          make([]PackedItem, 0, len(items)),
+	}
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if seen[item.ItemId] {
+			return Package{}, DuplicatePackageItem
+		}
+		seen[item.ItemId] = true
+		pkg.WeightInKg += item.WeightInKg
+		pkg.Items = append(pkg.Items, item)
+	}
+	return pkg, nil
+}
@@ -0,0 +1,25 @@
+package wms
+
+import "testing"
+
+func TestNewPackage(t *testing.T) {
+	items := []PackedItem{
+		{ItemId: "i1", Sku: "a", WeightInKg: 1.5},
+		{ItemId: "i2", Sku: "a", WeightInKg: 1.5},
+		{ItemId: "i3", Sku: "b", WeightInKg: 0.25},
+	}
+	pkg, err := NewPackage("s", "TRK1", items)
+	if err != nil || pkg.WeightInKg != 3.25 || len(pkg.Items) != 3 || pkg.ShipmentId != "s" {
+		t.Errorf("want a package of 3 items weighing 3.25kg, got: %v, %v", pkg, err)
+	}
+
+	_, err = NewPackage("s", "TRK1", nil)
+	if err != EmptyPackage {
+		t.Errorf("want: %v, got: %v", EmptyPackage, err)
+	}
+
+	_, err = NewPackage("s", "TRK1", append(items, items[0]))
+	if err != DuplicatePackageItem {
+		t.Errorf("want: %v, got: %v", DuplicatePackageItem, err)
+	}
+}
@@ -0,0 +1,153 @@
+package wms
+
+// StockLevel counts items. OnHand is every item on a shelf, Reserved those
+// held by a reservation or allocated to a pick list, and Available those that
+// could still be reserved: neither reserved, expired nor quarantined.
+// InTransit items are on their way to a shelf and not yet on hand.
+type StockLevel struct {
+	OnHand    int `json:"onHand"`
+	Reserved  int `json:"reserved"`
+	Available int `json:"available"`
+	InTransit int `json:"inTransit"`
+}
+
+func (s *StockLevel) add(other StockLevel) {
+	s.OnHand += other.OnHand
+	s.Reserved += other.Reserved
+	s.Available += other.Available
+	s.InTransit += other.InTransit
+}
+
+// StockRow is the stock of one sku on one shelf, the unit stock levels are
+// aggregated from.
+type StockRow struct {
+	WarehouseId   string
+	WarehouseName string
+	ShelfBlockId  string
+	Aisle         string
+	Rack          string
+	ShelfId       string
+	ShelfLabel    string
+	Sku           string
+	StockLevel
+}
+
+type ShelfStock struct {
+	ShelfId string `json:"shelfId"`
+	Label   string `json:"label"`
+	StockLevel
+}
+
+type ShelfBlockStock struct {
+	ShelfBlockId string `json:"shelfBlockId"`
+	Aisle        string `json:"aisle"`
+	Rack         string `json:"rack"`
+	StockLevel
+	Shelves []ShelfStock `json:"shelves"`
+}
+
+type WarehouseStock struct {
+	WarehouseId string `json:"warehouseId"`
+	Name        string `json:"name"`
+	StockLevel
+	ShelfBlocks []ShelfBlockStock `json:"shelfBlocks"`
+}
+
+// ProductStock is where a sku is stocked, by warehouse, shelf block and
+// shelf.
+type ProductStock struct {
+	Sku string `json:"sku"`
+	StockLevel
+	Warehouses []WarehouseStock `json:"warehouses"`
+}
+
+type SkuStock struct {
+	Sku string `json:"sku"`
+	StockLevel
+	ShelfBlocks []ShelfBlockStock `json:"shelfBlocks"`
+}
+
+// WarehouseInventory is what a warehouse stocks, by sku, shelf block and
+// shelf.
+type WarehouseInventory struct {
+	WarehouseId string `json:"warehouseId"`
+	StockLevel
+	Products []SkuStock `json:"products"`
+}
+
+// NewProductStock totals the rows of a sku. Warehouses, shelf blocks and
+// shelves are listed in the order they first appear in rows.
+func NewProductStock(sku string, rows []StockRow) ProductStock {
+	stock := ProductStock{Sku: sku, Warehouses: []WarehouseStock{}}
+	warehouses := make(map[string]int)
+	var warehouseRows [][]StockRow
+	for _, row := range rows {
+		i, ok := warehouses[row.WarehouseId]
+		if !ok {
+			i = len(stock.Warehouses)
+			warehouses[row.WarehouseId] = i
+			stock.Warehouses = append(stock.Warehouses, WarehouseStock{WarehouseId: row.WarehouseId, Name: row.WarehouseName})
+			warehouseRows = append(warehouseRows, nil)
+		}
+		warehouseRows[i] = append(warehouseRows[i], row)
+	}
+	for i := range stock.Warehouses {
+		stock.Warehouses[i].ShelfBlocks = shelfBlockStock(warehouseRows[i])
+		for _, block := range stock.Warehouses[i].ShelfBlocks {
+			stock.Warehouses[i].add(block.StockLevel)
+		}
+		stock.add(stock.Warehouses[i].StockLevel)
+	}
+	return stock
+}
+
+// NewWarehouseInventory totals the rows of a warehouse by sku. Skus, shelf
+// blocks and shelves are listed in the order they first appear in rows.
+func NewWarehouseInventory(warehouseId string, rows []StockRow) WarehouseInventory {
+	inventory := WarehouseInventory{WarehouseId: warehouseId, Products: []SkuStock{}}
+	skus := make(map[string]int)
+	var skuRows [][]StockRow
+	for _, row := range rows {
+		i, ok := skus[row.Sku]
+		if !ok {
+			i = len(inventory.Products)
+			skus[row.Sku] = i
+			inventory.Products = append(inventory.Products, SkuStock{Sku: row.Sku})
+			skuRows = append(skuRows, nil)
+		}
+		skuRows[i] = append(skuRows[i], row)
+	}
+	for i := range inventory.Products {
+		inventory.Products[i].ShelfBlocks = shelfBlockStock(skuRows[i])
+		for _, block := range inventory.Products[i].ShelfBlocks {
+			inventory.Products[i].add(block.StockLevel)
+		}
+		inventory.add(inventory.Products[i].StockLevel)
+	}
+	return inventory
+}
+
+func shelfBlockStock(rows []StockRow) []ShelfBlockStock {
+	blocks := []ShelfBlockStock{}
+	blockIndex := make(map[string]int)
+	for _, row := range rows {
+		i, ok := blockIndex[row.ShelfBlockId]
+		if !ok {
+			i = len(blocks)
+			blockIndex[row.ShelfBlockId] = i
+			blocks = append(blocks, ShelfBlockStock{
+				ShelfBlockId: row.ShelfBlockId,
+				Aisle:        row.Aisle,
+				Rack:         row.Rack,
+				Shelves:      []ShelfStock{},
+			})
+		}
+		blocks[i].add(row.StockLevel)
+		blocks[i].Shelves = append(blocks[i].Shelves, ShelfStock{
+			ShelfId:    row.ShelfId,
+			Label:      row.ShelfLabel,
+			StockLevel: row.StockLevel,
+		})
+	}
+	return blocks
+}
@@ -0,0 +1,59 @@
+package wms
+
+import (
+	"reflect"
+	"testing"
+)
+
+var stockRows = []StockRow{
+	{WarehouseId: "w1", WarehouseName: "east", ShelfBlockId: "b1", Aisle: "1", Rack: "1", ShelfId: "s1", ShelfLabel: "A", Sku: "a",
+		StockLevel: StockLevel{OnHand: 3, Reserved: 1, Available: 2}},
+	{WarehouseId: "w1", WarehouseName: "east", ShelfBlockId: "b1", Aisle: "1", Rack: "1", ShelfId: "s2", ShelfLabel: "B", Sku: "a",
+		StockLevel: StockLevel{OnHand: 2, Reserved: 0, Available: 1}},
+	{WarehouseId: "w2", WarehouseName: "west", ShelfBlockId: "b2", Aisle: "1", Rack: "1", ShelfId: "s3", ShelfLabel: "A", Sku: "a",
+		StockLevel: StockLevel{OnHand: 4, Reserved: 4, Available: 0}},
+}
+
+func TestNewProductStock(t *testing.T) {
+	stock := NewProductStock("a", stockRows)
+
+	if stock.StockLevel != (StockLevel{OnHand: 9, Reserved: 5, Available: 3}) {
+		t.Errorf("want a total of 9 on hand, 5 reserved and 3 available, got: %v", stock.StockLevel)
+	}
+	if len(stock.Warehouses) != 2 || stock.Warehouses[0].WarehouseId != "w1" || stock.Warehouses[1].WarehouseId != "w2" {
+		t.Fatalf("want warehouses w1 and w2, got: %v", stock.Warehouses)
+	}
+	want := []ShelfBlockStock{{
+		ShelfBlockId: "b1",
+		Aisle:        "1",
+		Rack:         "1",
+		StockLevel:   StockLevel{OnHand: 5, Reserved: 1, Available: 3},
+		Shelves: []ShelfStock{
+			{ShelfId: "s1", Label: "A", StockLevel: StockLevel{OnHand: 3, Reserved: 1, Available: 2}},
+			{ShelfId: "s2", Label: "B", StockLevel: StockLevel{OnHand: 2, Reserved: 0, Available: 1}},
+		},
+	}}
+	if !reflect.DeepEqual(stock.Warehouses[0].ShelfBlocks, want) || stock.Warehouses[0].StockLevel != want[0].StockLevel {
+		t.Errorf("want: %v, got: %v", want, stock.Warehouses[0])
+	}
+
+	if stock := NewProductStock("b", nil); len(stock.Warehouses) != 0 || stock.OnHand != 0 {
+		t.Errorf("want no stock, got: %v", stock)
+	}
+}
+
+func TestNewWarehouseInventory(t *testing.T) {
+	rows := append([]StockRow{}, stockRows[:2]...)
+	rows = append(rows, StockRow{WarehouseId: "w1", ShelfBlockId: "b1", ShelfId: "s1", ShelfLabel: "A", Sku: "b",
+		StockLevel: StockLevel{OnHand: 1, Available: 1}})
+
+	inventory := NewWarehouseInventory("w1", rows)
+	if inventory.StockLevel != (StockLevel{OnHand: 6, Reserved: 1, Available: 4}) {
+		t.Errorf("want a total of 6 on hand, 1 reserved and 4 available, got: %v", inventory.StockLevel)
+	}
+	if len(inventory.Products) != 2 ||
+		inventory.Products[0].Sku != "a" || inventory.Products[0].OnHand != 5 || len(inventory.Products[0].ShelfBlocks[0].Shelves) != 2 ||
+		inventory.Products[1].Sku != "b" || inventory.Products[1].OnHand != 1 {
+		t.Errorf("want 5 of a on 2 shelves and 1 of b, got: %v", inventory.Products)
+	}
+}
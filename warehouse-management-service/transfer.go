@@ -0,0 +1,188 @@
+package wms
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	TransferInTransit = "in_transit"
+	TransferCompleted = "completed"
+)
+
+const (
+	// MovementMove is an item moved between shelves of one warehouse.
+	MovementMove = "move"
+	// MovementDispatch is an item sent to another warehouse, or shipped out
+	// to a customer, and MovementReceive the item arriving at a warehouse.
+	MovementDispatch = "dispatch"
+	MovementReceive  = "receive"
+	// MovementRestock and MovementQuarantine are returned items put back on
+	// a shelf, for sale or not, and MovementWriteOff a returned item that
+	// goes nowhere.
+	MovementRestock    = "restock"
+	MovementQuarantine = "quarantine"
+	MovementWriteOff   = "write_off"
+	// MovementRemove is an item deleted from stock by hand.
+	MovementRemove = "remove"
+)
+
+// Transfer moves items onto a shelf. Within a warehouse it completes at
+// once; into another warehouse the items are in transit until received.
+type Transfer struct {
+	Id              string          `json:"id"`
+	FromWarehouseId string          `json:"fromWarehouseId"`
+	ToWarehouseId   string          `json:"toWarehouseId"`
+	ToShelfId       string          `json:"toShelfId"`
+	Status          string          `json:"status"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	ReceivedAt      *time.Time      `json:"receivedAt,omitempty"`
+	Movements       []StockMovement `json:"movements"`
+}
+
+// StockMovement is an entry of the stock ledger: one item leaving one shelf
+// for another. Items coming back into stock have no shelf they left, and
+// items written off no shelf they went to.
+type StockMovement struct {
+	Id          int64     `json:"id"`
+	ItemId      string    `json:"itemId"`
+	Sku         string    `json:"sku"`
+	FromShelfId string    `json:"fromShelfId,omitempty"`
+	ToShelfId   string    `json:"toShelfId,omitempty"`
+	TransferId  string    `json:"transferId,omitempty"`
+	Kind        string    `json:"kind"`
+	Actor       string    `json:"actor,omitempty"`
+	MovedAt     time.Time `json:"movedAt"`
+}
+
+// TransferSelection says which items a transfer moves: either ItemIds, or
+// Quantity items of Sku from the shelf FromShelfId.
+type TransferSelection struct {
+	ItemIds     []string
+	FromShelfId string
+	Sku         string
+	Quantity    int
+}
+
+// Validate checks that items are selected one way or the other, and that no
+// item is listed twice.
+func (s TransferSelection) Validate() error {
+	if len(s.ItemIds) > 0 {
+		if s.FromShelfId != "" || s.Sku != "" || s.Quantity != 0 {
+			return InvalidTransferSelection
+		}
+		seen := make(map[string]bool, len(s.ItemIds))
+		for _, itemId := range s.ItemIds {
+			if seen[itemId] {
+				return DuplicateTransferItem
+			}
+			seen[itemId] = true
+		}
+		return nil
+	}
+	if s.FromShelfId == "" || s.Sku == "" || s.Quantity < 1 {
+		return InvalidTransferSelection
+	}
+	return nil
+}
+
+// TransferItem is an item considered for a transfer.
+type TransferItem struct {
+	Id          string
+	Sku         string
+	ShelfId     string
+	WarehouseId string
+	Status      string
+	// Claimed is set when the item is reserved or on a pick list.
+	Claimed bool
+}
+
+var TransferDoesNotExist = errors.New("transfer does not exist")
+var TransferNotInTransit = errors.New("transfer is not in transit")
+var ItemNotTransferable = errors.New("item cannot be transferred")
+var EmptyTransfer = errors.New("transfer has no items")
+var InvalidTransferSelection = errors.New("transfer needs either item ids, or a shelf, sku and quantity")
+var DuplicateTransferItem = errors.New("transfer lists an item more than once")
+
+// ItemNotTransferableError says why an item cannot be transferred. It
+// matches ItemNotTransferable with errors.Is.
+type ItemNotTransferableError struct {
+	ItemId string
+	Reason string
+}
+
+func (e *ItemNotTransferableError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ItemNotTransferable.Error(), e.ItemId, e.Reason)
+}
+
+func (e *ItemNotTransferableError) Unwrap() error {
+	return ItemNotTransferable
+}
+
+// CheckMove checks that item can be moved onto the shelf toShelfId of
+// toWarehouseId outside of a transfer. It must be transferable, and the shelf
+// must be in the item's own warehouse.
+func (item TransferItem) CheckMove(toShelfId string, toWarehouseId string) error {
+	if item.WarehouseId != toWarehouseId {
+		return &ItemNotTransferableError{
+			ItemId: item.Id,
+			Reason: fmt.Sprintf("is in warehouse %s, use a transfer to move it to warehouse %s", item.WarehouseId, toWarehouseId),
+		}
+	}
+	return item.checkTransferable(toShelfId)
+}
+
+func (item TransferItem) checkTransferable(toShelfId string) error {
+	var reason string
+	switch {
+	case item.ShelfId == toShelfId:
+		reason = "is already on the shelf"
+	case item.Status != ItemAvailable:
+		reason = "is " + item.Status
+	case item.Claimed:
+		reason = "is reserved or being picked"
+	}
+	if reason != "" {
+		return &ItemNotTransferableError{ItemId: item.Id, Reason: reason}
+	}
+	return nil
+}
+
+// NewTransfer starts a transfer of items onto a shelf of toWarehouseId.
+// The items must all be available, unclaimed, in one warehouse and not on
+// the shelf already.
+func NewTransfer(items []TransferItem, toShelfId string, toWarehouseId string) (Transfer, error) {
+	if len(items) == 0 {
+		return Transfer{}, EmptyTransfer
+	}
+	fromWarehouseId := items[0].WarehouseId
+	for _, item := range items {
+		if item.WarehouseId != fromWarehouseId {
+			return Transfer{}, &ItemNotTransferableError{
+				ItemId: item.Id,
+				Reason: fmt.Sprintf("is not in warehouse %s with the other items", fromWarehouseId),
+			}
+		}
+		err := item.checkTransferable(toShelfId)
+		if err != nil {
+			return Transfer{}, err
+		}
+	}
+
+	transfer := Transfer{
+		Id:              generateUUID(),
+		FromWarehouseId: fromWarehouseId,
+		ToWarehouseId:   toWarehouseId,
+		ToShelfId:       toShelfId,
+		Status:          TransferCompleted,
+		CreatedAt:       time.Now().UTC(),
+		Movements:       []StockMovement{},
+	}
+	if fromWarehouseId != toWarehouseId {
+		transfer.Status = TransferInTransit
+	} else {
+		transfer.ReceivedAt = &transfer.CreatedAt
+	}
+	return transfer, nil
+}
@@ -0,0 +1,95 @@
+package wms
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewTransfer(t *testing.T) {
+	item := TransferItem{Id: "i", Sku: "a", ShelfId: "s1", WarehouseId: "w1", Status: ItemAvailable}
+
+	transfer, err := NewTransfer([]TransferItem{item}, "s2", "w1")
+	if err != nil || transfer.Status != TransferCompleted || transfer.ReceivedAt == nil {
+		t.Errorf("want a completed transfer within a warehouse, got: %v, %v", transfer, err)
+	}
+
+	transfer, err = NewTransfer([]TransferItem{item}, "s2", "w2")
+	if err != nil || transfer.Status != TransferInTransit || transfer.ReceivedAt != nil || transfer.FromWarehouseId != "w1" {
+		t.Errorf("want a transfer from w1 in transit, got: %v, %v", transfer, err)
+	}
+
+	_, err = NewTransfer(nil, "s2", "w1")
+	if err != EmptyTransfer {
+		t.Errorf("want: %v, got: %v", EmptyTransfer, err)
+	}
+
+	elsewhere := item
+	elsewhere.WarehouseId = "w2"
+	quarantined := item
+	quarantined.Status = ItemQuarantined
+	claimed := item
+	claimed.Claimed = true
+	tests := [][]TransferItem{
+		{item, elsewhere},
+		{quarantined},
+		{claimed},
+	}
+	for _, items := range tests {
+		_, err = NewTransfer(items, "s2", "w1")
+		if !errors.Is(err, ItemNotTransferable) {
+			t.Errorf("%v: want: %v, got: %v", items, ItemNotTransferable, err)
+		}
+	}
+	_, err = NewTransfer([]TransferItem{item}, "s1", "w1")
+	if !errors.Is(err, ItemNotTransferable) {
+		t.Errorf("want an item already on the shelf rejected, got: %v", err)
+	}
+}
+
+func TestCheckMove(t *testing.T) {
+	item := TransferItem{Id: "i", Sku: "a", ShelfId: "s1", WarehouseId: "w1", Status: ItemAvailable}
+
+	err := item.CheckMove("s2", "w1")
+	if err != nil {
+		t.Error(err)
+	}
+
+	quarantined := item
+	quarantined.Status = ItemQuarantined
+	claimed := item
+	claimed.Claimed = true
+	tests := []struct {
+		item          TransferItem
+		toWarehouseId string
+	}{
+		{item: item, toWarehouseId: "w2"},
+		{item: quarantined, toWarehouseId: "w1"},
+		{item: claimed, toWarehouseId: "w1"},
+	}
+	for _, test := range tests {
+		err = test.item.CheckMove("s2", test.toWarehouseId)
+		if !errors.Is(err, ItemNotTransferable) {
+			t.Errorf("%v: want: %v, got: %v", test.item, ItemNotTransferable, err)
+		}
+	}
+}
+
+func TestTransferSelectionValidate(t *testing.T) {
+	tests := []struct {
+		selection TransferSelection
+		want      error
+	}{
+		{selection: TransferSelection{ItemIds: []string{"a", "b"}}, want: nil},
+		{selection: TransferSelection{FromShelfId: "s", Sku: "a", Quantity: 2}, want: nil},
+		{selection: TransferSelection{ItemIds: []string{"a", "a"}}, want: DuplicateTransferItem},
+		{selection: TransferSelection{ItemIds: []string{"a"}, Sku: "a"}, want: InvalidTransferSelection},
+		{selection: TransferSelection{Sku: "a", Quantity: 2}, want: InvalidTransferSelection},
+		{selection: TransferSelection{FromShelfId: "s", Sku: "a"}, want: InvalidTransferSelection},
+		{selection: TransferSelection{}, want: InvalidTransferSelection},
+	}
+	for _, test := range tests {
+		if got := test.selection.Validate(); got != test.want {
+			t.Errorf("%v: want: %v, got: %v", test.selection, test.want, got)
+		}
+	}
+}
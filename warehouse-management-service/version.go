@@ -0,0 +1,7 @@
+package wms
+
+import "errors"
+
+// VersionMismatch is returned when a change is made against a version of a
+// row that is no longer current, i.e. someone else changed it in between.
+var VersionMismatch = errors.New("version mismatch")
@@ -9,6 +9,14 @@ type Warehouse struct {
 	Name      string
 	Latitude  float64
 	Longitude float64
+	Version   int
+}
+
+// NearbyWarehouse is a warehouse along with its great-circle distance from
+// the point it was searched from.
+type NearbyWarehouse struct {
+	Warehouse
+	DistanceInKm float64
 }
 
 var WarehouseDoesNotExist = errors.New("warehouse does not exist")